@@ -2,6 +2,7 @@ package mysql
 
 import (
 	"fmt"
+	"net/url"
 	"os"
 	"sync"
 
@@ -21,7 +22,11 @@ type MySQL struct {
 func New() (*MySQL, error) {
 	once.Do(func() {
 		var err error
-		mySqlDB, err = sqlx.Open(os.Getenv("DB_DRIVER"), fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?parseTime=true", os.Getenv("DB_USER"), os.Getenv("DB_PASSWORD"), os.Getenv("DB_HOST"), os.Getenv("DB_PORT"), os.Getenv("DB_NAME")))
+		loc := os.Getenv("DB_TIMEZONE")
+		if loc == "" {
+			loc = "UTC"
+		}
+		mySqlDB, err = sqlx.Open(os.Getenv("DB_DRIVER"), fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?parseTime=true&loc=%s", os.Getenv("DB_USER"), os.Getenv("DB_PASSWORD"), os.Getenv("DB_HOST"), os.Getenv("DB_PORT"), os.Getenv("DB_NAME"), url.QueryEscape(loc)))
 		if err != nil {
 			mySqlError = err
 		}
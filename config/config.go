@@ -2,25 +2,88 @@ package config
 
 import (
 	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/joho/godotenv"
 	"github.com/redis/go-redis/v9"
 	aMySQL "github.com/rzfhlv/gin-example/adapter/mysql"
 	aRedis "github.com/rzfhlv/gin-example/adapter/redis"
+	"github.com/rzfhlv/gin-example/pkg/apikey"
+	"github.com/rzfhlv/gin-example/pkg/audit"
 	"github.com/rzfhlv/gin-example/pkg/hasher"
 	pJwt "github.com/rzfhlv/gin-example/pkg/jwt"
+	"github.com/rzfhlv/gin-example/pkg/lockout"
+	"github.com/rzfhlv/gin-example/pkg/mailer"
+	"github.com/rzfhlv/gin-example/pkg/oidc"
+	"github.com/rzfhlv/gin-example/pkg/param"
+	"github.com/rzfhlv/gin-example/pkg/password"
+	"github.com/rzfhlv/gin-example/pkg/session"
 )
 
 type Config struct {
-	MySQL *sqlx.DB
-	Redis *redis.Client
-	Pkg   Pkg
+	MySQL    *sqlx.DB
+	Redis    *redis.Client
+	Pkg      Pkg
+	App      App
+	Auth     Auth
+	Expiry   Expiry
+	Import   Import
+	Response Response
 }
 
 type Pkg struct {
 	Hasher  hasher.HashPassword
 	JWTImpl pJwt.JWTInterface
+	Session session.Store
+	Mailer  mailer.Sender
+	Policy  password.Checker
+	APIKeys apikey.Store
+	OIDC    oidc.Provider
+	Audit   audit.Store
+
+	LoginUsers lockout.Limiter
+	LoginIPs   lockout.Limiter
+}
+
+// App is how the service names itself to users, in the mails it sends and
+// the authenticator entries it enrolls.
+type App struct {
+	Name     string
+	MailFrom string
+}
+
+type Auth struct {
+	RequireVerifiedEmail bool
+	Clients              map[string]string
+}
+
+// Expiry is how long each token handed to a user stays valid.
+type Expiry struct {
+	Access         time.Duration
+	Refresh        time.Duration
+	PasswordReset  time.Duration
+	EmailVerify    time.Duration
+	ResendInterval time.Duration
+	MFA            time.Duration
+	Impersonate    time.Duration
+	OIDCState      time.Duration
+}
+
+type Import struct {
+	// AsyncRows is the most rows a member import handles within the request,
+	// a bigger file is imported in the background
+	AsyncRows int
+	MaxRows   int
+}
+
+type Response struct {
+	// Problem answers every error as problem+json, not only the requests
+	// that accept it
+	Problem bool
 }
 
 func Init() *Config {
@@ -39,15 +102,191 @@ func Init() *Config {
 		log.Fatalf("Failed to MySQL connection %v", err.Error())
 	}
 
-	hasher := hasher.HasherPassword{}
-	jwtImpl := pJwt.JWTImpl{}
+	jwtExpired, err := strconv.Atoi(os.Getenv("JWT_EXPIRED"))
+	if err != nil {
+		log.Fatalf("Failed Parse JWT Expired %v", err.Error())
+	}
+	accessExpired := time.Duration(jwtExpired) * time.Hour
+
+	jwtImpl, err := pJwt.New(pJwt.Config{
+		Algorithm: os.Getenv("JWT_ALGORITHM"),
+		Secret:    []byte(os.Getenv("JWT_SECRET")),
+		KeysDir:   os.Getenv("JWT_KEYS_DIR"),
+		ActiveKID: os.Getenv("JWT_ACTIVE_KID"),
+		Expired:   accessExpired,
+		Issuer:    os.Getenv("APP_NAME"),
+	})
+	if err != nil {
+		log.Fatalf("Failed to JWT keys %v", err.Error())
+	}
+
+	// cursor times are kept in the zone the database connection reads them in
+	dbLocation, err := time.LoadLocation(os.Getenv("DB_TIMEZONE"))
+	if err != nil {
+		log.Fatalf("Failed Parse DB Timezone %v", err.Error())
+	}
+
+	// without a secret the lists keep paging by number only
+	param.Configure(param.CursorConfig{
+		Secret:   []byte(os.Getenv("CURSOR_SECRET")),
+		Location: dbLocation,
+	})
+
+	mailer, err := mailer.New(os.Getenv("MAIL_DRIVER"), os.Getenv("MAIL_OUTBOX"))
+	if err != nil {
+		log.Fatalf("Failed to Mailer %v", err.Error())
+	}
+
+	// a missing or malformed switch keeps unverified users allowed
+	requireVerifiedEmail, _ := strconv.ParseBool(os.Getenv("AUTH_REQUIRE_VERIFIED_EMAIL"))
+
+	hasher, err := hasher.New(hasher.Config{
+		Algorithm:  os.Getenv("PASSWORD_HASHER"),
+		BcryptCost: envInt("BCRYPT_COST", 0),
+		Argon2: hasher.Argon2Params{
+			Time:    uint32(envInt("ARGON2_TIME", 0)),
+			Memory:  uint32(envInt("ARGON2_MEMORY", 0)),
+			Threads: uint8(envInt("ARGON2_THREADS", 0)),
+		},
+	})
+	if err != nil {
+		log.Fatalf("Failed to Hasher %v", err.Error())
+	}
+
+	session := session.New(redis.GetClient())
+
+	// without a list configured only the policy rules apply
+	var breached *password.Breached
+	if path := os.Getenv("PASSWORD_BREACHED_LIST"); path != "" {
+		breached, err = password.LoadBreached(path)
+		if err != nil {
+			log.Fatalf("Failed to Load Breached Passwords %v", err.Error())
+		}
+	}
+	policy := password.New(password.Config{
+		MinLength:     envInt("PASSWORD_MIN_LENGTH", 8),
+		RequireUpper:  envBool("PASSWORD_REQUIRE_UPPER"),
+		RequireLower:  envBool("PASSWORD_REQUIRE_LOWER"),
+		RequireDigit:  envBool("PASSWORD_REQUIRE_DIGIT"),
+		RequireSymbol: envBool("PASSWORD_REQUIRE_SYMBOL"),
+		Breached:      breached,
+	})
+
+	// usernames lock after too many failures, client IPs only back off so a
+	// shared address can't lock everybody out
+	loginPolicy := lockout.Policy{
+		Window:       time.Duration(envInt("LOGIN_FAIL_WINDOW", 15)) * time.Minute,
+		BackoffAfter: envInt("LOGIN_BACKOFF_AFTER", 3),
+		BackoffBase:  time.Duration(envInt("LOGIN_BACKOFF_BASE", 1)) * time.Second,
+		BackoffMax:   time.Duration(envInt("LOGIN_BACKOFF_MAX", 300)) * time.Second,
+		LockAfter:    envInt("LOGIN_LOCK_AFTER", 10),
+		LockFor:      time.Duration(envInt("LOGIN_LOCK_DURATION", 15)) * time.Minute,
+	}
+	ipPolicy := loginPolicy
+	ipPolicy.LockAfter = 0
+
+	// the provider is only discovered on the first login, without an issuer
+	// the oidc endpoints answer not found
+	var provider oidc.Provider
+	if issuer := os.Getenv("OIDC_ISSUER"); issuer != "" {
+		provider = oidc.New(oidc.Config{
+			Issuer:       issuer,
+			ClientID:     os.Getenv("OIDC_CLIENT_ID"),
+			ClientSecret: os.Getenv("OIDC_CLIENT_SECRET"),
+			RedirectURL:  os.Getenv("OIDC_REDIRECT_URL"),
+		}, nil)
+	}
 
 	return &Config{
 		MySQL: mySql.GetDB(),
 		Redis: redis.GetClient(),
 		Pkg: Pkg{
-			Hasher:  &hasher,
-			JWTImpl: &jwtImpl,
+			Hasher:  hasher,
+			JWTImpl: jwtImpl,
+			Session: session,
+			Mailer:  mailer,
+			Policy:  policy,
+			APIKeys: apikey.New(mySql.GetDB()),
+			OIDC:    provider,
+			Audit:   audit.New(mySql.GetDB()),
+
+			LoginUsers: lockout.New(redis.GetClient(), "login:user", loginPolicy),
+			LoginIPs:   lockout.New(redis.GetClient(), "login:ip", ipPolicy),
 		},
+		App: App{
+			Name:     envString("APP_NAME", "gin-example"),
+			MailFrom: os.Getenv("MAIL_FROM"),
+		},
+		Auth: Auth{
+			RequireVerifiedEmail: requireVerifiedEmail,
+			Clients:              envClients("OAUTH_CLIENTS"),
+		},
+		Expiry: Expiry{
+			Access:         accessExpired,
+			Refresh:        envDuration("JWT_REFRESH_EXPIRED", 168, time.Hour),
+			PasswordReset:  envDuration("PASSWORD_RESET_EXPIRED", 30, time.Minute),
+			EmailVerify:    envDuration("EMAIL_VERIFY_EXPIRED", 24, time.Hour),
+			ResendInterval: envDuration("EMAIL_VERIFY_RESEND_INTERVAL", 60, time.Second),
+			MFA:            envDuration("MFA_EXPIRED", 5, time.Minute),
+			Impersonate:    envDuration("IMPERSONATE_EXPIRED", 15, time.Minute),
+			OIDCState:      envDuration("OIDC_STATE_EXPIRED", 10, time.Minute),
+		},
+		Import: Import{
+			AsyncRows: envPositive("IMPORT_ASYNC_ROWS", 100),
+			MaxRows:   envPositive("IMPORT_MAX_ROWS", 10000),
+		},
+		Response: Response{
+			Problem: envBool("RESPONSE_PROBLEM_JSON"),
+		},
+	}
+}
+
+func envString(key, fallback string) string {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+	return value
+}
+
+func envInt(key string, fallback int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil || value < 0 {
+		return fallback
+	}
+	return value
+}
+
+// envPositive is envInt for a count that can't be zero.
+func envPositive(key string, fallback int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil || value < 1 {
+		return fallback
+	}
+	return value
+}
+
+// envDuration reads a whole number of unit, the tokens are configured in the
+// unit they are reported in.
+func envDuration(key string, fallback int, unit time.Duration) time.Duration {
+	return time.Duration(envPositive(key, fallback)) * unit
+}
+
+func envBool(key string) bool {
+	value, _ := strconv.ParseBool(os.Getenv(key))
+	return value
+}
+
+// envClients reads client credentials as comma separated id:secret pairs,
+// a pair without a secret is skipped.
+func envClients(key string) map[string]string {
+	clients := map[string]string{}
+	for _, pair := range strings.Split(os.Getenv(key), ",") {
+		id, secret, ok := strings.Cut(strings.TrimSpace(pair), ":")
+		if !ok || id == "" || secret == "" {
+			continue
+		}
+		clients[id] = secret
 	}
+	return clients
 }
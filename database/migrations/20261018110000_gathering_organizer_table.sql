@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS gathering_organizers (
+    gathering_id BIGINT UNSIGNED NOT NULL,
+    member_id BIGINT UNSIGNED NOT NULL,
+    PRIMARY KEY (gathering_id, member_id),
+
+    FOREIGN KEY (gathering_id) REFERENCES gatherings(id),
+    FOREIGN KEY (member_id) REFERENCES members(id)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS gathering_organizers;
+-- +goose StatementEnd
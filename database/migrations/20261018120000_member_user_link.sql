@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE members
+    ADD COLUMN user_id BIGINT UNSIGNED NULL AFTER id,
+    ADD CONSTRAINT members_user_id_unique UNIQUE (user_id),
+    ADD CONSTRAINT members_user_id_fk FOREIGN KEY (user_id) REFERENCES users(id);
+-- +goose StatementEnd
+
+-- existing users get the member with their email, like a claim would, users
+-- of before verification are trusted with their email
+-- +goose StatementBegin
+UPDATE members
+    JOIN users ON users.email = members.email
+SET members.user_id = users.id
+WHERE members.user_id IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE members
+    DROP FOREIGN KEY members_user_id_fk,
+    DROP INDEX members_user_id_unique,
+    DROP COLUMN user_id;
+-- +goose StatementEnd
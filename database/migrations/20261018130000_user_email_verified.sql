@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE users
+    ADD COLUMN email_verified_at TIMESTAMP NULL AFTER password;
+-- +goose StatementEnd
+
+-- users registered before verification existed are trusted as they are
+-- +goose StatementBegin
+UPDATE users SET email_verified_at = created_at;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE users
+    DROP COLUMN email_verified_at;
+-- +goose StatementEnd
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS impersonation_audit (
+    id BIGINT UNSIGNED AUTO_INCREMENT,
+    actor_id BIGINT UNSIGNED NOT NULL,
+    user_id BIGINT UNSIGNED NOT NULL,
+    session_id VARCHAR(64) NOT NULL,
+    action VARCHAR(32) NOT NULL,
+    method VARCHAR(8) NOT NULL DEFAULT '',
+    path VARCHAR(255) NOT NULL DEFAULT '',
+    status SMALLINT UNSIGNED NOT NULL DEFAULT 0,
+    ip VARCHAR(45) NOT NULL DEFAULT '',
+    user_agent VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    PRIMARY KEY(id),
+    INDEX(actor_id, created_at),
+    INDEX(user_id, created_at)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS impersonation_audit;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE members
+    ADD COLUMN deleted_at TIMESTAMP NULL AFTER created_at,
+    ADD INDEX members_deleted_at_index (deleted_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE members
+    DROP INDEX members_deleted_at_index,
+    DROP COLUMN deleted_at;
+-- +goose StatementEnd
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE user_mfa
+    ADD COLUMN last_step BIGINT NULL AFTER enabled_at;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE user_mfa
+    DROP COLUMN last_step;
+-- +goose StatementEnd
@@ -0,0 +1,32 @@
+package apikey
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/rzfhlv/gin-example/config"
+	"github.com/rzfhlv/gin-example/internal/modules/apikey/handler"
+	"github.com/rzfhlv/gin-example/internal/modules/apikey/usecase"
+	"github.com/rzfhlv/gin-example/middleware/auth"
+	"github.com/rzfhlv/gin-example/pkg/rbac"
+)
+
+func Mount(route *gin.RouterGroup, h handler.IHandler, a auth.IAuth) (g *gin.RouterGroup) {
+	g = route.Group("/admin/api-keys")
+	g.Use(a.Bearer())
+	g.GET("", a.Can(rbac.APIKEYSMANAGE), h.List)
+	g.POST("", a.Can(rbac.APIKEYSMANAGE), h.Create)
+	g.DELETE("/:id", a.Can(rbac.APIKEYSMANAGE), h.Revoke)
+	return
+}
+
+type APIKey struct {
+	Handler handler.IHandler
+}
+
+func New(cfg *config.Config) *APIKey {
+	Usecase := usecase.New(cfg.Pkg.APIKeys)
+	Handler := handler.New(Usecase)
+
+	return &APIKey{
+		Handler: Handler,
+	}
+}
@@ -0,0 +1,42 @@
+package apikey
+
+import (
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rzfhlv/gin-example/config"
+	mockAuth "github.com/rzfhlv/gin-example/shared/mocks/middleware/auth"
+	mockHandler "github.com/rzfhlv/gin-example/shared/mocks/modules/apikey/handler"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestNew(t *testing.T) {
+	cfg := config.Config{
+		MySQL: nil,
+		Redis: nil,
+	}
+
+	a := New(&cfg)
+	assert.NotNil(t, a)
+}
+
+func TestMount(t *testing.T) {
+	mockHandler := mockHandler.IHandler{}
+	mockAuth := mockAuth.IAuth{}
+	mockAuth.On("Bearer").Return(func() gin.HandlerFunc {
+		return func(c *gin.Context) {
+			c.Next()
+		}
+	})
+	mockAuth.On("Can", mock.Anything).Return(func(permission string) gin.HandlerFunc {
+		return func(c *gin.Context) {
+			c.Next()
+		}
+	})
+
+	g := gin.Default()
+	route := g.Group("/v1")
+	m := Mount(route, &mockHandler, &mockAuth)
+	assert.NotNil(t, m)
+}
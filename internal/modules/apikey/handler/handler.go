@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rzfhlv/gin-example/internal/modules/apikey/model"
+	"github.com/rzfhlv/gin-example/internal/modules/apikey/usecase"
+	pAPIKey "github.com/rzfhlv/gin-example/pkg/apikey"
+	"github.com/rzfhlv/gin-example/pkg/message"
+	"github.com/rzfhlv/gin-example/pkg/rbac"
+	"github.com/rzfhlv/gin-example/pkg/response"
+	"github.com/rzfhlv/gin-example/pkg/validation"
+)
+
+type IHandler interface {
+	Create(g *gin.Context)
+	List(g *gin.Context)
+	Revoke(g *gin.Context)
+}
+
+type Handler struct {
+	usecase usecase.IUsecase
+}
+
+func New(usecase usecase.IUsecase) IHandler {
+	return &Handler{
+		usecase: usecase,
+	}
+}
+
+func (h *Handler) Create(g *gin.Context) {
+	ctx := g.Request.Context()
+
+	payload := model.CreateAPIKey{}
+	err := g.ShouldBindJSON(&payload)
+	if err != nil {
+		log.Printf("Error Binding and Validation API Key, %v", err.Error())
+		response.Error(g, http.StatusUnprocessableEntity, message.UNPROCESSABLEENTITY, validation.Translate(err, g.GetHeader("Accept-Language"))...)
+		return
+	}
+
+	created, err := h.usecase.Create(ctx, payload)
+	if err != nil {
+		log.Printf("Error Create API Key, %v", err.Error())
+		switch err {
+		case rbac.ErrUnknownPermission:
+			response.Error(g, http.StatusUnprocessableEntity, message.UNKNOWNSCOPE)
+		case usecase.ErrInvalidExpiry:
+			response.Error(g, http.StatusUnprocessableEntity, message.INVALIDEXPIRY)
+		default:
+			g.Error(err)
+		}
+		return
+	}
+
+	g.JSON(http.StatusOK, response.Set(message.SUCCESS, message.OK, nil, created))
+}
+
+func (h *Handler) List(g *gin.Context) {
+	ctx := g.Request.Context()
+
+	keys, err := h.usecase.List(ctx)
+	if err != nil {
+		log.Printf("Error List API Key, %v", err.Error())
+		g.Error(err)
+		return
+	}
+
+	g.JSON(http.StatusOK, response.Set(message.SUCCESS, message.OK, nil, keys))
+}
+
+func (h *Handler) Revoke(g *gin.Context) {
+	ctx := g.Request.Context()
+
+	id, err := strconv.ParseInt(g.Param("id"), 10, 64)
+	if err != nil {
+		log.Printf("Error Parse API Key ID, %v", err.Error())
+		response.Error(g, http.StatusUnprocessableEntity, message.UNPROCESSABLEENTITY)
+		return
+	}
+
+	err = h.usecase.Revoke(ctx, id)
+	if err != nil {
+		log.Printf("Error Revoke API Key, %v", err.Error())
+		if err == pAPIKey.ErrNotFound {
+			response.Error(g, http.StatusNotFound, message.NOTFOUND)
+			return
+		}
+		g.Error(err)
+		return
+	}
+
+	g.JSON(http.StatusOK, response.Set(message.SUCCESS, message.OK, nil, nil))
+}
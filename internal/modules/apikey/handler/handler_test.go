@@ -0,0 +1,155 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rzfhlv/gin-example/internal/modules/apikey/model"
+	"github.com/rzfhlv/gin-example/internal/modules/apikey/usecase"
+	"github.com/rzfhlv/gin-example/middleware"
+	pAPIKey "github.com/rzfhlv/gin-example/pkg/apikey"
+	"github.com/rzfhlv/gin-example/pkg/rbac"
+	mockUsecase "github.com/rzfhlv/gin-example/shared/mocks/modules/apikey/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type testCase struct {
+	name, body, param string
+	wantError         error
+	code              int
+}
+
+var (
+	errFoo         = errors.New("error")
+	payloadSuccess = `{"name":"batch","scopes":["gatherings:read"],"expires_at":"2030-01-01T00:00:00Z"}`
+	payloadFail    = `{"name":"batch","scopes":[]}`
+)
+
+func TestNew(t *testing.T) {
+	mockUsecase := mockUsecase.IUsecase{}
+
+	h := New(&mockUsecase)
+	assert.NotNil(t, h)
+}
+
+func TestCreate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCase := []testCase{
+		{
+			name: "Testcase #1: Positive", body: payloadSuccess, wantError: nil, code: http.StatusOK,
+		},
+		{
+			name: "Testcase #2: Negative", body: payloadSuccess, wantError: errFoo, code: http.StatusInternalServerError,
+		},
+		{
+			name: "Testcase #3: Negative", body: payloadFail, wantError: nil, code: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "Testcase #4: Negative", body: payloadSuccess, wantError: rbac.ErrUnknownPermission, code: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "Testcase #5: Negative", body: payloadSuccess, wantError: usecase.ErrInvalidExpiry, code: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "Testcase #6: Negative", body: payloadSuccess, wantError: rbac.ErrForbidden, code: http.StatusForbidden,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := mockUsecase.IUsecase{}
+			mockUsecase.On("Create", mock.Anything, mock.Anything).Return(model.CreatedAPIKey{Secret: "gek_secret"}, tt.wantError)
+
+			h := &Handler{
+				usecase: &mockUsecase,
+			}
+
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = httptest.NewRequest(http.MethodPost, "/v1/admin/api-keys", strings.NewReader(tt.body))
+			ctx.Request.Header.Set("Content-Type", "application/json")
+
+			h.Create(ctx)
+			middleware.RenderError(ctx)
+			assert.Equal(t, tt.code, w.Code)
+			if tt.code == http.StatusOK {
+				assert.Contains(t, w.Body.String(), `"secret":"gek_secret"`)
+			}
+		})
+	}
+}
+
+func TestList(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCase := []testCase{
+		{
+			name: "Testcase #1: Positive", wantError: nil, code: http.StatusOK,
+		},
+		{
+			name: "Testcase #2: Negative", wantError: errFoo, code: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := mockUsecase.IUsecase{}
+			mockUsecase.On("List", mock.Anything).Return([]pAPIKey.Key{{ID: 1, Hash: "hashed"}}, tt.wantError)
+
+			h := &Handler{
+				usecase: &mockUsecase,
+			}
+
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = httptest.NewRequest(http.MethodGet, "/v1/admin/api-keys", nil)
+
+			h.List(ctx)
+			middleware.RenderError(ctx)
+			assert.Equal(t, tt.code, w.Code)
+			assert.NotContains(t, w.Body.String(), "hashed")
+		})
+	}
+}
+
+func TestRevoke(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCase := []testCase{
+		{
+			name: "Testcase #1: Positive", param: "1", wantError: nil, code: http.StatusOK,
+		},
+		{
+			name: "Testcase #2: Negative", param: "1", wantError: errFoo, code: http.StatusInternalServerError,
+		},
+		{
+			name: "Testcase #3: Negative", param: "one", wantError: nil, code: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "Testcase #4: Negative", param: "1", wantError: pAPIKey.ErrNotFound, code: http.StatusNotFound,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := mockUsecase.IUsecase{}
+			mockUsecase.On("Revoke", mock.Anything, int64(1)).Return(tt.wantError)
+
+			h := &Handler{
+				usecase: &mockUsecase,
+			}
+
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = httptest.NewRequest(http.MethodDelete, "/v1/admin/api-keys/"+tt.param, nil)
+			ctx.Params = gin.Params{{Key: "id", Value: tt.param}}
+
+			h.Revoke(ctx)
+			middleware.RenderError(ctx)
+			assert.Equal(t, tt.code, w.Code)
+		})
+	}
+}
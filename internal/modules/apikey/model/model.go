@@ -0,0 +1,19 @@
+package model
+
+import (
+	"time"
+
+	pAPIKey "github.com/rzfhlv/gin-example/pkg/apikey"
+)
+
+type CreateAPIKey struct {
+	Name      string     `json:"name" binding:"required"`
+	Scopes    []string   `json:"scopes" binding:"required,min=1"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// CreatedAPIKey is the only response carrying the secret.
+type CreatedAPIKey struct {
+	pAPIKey.Key
+	Secret string `json:"secret"`
+}
@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/rzfhlv/gin-example/internal/modules/apikey/model"
+	pAPIKey "github.com/rzfhlv/gin-example/pkg/apikey"
+	"github.com/rzfhlv/gin-example/pkg/identity"
+	"github.com/rzfhlv/gin-example/pkg/rbac"
+)
+
+var (
+	ErrInvalidExpiry = errors.New("api key expiry must be in the future")
+)
+
+type IUsecase interface {
+	Create(ctx context.Context, payload model.CreateAPIKey) (created model.CreatedAPIKey, err error)
+	List(ctx context.Context) (keys []pAPIKey.Key, err error)
+	Revoke(ctx context.Context, id int64) (err error)
+}
+
+type Usecase struct {
+	store pAPIKey.Store
+}
+
+func New(store pAPIKey.Store) IUsecase {
+	return &Usecase{
+		store: store,
+	}
+}
+
+// Create issues a key owned by the caller, the secret is returned here only.
+func (u *Usecase) Create(ctx context.Context, payload model.CreateAPIKey) (created model.CreatedAPIKey, err error) {
+	caller, ok := identity.FromContext(ctx)
+	if !ok {
+		err = rbac.ErrForbidden
+		return
+	}
+
+	for _, scope := range payload.Scopes {
+		if !rbac.IsPermission(scope) {
+			err = rbac.ErrUnknownPermission
+			return
+		}
+	}
+
+	now := time.Now()
+	if payload.ExpiresAt != nil && !payload.ExpiresAt.After(now) {
+		err = ErrInvalidExpiry
+		return
+	}
+
+	secret, prefix, hash, err := pAPIKey.Generate()
+	if err != nil {
+		return
+	}
+
+	key := pAPIKey.Key{
+		UserID:    caller.ID,
+		Name:      payload.Name,
+		Prefix:    prefix,
+		Hash:      hash,
+		Scopes:    payload.Scopes,
+		ExpiresAt: payload.ExpiresAt,
+		CreatedAt: now,
+	}
+	key.ID, err = u.store.Create(ctx, key)
+	if err != nil {
+		return
+	}
+
+	created = model.CreatedAPIKey{
+		Key:    key,
+		Secret: secret,
+	}
+	return
+}
+
+func (u *Usecase) List(ctx context.Context) (keys []pAPIKey.Key, err error) {
+	keys, err = u.store.List(ctx)
+	if err != nil {
+		return
+	}
+
+	if len(keys) < 1 {
+		keys = []pAPIKey.Key{}
+	}
+	return
+}
+
+func (u *Usecase) Revoke(ctx context.Context, id int64) (err error) {
+	err = u.store.Revoke(ctx, id, time.Now())
+	return
+}
@@ -0,0 +1,156 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rzfhlv/gin-example/internal/modules/apikey/model"
+	pAPIKey "github.com/rzfhlv/gin-example/pkg/apikey"
+	"github.com/rzfhlv/gin-example/pkg/identity"
+	"github.com/rzfhlv/gin-example/pkg/rbac"
+	pToken "github.com/rzfhlv/gin-example/pkg/token"
+	mockAPIKey "github.com/rzfhlv/gin-example/shared/mocks/pkg/apikey"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	errFoo = errors.New("error")
+	admin  = identity.NewContext(context.Background(), identity.Identity{ID: 1, Roles: []string{rbac.ADMIN}})
+)
+
+func TestNew(t *testing.T) {
+	mockAPIKey := mockAPIKey.Store{}
+
+	u := New(&mockAPIKey)
+	assert.NotNil(t, u)
+}
+
+func TestCreate(t *testing.T) {
+	past, future := time.Now().Add(-time.Hour), time.Now().Add(time.Hour)
+
+	testCase := []struct {
+		name      string
+		ctx       context.Context
+		payload   model.CreateAPIKey
+		wantDBErr error
+		wantError error
+	}{
+		{
+			name: "Testcase #1: Positive", ctx: admin,
+			payload: model.CreateAPIKey{Name: "batch", Scopes: []string{rbac.GATHERINGSREAD, rbac.INVITATIONSWRITE}, ExpiresAt: &future},
+		},
+		{
+			name: "Testcase #2: Positive", ctx: admin,
+			payload: model.CreateAPIKey{Name: "batch", Scopes: []string{rbac.GATHERINGSREAD}},
+		},
+		{
+			name: "Testcase #3: Negative", ctx: admin,
+			payload:   model.CreateAPIKey{Name: "batch", Scopes: []string{rbac.ALL}},
+			wantError: rbac.ErrUnknownPermission,
+		},
+		{
+			name: "Testcase #4: Negative", ctx: admin,
+			payload:   model.CreateAPIKey{Name: "batch", Scopes: []string{rbac.GATHERINGSREAD}, ExpiresAt: &past},
+			wantError: ErrInvalidExpiry,
+		},
+		{
+			name: "Testcase #5: Negative", ctx: admin,
+			payload:   model.CreateAPIKey{Name: "batch", Scopes: []string{rbac.GATHERINGSREAD}},
+			wantDBErr: errFoo, wantError: errFoo,
+		},
+		{
+			name: "Testcase #6: Negative", ctx: context.Background(),
+			payload:   model.CreateAPIKey{Name: "batch", Scopes: []string{rbac.GATHERINGSREAD}},
+			wantError: rbac.ErrForbidden,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPIKey := mockAPIKey.Store{}
+			mockAPIKey.On("Create", mock.Anything, mock.Anything).Return(int64(3), tt.wantDBErr)
+
+			u := &Usecase{
+				store: &mockAPIKey,
+			}
+
+			created, err := u.Create(tt.ctx, tt.payload)
+			assert.Equal(t, tt.wantError, err)
+			if tt.wantError != nil {
+				return
+			}
+
+			assert.Equal(t, int64(3), created.ID)
+			assert.Equal(t, int64(1), created.UserID)
+			assert.True(t, strings.HasPrefix(created.Secret, pAPIKey.KEYPREFIX))
+			assert.Equal(t, pToken.Hash(created.Secret), created.Hash)
+			assert.Equal(t, created.Secret[:pAPIKey.DISPLAYLENGTH], created.Prefix)
+			assert.Equal(t, pAPIKey.Scopes(tt.payload.Scopes), created.Scopes)
+		})
+	}
+}
+
+func TestList(t *testing.T) {
+	testCase := []struct {
+		name      string
+		keys      []pAPIKey.Key
+		wantError error
+	}{
+		{
+			name: "Testcase #1: Positive", keys: []pAPIKey.Key{{ID: 1}},
+		},
+		{
+			name: "Testcase #2: Positive", keys: nil,
+		},
+		{
+			name: "Testcase #3: Negative", wantError: errFoo,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPIKey := mockAPIKey.Store{}
+			mockAPIKey.On("List", mock.Anything).Return(tt.keys, tt.wantError)
+
+			u := &Usecase{
+				store: &mockAPIKey,
+			}
+
+			keys, err := u.List(context.Background())
+			assert.Equal(t, tt.wantError, err)
+			if tt.wantError == nil {
+				assert.NotNil(t, keys)
+				assert.Len(t, keys, len(tt.keys))
+			}
+		})
+	}
+}
+
+func TestRevoke(t *testing.T) {
+	testCase := []struct {
+		name      string
+		wantError error
+	}{
+		{
+			name: "Testcase #1: Positive",
+		},
+		{
+			name: "Testcase #2: Negative", wantError: pAPIKey.ErrNotFound,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPIKey := mockAPIKey.Store{}
+			mockAPIKey.On("Revoke", mock.Anything, int64(1), mock.Anything).Return(tt.wantError)
+
+			u := &Usecase{
+				store: &mockAPIKey,
+			}
+
+			err := u.Revoke(context.Background(), 1)
+			assert.Equal(t, tt.wantError, err)
+		})
+	}
+}
@@ -7,15 +7,18 @@ import (
 	"github.com/rzfhlv/gin-example/internal/modules/gathering/repository"
 	"github.com/rzfhlv/gin-example/internal/modules/gathering/usecase"
 	"github.com/rzfhlv/gin-example/middleware/auth"
+	"github.com/rzfhlv/gin-example/pkg/rbac"
 )
 
 func Mount(route *gin.RouterGroup, h handler.IHandler, a auth.IAuth) (g *gin.RouterGroup) {
 	g = route.Group("/gatherings")
-	g.Use(a.Bearer())
-	g.GET("", h.Get)
-	g.GET("/:id", h.GetByID)
-	g.POST("", h.Create)
-	g.GET("/:id/detail", h.GetDetailByID)
+	g.Use(a.APIKey(), a.Bearer())
+	g.GET("", a.Can(rbac.GATHERINGSREAD), h.Get)
+	g.GET("/:id", a.Can(rbac.GATHERINGSREAD), h.GetByID)
+	g.POST("", a.Can(rbac.GATHERINGSWRITE), h.Create)
+	g.GET("/:id/detail", a.Can(rbac.GATHERINGSREAD), h.GetDetailByID)
+	g.PATCH("/:id", a.Can(rbac.GATHERINGSWRITE), h.Update)
+	g.POST("/:id/organizers", a.Can(rbac.GATHERINGSWRITE), h.AddOrganizer)
 	return
 }
 
@@ -1,7 +1,7 @@
 package handler
 
 import (
-	"database/sql"
+	"errors"
 	"log"
 	"net/http"
 	"strconv"
@@ -12,6 +12,7 @@ import (
 	"github.com/rzfhlv/gin-example/pkg/message"
 	"github.com/rzfhlv/gin-example/pkg/param"
 	"github.com/rzfhlv/gin-example/pkg/response"
+	"github.com/rzfhlv/gin-example/pkg/validation"
 )
 
 type IHandler interface {
@@ -19,6 +20,8 @@ type IHandler interface {
 	Get(g *gin.Context)
 	GetByID(g *gin.Context)
 	GetDetailByID(g *gin.Context)
+	Update(g *gin.Context)
+	AddOrganizer(g *gin.Context)
 }
 
 type Handler struct {
@@ -38,14 +41,14 @@ func (h *Handler) Create(g *gin.Context) {
 	err := g.ShouldBindJSON(&gatheringPayload)
 	if err != nil {
 		log.Printf("Error Binding and Validation Gathering, %v", err.Error())
-		g.JSON(http.StatusUnprocessableEntity, response.Set(message.ERROR, err.Error(), nil, nil))
+		response.Error(g, http.StatusUnprocessableEntity, message.UNPROCESSABLEENTITY, validation.Translate(err, g.GetHeader("Accept-Language"))...)
 		return
 	}
 
 	gathering, err := h.usecase.Create(ctx, gatheringPayload)
 	if err != nil {
 		log.Printf("Error Create Gathering, %v", err.Error())
-		g.JSON(http.StatusInternalServerError, response.Set(message.ERROR, message.SOMETHINGWENTWRONG, nil, nil))
+		g.Error(err)
 		return
 	}
 
@@ -61,18 +64,26 @@ func (h *Handler) Get(g *gin.Context) {
 	err := g.ShouldBind(&queryParam)
 	if err != nil {
 		log.Printf("Error Binding Query Param Gathering, %v", err.Error())
-		g.JSON(http.StatusUnprocessableEntity, response.Set(message.ERROR, message.UNPROCESSABLEENTITY, nil, nil))
+		response.Error(g, http.StatusUnprocessableEntity, message.UNPROCESSABLEENTITY, validation.Translate(err, g.GetHeader("Accept-Language"))...)
 		return
 	}
+	queryParam.Filters = g.Request.URL.Query()
 
-	gatherings, total, err := h.usecase.Get(ctx, queryParam)
+	gatherings, page, err := h.usecase.Get(ctx, queryParam)
 	if err != nil {
 		log.Printf("Error Get Gathering, %v", err.Error())
-		g.JSON(http.StatusInternalServerError, response.Set(message.ERROR, message.SOMETHINGWENTWRONG, nil, nil))
+		if errors.Is(err, param.ErrCursorDisabled) {
+			response.Error(g, http.StatusBadRequest, err.Error())
+			return
+		}
+		if param.IsInvalid(err) {
+			response.Error(g, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		g.Error(err)
 		return
 	}
-	queryParam.Total = total
-	meta := response.BuildMeta(queryParam, len(gatherings))
+	meta := response.BuildMeta(queryParam, page, len(gatherings), g.Request.URL)
 
 	g.JSON(http.StatusOK, response.Set(message.SUCCESS, message.OK, meta, gatherings))
 }
@@ -84,18 +95,14 @@ func (h *Handler) GetByID(g *gin.Context) {
 	gatheringID, err := strconv.ParseInt(id, 10, 64)
 	if err != nil {
 		log.Printf("Error Parse Gathering ID, %v", err.Error())
-		g.JSON(http.StatusUnprocessableEntity, response.Set(message.ERROR, message.UNPROCESSABLEENTITY, nil, nil))
+		response.Error(g, http.StatusUnprocessableEntity, message.UNPROCESSABLEENTITY)
 		return
 	}
 
 	gathering, err := h.usecase.GetByID(ctx, gatheringID)
 	if err != nil {
 		log.Printf("Error Get By ID Gathering, %v", err.Error())
-		if err == sql.ErrNoRows {
-			g.JSON(http.StatusNotFound, response.Set(message.ERROR, message.NOTFOUND, nil, nil))
-			return
-		}
-		g.JSON(http.StatusInternalServerError, response.Set(message.SUCCESS, message.SOMETHINGWENTWRONG, nil, nil))
+		g.Error(err)
 		return
 	}
 
@@ -109,20 +116,74 @@ func (h *Handler) GetDetailByID(g *gin.Context) {
 	gatheringID, err := strconv.ParseInt(id, 10, 64)
 	if err != nil {
 		log.Printf("Error Parse Gathering ID, %v", err.Error())
-		g.JSON(http.StatusUnprocessableEntity, response.Set(message.ERROR, message.UNPROCESSABLEENTITY, nil, nil))
+		response.Error(g, http.StatusUnprocessableEntity, message.UNPROCESSABLEENTITY)
 		return
 	}
 
 	gathering, err := h.usecase.GetDetailByID(ctx, gatheringID)
 	if err != nil {
 		log.Printf("Error Get Detail By ID Gathering, %v", err.Error())
-		if err == sql.ErrNoRows {
-			g.JSON(http.StatusNotFound, response.Set(message.ERROR, message.NOTFOUND, nil, nil))
-			return
-		}
-		g.JSON(http.StatusInternalServerError, response.Set(message.SUCCESS, message.SOMETHINGWENTWRONG, nil, nil))
+		g.Error(err)
 		return
 	}
 
 	g.JSON(http.StatusOK, response.Set(message.SUCCESS, message.OK, nil, gathering))
 }
+
+func (h *Handler) Update(g *gin.Context) {
+	ctx := g.Request.Context()
+
+	id := g.Param("id")
+	gatheringID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		log.Printf("Error Parse Gathering ID, %v", err.Error())
+		response.Error(g, http.StatusUnprocessableEntity, message.UNPROCESSABLEENTITY)
+		return
+	}
+
+	gatheringPayload := model.Gathering{}
+	err = g.ShouldBindJSON(&gatheringPayload)
+	if err != nil {
+		log.Printf("Error Binding and Validation Gathering, %v", err.Error())
+		response.Error(g, http.StatusUnprocessableEntity, message.UNPROCESSABLEENTITY, validation.Translate(err, g.GetHeader("Accept-Language"))...)
+		return
+	}
+
+	gathering, err := h.usecase.Update(ctx, gatheringPayload, gatheringID)
+	if err != nil {
+		log.Printf("Error Update Gathering, %v", err.Error())
+		g.Error(err)
+		return
+	}
+
+	g.JSON(http.StatusOK, response.Set(message.SUCCESS, message.OK, nil, gathering))
+}
+
+func (h *Handler) AddOrganizer(g *gin.Context) {
+	ctx := g.Request.Context()
+
+	id := g.Param("id")
+	gatheringID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		log.Printf("Error Parse Gathering ID, %v", err.Error())
+		response.Error(g, http.StatusUnprocessableEntity, message.UNPROCESSABLEENTITY)
+		return
+	}
+
+	organizer := model.Organizer{}
+	err = g.ShouldBindJSON(&organizer)
+	if err != nil {
+		log.Printf("Error Binding and Validation Organizer, %v", err.Error())
+		response.Error(g, http.StatusUnprocessableEntity, message.UNPROCESSABLEENTITY, validation.Translate(err, g.GetHeader("Accept-Language"))...)
+		return
+	}
+
+	err = h.usecase.AddOrganizer(ctx, gatheringID, organizer.MemberID)
+	if err != nil {
+		log.Printf("Error Add Organizer Gathering, %v", err.Error())
+		g.Error(err)
+		return
+	}
+
+	g.JSON(http.StatusOK, response.Set(message.SUCCESS, message.OK, nil, organizer))
+}
@@ -3,6 +3,7 @@ package handler
 import (
 	"database/sql"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -10,6 +11,9 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/rzfhlv/gin-example/internal/modules/gathering/model"
+	"github.com/rzfhlv/gin-example/middleware"
+	"github.com/rzfhlv/gin-example/pkg/param"
+	"github.com/rzfhlv/gin-example/pkg/rbac"
 	mockUsecase "github.com/rzfhlv/gin-example/shared/mocks/modules/gathering/usecase"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -23,8 +27,8 @@ type testCase struct {
 
 var (
 	errFoo         = errors.New("error")
-	payloadSuccess = `{"creator":"john doe","type":"family","name":"family gathering","location":"puncak","schedule_at":"2023-11-10 12:00:00"}`
-	payloadFail    = `{"creator":"john doe","type":"","name":"family gathering","location":"puncak","schedule_at":"2023-11-10 12:00:00"}`
+	payloadSuccess = `{"creator":"john doe","type":"family","name":"family gathering","location":"puncak","schedule_at":"2023-11-10T12:00:00+07:00"}`
+	payloadFail    = `{"creator":"john doe","type":"","name":"family gathering","location":"puncak","schedule_at":"2023-11-10T12:00:00+07:00"}`
 )
 
 func TestNew(t *testing.T) {
@@ -47,6 +51,15 @@ func TestCreate(t *testing.T) {
 		{
 			name: "Testcase #3: Negative", body: payloadFail, wantError: errFoo, code: http.StatusUnprocessableEntity,
 		},
+		{
+			name: "Testcase #4: Negative", body: payloadSuccess, wantError: rbac.ErrForbidden, code: http.StatusForbidden,
+		},
+		{
+			name: "Testcase #5: Negative", body: strings.Replace(payloadSuccess, `"family"`, `"party"`, 1), wantError: nil, code: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "Testcase #6: Negative", body: strings.Replace(payloadSuccess, "2023-11-10T12:00:00+07:00", "2023-11-10 12:00:00", 1), wantError: nil, code: http.StatusUnprocessableEntity,
+		},
 	}
 	for _, tt := range testCase {
 		t.Run(tt.name, func(t *testing.T) {
@@ -63,6 +76,7 @@ func TestCreate(t *testing.T) {
 			ctx.Request.Header.Set("Content-Type", "application/json")
 
 			h.Create(ctx)
+			middleware.RenderError(ctx)
 			assert.EqualValues(t, tt.code, w.Code)
 		})
 	}
@@ -82,11 +96,17 @@ func TestGet(t *testing.T) {
 		{
 			name: "Testcase #3: Negative", queryParam: "?page=one", wantError: errFoo, code: http.StatusUnprocessableEntity,
 		},
+		{
+			name: "Testcase #4: Negative", queryParam: "?sort=password", wantError: fmt.Errorf("%w: password", param.ErrInvalidSort), code: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "Testcase #5: Negative", queryParam: "?cursor=e30.", wantError: param.ErrCursorDisabled, code: http.StatusBadRequest,
+		},
 	}
 	for _, tt := range testCase {
 		t.Run(tt.name, func(t *testing.T) {
 			mockUsecase := mockUsecase.IUsecase{}
-			mockUsecase.On("Get", mock.Anything, mock.Anything).Return([]model.Gathering{}, expectedCount, tt.wantError)
+			mockUsecase.On("Get", mock.Anything, mock.Anything).Return([]model.Gathering{}, param.Page{Total: &expectedCount}, tt.wantError)
 
 			h := &Handler{
 				usecase: &mockUsecase,
@@ -98,6 +118,7 @@ func TestGet(t *testing.T) {
 			ctx.Request.Header.Set("Content-Type", "application/json")
 
 			h.Get(ctx)
+			middleware.RenderError(ctx)
 			assert.Equal(t, tt.code, w.Code)
 		})
 	}
@@ -136,6 +157,7 @@ func TestGetByID(t *testing.T) {
 			ctx.Params = gin.Params{{Key: "id", Value: tt.param}}
 
 			h.GetByID(ctx)
+			middleware.RenderError(ctx)
 			assert.Equal(t, tt.code, w.Code)
 		})
 	}
@@ -157,6 +179,9 @@ func TestGetDetailByID(t *testing.T) {
 		{
 			name: "Testcase #3: Negative", param: "0", wantError: sql.ErrNoRows, code: http.StatusNotFound,
 		},
+		{
+			name: "Testcase #4: Negative", param: "1", wantError: rbac.ErrForbidden, code: http.StatusForbidden,
+		},
 	}
 	for _, tt := range testCase {
 		t.Run(tt.name, func(t *testing.T) {
@@ -174,6 +199,97 @@ func TestGetDetailByID(t *testing.T) {
 			ctx.Params = gin.Params{{Key: "id", Value: tt.param}}
 
 			h.GetDetailByID(ctx)
+			middleware.RenderError(ctx)
+			assert.Equal(t, tt.code, w.Code)
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCase := []testCase{
+		{
+			name: "Testcase #1: Positive", param: "1", body: payloadSuccess, wantError: nil, code: http.StatusOK,
+		},
+		{
+			name: "Testcase #2: Negative", param: "one", body: payloadSuccess, wantError: nil, code: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "Testcase #3: Negative", param: "1", body: payloadFail, wantError: nil, code: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "Testcase #4: Negative", param: "1", body: payloadSuccess, wantError: sql.ErrNoRows, code: http.StatusNotFound,
+		},
+		{
+			name: "Testcase #5: Negative", param: "1", body: payloadSuccess, wantError: rbac.ErrForbidden, code: http.StatusForbidden,
+		},
+		{
+			name: "Testcase #6: Negative", param: "1", body: payloadSuccess, wantError: errFoo, code: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := mockUsecase.IUsecase{}
+			mockUsecase.On("Update", mock.Anything, mock.Anything, int64(1)).Return(model.Gathering{}, tt.wantError)
+
+			h := &Handler{
+				usecase: &mockUsecase,
+			}
+
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = httptest.NewRequest(http.MethodPatch, "/v1/gatherings/"+tt.param, strings.NewReader(tt.body))
+			ctx.Request.Header.Set("Content-Type", "application/json")
+			ctx.Params = gin.Params{{Key: "id", Value: tt.param}}
+
+			h.Update(ctx)
+			middleware.RenderError(ctx)
+			assert.Equal(t, tt.code, w.Code)
+		})
+	}
+}
+
+func TestAddOrganizer(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCase := []testCase{
+		{
+			name: "Testcase #1: Positive", param: "1", body: `{"member_id":2}`, wantError: nil, code: http.StatusOK,
+		},
+		{
+			name: "Testcase #2: Negative", param: "one", body: `{"member_id":2}`, wantError: nil, code: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "Testcase #3: Negative", param: "1", body: `{"member_id":0}`, wantError: nil, code: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "Testcase #4: Negative", param: "1", body: `{"member_id":2}`, wantError: sql.ErrNoRows, code: http.StatusNotFound,
+		},
+		{
+			name: "Testcase #5: Negative", param: "1", body: `{"member_id":2}`, wantError: rbac.ErrForbidden, code: http.StatusForbidden,
+		},
+		{
+			name: "Testcase #6: Negative", param: "1", body: `{"member_id":2}`, wantError: errFoo, code: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := mockUsecase.IUsecase{}
+			mockUsecase.On("AddOrganizer", mock.Anything, int64(1), int64(2)).Return(tt.wantError)
+
+			h := &Handler{
+				usecase: &mockUsecase,
+			}
+
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = httptest.NewRequest(http.MethodPost, "/v1/gatherings/"+tt.param+"/organizers", strings.NewReader(tt.body))
+			ctx.Request.Header.Set("Content-Type", "application/json")
+			ctx.Params = gin.Params{{Key: "id", Value: tt.param}}
+
+			h.AddOrganizer(ctx)
+			middleware.RenderError(ctx)
 			assert.Equal(t, tt.code, w.Code)
 		})
 	}
@@ -6,11 +6,11 @@ import (
 
 type Gathering struct {
 	ID           int64     `json:"id,omitempty" db:"id"`
-	Creator      string    `json:"creator" db:"creator" binding:"required"`
-	Type         string    `json:"type" db:"type" binding:"required"`
+	Creator      string    `json:"creator" db:"creator"`
+	Type         string    `json:"type" db:"type" binding:"required,gathering_type"`
 	Name         string    `json:"name" db:"name" binding:"required"`
 	Location     string    `json:"location" db:"location" binding:"required"`
-	ScheduleAt   string    `json:"schedule_at" db:"schedule_at" binding:"required"`
+	ScheduleAt   string    `json:"schedule_at" db:"schedule_at" binding:"required,rfc3339"`
 	MemberID     int64     `json:"-" db:"member_id"`
 	ScheduleAtDB time.Time `json:"-" db:"schedule_at"`
 }
@@ -27,3 +27,7 @@ type Attendee struct {
 	Email     string `json:"email"`
 	Status    string `json:"status"`
 }
+
+type Organizer struct {
+	MemberID int64 `json:"member_id" binding:"required"`
+}
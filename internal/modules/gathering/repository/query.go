@@ -1,22 +1,50 @@
 package repository
 
+import "github.com/rzfhlv/gin-example/pkg/param"
+
 var (
+	GatheringSpec = param.Spec{
+		Filters: map[string]param.Filter{
+			"type":          {Columns: []string{"type"}, Match: param.EQUAL},
+			"creator":       {Columns: []string{"creator"}, Match: param.EQUAL},
+			"location":      {Columns: []string{"location"}, Match: param.CONTAINS},
+			"schedule_from": {Columns: []string{"schedule_at"}, Match: param.FROM},
+			"schedule_to":   {Columns: []string{"schedule_at"}, Match: param.TO},
+		},
+		Search: []string{"name", "location"},
+		Sorts: map[string]string{
+			"id": "id", "name": "name", "type": "type", "schedule_at": "schedule_at",
+		},
+		Default: "-id",
+	}
+
 	CreateGatheringQuery = `INSERT INTO gatherings
 		(creator, member_id, type, name, location, schedule_at)
 		VALUES (?, ?, ?, ?, ?, ?);`
 	GetGatheringQuery = `SELECT id, creator, member_id, type,
 		name, location, schedule_at
-		FROM gatherings ORDER BY id DESC LIMIT ? OFFSET ?;`
+		FROM gatherings %s %s LIMIT ? OFFSET ?;`
 	GetGatheringByIDQuery = `SELECT id, creator, member_id,
 		type, name, location, schedule_at
 		FROM gatherings WHERE id = ?;`
 	CountGatheringQuery = `SELECT count(*)
-		FROM gatherings;`
+		FROM gatherings %s`
 	GetDetailGatheringByIDQuery = `SELECT m.id, m.first_name,
 		m.last_name, m.email, i.status
 		FROM members m
 		LEFT JOIN attendee a ON m.id = a.member_id
 		LEFT JOIN invitations i ON a.gathering_id = i.gathering_id
 		AND a.member_id = i.member_id
-		WHERE a.gathering_id = ?;`
+		WHERE a.gathering_id = ? AND m.deleted_at IS NULL;`
+	UpdateGatheringQuery = `UPDATE gatherings
+		SET type = ?, name = ?, location = ?, schedule_at = ?
+		WHERE id = ?;`
+	GetMemberIDByUserIDQuery = `SELECT id
+		FROM members WHERE user_id = ? AND deleted_at IS NULL;`
+	IsOrganizerQuery = `SELECT count(*)
+		FROM gathering_organizers
+		WHERE gathering_id = ? AND member_id = ?;`
+	AddOrganizerQuery = `INSERT IGNORE INTO gathering_organizers
+		(gathering_id, member_id)
+		VALUES (?, ?);`
 )
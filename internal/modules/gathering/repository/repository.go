@@ -3,18 +3,25 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/rzfhlv/gin-example/internal/modules/gathering/model"
+	pErrors "github.com/rzfhlv/gin-example/pkg/errors"
 	"github.com/rzfhlv/gin-example/pkg/param"
 )
 
 type IRepository interface {
 	Create(ctx context.Context, gathering model.Gathering) (result sql.Result, err error)
-	Get(ctx context.Context, param param.Param) (gatherings []model.Gathering, err error)
+	Get(ctx context.Context, param param.Param) (gatherings []model.Gathering, page param.Page, err error)
 	GetByID(ctx context.Context, id int64) (gathering model.Gathering, err error)
-	Count(ctx context.Context) (total int64, err error)
+	Count(ctx context.Context, param param.Param) (total int64, err error)
 	GetDetailByID(ctx context.Context, id int64) (gathering model.GatheringDetail, err error)
+	Update(ctx context.Context, gathering model.Gathering, id int64) (result sql.Result, err error)
+	GetMemberIDByUserID(ctx context.Context, userID int64) (memberID int64, err error)
+	IsOrganizer(ctx context.Context, gatheringID, memberID int64) (ok bool, err error)
+	AddOrganizer(ctx context.Context, gatheringID, memberID int64) (err error)
 }
 
 type Repository struct {
@@ -31,21 +38,47 @@ func (r *Repository) Create(ctx context.Context, gathering model.Gathering) (res
 	result, err = r.db.Exec(CreateGatheringQuery,
 		gathering.Creator, gathering.MemberID, gathering.Type,
 		gathering.Name, gathering.Location, gathering.ScheduleAtDB)
+	err = pErrors.FromSQL(err)
 	return
 }
 
-func (r *Repository) Get(ctx context.Context, param param.Param) (gatherings []model.Gathering, err error) {
-	err = r.db.Select(&gatherings, GetGatheringQuery, param.Limit, param.CalculateOffset())
+func (r *Repository) Get(ctx context.Context, param param.Param) (gatherings []model.Gathering, page param.Page, err error) {
+	where, args, err := GatheringSpec.Seek(param)
+	if err != nil {
+		return
+	}
+
+	orderBy, err := GatheringSpec.OrderBy(param)
+	if err != nil {
+		return
+	}
+
+	args = append(args, param.Limit, param.CalculateOffset())
+	err = r.db.Select(&gatherings, fmt.Sprintf(GetGatheringQuery, where, orderBy), args...)
+	if err != nil {
+		return
+	}
+
+	page, err = GatheringSpec.Paginate(param, gatherings, func(i int) map[string]interface{} {
+		return gatheringValues(gatherings[i])
+	})
 	return
 }
 
 func (r *Repository) GetByID(ctx context.Context, id int64) (gathering model.Gathering, err error) {
 	err = r.db.Get(&gathering, GetGatheringByIDQuery, id)
+	err = pErrors.FromSQL(err)
 	return
 }
 
-func (r *Repository) Count(ctx context.Context) (total int64, err error) {
-	err = r.db.Get(&total, CountGatheringQuery)
+// Count applies the same filters as Get so the total matches the list.
+func (r *Repository) Count(ctx context.Context, param param.Param) (total int64, err error) {
+	where, args, err := GatheringSpec.Where(param)
+	if err != nil {
+		return
+	}
+
+	err = r.db.Get(&total, fmt.Sprintf(CountGatheringQuery, where), args...)
 	return
 }
 
@@ -67,3 +100,42 @@ func (r *Repository) GetDetailByID(ctx context.Context, id int64) (gathering mod
 	}
 	return
 }
+
+func (r *Repository) Update(ctx context.Context, gathering model.Gathering, id int64) (result sql.Result, err error) {
+	result, err = r.db.Exec(UpdateGatheringQuery,
+		gathering.Type, gathering.Name, gathering.Location,
+		gathering.ScheduleAtDB, id)
+	err = pErrors.FromSQL(err)
+	return
+}
+
+func (r *Repository) GetMemberIDByUserID(ctx context.Context, userID int64) (memberID int64, err error) {
+	err = r.db.Get(&memberID, GetMemberIDByUserIDQuery, userID)
+	err = pErrors.FromSQL(err)
+	return
+}
+
+func (r *Repository) IsOrganizer(ctx context.Context, gatheringID, memberID int64) (ok bool, err error) {
+	var total int64
+	err = r.db.Get(&total, IsOrganizerQuery, gatheringID, memberID)
+	ok = total > 0
+	return
+}
+
+func (r *Repository) AddOrganizer(ctx context.Context, gatheringID, memberID int64) (err error) {
+	_, err = r.db.Exec(AddOrganizerQuery, gatheringID, memberID)
+	err = pErrors.FromSQL(err)
+	return
+}
+
+// gatheringValues are the sort columns of a gathering, for its cursor. The
+// schedule is read back as RFC 3339 text.
+func gatheringValues(gathering model.Gathering) map[string]interface{} {
+	var scheduleAt interface{} = gathering.ScheduleAt
+	if parsed, err := time.Parse(time.RFC3339, gathering.ScheduleAt); err == nil {
+		scheduleAt = parsed
+	}
+	return map[string]interface{}{
+		"id": gathering.ID, "name": gathering.Name, "type": gathering.Type, "schedule_at": scheduleAt,
+	}
+}
@@ -141,7 +141,7 @@ func TestGet(t *testing.T) {
 				tt.beforeTest(mockSQL)
 			}
 
-			gatherings, err := r.Get(tt.args, paramTest)
+			gatherings, _, err := r.Get(tt.args, paramTest)
 			if tt.wantError {
 				assert.Error(t, err)
 				assert.Empty(t, gatherings)
@@ -227,7 +227,7 @@ func TestCount(t *testing.T) {
 			beforeTest: func(s sqlmock.Sqlmock) {
 				rows := sqlmock.NewRows([]string{"count"}).
 					AddRow(expectedCount)
-				s.ExpectQuery("SELECT count(*) FROM gatherings;").
+				s.ExpectQuery("SELECT count(*) FROM gatherings").
 					WillReturnRows(rows)
 			},
 			want:      nil,
@@ -237,7 +237,7 @@ func TestCount(t *testing.T) {
 			name: "Testcase #2: Negative",
 			args: ctx,
 			beforeTest: func(s sqlmock.Sqlmock) {
-				s.ExpectQuery("SELECT count(*) FROM gatherings;").
+				s.ExpectQuery("SELECT count(*) FROM gatherings").
 					WithArgs(gatherings[0].ID).
 					WillReturnError(errFoo)
 			},
@@ -260,7 +260,7 @@ func TestCount(t *testing.T) {
 				tt.beforeTest(mockSQL)
 			}
 
-			total, err := r.Count(tt.args)
+			total, err := r.Count(tt.args, paramTest)
 			if tt.wantError {
 				assert.Error(t, err)
 			} else {
@@ -291,7 +291,7 @@ func TestGetDetailByID(t *testing.T) {
 				LEFT JOIN attendee a ON m.id = a.member_id
 				LEFT JOIN invitations i ON a.gathering_id = i.gathering_id
 				AND a.member_id = i.member_id
-				WHERE a.gathering_id = ?;`).
+				WHERE a.gathering_id = ? AND m.deleted_at IS NULL;`).
 					WithArgs(gatherings[0].ID).
 					WillReturnRows(rows)
 			},
@@ -307,7 +307,7 @@ func TestGetDetailByID(t *testing.T) {
 				LEFT JOIN attendee a ON m.id = a.member_id
 				LEFT JOIN invitations i ON a.gathering_id = i.gathering_id
 				AND a.member_id = i.member_id
-				WHERE a.gathering_id = ?;`).
+				WHERE a.gathering_id = ? AND m.deleted_at IS NULL;`).
 					WithArgs(gatherings[0].ID).
 					WillReturnError(errFoo)
 			},
@@ -328,7 +328,7 @@ func TestGetDetailByID(t *testing.T) {
 				LEFT JOIN attendee a ON m.id = a.member_id
 				LEFT JOIN invitations i ON a.gathering_id = i.gathering_id
 				AND a.member_id = i.member_id
-				WHERE a.gathering_id = ?;`).
+				WHERE a.gathering_id = ? AND m.deleted_at IS NULL;`).
 					WithArgs(gatherings[0].ID).
 					WillReturnRows(rows)
 			},
@@ -366,3 +366,227 @@ func TestGetDetailByID(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdate(t *testing.T) {
+	testCase := []testCase{
+		{
+			name: "Testcase #1: Positive",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				s.ExpectExec("UPDATE gatherings SET type = ?, name = ?, location = ?, schedule_at = ? WHERE id = ?;").
+					WithArgs(gatherings[0].Type, gatherings[0].Name, gatherings[0].Location, gatherings[0].ScheduleAtDB, gatherings[0].ID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			want:      nil,
+			wantError: false,
+		},
+		{
+			name: "Testcase #2: Negative",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				s.ExpectExec("UPDATE gatherings SET type = ?, name = ?, location = ?, schedule_at = ? WHERE id = ?;").
+					WithArgs(gatherings[0].Type, gatherings[0].Name, gatherings[0].Location, gatherings[0].ScheduleAtDB, gatherings[0].ID).
+					WillReturnError(errFoo)
+			},
+			want:      errFoo,
+			wantError: true,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mockSQL, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			defer mockDB.Close()
+
+			db := sqlx.NewDb(mockDB, "sqlmock")
+
+			r := &Repository{
+				db: db,
+			}
+
+			if tt.beforeTest != nil {
+				tt.beforeTest(mockSQL)
+			}
+
+			result, err := r.Update(tt.args, gatherings[0], gatherings[0].ID)
+			if tt.wantError {
+				assert.Error(t, err)
+				assert.Empty(t, result)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			if err := mockSQL.ExpectationsWereMet(); err != nil {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestGetMemberIDByUserID(t *testing.T) {
+	testCase := []testCase{
+		{
+			name: "Testcase #1: Positive",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id"}).
+					AddRow(gatherings[0].MemberID)
+				s.ExpectQuery("SELECT id FROM members WHERE user_id = ? AND deleted_at IS NULL;").
+					WithArgs(int64(1)).
+					WillReturnRows(rows)
+			},
+			want:      nil,
+			wantError: false,
+		},
+		{
+			name: "Testcase #2: Negative",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				s.ExpectQuery("SELECT id FROM members WHERE user_id = ? AND deleted_at IS NULL;").
+					WithArgs(int64(1)).
+					WillReturnError(errFoo)
+			},
+			want:      errFoo,
+			wantError: true,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mockSQL, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			defer mockDB.Close()
+
+			db := sqlx.NewDb(mockDB, "sqlmock")
+
+			r := &Repository{
+				db: db,
+			}
+
+			if tt.beforeTest != nil {
+				tt.beforeTest(mockSQL)
+			}
+
+			memberID, err := r.GetMemberIDByUserID(tt.args, 1)
+			if tt.wantError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, gatherings[0].MemberID, memberID)
+			}
+
+			if err := mockSQL.ExpectationsWereMet(); err != nil {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestIsOrganizer(t *testing.T) {
+	testCase := []testCase{
+		{
+			name: "Testcase #1: Positive",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"count"}).
+					AddRow(1)
+				s.ExpectQuery("SELECT count(*) FROM gathering_organizers WHERE gathering_id = ? AND member_id = ?;").
+					WithArgs(gatherings[0].ID, int64(2)).
+					WillReturnRows(rows)
+			},
+			want:      nil,
+			wantError: false,
+		},
+		{
+			name: "Testcase #2: Negative",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				s.ExpectQuery("SELECT count(*) FROM gathering_organizers WHERE gathering_id = ? AND member_id = ?;").
+					WithArgs(gatherings[0].ID, int64(2)).
+					WillReturnError(errFoo)
+			},
+			want:      errFoo,
+			wantError: true,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mockSQL, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			defer mockDB.Close()
+
+			db := sqlx.NewDb(mockDB, "sqlmock")
+
+			r := &Repository{
+				db: db,
+			}
+
+			if tt.beforeTest != nil {
+				tt.beforeTest(mockSQL)
+			}
+
+			ok, err := r.IsOrganizer(tt.args, gatherings[0].ID, 2)
+			if tt.wantError {
+				assert.Error(t, err)
+				assert.False(t, ok)
+			} else {
+				assert.NoError(t, err)
+				assert.True(t, ok)
+			}
+
+			if err := mockSQL.ExpectationsWereMet(); err != nil {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestAddOrganizer(t *testing.T) {
+	testCase := []testCase{
+		{
+			name: "Testcase #1: Positive",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				s.ExpectExec("INSERT IGNORE INTO gathering_organizers (gathering_id, member_id) VALUES (?, ?);").
+					WithArgs(gatherings[0].ID, int64(2)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			want:      nil,
+			wantError: false,
+		},
+		{
+			name: "Testcase #2: Negative",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				s.ExpectExec("INSERT IGNORE INTO gathering_organizers (gathering_id, member_id) VALUES (?, ?);").
+					WithArgs(gatherings[0].ID, int64(2)).
+					WillReturnError(errFoo)
+			},
+			want:      errFoo,
+			wantError: true,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mockSQL, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			defer mockDB.Close()
+
+			db := sqlx.NewDb(mockDB, "sqlmock")
+
+			r := &Repository{
+				db: db,
+			}
+
+			if tt.beforeTest != nil {
+				tt.beforeTest(mockSQL)
+			}
+
+			err := r.AddOrganizer(tt.args, gatherings[0].ID, 2)
+			if tt.wantError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			if err := mockSQL.ExpectationsWereMet(); err != nil {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
@@ -2,18 +2,24 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/rzfhlv/gin-example/internal/modules/gathering/model"
 	"github.com/rzfhlv/gin-example/internal/modules/gathering/repository"
+	pErrors "github.com/rzfhlv/gin-example/pkg/errors"
+	"github.com/rzfhlv/gin-example/pkg/identity"
 	"github.com/rzfhlv/gin-example/pkg/param"
+	"github.com/rzfhlv/gin-example/pkg/rbac"
 )
 
 type IUsecase interface {
 	Create(ctx context.Context, gathering model.Gathering) (result model.Gathering, err error)
-	Get(ctx context.Context, param param.Param) (gatherings []model.Gathering, total int64, err error)
+	Get(ctx context.Context, param param.Param) (gatherings []model.Gathering, page param.Page, err error)
 	GetByID(ctx context.Context, id int64) (gathering model.Gathering, err error)
 	GetDetailByID(ctx context.Context, id int64) (gathering model.GatheringDetail, err error)
+	Update(ctx context.Context, gatheringPayload model.Gathering, id int64) (gathering model.Gathering, err error)
+	AddOrganizer(ctx context.Context, id, memberID int64) (err error)
 }
 
 type Usecase struct {
@@ -27,11 +33,18 @@ func New(repo repository.IRepository) IUsecase {
 }
 
 func (u *Usecase) Create(ctx context.Context, gatheringPayload model.Gathering) (gathering model.Gathering, err error) {
-	scheduleAt, err := time.Parse("2006-01-02 03:04:05", gatheringPayload.ScheduleAt)
+	scheduleAt, err := time.Parse(time.RFC3339, gatheringPayload.ScheduleAt)
 	if err != nil {
 		return
 	}
 	gatheringPayload.ScheduleAtDB = scheduleAt
+
+	caller, _ := identity.FromContext(ctx)
+	gatheringPayload.MemberID, err = u.member(ctx)
+	if err != nil {
+		return
+	}
+	gatheringPayload.Creator = caller.Username
 	result, err := u.repo.Create(ctx, gatheringPayload)
 	if err != nil {
 		return
@@ -46,8 +59,8 @@ func (u *Usecase) Create(ctx context.Context, gatheringPayload model.Gathering)
 	return
 }
 
-func (u *Usecase) Get(ctx context.Context, param param.Param) (gatherings []model.Gathering, total int64, err error) {
-	gatherings, err = u.repo.Get(ctx, param)
+func (u *Usecase) Get(ctx context.Context, param param.Param) (gatherings []model.Gathering, page param.Page, err error) {
+	gatherings, page, err = u.repo.Get(ctx, param)
 	if err != nil {
 		return
 	}
@@ -55,7 +68,12 @@ func (u *Usecase) Get(ctx context.Context, param param.Param) (gatherings []mode
 	if len(gatherings) < 1 {
 		gatherings = []model.Gathering{}
 	}
-	total, err = u.repo.Count(ctx)
+	if !param.Counts() {
+		return
+	}
+
+	total, err := u.repo.Count(ctx, param)
+	page.Total = &total
 	return
 }
 
@@ -65,7 +83,7 @@ func (u *Usecase) GetByID(ctx context.Context, id int64) (gathering model.Gather
 }
 
 func (u *Usecase) GetDetailByID(ctx context.Context, id int64) (gathering model.GatheringDetail, err error) {
-	gatheringByID, err := u.repo.GetByID(ctx, id)
+	gatheringByID, err := u.organize(ctx, id)
 	if err != nil {
 		return
 	}
@@ -74,3 +92,102 @@ func (u *Usecase) GetDetailByID(ctx context.Context, id int64) (gathering model.
 	gathering.Gathering = gatheringByID
 	return
 }
+
+func (u *Usecase) Update(ctx context.Context, gatheringPayload model.Gathering, id int64) (gathering model.Gathering, err error) {
+	scheduleAt, err := time.Parse(time.RFC3339, gatheringPayload.ScheduleAt)
+	if err != nil {
+		return
+	}
+	gatheringPayload.ScheduleAtDB = scheduleAt
+
+	gathering, err = u.organize(ctx, id)
+	if err != nil {
+		return
+	}
+
+	_, err = u.repo.Update(ctx, gatheringPayload, id)
+	if err != nil {
+		return
+	}
+
+	gathering.Type = gatheringPayload.Type
+	gathering.Name = gatheringPayload.Name
+	gathering.Location = gatheringPayload.Location
+	gathering.ScheduleAt = gatheringPayload.ScheduleAt
+	gathering.ScheduleAtDB = gatheringPayload.ScheduleAtDB
+	return
+}
+
+// AddOrganizer is reserved to the creator, co-organizers can not appoint
+// other co-organizers.
+func (u *Usecase) AddOrganizer(ctx context.Context, id, memberID int64) (err error) {
+	gathering, err := u.repo.GetByID(ctx, id)
+	if err != nil {
+		return
+	}
+
+	caller, _ := identity.FromContext(ctx)
+	if !caller.Can(rbac.GATHERINGSMANAGE) {
+		var callerID int64
+		callerID, err = u.member(ctx)
+		if err != nil {
+			return
+		}
+
+		if gathering.MemberID != callerID {
+			err = rbac.ErrForbidden
+			return
+		}
+	}
+
+	err = u.repo.AddOrganizer(ctx, id, memberID)
+	return
+}
+
+// member resolves the caller to the member profile linked to their user.
+func (u *Usecase) member(ctx context.Context) (memberID int64, err error) {
+	caller, ok := identity.FromContext(ctx)
+	if !ok {
+		err = rbac.ErrForbidden
+		return
+	}
+
+	memberID, err = u.repo.GetMemberIDByUserID(ctx, caller.ID)
+	if errors.Is(err, pErrors.ErrNotFound) {
+		err = rbac.ErrForbidden
+	}
+	return
+}
+
+// organize returns the gathering when the caller is its creator, one of its
+// co-organizers or allowed to manage every gathering.
+func (u *Usecase) organize(ctx context.Context, id int64) (gathering model.Gathering, err error) {
+	gathering, err = u.repo.GetByID(ctx, id)
+	if err != nil {
+		return
+	}
+
+	caller, _ := identity.FromContext(ctx)
+	if caller.Can(rbac.GATHERINGSMANAGE) {
+		return
+	}
+
+	memberID, err := u.member(ctx)
+	if err != nil {
+		return
+	}
+
+	if gathering.MemberID == memberID {
+		return
+	}
+
+	ok, err := u.repo.IsOrganizer(ctx, id, memberID)
+	if err != nil {
+		return
+	}
+
+	if !ok {
+		err = rbac.ErrForbidden
+	}
+	return
+}
@@ -7,7 +7,10 @@ import (
 	"time"
 
 	"github.com/rzfhlv/gin-example/internal/modules/gathering/model"
+	pErrors "github.com/rzfhlv/gin-example/pkg/errors"
+	"github.com/rzfhlv/gin-example/pkg/identity"
 	"github.com/rzfhlv/gin-example/pkg/param"
+	"github.com/rzfhlv/gin-example/pkg/rbac"
 	mockRepo "github.com/rzfhlv/gin-example/shared/mocks/modules/gathering/repository"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -16,22 +19,29 @@ import (
 type testCase struct {
 	name                   string
 	wantError, wantIDError error
+	wantMemberError        error
 	isErr                  bool
 	result                 CustomResult
 	payload                model.Gathering
 }
 
 var (
-	scheduleAt, _    = time.Parse("2006-01-02 03:04:05", "2023-11-10 12:00:00")
+	organizer = identity.NewContext(context.Background(), identity.Identity{
+		ID: 1, Username: "johndoe", Email: "john@test.com", Roles: []string{rbac.ORGANIZER},
+	})
+	admin = identity.NewContext(context.Background(), identity.Identity{
+		ID: 2, Username: "admin", Email: "admin@test.com", Roles: []string{rbac.ADMIN},
+	})
+	scheduleAt, _    = time.Parse(time.RFC3339, "2023-11-10T12:00:00+07:00")
 	errFoo           = errors.New("error")
-	errTime          = &time.ParseError{Layout: "2006-01-02 03:04:05", Value: "2023-11-10", LayoutElem: "03", ValueElem: "", Message: ""}
+	_, errTime       = time.Parse(time.RFC3339, "2023-11-10")
 	gatheringPayload = model.Gathering{
 		ID:           1,
 		Creator:      "John Doe",
 		Type:         "family",
 		Name:         "Family Gathering",
 		Location:     "Puncak",
-		ScheduleAt:   "2023-11-10 12:00:00",
+		ScheduleAt:   "2023-11-10T12:00:00+07:00",
 		MemberID:     1,
 		ScheduleAtDB: scheduleAt,
 	}
@@ -82,17 +92,23 @@ func TestCreate(t *testing.T) {
 		{
 			name: "Testcase #4: Negative", wantError: nil, wantIDError: errFoo, isErr: true, result: CustomResult{lastInsertID: 1, rowsAffected: 1, err: errFoo}, payload: gatheringPayload,
 		},
+		{
+			name: "Testcase #5: Negative", wantError: rbac.ErrForbidden, wantMemberError: pErrors.ErrNotFound, isErr: false, result: CustomResult{lastInsertID: 1, rowsAffected: 1, err: nil}, payload: gatheringPayload,
+		},
 	}
 	for _, tt := range testCase {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := mockRepo.IRepository{}
-			mockRepo.On("Create", mock.Anything, mock.Anything).Return(&tt.result, tt.wantError)
+			mockRepo.On("GetMemberIDByUserID", mock.Anything, int64(1)).Return(int64(1), tt.wantMemberError)
+			mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(gathering model.Gathering) bool {
+				return gathering.MemberID == 1 && gathering.Creator == "johndoe"
+			})).Return(&tt.result, tt.wantError)
 
 			u := &Usecase{
 				repo: &mockRepo,
 			}
 
-			_, err := u.Create(context.Background(), tt.payload)
+			_, err := u.Create(organizer, tt.payload)
 			if tt.isErr {
 				assert.EqualValues(t, err, tt.wantIDError)
 			} else {
@@ -115,8 +131,8 @@ func TestGet(t *testing.T) {
 	for _, tt := range testCase {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := mockRepo.IRepository{}
-			mockRepo.On("Get", mock.Anything, mock.Anything).Return([]model.Gathering{}, tt.wantError)
-			mockRepo.On("Count", mock.Anything).Return(expectedCount, tt.wantError)
+			mockRepo.On("Get", mock.Anything, mock.Anything).Return([]model.Gathering{}, param.Page{}, tt.wantError)
+			mockRepo.On("Count", mock.Anything, mock.Anything).Return(expectedCount, tt.wantError)
 
 			u := &Usecase{
 				repo: &mockRepo,
@@ -153,26 +169,144 @@ func TestGetByID(t *testing.T) {
 }
 
 func TestGetDetailByID(t *testing.T) {
+	testCase := []struct {
+		name                                     string
+		ctx                                      context.Context
+		gathering                                model.Gathering
+		isOrganizer                              bool
+		wantError, wantDetailError, wantOrgError error
+	}{
+		{
+			name: "Testcase #1: Positive", ctx: organizer, gathering: model.Gathering{ID: 1, MemberID: 1},
+		},
+		{
+			name: "Testcase #2: Positive", ctx: organizer, gathering: model.Gathering{ID: 1, MemberID: 3}, isOrganizer: true,
+		},
+		{
+			name: "Testcase #3: Positive", ctx: admin, gathering: model.Gathering{ID: 1, MemberID: 3},
+		},
+		{
+			name: "Testcase #4: Negative", ctx: organizer, gathering: model.Gathering{ID: 1, MemberID: 3}, wantError: rbac.ErrForbidden,
+		},
+		{
+			name: "Testcase #5: Negative", ctx: context.Background(), gathering: model.Gathering{ID: 1, MemberID: 3}, wantError: rbac.ErrForbidden,
+		},
+		{
+			name: "Testcase #6: Negative", ctx: organizer, gathering: model.Gathering{ID: 1, MemberID: 3}, wantOrgError: errFoo, wantError: errFoo,
+		},
+		{
+			name: "Testcase #7: Negative", ctx: organizer, gathering: model.Gathering{ID: 1, MemberID: 1}, wantDetailError: errFoo, wantError: errFoo,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mockRepo.IRepository{}
+			mockRepo.On("GetByID", mock.Anything, int64(1)).Return(tt.gathering, nil)
+			mockRepo.On("GetMemberIDByUserID", mock.Anything, int64(1)).Return(int64(1), nil)
+			mockRepo.On("IsOrganizer", mock.Anything, int64(1), int64(1)).Return(tt.isOrganizer, tt.wantOrgError)
+			mockRepo.On("GetDetailByID", mock.Anything, int64(1)).Return(model.GatheringDetail{}, tt.wantDetailError)
+
+			u := &Usecase{
+				repo: &mockRepo,
+			}
+
+			gathering, err := u.GetDetailByID(tt.ctx, 1)
+			assert.Equal(t, tt.wantError, err)
+			if tt.wantError == nil {
+				assert.Equal(t, tt.gathering, gathering.Gathering)
+			}
+		})
+	}
+
+	t.Run("Testcase #8: Negative", func(t *testing.T) {
+		mockRepo := mockRepo.IRepository{}
+		mockRepo.On("GetByID", mock.Anything, int64(1)).Return(model.Gathering{}, pErrors.ErrNotFound)
+
+		u := &Usecase{
+			repo: &mockRepo,
+		}
+
+		_, err := u.GetDetailByID(organizer, 1)
+		assert.Equal(t, pErrors.ErrNotFound, err)
+	})
+}
+
+func TestUpdate(t *testing.T) {
 	testCase := []testCase{
 		{
-			name: "Testcase #1: Positive", wantError: nil, isErr: false,
+			name: "Testcase #1: Positive", wantError: nil, payload: gatheringPayload,
 		},
 		{
-			name: "Testcase #2: Negative", wantError: errFoo, isErr: true,
+			name: "Testcase #2: Negative", wantError: errTime, payload: gatheringPayloadFail,
+		},
+		{
+			name: "Testcase #3: Negative", wantError: rbac.ErrForbidden, wantMemberError: pErrors.ErrNotFound, payload: gatheringPayload,
+		},
+		{
+			name: "Testcase #4: Negative", wantError: errFoo, wantIDError: errFoo, payload: gatheringPayload,
 		},
 	}
 	for _, tt := range testCase {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := mockRepo.IRepository{}
-			mockRepo.On("GetByID", mock.Anything, mock.Anything).Return(model.Gathering{}, tt.wantError)
-			mockRepo.On("GetDetailByID", mock.Anything, mock.Anything).Return(model.GatheringDetail{}, tt.wantError)
+			mockRepo.On("GetByID", mock.Anything, int64(1)).Return(model.Gathering{ID: 1, MemberID: 1, Creator: "johndoe"}, nil)
+			mockRepo.On("GetMemberIDByUserID", mock.Anything, int64(1)).Return(int64(1), tt.wantMemberError)
+			mockRepo.On("Update", mock.Anything, mock.Anything, int64(1)).Return(&CustomResult{rowsAffected: 1}, tt.wantIDError)
 
 			u := &Usecase{
 				repo: &mockRepo,
 			}
 
-			_, err := u.GetDetailByID(context.Background(), gatheringPayload.ID)
-			assert.EqualValues(t, err, tt.wantError)
+			gathering, err := u.Update(organizer, tt.payload, 1)
+			assert.EqualValues(t, tt.wantError, err)
+			if tt.wantError == nil {
+				assert.Equal(t, "johndoe", gathering.Creator)
+				assert.Equal(t, tt.payload.Name, gathering.Name)
+			}
+		})
+	}
+}
+
+func TestAddOrganizer(t *testing.T) {
+	testCase := []struct {
+		name                          string
+		ctx                           context.Context
+		gathering                     model.Gathering
+		wantGatheringError, wantError error
+		wantAddError                  error
+	}{
+		{
+			name: "Testcase #1: Positive", ctx: organizer, gathering: model.Gathering{ID: 1, MemberID: 1},
+		},
+		{
+			name: "Testcase #2: Positive", ctx: admin, gathering: model.Gathering{ID: 1, MemberID: 3},
+		},
+		{
+			name: "Testcase #3: Negative", ctx: organizer, gathering: model.Gathering{ID: 1, MemberID: 3}, wantError: rbac.ErrForbidden,
+		},
+		{
+			name: "Testcase #4: Negative", ctx: organizer, wantGatheringError: pErrors.ErrNotFound, wantError: pErrors.ErrNotFound,
+		},
+		{
+			name: "Testcase #5: Negative", ctx: context.Background(), gathering: model.Gathering{ID: 1, MemberID: 1}, wantError: rbac.ErrForbidden,
+		},
+		{
+			name: "Testcase #6: Negative", ctx: organizer, gathering: model.Gathering{ID: 1, MemberID: 1}, wantAddError: errFoo, wantError: errFoo,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mockRepo.IRepository{}
+			mockRepo.On("GetByID", mock.Anything, int64(1)).Return(tt.gathering, tt.wantGatheringError)
+			mockRepo.On("GetMemberIDByUserID", mock.Anything, int64(1)).Return(int64(1), nil)
+			mockRepo.On("AddOrganizer", mock.Anything, int64(1), int64(2)).Return(tt.wantAddError)
+
+			u := &Usecase{
+				repo: &mockRepo,
+			}
+
+			err := u.AddOrganizer(tt.ctx, 1, 2)
+			assert.Equal(t, tt.wantError, err)
 		})
 	}
 }
@@ -29,7 +29,7 @@ func (h *Handler) Ping(g *gin.Context) {
 	err := h.usecase.Ping(ctx)
 	if err != nil {
 		log.Printf("Error Ping %v", err.Error())
-		g.JSON(http.StatusInternalServerError, response.Set(message.ERROR, message.SOMETHINGWENTWRONG, nil, nil))
+		g.Error(err)
 		return
 	}
 	g.JSON(http.StatusOK, response.Set(message.SUCCESS, message.HEALTHCHECK, nil, nil))
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/gin-gonic/gin"
+	"github.com/rzfhlv/gin-example/middleware"
 	mockUsecase "github.com/rzfhlv/gin-example/shared/mocks/modules/health-check/usecase"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -56,6 +57,7 @@ func TestPing(t *testing.T) {
 			}
 
 			h.Ping(ctx)
+			middleware.RenderError(ctx)
 			assert.EqualValues(t, tt.code, w.Code)
 		})
 	}
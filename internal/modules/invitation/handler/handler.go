@@ -1,7 +1,7 @@
 package handler
 
 import (
-	"database/sql"
+	"errors"
 	"log"
 	"net/http"
 	"strconv"
@@ -12,6 +12,7 @@ import (
 	"github.com/rzfhlv/gin-example/pkg/message"
 	"github.com/rzfhlv/gin-example/pkg/param"
 	"github.com/rzfhlv/gin-example/pkg/response"
+	"github.com/rzfhlv/gin-example/pkg/validation"
 )
 
 type IHandler interface {
@@ -20,6 +21,7 @@ type IHandler interface {
 	GetByID(g *gin.Context)
 	Update(g *gin.Context)
 	GetByMemberID(g *gin.Context)
+	GetMine(g *gin.Context)
 }
 
 type Handler struct {
@@ -39,14 +41,14 @@ func (h *Handler) Create(g *gin.Context) {
 	err := g.ShouldBindJSON(&invitationPayload)
 	if err != nil {
 		log.Printf("Error Binding and Validation Invitation, %v", err.Error())
-		g.JSON(http.StatusUnprocessableEntity, response.Set(message.ERROR, err.Error(), nil, nil))
+		response.Error(g, http.StatusUnprocessableEntity, message.UNPROCESSABLEENTITY, validation.Translate(err, g.GetHeader("Accept-Language"))...)
 		return
 	}
 
 	invitation, err := h.usecase.Create(ctx, invitationPayload)
 	if err != nil {
 		log.Printf("Error Create Invitation, %v", err.Error())
-		g.JSON(http.StatusInternalServerError, response.Set(message.ERROR, message.SOMETHINGWENTWRONG, nil, nil))
+		g.Error(err)
 		return
 	}
 
@@ -62,18 +64,26 @@ func (h *Handler) Get(g *gin.Context) {
 	err := g.ShouldBind(&queryParam)
 	if err != nil {
 		log.Printf("Error Binding Query Param Gathering, %v", err.Error())
-		g.JSON(http.StatusUnprocessableEntity, response.Set(message.ERROR, message.UNPROCESSABLEENTITY, nil, nil))
+		response.Error(g, http.StatusUnprocessableEntity, message.UNPROCESSABLEENTITY, validation.Translate(err, g.GetHeader("Accept-Language"))...)
 		return
 	}
+	queryParam.Filters = g.Request.URL.Query()
 
-	invitations, total, err := h.usecase.Get(ctx, queryParam)
+	invitations, page, err := h.usecase.Get(ctx, queryParam)
 	if err != nil {
 		log.Printf("Error Get Invitation, %v", err.Error())
-		g.JSON(http.StatusInternalServerError, response.Set(message.ERROR, message.SOMETHINGWENTWRONG, nil, nil))
+		if errors.Is(err, param.ErrCursorDisabled) {
+			response.Error(g, http.StatusBadRequest, err.Error())
+			return
+		}
+		if param.IsInvalid(err) {
+			response.Error(g, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		g.Error(err)
 		return
 	}
-	queryParam.Total = total
-	meta := response.BuildMeta(queryParam, len(invitations))
+	meta := response.BuildMeta(queryParam, page, len(invitations), g.Request.URL)
 
 	g.JSON(http.StatusOK, response.Set(message.SUCCESS, message.OK, meta, invitations))
 }
@@ -85,18 +95,14 @@ func (h *Handler) GetByID(g *gin.Context) {
 	invitationID, err := strconv.ParseInt(id, 10, 64)
 	if err != nil {
 		log.Printf("Error Parse Invitation ID, %v", err.Error())
-		g.JSON(http.StatusUnprocessableEntity, response.Set(message.ERROR, message.UNPROCESSABLEENTITY, nil, nil))
+		response.Error(g, http.StatusUnprocessableEntity, message.UNPROCESSABLEENTITY)
 		return
 	}
 
 	invitation, err := h.usecase.GetByID(ctx, invitationID)
 	if err != nil {
 		log.Printf("Error Get By ID Invitation, %v", err.Error())
-		if err == sql.ErrNoRows {
-			g.JSON(http.StatusNotFound, response.Set(message.ERROR, message.NOTFOUND, nil, nil))
-			return
-		}
-		g.JSON(http.StatusInternalServerError, response.Set(message.SUCCESS, message.SOMETHINGWENTWRONG, nil, nil))
+		g.Error(err)
 		return
 	}
 
@@ -110,22 +116,22 @@ func (h *Handler) Update(g *gin.Context) {
 	invitationID, err := strconv.ParseInt(id, 10, 64)
 	if err != nil {
 		log.Printf("Error Parse Invitation ID, %v", err.Error())
-		g.JSON(http.StatusUnprocessableEntity, response.Set(message.ERROR, message.UNPROCESSABLEENTITY, nil, nil))
+		response.Error(g, http.StatusUnprocessableEntity, message.UNPROCESSABLEENTITY)
 		return
 	}
 
-	invitationPayload := model.Invitation{}
-	err = g.ShouldBindJSON(&invitationPayload)
+	rsvp := model.RSVP{}
+	err = g.ShouldBindJSON(&rsvp)
 	if err != nil {
 		log.Printf("Error Binding and Validation Invitation, %v", err.Error())
-		g.JSON(http.StatusUnprocessableEntity, response.Set(message.ERROR, err.Error(), nil, nil))
+		response.Error(g, http.StatusUnprocessableEntity, message.UNPROCESSABLEENTITY, validation.Translate(err, g.GetHeader("Accept-Language"))...)
 		return
 	}
 
-	invitation, err := h.usecase.Update(ctx, invitationPayload, invitationID)
+	invitation, err := h.usecase.Update(ctx, model.Invitation{Status: rsvp.Status}, invitationID)
 	if err != nil {
 		log.Printf("Error Update Invitation, %v", err.Error())
-		g.JSON(http.StatusInternalServerError, response.Set(message.SUCCESS, message.SOMETHINGWENTWRONG, nil, nil))
+		g.Error(err)
 		return
 	}
 
@@ -139,18 +145,27 @@ func (h *Handler) GetByMemberID(g *gin.Context) {
 	memberID, err := strconv.ParseInt(id, 10, 64)
 	if err != nil {
 		log.Printf("Error Parse Invitation Member ID, %v", err.Error())
-		g.JSON(http.StatusUnprocessableEntity, response.Set(message.ERROR, message.UNPROCESSABLEENTITY, nil, nil))
+		response.Error(g, http.StatusUnprocessableEntity, message.UNPROCESSABLEENTITY)
 		return
 	}
 
 	invitations, err := h.usecase.GetByMemberID(ctx, memberID)
 	if err != nil {
 		log.Printf("Error Get By Member ID Invitation, %v", err.Error())
-		if err == sql.ErrNoRows {
-			g.JSON(http.StatusNotFound, response.Set(message.ERROR, message.NOTFOUND, nil, nil))
-			return
-		}
-		g.JSON(http.StatusInternalServerError, response.Set(message.SUCCESS, message.SOMETHINGWENTWRONG, nil, nil))
+		g.Error(err)
+		return
+	}
+
+	g.JSON(http.StatusOK, response.Set(message.SUCCESS, message.OK, nil, invitations))
+}
+
+func (h *Handler) GetMine(g *gin.Context) {
+	ctx := g.Request.Context()
+
+	invitations, err := h.usecase.GetMine(ctx)
+	if err != nil {
+		log.Printf("Error Get Mine Invitation, %v", err.Error())
+		g.Error(err)
 		return
 	}
 
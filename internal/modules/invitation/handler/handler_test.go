@@ -3,6 +3,7 @@ package handler
 import (
 	"database/sql"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -10,6 +11,9 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/rzfhlv/gin-example/internal/modules/invitation/model"
+	"github.com/rzfhlv/gin-example/middleware"
+	"github.com/rzfhlv/gin-example/pkg/param"
+	"github.com/rzfhlv/gin-example/pkg/rbac"
 	mockUsecase "github.com/rzfhlv/gin-example/shared/mocks/modules/invitation/usecase"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -47,6 +51,9 @@ func TestCreate(t *testing.T) {
 		{
 			name: "Testcase #3: Negative", body: payloadFail, wantError: errFoo, code: http.StatusUnprocessableEntity,
 		},
+		{
+			name: "Testcase #4: Negative", body: payloadSuccess, wantError: rbac.ErrForbidden, code: http.StatusForbidden,
+		},
 	}
 	for _, tt := range testCase {
 		t.Run(tt.name, func(t *testing.T) {
@@ -63,6 +70,7 @@ func TestCreate(t *testing.T) {
 			ctx.Request.Header.Set("Content-Type", "application/json")
 
 			h.Create(ctx)
+			middleware.RenderError(ctx)
 			assert.EqualValues(t, tt.code, w.Code)
 		})
 	}
@@ -82,11 +90,17 @@ func TestGet(t *testing.T) {
 		{
 			name: "Testcase #3: Negative", queryParam: "?page=one", wantError: errFoo, code: http.StatusUnprocessableEntity,
 		},
+		{
+			name: "Testcase #4: Negative", queryParam: "?sort=password", wantError: fmt.Errorf("%w: password", param.ErrInvalidSort), code: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "Testcase #5: Negative", queryParam: "?cursor=e30.", wantError: param.ErrCursorDisabled, code: http.StatusBadRequest,
+		},
 	}
 	for _, tt := range testCase {
 		t.Run(tt.name, func(t *testing.T) {
 			mockUsecase := mockUsecase.IUsecase{}
-			mockUsecase.On("Get", mock.Anything, mock.Anything).Return([]model.Invitation{}, expectedCount, tt.wantError)
+			mockUsecase.On("Get", mock.Anything, mock.Anything).Return([]model.Invitation{}, param.Page{Total: &expectedCount}, tt.wantError)
 
 			h := &Handler{
 				usecase: &mockUsecase,
@@ -98,6 +112,7 @@ func TestGet(t *testing.T) {
 			ctx.Request.Header.Set("Content-Type", "application/json")
 
 			h.Get(ctx)
+			middleware.RenderError(ctx)
 			assert.Equal(t, tt.code, w.Code)
 		})
 	}
@@ -136,6 +151,7 @@ func TestGetByID(t *testing.T) {
 			ctx.Params = gin.Params{{Key: "id", Value: tt.param}}
 
 			h.GetByID(ctx)
+			middleware.RenderError(ctx)
 			assert.Equal(t, tt.code, w.Code)
 		})
 	}
@@ -157,6 +173,18 @@ func TestUpdate(t *testing.T) {
 		{
 			name: "Testcase #4: Negative", body: payloadSuccess, param: "one", wantError: errFoo, code: http.StatusUnprocessableEntity,
 		},
+		{
+			name: "Testcase #5: Negative", body: payloadSuccess, param: "1", wantError: rbac.ErrForbidden, code: http.StatusForbidden,
+		},
+		{
+			name: "Testcase #6: Negative", body: `{"status":"reject"}`, param: "1", wantError: sql.ErrNoRows, code: http.StatusNotFound,
+		},
+		{
+			name: "Testcase #7: Negative", body: `{"status":""}`, param: "1", wantError: nil, code: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "Testcase #8: Negative", body: `{"status":"maybe"}`, param: "1", wantError: nil, code: http.StatusUnprocessableEntity,
+		},
 	}
 	for _, tt := range testCase {
 		t.Run(tt.name, func(t *testing.T) {
@@ -174,6 +202,7 @@ func TestUpdate(t *testing.T) {
 			ctx.Params = gin.Params{{Key: "id", Value: tt.param}}
 
 			h.Update(ctx)
+			middleware.RenderError(ctx)
 			assert.EqualValues(t, tt.code, w.Code)
 		})
 	}
@@ -195,6 +224,9 @@ func TestGetByMemberID(t *testing.T) {
 		{
 			name: "Testcase #3: Negative", param: "0", wantError: sql.ErrNoRows, code: http.StatusNotFound,
 		},
+		{
+			name: "Testcase #5: Negative", param: "2", wantError: rbac.ErrForbidden, code: http.StatusForbidden,
+		},
 	}
 	for _, tt := range testCase {
 		t.Run(tt.name, func(t *testing.T) {
@@ -212,6 +244,41 @@ func TestGetByMemberID(t *testing.T) {
 			ctx.Params = gin.Params{{Key: "id", Value: tt.param}}
 
 			h.GetByMemberID(ctx)
+			middleware.RenderError(ctx)
+			assert.Equal(t, tt.code, w.Code)
+		})
+	}
+}
+
+func TestGetMine(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCase := []testCase{
+		{
+			name: "Testcase #1: Positive", wantError: nil, code: http.StatusOK,
+		},
+		{
+			name: "Testcase #2: Negative", wantError: rbac.ErrForbidden, code: http.StatusForbidden,
+		},
+		{
+			name: "Testcase #3: Negative", wantError: errFoo, code: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := mockUsecase.IUsecase{}
+			mockUsecase.On("GetMine", mock.Anything).Return([]model.InvitationDetail{}, tt.wantError)
+
+			h := &Handler{
+				usecase: &mockUsecase,
+			}
+
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = httptest.NewRequest(http.MethodGet, "/v1/invitations/me", nil)
+
+			h.GetMine(ctx)
+			middleware.RenderError(ctx)
 			assert.Equal(t, tt.code, w.Code)
 		})
 	}
@@ -7,16 +7,18 @@ import (
 	"github.com/rzfhlv/gin-example/internal/modules/invitation/repository"
 	"github.com/rzfhlv/gin-example/internal/modules/invitation/usecase"
 	"github.com/rzfhlv/gin-example/middleware/auth"
+	"github.com/rzfhlv/gin-example/pkg/rbac"
 )
 
 func Mount(route *gin.RouterGroup, h handler.IHandler, a auth.IAuth) (g *gin.RouterGroup) {
 	g = route.Group("/invitations")
-	g.Use(a.Bearer())
-	g.GET("", h.Get)
-	g.GET("/:id", h.GetByID)
-	g.POST("", h.Create)
-	g.PATCH("/:id", h.Update)
-	g.GET("/me/:id", h.GetByMemberID)
+	g.Use(a.APIKey(), a.Bearer())
+	g.GET("", a.Can(rbac.INVITATIONSREAD), h.Get)
+	g.GET("/:id", a.Can(rbac.INVITATIONSREAD), h.GetByID)
+	g.POST("", a.Can(rbac.INVITATIONSWRITE), h.Create)
+	g.PATCH("/:id", a.Can(rbac.INVITATIONSRESPOND), h.Update)
+	g.GET("/me", a.Can(rbac.INVITATIONSRESPOND), h.GetMine)
+	g.GET("/me/:id", a.Can(rbac.INVITATIONSRESPOND), h.GetByMemberID)
 	return
 }
 
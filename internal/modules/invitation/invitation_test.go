@@ -8,6 +8,7 @@ import (
 	mockAuth "github.com/rzfhlv/gin-example/shared/mocks/middleware/auth"
 	mockHandler "github.com/rzfhlv/gin-example/shared/mocks/modules/invitation/handler"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestNew(t *testing.T) {
@@ -28,6 +29,16 @@ func TestMount(t *testing.T) {
 			c.Next()
 		}
 	})
+	mockAuth.On("APIKey").Return(func() gin.HandlerFunc {
+		return func(c *gin.Context) {
+			c.Next()
+		}
+	})
+	mockAuth.On("Can", mock.Anything).Return(func(permission string) gin.HandlerFunc {
+		return func(c *gin.Context) {
+			c.Next()
+		}
+	})
 
 	g := gin.Default()
 	route := g.Group("/v1")
@@ -8,7 +8,11 @@ type Invitation struct {
 	ID          int64  `json:"id" db:"id"`
 	MemberID    int64  `json:"member_id" db:"member_id" binding:"required"`
 	GatheringID int64  `json:"gathering_id" db:"gathering_id" binding:"required"`
-	Status      string `json:"status" db:"status" binding:"required"`
+	Status      string `json:"status" db:"status" binding:"required,invitation_status"`
+}
+
+type RSVP struct {
+	Status string `json:"status" binding:"required,invitation_status"`
 }
 
 type Attendee struct {
@@ -1,12 +1,26 @@
 package repository
 
+import "github.com/rzfhlv/gin-example/pkg/param"
+
 var (
+	InvitationSpec = param.Spec{
+		Filters: map[string]param.Filter{
+			"status":       {Columns: []string{"status"}, Match: param.EQUAL},
+			"member_id":    {Columns: []string{"member_id"}, Match: param.EQUAL},
+			"gathering_id": {Columns: []string{"gathering_id"}, Match: param.EQUAL},
+		},
+		Sorts: map[string]string{
+			"id": "id", "status": "status", "member_id": "member_id", "gathering_id": "gathering_id",
+		},
+		Default: "-id",
+	}
+
 	CreateInvitationQuery = `INSERT INTO invitations
 		(member_id, gathering_id, status)
 		VALUES (?, ?, ?);`
 	GetInvitationQuery = `SELECT id, member_id,
 		gathering_id, status
-		FROM invitations ORDER BY id DESC LIMIT ? OFFSET ?;`
+		FROM invitations %s %s LIMIT ? OFFSET ?;`
 	GetInvitationByIDQuery = `SELECT id, member_id,
 		gathering_id, status
 		FROM invitations WHERE id = ?;`
@@ -17,11 +31,18 @@ var (
 		(member_id, gathering_id)
 		VALUES (?, ?);`
 	CountInvitationQuery = `SELECT count(*)
-		FROM invitations;`
+		FROM invitations %s`
 	GetInvitationByMemberIDQuery = `SELECT i.id as iid, i.member_id,
 		i.gathering_id, i.status, g.id as gid, g.creator,
 		g.type, g.name, g.location, g.schedule_at 
 		FROM invitations i
 		LEFT JOIN gatherings g ON i.gathering_id = g.id
 		WHERE i.member_id = ?`
+	GetMemberIDByUserIDQuery = `SELECT id
+		FROM members WHERE user_id = ? AND deleted_at IS NULL;`
+	IsOrganizerQuery = `SELECT count(*)
+		FROM gatherings g
+		LEFT JOIN gathering_organizers o ON o.gathering_id = g.id
+		AND o.member_id = ?
+		WHERE g.id = ? AND (g.member_id = ? OR o.member_id IS NOT NULL);`
 )
@@ -3,20 +3,24 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"fmt"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/rzfhlv/gin-example/internal/modules/invitation/model"
+	pErrors "github.com/rzfhlv/gin-example/pkg/errors"
 	"github.com/rzfhlv/gin-example/pkg/param"
 )
 
 type IRepository interface {
 	Create(ctx context.Context, invitation model.Invitation) (result sql.Result, err error)
-	Get(ctx context.Context, param param.Param) (invitations []model.Invitation, err error)
+	Get(ctx context.Context, param param.Param) (invitations []model.Invitation, page param.Page, err error)
 	GetByID(ctx context.Context, id int64) (invitation model.Invitation, err error)
 	Update(ctx context.Context, invitation model.Invitation, id int64) (result sql.Result, err error)
 	CreateAttendee(ctx context.Context, attendee model.Attendee) (err error)
-	Count(ctx context.Context) (total int64, err error)
+	Count(ctx context.Context, param param.Param) (total int64, err error)
 	GetByMemberID(ctx context.Context, memberID int64) (invitations []model.InvitationDetail, err error)
+	GetMemberIDByUserID(ctx context.Context, userID int64) (memberID int64, err error)
+	IsOrganizer(ctx context.Context, gatheringID, memberID int64) (ok bool, err error)
 }
 
 type Repository struct {
@@ -31,31 +35,59 @@ func New(db *sqlx.DB) IRepository {
 
 func (r *Repository) Create(ctx context.Context, invitation model.Invitation) (result sql.Result, err error) {
 	result, err = r.db.Exec(CreateInvitationQuery, invitation.MemberID, invitation.GatheringID, invitation.Status)
+	err = pErrors.FromSQL(err)
 	return
 }
 
-func (r *Repository) Get(ctx context.Context, param param.Param) (invitations []model.Invitation, err error) {
-	err = r.db.Select(&invitations, GetInvitationQuery, param.Limit, param.CalculateOffset())
+func (r *Repository) Get(ctx context.Context, param param.Param) (invitations []model.Invitation, page param.Page, err error) {
+	where, args, err := InvitationSpec.Seek(param)
+	if err != nil {
+		return
+	}
+
+	orderBy, err := InvitationSpec.OrderBy(param)
+	if err != nil {
+		return
+	}
+
+	args = append(args, param.Limit, param.CalculateOffset())
+	err = r.db.Select(&invitations, fmt.Sprintf(GetInvitationQuery, where, orderBy), args...)
+	if err != nil {
+		return
+	}
+
+	page, err = InvitationSpec.Paginate(param, invitations, func(i int) map[string]interface{} {
+		return invitationValues(invitations[i])
+	})
 	return
 }
 
 func (r *Repository) GetByID(ctx context.Context, id int64) (invitation model.Invitation, err error) {
 	err = r.db.Get(&invitation, GetInvitationByIDQuery, id)
+	err = pErrors.FromSQL(err)
 	return
 }
 
 func (r *Repository) Update(ctx context.Context, invitation model.Invitation, id int64) (result sql.Result, err error) {
 	result, err = r.db.Exec(UpdateInvitationQuery, invitation.Status, id)
+	err = pErrors.FromSQL(err)
 	return
 }
 
 func (r *Repository) CreateAttendee(ctx context.Context, attendee model.Attendee) (err error) {
 	_, err = r.db.Exec(CreateAttendeeQuery, attendee.MemberID, attendee.GatheringID)
+	err = pErrors.FromSQL(err)
 	return
 }
 
-func (r *Repository) Count(ctx context.Context) (total int64, err error) {
-	err = r.db.Get(&total, CountInvitationQuery)
+// Count applies the same filters as Get so the total matches the list.
+func (r *Repository) Count(ctx context.Context, param param.Param) (total int64, err error) {
+	where, args, err := InvitationSpec.Where(param)
+	if err != nil {
+		return
+	}
+
+	err = r.db.Get(&total, fmt.Sprintf(CountInvitationQuery, where), args...)
 	return
 }
 
@@ -79,3 +111,25 @@ func (r *Repository) GetByMemberID(ctx context.Context, memberID int64) (invitat
 	}
 	return
 }
+
+func (r *Repository) GetMemberIDByUserID(ctx context.Context, userID int64) (memberID int64, err error) {
+	err = r.db.Get(&memberID, GetMemberIDByUserIDQuery, userID)
+	err = pErrors.FromSQL(err)
+	return
+}
+
+// IsOrganizer is true for the creator of the gathering and its co-organizers.
+func (r *Repository) IsOrganizer(ctx context.Context, gatheringID, memberID int64) (ok bool, err error) {
+	var total int64
+	err = r.db.Get(&total, IsOrganizerQuery, memberID, gatheringID, memberID)
+	ok = total > 0
+	return
+}
+
+// invitationValues are the sort columns of an invitation, for its cursor.
+func invitationValues(invitation model.Invitation) map[string]interface{} {
+	return map[string]interface{}{
+		"id": invitation.ID, "status": invitation.Status,
+		"member_id": invitation.MemberID, "gathering_id": invitation.GatheringID,
+	}
+}
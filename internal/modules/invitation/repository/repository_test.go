@@ -152,7 +152,7 @@ func TestGet(t *testing.T) {
 				tt.beforeTest(mockSQL)
 			}
 
-			invitations, err := r.Get(tt.args, paramTest)
+			invitations, _, err := r.Get(tt.args, paramTest)
 			if tt.wantError {
 				assert.Error(t, err)
 				assert.Empty(t, invitations)
@@ -358,7 +358,7 @@ func TestCount(t *testing.T) {
 			beforeTest: func(s sqlmock.Sqlmock) {
 				rows := sqlmock.NewRows([]string{"count"}).
 					AddRow(expectedCount)
-				s.ExpectQuery("SELECT count(*) FROM invitations;").
+				s.ExpectQuery("SELECT count(*) FROM invitations").
 					WillReturnRows(rows)
 			},
 			want:      nil,
@@ -368,7 +368,7 @@ func TestCount(t *testing.T) {
 			name: "Testcase #2: Negative",
 			args: ctx,
 			beforeTest: func(s sqlmock.Sqlmock) {
-				s.ExpectQuery("SELECT count(*) FROM invitations;").
+				s.ExpectQuery("SELECT count(*) FROM invitations").
 					WillReturnError(errFoo)
 			},
 			want:      errFoo,
@@ -390,7 +390,7 @@ func TestCount(t *testing.T) {
 				tt.beforeTest(mockSQL)
 			}
 
-			total, err := r.Count(tt.args)
+			total, err := r.Count(tt.args, paramTest)
 			if tt.wantError {
 				assert.Error(t, err)
 			} else {
@@ -498,3 +498,124 @@ func TestGetByMemberID(t *testing.T) {
 		})
 	}
 }
+
+func TestGetMemberIDByUserID(t *testing.T) {
+	testCase := []testCase{
+		{
+			name: "Testcase #1: Positive",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id"}).
+					AddRow(invitations[0].MemberID)
+				s.ExpectQuery("SELECT id FROM members WHERE user_id = ? AND deleted_at IS NULL;").
+					WithArgs(int64(1)).
+					WillReturnRows(rows)
+			},
+			want:      nil,
+			wantError: false,
+		},
+		{
+			name: "Testcase #2: Negative",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				s.ExpectQuery("SELECT id FROM members WHERE user_id = ? AND deleted_at IS NULL;").
+					WithArgs(int64(1)).
+					WillReturnError(errFoo)
+			},
+			want:      errFoo,
+			wantError: true,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mockSQL, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			defer mockDB.Close()
+
+			db := sqlx.NewDb(mockDB, "sqlmock")
+
+			r := &Repository{
+				db: db,
+			}
+
+			if tt.beforeTest != nil {
+				tt.beforeTest(mockSQL)
+			}
+
+			memberID, err := r.GetMemberIDByUserID(tt.args, 1)
+			if tt.wantError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, invitations[0].MemberID, memberID)
+			}
+
+			if err := mockSQL.ExpectationsWereMet(); err != nil {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestIsOrganizer(t *testing.T) {
+	testCase := []testCase{
+		{
+			name: "Testcase #1: Positive",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"count"}).
+					AddRow(1)
+				s.ExpectQuery(`SELECT count(*) FROM gatherings g
+				LEFT JOIN gathering_organizers o ON o.gathering_id = g.id
+				AND o.member_id = ?
+				WHERE g.id = ? AND (g.member_id = ? OR o.member_id IS NOT NULL);`).
+					WithArgs(int64(2), gathering.ID, int64(2)).
+					WillReturnRows(rows)
+			},
+			want:      nil,
+			wantError: false,
+		},
+		{
+			name: "Testcase #2: Negative",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				s.ExpectQuery(`SELECT count(*) FROM gatherings g
+				LEFT JOIN gathering_organizers o ON o.gathering_id = g.id
+				AND o.member_id = ?
+				WHERE g.id = ? AND (g.member_id = ? OR o.member_id IS NOT NULL);`).
+					WithArgs(int64(2), gathering.ID, int64(2)).
+					WillReturnError(errFoo)
+			},
+			want:      errFoo,
+			wantError: true,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mockSQL, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			defer mockDB.Close()
+
+			db := sqlx.NewDb(mockDB, "sqlmock")
+
+			r := &Repository{
+				db: db,
+			}
+
+			if tt.beforeTest != nil {
+				tt.beforeTest(mockSQL)
+			}
+
+			ok, err := r.IsOrganizer(tt.args, gathering.ID, 2)
+			if tt.wantError {
+				assert.Error(t, err)
+				assert.False(t, ok)
+			} else {
+				assert.NoError(t, err)
+				assert.True(t, ok)
+			}
+
+			if err := mockSQL.ExpectationsWereMet(); err != nil {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
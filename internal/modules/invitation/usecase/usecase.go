@@ -2,18 +2,23 @@ package usecase
 
 import (
 	"context"
+	"errors"
 
 	"github.com/rzfhlv/gin-example/internal/modules/invitation/model"
 	"github.com/rzfhlv/gin-example/internal/modules/invitation/repository"
+	pErrors "github.com/rzfhlv/gin-example/pkg/errors"
+	"github.com/rzfhlv/gin-example/pkg/identity"
 	"github.com/rzfhlv/gin-example/pkg/param"
+	"github.com/rzfhlv/gin-example/pkg/rbac"
 )
 
 type IUsecase interface {
 	Create(ctx context.Context, invitationPayload model.Invitation) (invitation model.Invitation, err error)
-	Get(ctx context.Context, param param.Param) (invitations []model.Invitation, total int64, err error)
+	Get(ctx context.Context, param param.Param) (invitations []model.Invitation, page param.Page, err error)
 	GetByID(ctx context.Context, id int64) (invitation model.Invitation, err error)
 	Update(ctx context.Context, invitationPayload model.Invitation, id int64) (invitation model.Invitation, err error)
 	GetByMemberID(ctx context.Context, memberID int64) (invitations []model.InvitationDetail, err error)
+	GetMine(ctx context.Context) (invitations []model.InvitationDetail, err error)
 }
 
 type Usecase struct {
@@ -27,6 +32,25 @@ func New(repo repository.IRepository) IUsecase {
 }
 
 func (u *Usecase) Create(ctx context.Context, invitationPayload model.Invitation) (invitation model.Invitation, err error) {
+	caller, _ := identity.FromContext(ctx)
+	if !caller.Can(rbac.INVITATIONSMANAGE) {
+		var memberID int64
+		memberID, err = u.member(ctx)
+		if err != nil {
+			return
+		}
+
+		var ok bool
+		ok, err = u.repo.IsOrganizer(ctx, invitationPayload.GatheringID, memberID)
+		if err != nil {
+			return
+		}
+		if !ok {
+			err = rbac.ErrForbidden
+			return
+		}
+	}
+
 	result, err := u.repo.Create(ctx, invitationPayload)
 	if err != nil {
 		return
@@ -49,8 +73,8 @@ func (u *Usecase) Create(ctx context.Context, invitationPayload model.Invitation
 	return
 }
 
-func (u *Usecase) Get(ctx context.Context, param param.Param) (invitations []model.Invitation, total int64, err error) {
-	invitations, err = u.repo.Get(ctx, param)
+func (u *Usecase) Get(ctx context.Context, param param.Param) (invitations []model.Invitation, page param.Page, err error) {
+	invitations, page, err = u.repo.Get(ctx, param)
 	if err != nil {
 		return
 	}
@@ -58,7 +82,12 @@ func (u *Usecase) Get(ctx context.Context, param param.Param) (invitations []mod
 	if len(invitations) < 1 {
 		invitations = []model.Invitation{}
 	}
-	total, err = u.repo.Count(ctx)
+	if !param.Counts() {
+		return
+	}
+
+	total, err := u.repo.Count(ctx, param)
+	page.Total = &total
 	return
 }
 
@@ -67,18 +96,84 @@ func (u *Usecase) GetByID(ctx context.Context, id int64) (invitation model.Invit
 	return
 }
 
+// Update only changes the status and only the invited member can answer.
 func (u *Usecase) Update(ctx context.Context, invitationPayload model.Invitation, id int64) (invitation model.Invitation, err error) {
+	invitation, err = u.repo.GetByID(ctx, id)
+	if err != nil {
+		return
+	}
+
+	err = u.owner(ctx, invitation.MemberID)
+	if err != nil {
+		return
+	}
+
 	_, err = u.repo.Update(ctx, invitationPayload, id)
 	if err != nil {
 		return
 	}
 
-	invitationPayload.ID = id
-	invitation = invitationPayload
+	invitation.Status = invitationPayload.Status
 	return
 }
 
 func (u *Usecase) GetByMemberID(ctx context.Context, memberID int64) (invitations []model.InvitationDetail, err error) {
+	err = u.owner(ctx, memberID)
+	if err != nil {
+		return
+	}
+
+	invitations, err = u.repo.GetByMemberID(ctx, memberID)
+	return
+}
+
+func (u *Usecase) GetMine(ctx context.Context) (invitations []model.InvitationDetail, err error) {
+	memberID, err := u.member(ctx)
+	if err != nil {
+		return
+	}
+
 	invitations, err = u.repo.GetByMemberID(ctx, memberID)
+	if err != nil {
+		return
+	}
+
+	if len(invitations) < 1 {
+		invitations = []model.InvitationDetail{}
+	}
+	return
+}
+
+// member resolves the caller to the member profile linked to their user.
+func (u *Usecase) member(ctx context.Context) (memberID int64, err error) {
+	caller, ok := identity.FromContext(ctx)
+	if !ok {
+		err = rbac.ErrForbidden
+		return
+	}
+
+	memberID, err = u.repo.GetMemberIDByUserID(ctx, caller.ID)
+	if errors.Is(err, pErrors.ErrNotFound) {
+		err = rbac.ErrForbidden
+	}
+	return
+}
+
+// owner allows the caller to act for memberID when it is their own profile or
+// when they may manage every invitation.
+func (u *Usecase) owner(ctx context.Context, memberID int64) (err error) {
+	caller, _ := identity.FromContext(ctx)
+	if caller.Can(rbac.INVITATIONSMANAGE) {
+		return
+	}
+
+	callerID, err := u.member(ctx)
+	if err != nil {
+		return
+	}
+
+	if callerID != memberID {
+		err = rbac.ErrForbidden
+	}
 	return
 }
@@ -6,7 +6,10 @@ import (
 	"testing"
 
 	"github.com/rzfhlv/gin-example/internal/modules/invitation/model"
+	pErrors "github.com/rzfhlv/gin-example/pkg/errors"
+	"github.com/rzfhlv/gin-example/pkg/identity"
 	"github.com/rzfhlv/gin-example/pkg/param"
+	"github.com/rzfhlv/gin-example/pkg/rbac"
 	mockRepo "github.com/rzfhlv/gin-example/shared/mocks/modules/invitation/repository"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -15,11 +18,19 @@ import (
 type testCase struct {
 	name                                      string
 	wantError, wantAttendeeError, wantIDError error
+	wantMemberError                           error
+	isOrganizer                               bool
 	isErr                                     bool
 	result                                    CustomResult
 }
 
 var (
+	member = identity.NewContext(context.Background(), identity.Identity{
+		ID: 1, Username: "johndoe", Email: "john@test.com", Roles: []string{rbac.ORGANIZER},
+	})
+	admin = identity.NewContext(context.Background(), identity.Identity{
+		ID: 2, Username: "admin", Email: "admin@test.com", Roles: []string{rbac.ADMIN},
+	})
 	errFoo            = errors.New("error")
 	invitationPayload = model.Invitation{
 		ID:          1,
@@ -68,6 +79,8 @@ func TestCreate(t *testing.T) {
 	for _, tt := range testCase {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := mockRepo.IRepository{}
+			mockRepo.On("GetMemberIDByUserID", mock.Anything, int64(1)).Return(int64(1), nil)
+			mockRepo.On("IsOrganizer", mock.Anything, invitationPayload.GatheringID, int64(1)).Return(true, nil)
 			mockRepo.On("Create", mock.Anything, mock.Anything).Return(&tt.result, tt.wantError)
 			mockRepo.On("CreateAttendee", mock.Anything, mock.Anything).Return(tt.wantAttendeeError)
 
@@ -75,7 +88,7 @@ func TestCreate(t *testing.T) {
 				repo: &mockRepo,
 			}
 
-			_, err := u.Create(context.Background(), invitationPayload)
+			_, err := u.Create(member, invitationPayload)
 			if tt.wantAttendeeError != nil {
 				assert.EqualValues(t, err, tt.wantAttendeeError)
 			} else if tt.wantError != nil {
@@ -100,8 +113,8 @@ func TestGet(t *testing.T) {
 	for _, tt := range testCase {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := mockRepo.IRepository{}
-			mockRepo.On("Get", mock.Anything, mock.Anything).Return([]model.Invitation{}, tt.wantError)
-			mockRepo.On("Count", mock.Anything).Return(expectedCount, tt.wantError)
+			mockRepo.On("Get", mock.Anything, mock.Anything).Return([]model.Invitation{}, param.Page{}, tt.wantError)
+			mockRepo.On("Count", mock.Anything, mock.Anything).Return(expectedCount, tt.wantError)
 
 			u := &Usecase{
 				repo: &mockRepo,
@@ -137,26 +150,92 @@ func TestGetByID(t *testing.T) {
 	}
 }
 
-func TestUpdate(t *testing.T) {
+func TestCreateForbidden(t *testing.T) {
 	testCase := []testCase{
 		{
-			name: "Testcase #1: Positive", wantError: nil, isErr: false,
+			name: "Testcase #1: Negative", wantError: rbac.ErrForbidden, isOrganizer: false,
 		},
 		{
-			name: "Testcase #2: Negative", wantError: errFoo, isErr: true,
+			name: "Testcase #2: Negative", wantError: rbac.ErrForbidden, wantMemberError: pErrors.ErrNotFound, isOrganizer: true,
+		},
+		{
+			name: "Testcase #3: Negative", wantError: errFoo, wantIDError: errFoo, isOrganizer: false,
 		},
 	}
 	for _, tt := range testCase {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := mockRepo.IRepository{}
-			mockRepo.On("Update", mock.Anything, mock.Anything, mock.Anything).Return(&CustomResult{lastInsertID: 1, rowsAffected: 1}, tt.wantError)
+			mockRepo.On("GetMemberIDByUserID", mock.Anything, int64(1)).Return(int64(1), tt.wantMemberError)
+			mockRepo.On("IsOrganizer", mock.Anything, invitationPayload.GatheringID, int64(1)).Return(tt.isOrganizer, tt.wantIDError)
 
 			u := &Usecase{
 				repo: &mockRepo,
 			}
 
-			_, err := u.Update(context.Background(), invitationPayload, invitationPayload.ID)
-			assert.EqualValues(t, err, tt.wantError)
+			_, err := u.Create(member, invitationPayload)
+			assert.Equal(t, tt.wantError, err)
+			mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		})
+	}
+
+	t.Run("Testcase #4: Positive", func(t *testing.T) {
+		mockRepo := mockRepo.IRepository{}
+		mockRepo.On("Create", mock.Anything, mock.Anything).Return(&CustomResult{lastInsertID: 1, rowsAffected: 1}, nil)
+		mockRepo.On("CreateAttendee", mock.Anything, mock.Anything).Return(nil)
+
+		u := &Usecase{
+			repo: &mockRepo,
+		}
+
+		_, err := u.Create(admin, invitationPayload)
+		assert.NoError(t, err)
+		mockRepo.AssertNotCalled(t, "IsOrganizer", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestUpdate(t *testing.T) {
+	testCase := []struct {
+		name                                string
+		ctx                                 context.Context
+		invitation                          model.Invitation
+		wantGetError, wantError, wantUpdate error
+	}{
+		{
+			name: "Testcase #1: Positive", ctx: member, invitation: invitationPayload,
+		},
+		{
+			name: "Testcase #2: Positive", ctx: admin, invitation: model.Invitation{ID: 1, MemberID: 3, GatheringID: 1, Status: "pending"},
+		},
+		{
+			name: "Testcase #3: Negative", ctx: member, invitation: model.Invitation{ID: 1, MemberID: 3, GatheringID: 1, Status: "pending"}, wantError: rbac.ErrForbidden,
+		},
+		{
+			name: "Testcase #4: Negative", ctx: context.Background(), invitation: invitationPayload, wantError: rbac.ErrForbidden,
+		},
+		{
+			name: "Testcase #5: Negative", ctx: member, wantGetError: pErrors.ErrNotFound, wantError: pErrors.ErrNotFound,
+		},
+		{
+			name: "Testcase #6: Negative", ctx: member, invitation: invitationPayload, wantUpdate: errFoo, wantError: errFoo,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mockRepo.IRepository{}
+			mockRepo.On("GetByID", mock.Anything, invitationPayload.ID).Return(tt.invitation, tt.wantGetError)
+			mockRepo.On("GetMemberIDByUserID", mock.Anything, int64(1)).Return(int64(1), nil)
+			mockRepo.On("Update", mock.Anything, mock.Anything, invitationPayload.ID).Return(&CustomResult{lastInsertID: 1, rowsAffected: 1}, tt.wantUpdate)
+
+			u := &Usecase{
+				repo: &mockRepo,
+			}
+
+			invitation, err := u.Update(tt.ctx, model.Invitation{Status: "reject"}, invitationPayload.ID)
+			assert.EqualValues(t, tt.wantError, err)
+			if tt.wantError == nil {
+				assert.Equal(t, "reject", invitation.Status)
+				assert.Equal(t, tt.invitation.MemberID, invitation.MemberID)
+			}
 		})
 	}
 }
@@ -173,14 +252,70 @@ func TestGetByMemberID(t *testing.T) {
 	for _, tt := range testCase {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := mockRepo.IRepository{}
+			mockRepo.On("GetMemberIDByUserID", mock.Anything, int64(1)).Return(int64(1), nil)
 			mockRepo.On("GetByMemberID", mock.Anything, mock.Anything).Return([]model.InvitationDetail{}, tt.wantError)
 
 			u := &Usecase{
 				repo: &mockRepo,
 			}
 
-			_, err := u.GetByMemberID(context.Background(), invitationPayload.MemberID)
+			_, err := u.GetByMemberID(member, invitationPayload.MemberID)
 			assert.EqualValues(t, err, tt.wantError)
 		})
 	}
+
+	t.Run("Testcase #3: Negative", func(t *testing.T) {
+		mockRepo := mockRepo.IRepository{}
+		mockRepo.On("GetMemberIDByUserID", mock.Anything, int64(1)).Return(int64(1), nil)
+
+		u := &Usecase{
+			repo: &mockRepo,
+		}
+
+		_, err := u.GetByMemberID(member, 3)
+		assert.Equal(t, rbac.ErrForbidden, err)
+	})
+
+	t.Run("Testcase #4: Positive", func(t *testing.T) {
+		mockRepo := mockRepo.IRepository{}
+		mockRepo.On("GetByMemberID", mock.Anything, int64(3)).Return([]model.InvitationDetail{}, nil)
+
+		u := &Usecase{
+			repo: &mockRepo,
+		}
+
+		_, err := u.GetByMemberID(admin, 3)
+		assert.NoError(t, err)
+	})
+}
+
+func TestGetMine(t *testing.T) {
+	testCase := []testCase{
+		{
+			name: "Testcase #1: Positive", wantError: nil, wantMemberError: nil,
+		},
+		{
+			name: "Testcase #2: Negative", wantError: rbac.ErrForbidden, wantMemberError: pErrors.ErrNotFound,
+		},
+		{
+			name: "Testcase #3: Negative", wantError: errFoo, wantIDError: errFoo,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mockRepo.IRepository{}
+			mockRepo.On("GetMemberIDByUserID", mock.Anything, int64(1)).Return(int64(5), tt.wantMemberError)
+			mockRepo.On("GetByMemberID", mock.Anything, int64(5)).Return(nil, tt.wantIDError)
+
+			u := &Usecase{
+				repo: &mockRepo,
+			}
+
+			invitations, err := u.GetMine(member)
+			assert.Equal(t, tt.wantError, err)
+			if tt.wantError == nil {
+				assert.Equal(t, []model.InvitationDetail{}, invitations)
+			}
+		})
+	}
 }
@@ -1,7 +1,8 @@
 package handler
 
 import (
-	"database/sql"
+	"errors"
+	"fmt"
 	"log"
 	"net/http"
 	"strconv"
@@ -10,17 +11,38 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/rzfhlv/gin-example/internal/modules/member/model"
 	"github.com/rzfhlv/gin-example/internal/modules/member/usecase"
+	"github.com/rzfhlv/gin-example/pkg/export"
 	"github.com/rzfhlv/gin-example/pkg/message"
 	"github.com/rzfhlv/gin-example/pkg/param"
+	"github.com/rzfhlv/gin-example/pkg/password"
 	"github.com/rzfhlv/gin-example/pkg/response"
+	"github.com/rzfhlv/gin-example/pkg/validation"
 )
 
 type IHandler interface {
 	Create(g *gin.Context)
 	Get(g *gin.Context)
 	GetByID(g *gin.Context)
+	Update(g *gin.Context)
+	Delete(g *gin.Context)
+	Restore(g *gin.Context)
+	Import(g *gin.Context)
+	GetImport(g *gin.Context)
+	Export(g *gin.Context)
 }
 
+var (
+	MAXIMPORTSIZE int64 = 5 << 20
+
+	EXPORTCOLUMNS = []export.Column[model.Member]{
+		{Name: "id", Value: func(m model.Member) interface{} { return m.ID }},
+		{Name: "first_name", Value: func(m model.Member) interface{} { return m.FirstName }},
+		{Name: "last_name", Value: func(m model.Member) interface{} { return m.LastName }},
+		{Name: "email", Value: func(m model.Member) interface{} { return m.Email }},
+		{Name: "created_at", Value: func(m model.Member) interface{} { return m.CreatedAt }},
+	}
+)
+
 type Handler struct {
 	usecase usecase.IUsecase
 }
@@ -38,7 +60,7 @@ func (h *Handler) Create(g *gin.Context) {
 	err := g.ShouldBindJSON(&memberPayload)
 	if err != nil {
 		log.Printf("Error Binding and Validation Member, %v", err.Error())
-		g.JSON(http.StatusUnprocessableEntity, response.Set(message.ERROR, err.Error(), nil, nil))
+		response.Error(g, http.StatusUnprocessableEntity, message.UNPROCESSABLEENTITY, validation.Translate(err, g.GetHeader("Accept-Language"))...)
 		return
 	}
 	memberPayload.CreatedAt = time.Now()
@@ -46,7 +68,12 @@ func (h *Handler) Create(g *gin.Context) {
 	member, err := h.usecase.Create(ctx, memberPayload)
 	if err != nil {
 		log.Printf("Error Create Member, %v", err.Error())
-		g.JSON(http.StatusInternalServerError, response.Set(message.ERROR, message.SOMETHINGWENTWRONG, nil, nil))
+		var invalid *password.Error
+		if errors.As(err, &invalid) {
+			response.Error(g, http.StatusUnprocessableEntity, message.WEAKPASSWORD, invalid.Fields...)
+			return
+		}
+		g.Error(err)
 		return
 	}
 
@@ -62,18 +89,26 @@ func (h *Handler) Get(g *gin.Context) {
 	err := g.ShouldBind(&queryParam)
 	if err != nil {
 		log.Printf("Error Binding Query Param Gathering, %v", err.Error())
-		g.JSON(http.StatusUnprocessableEntity, response.Set(message.ERROR, message.UNPROCESSABLEENTITY, nil, nil))
+		response.Error(g, http.StatusUnprocessableEntity, message.UNPROCESSABLEENTITY, validation.Translate(err, g.GetHeader("Accept-Language"))...)
 		return
 	}
+	queryParam.Filters = g.Request.URL.Query()
 
-	members, total, err := h.usecase.Get(ctx, queryParam)
+	members, page, err := h.usecase.Get(ctx, queryParam)
 	if err != nil {
 		log.Printf("Error Get Member, %v", err.Error())
-		g.JSON(http.StatusInternalServerError, response.Set(message.ERROR, message.SOMETHINGWENTWRONG, nil, nil))
+		if errors.Is(err, param.ErrCursorDisabled) {
+			response.Error(g, http.StatusBadRequest, err.Error())
+			return
+		}
+		if param.IsInvalid(err) {
+			response.Error(g, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		g.Error(err)
 		return
 	}
-	queryParam.Total = total
-	meta := response.BuildMeta(queryParam, len(members))
+	meta := response.BuildMeta(queryParam, page, len(members), g.Request.URL)
 
 	g.JSON(http.StatusOK, response.Set(message.SUCCESS, message.OK, meta, members))
 }
@@ -85,20 +120,208 @@ func (h *Handler) GetByID(g *gin.Context) {
 	memberID, err := strconv.ParseInt(id, 10, 64)
 	if err != nil {
 		log.Printf("Error Parse Member ID, %v", err.Error())
-		g.JSON(http.StatusUnprocessableEntity, response.Set(message.ERROR, message.UNPROCESSABLEENTITY, nil, nil))
+		response.Error(g, http.StatusUnprocessableEntity, message.UNPROCESSABLEENTITY)
 		return
 	}
 
 	member, err := h.usecase.GetByID(ctx, memberID)
 	if err != nil {
 		log.Printf("Error Get By ID Member, %v", err.Error())
-		if err == sql.ErrNoRows {
-			g.JSON(http.StatusNotFound, response.Set(message.ERROR, message.NOTFOUND, nil, nil))
+		g.Error(err)
+		return
+	}
+
+	g.JSON(http.StatusOK, response.Set(message.SUCCESS, message.OK, nil, member))
+}
+
+func (h *Handler) Update(g *gin.Context) {
+	ctx := g.Request.Context()
+
+	memberID, err := strconv.ParseInt(g.Param("id"), 10, 64)
+	if err != nil {
+		log.Printf("Error Parse Member ID, %v", err.Error())
+		response.Error(g, http.StatusUnprocessableEntity, message.UNPROCESSABLEENTITY)
+		return
+	}
+
+	update := model.UpdateMember{}
+	err = g.ShouldBindJSON(&update)
+	if err != nil {
+		log.Printf("Error Binding and Validation Member, %v", err.Error())
+		response.Error(g, http.StatusUnprocessableEntity, message.UNPROCESSABLEENTITY, validation.Translate(err, g.GetHeader("Accept-Language"))...)
+		return
+	}
+
+	member, err := h.usecase.Update(ctx, memberID, update)
+	if err != nil {
+		log.Printf("Error Update Member, %v", err.Error())
+		g.Error(err)
+		return
+	}
+
+	g.JSON(http.StatusOK, response.Set(message.SUCCESS, message.OK, nil, member))
+}
+
+func (h *Handler) Delete(g *gin.Context) {
+	ctx := g.Request.Context()
+
+	memberID, err := strconv.ParseInt(g.Param("id"), 10, 64)
+	if err != nil {
+		log.Printf("Error Parse Member ID, %v", err.Error())
+		response.Error(g, http.StatusUnprocessableEntity, message.UNPROCESSABLEENTITY)
+		return
+	}
+
+	err = h.usecase.Delete(ctx, memberID)
+	if err != nil {
+		log.Printf("Error Delete Member, %v", err.Error())
+		g.Error(err)
+		return
+	}
+
+	g.JSON(http.StatusOK, response.Set(message.SUCCESS, message.OK, nil, nil))
+}
+
+func (h *Handler) Restore(g *gin.Context) {
+	ctx := g.Request.Context()
+
+	memberID, err := strconv.ParseInt(g.Param("id"), 10, 64)
+	if err != nil {
+		log.Printf("Error Parse Member ID, %v", err.Error())
+		response.Error(g, http.StatusUnprocessableEntity, message.UNPROCESSABLEENTITY)
+		return
+	}
+
+	member, err := h.usecase.Restore(ctx, memberID)
+	if err != nil {
+		log.Printf("Error Restore Member, %v", err.Error())
+		g.Error(err)
+		return
+	}
+
+	g.JSON(http.StatusOK, response.Set(message.SUCCESS, message.OK, nil, member))
+}
+
+func (h *Handler) Import(g *gin.Context) {
+	ctx := g.Request.Context()
+
+	file, err := g.FormFile("file")
+	if err != nil {
+		log.Printf("Error Form File Member Import, %v", err.Error())
+		response.Error(g, http.StatusUnprocessableEntity, message.UNPROCESSABLEENTITY)
+		return
+	}
+	if file.Size > MAXIMPORTSIZE {
+		log.Printf("Error Member Import Size, %v", file.Size)
+		response.Error(g, http.StatusRequestEntityTooLarge, message.FILETOOLARGE)
+		return
+	}
+
+	dryRun := false
+	if g.Query("dry_run") != "" {
+		dryRun, err = strconv.ParseBool(g.Query("dry_run"))
+		if err != nil {
+			log.Printf("Error Parse Dry Run, %v", err.Error())
+			response.Error(g, http.StatusUnprocessableEntity, message.UNPROCESSABLEENTITY)
 			return
 		}
-		g.JSON(http.StatusInternalServerError, response.Set(message.SUCCESS, message.SOMETHINGWENTWRONG, nil, nil))
+	}
+
+	content, err := file.Open()
+	if err != nil {
+		log.Printf("Error Open Member Import, %v", err.Error())
+		g.Error(err)
 		return
 	}
+	defer content.Close()
 
-	g.JSON(http.StatusOK, response.Set(message.SUCCESS, message.OK, nil, member))
+	job, err := h.usecase.Import(ctx, content, dryRun)
+	if err != nil {
+		log.Printf("Error Import Member, %v", err.Error())
+		if errors.Is(err, usecase.ErrInvalidCSV) || errors.Is(err, usecase.ErrTooManyRows) {
+			response.Error(g, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		g.Error(err)
+		return
+	}
+
+	if job.Status == usecase.PENDING {
+		g.Header("Location", fmt.Sprintf("%s/%s", g.Request.URL.Path, job.ID))
+		g.JSON(http.StatusAccepted, response.Set(message.SUCCESS, message.OK, nil, job))
+		return
+	}
+
+	g.JSON(http.StatusOK, response.Set(message.SUCCESS, message.OK, nil, job))
+}
+
+func (h *Handler) GetImport(g *gin.Context) {
+	ctx := g.Request.Context()
+
+	job, err := h.usecase.GetImport(ctx, g.Param("job_id"))
+	if err != nil {
+		log.Printf("Error Get Member Import, %v", err.Error())
+		g.Error(err)
+		return
+	}
+
+	g.JSON(http.StatusOK, response.Set(message.SUCCESS, message.OK, nil, job))
+}
+
+// Export streams every member matching the list filters. Once the first rows
+// are out the status can't change anymore, a later failure only cuts the
+// stream short.
+func (h *Handler) Export(g *gin.Context) {
+	ctx := g.Request.Context()
+	queryParam := param.Param{}
+
+	err := g.ShouldBind(&queryParam)
+	if err != nil {
+		log.Printf("Error Binding Query Param Member Export, %v", err.Error())
+		response.Error(g, http.StatusUnprocessableEntity, message.UNPROCESSABLEENTITY, validation.Translate(err, g.GetHeader("Accept-Language"))...)
+		return
+	}
+	queryParam.Filters = g.Request.URL.Query()
+
+	format := g.DefaultQuery("format", export.CSV)
+	writer, err := export.NewWriter(g.Writer, format, EXPORTCOLUMNS)
+	if err != nil {
+		log.Printf("Error Export Format Member, %v", err.Error())
+		response.Error(g, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+	contentType, _ := export.ContentType(format)
+
+	g.Header("Content-Type", contentType)
+	g.Header("Content-Disposition", export.Disposition("members", format))
+	g.Header("Cache-Control", "no-store")
+
+	err = h.usecase.Export(ctx, queryParam, func(members []model.Member) (err error) {
+		err = writer.Write(members...)
+		if err != nil {
+			return
+		}
+		err = writer.Flush()
+		return
+	})
+	if err == nil {
+		err = writer.Flush()
+	}
+	if err != nil {
+		log.Printf("Error Export Member, %v", err.Error())
+		if g.Writer.Written() {
+			return
+		}
+		g.Header("Content-Type", "")
+		g.Header("Content-Disposition", "")
+		if errors.Is(err, param.ErrCursorDisabled) {
+			response.Error(g, http.StatusBadRequest, err.Error())
+			return
+		}
+		if param.IsInvalid(err) {
+			response.Error(g, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		g.Error(err)
+	}
 }
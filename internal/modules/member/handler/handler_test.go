@@ -1,8 +1,11 @@
 package handler
 
 import (
+	"bytes"
 	"database/sql"
 	"errors"
+	"fmt"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -10,6 +13,11 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/rzfhlv/gin-example/internal/modules/member/model"
+	"github.com/rzfhlv/gin-example/internal/modules/member/usecase"
+	"github.com/rzfhlv/gin-example/middleware"
+	"github.com/rzfhlv/gin-example/pkg/param"
+	"github.com/rzfhlv/gin-example/pkg/password"
+	"github.com/rzfhlv/gin-example/pkg/rbac"
 	mockUsecase "github.com/rzfhlv/gin-example/shared/mocks/modules/member/usecase"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -47,6 +55,9 @@ func TestCreate(t *testing.T) {
 		{
 			name: "Testcase #3: Negative", body: payloadFail, wantError: errFoo, code: http.StatusUnprocessableEntity,
 		},
+		{
+			name: "Testcase #4: Negative", body: payloadSuccess, wantError: &password.Error{Fields: []password.FieldError{{Field: password.FIELD, Message: "must contain a digit"}}}, code: http.StatusUnprocessableEntity,
+		},
 	}
 	for _, tt := range testCase {
 		t.Run(tt.name, func(t *testing.T) {
@@ -63,6 +74,7 @@ func TestCreate(t *testing.T) {
 			ctx.Request.Header.Set("Content-Type", "application/json")
 
 			h.Create(ctx)
+			middleware.RenderError(ctx)
 			assert.EqualValues(t, tt.code, w.Code)
 		})
 	}
@@ -82,11 +94,17 @@ func TestGet(t *testing.T) {
 		{
 			name: "Testcase #3: Negative", queryParam: "?page=one", wantError: errFoo, code: http.StatusUnprocessableEntity,
 		},
+		{
+			name: "Testcase #4: Negative", queryParam: "?sort=password", wantError: fmt.Errorf("%w: password", param.ErrInvalidSort), code: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "Testcase #5: Negative", queryParam: "?cursor=e30.", wantError: param.ErrCursorDisabled, code: http.StatusBadRequest,
+		},
 	}
 	for _, tt := range testCase {
 		t.Run(tt.name, func(t *testing.T) {
 			mockUsecase := mockUsecase.IUsecase{}
-			mockUsecase.On("Get", mock.Anything, mock.Anything).Return([]model.Member{}, expectedCount, tt.wantError)
+			mockUsecase.On("Get", mock.Anything, mock.Anything).Return([]model.Member{}, param.Page{Total: &expectedCount}, tt.wantError)
 
 			h := &Handler{
 				usecase: &mockUsecase,
@@ -98,6 +116,7 @@ func TestGet(t *testing.T) {
 			ctx.Request.Header.Set("Content-Type", "application/json")
 
 			h.Get(ctx)
+			middleware.RenderError(ctx)
 			assert.Equal(t, tt.code, w.Code)
 		})
 	}
@@ -136,7 +155,325 @@ func TestGetByID(t *testing.T) {
 			ctx.Params = gin.Params{{Key: "id", Value: tt.param}}
 
 			h.GetByID(ctx)
+			middleware.RenderError(ctx)
+			assert.Equal(t, tt.code, w.Code)
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCase := []testCase{
+		{
+			name: "Testcase #1: Positive", param: "1", body: `{"first_name":"Jane"}`, wantError: nil, code: http.StatusOK,
+		},
+		{
+			name: "Testcase #2: Negative", param: "1", body: `{"first_name":"Jane"}`, wantError: errFoo, code: http.StatusInternalServerError,
+		},
+		{
+			name: "Testcase #3: Negative", param: "one", body: `{"first_name":"Jane"}`, wantError: nil, code: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "Testcase #4: Negative", param: "1", body: `{"first_name":""}`, wantError: nil, code: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "Testcase #5: Negative", param: "1", body: `{"email":"notanemail"}`, wantError: nil, code: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "Testcase #6: Negative", param: "1", body: `{"email":"jane@test.com"}`, wantError: sql.ErrNoRows, code: http.StatusNotFound,
+		},
+		{
+			name: "Testcase #7: Negative", param: "1", body: `{"email":"jane@test.com"}`, wantError: usecase.ErrEmailExists, code: http.StatusConflict,
+		},
+		{
+			name: "Testcase #8: Negative", param: "1", body: `{"first_name":"Jane"}`, wantError: rbac.ErrForbidden, code: http.StatusForbidden,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := mockUsecase.IUsecase{}
+			mockUsecase.On("Update", mock.Anything, int64(1), mock.Anything).Return(model.Member{}, tt.wantError)
+
+			h := &Handler{
+				usecase: &mockUsecase,
+			}
+
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = httptest.NewRequest(http.MethodPatch, "/v1/members/"+tt.param, strings.NewReader(tt.body))
+			ctx.Request.Header.Set("Content-Type", "application/json")
+			ctx.Params = gin.Params{{Key: "id", Value: tt.param}}
+
+			h.Update(ctx)
+			middleware.RenderError(ctx)
+			assert.Equal(t, tt.code, w.Code)
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCase := []testCase{
+		{
+			name: "Testcase #1: Positive", param: "1", wantError: nil, code: http.StatusOK,
+		},
+		{
+			name: "Testcase #2: Negative", param: "1", wantError: errFoo, code: http.StatusInternalServerError,
+		},
+		{
+			name: "Testcase #3: Negative", param: "one", wantError: nil, code: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "Testcase #4: Negative", param: "1", wantError: sql.ErrNoRows, code: http.StatusNotFound,
+		},
+		{
+			name: "Testcase #5: Negative", param: "1", wantError: rbac.ErrForbidden, code: http.StatusForbidden,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := mockUsecase.IUsecase{}
+			mockUsecase.On("Delete", mock.Anything, int64(1)).Return(tt.wantError)
+
+			h := &Handler{
+				usecase: &mockUsecase,
+			}
+
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = httptest.NewRequest(http.MethodDelete, "/v1/members/"+tt.param, nil)
+			ctx.Params = gin.Params{{Key: "id", Value: tt.param}}
+
+			h.Delete(ctx)
+			middleware.RenderError(ctx)
+			assert.Equal(t, tt.code, w.Code)
+		})
+	}
+}
+
+func TestRestore(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCase := []testCase{
+		{
+			name: "Testcase #1: Positive", param: "1", wantError: nil, code: http.StatusOK,
+		},
+		{
+			name: "Testcase #2: Negative", param: "1", wantError: errFoo, code: http.StatusInternalServerError,
+		},
+		{
+			name: "Testcase #3: Negative", param: "one", wantError: nil, code: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "Testcase #4: Negative", param: "1", wantError: sql.ErrNoRows, code: http.StatusNotFound,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := mockUsecase.IUsecase{}
+			mockUsecase.On("Restore", mock.Anything, int64(1)).Return(model.Member{}, tt.wantError)
+
+			h := &Handler{
+				usecase: &mockUsecase,
+			}
+
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = httptest.NewRequest(http.MethodPost, "/v1/admin/members/"+tt.param+"/restore", nil)
+			ctx.Params = gin.Params{{Key: "id", Value: tt.param}}
+
+			h.Restore(ctx)
+			middleware.RenderError(ctx)
+			assert.Equal(t, tt.code, w.Code)
+		})
+	}
+}
+
+func multipartBody(field, content string) (body *bytes.Buffer, contentType string) {
+	body = &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, _ := writer.CreateFormFile(field, "members.csv")
+	_, _ = part.Write([]byte(content))
+	_ = writer.Close()
+	contentType = writer.FormDataContentType()
+	return
+}
+
+func TestImport(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	csv := "first_name,last_name,email\nJohn,Doe,john@test.com\n"
+	testCase := []struct {
+		name, field, content, queryParam string
+		job                              model.ImportJob
+		wantError                        error
+		code                             int
+	}{
+		{
+			name: "Testcase #1: Positive", field: "file", content: csv, job: model.ImportJob{Status: usecase.DONE}, code: http.StatusOK,
+		},
+		{
+			name: "Testcase #2: Positive", field: "file", content: csv, queryParam: "?dry_run=true", job: model.ImportJob{Status: usecase.DONE}, code: http.StatusOK,
+		},
+		{
+			name: "Testcase #3: Positive", field: "file", content: csv, job: model.ImportJob{ID: "thisisjob", Status: usecase.PENDING}, code: http.StatusAccepted,
+		},
+		{
+			name: "Testcase #4: Negative", field: "upload", content: csv, code: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "Testcase #5: Negative", field: "file", content: csv, queryParam: "?dry_run=maybe", code: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "Testcase #6: Negative", field: "file", content: csv, wantError: fmt.Errorf("%w: missing column email", usecase.ErrInvalidCSV), code: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "Testcase #7: Negative", field: "file", content: csv, wantError: usecase.ErrTooManyRows, code: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "Testcase #8: Negative", field: "file", content: csv, wantError: errFoo, code: http.StatusInternalServerError,
+		},
+		{
+			name: "Testcase #9: Negative", field: "file", content: strings.Repeat("a", 11), code: http.StatusRequestEntityTooLarge,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := mockUsecase.IUsecase{}
+			mockUsecase.On("Import", mock.Anything, mock.Anything, tt.queryParam == "?dry_run=true").Return(tt.job, tt.wantError)
+
+			h := &Handler{
+				usecase: &mockUsecase,
+			}
+
+			maxImportSize := MAXIMPORTSIZE
+			if tt.code == http.StatusRequestEntityTooLarge {
+				MAXIMPORTSIZE = 10
+			}
+			defer func() { MAXIMPORTSIZE = maxImportSize }()
+
+			body, contentType := multipartBody(tt.field, tt.content)
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = httptest.NewRequest(http.MethodPost, "/v1/members/import"+tt.queryParam, body)
+			ctx.Request.Header.Set("Content-Type", contentType)
+
+			h.Import(ctx)
+			middleware.RenderError(ctx)
+			assert.Equal(t, tt.code, w.Code)
+			if tt.code == http.StatusAccepted {
+				assert.Contains(t, w.Body.String(), "thisisjob")
+			}
+		})
+	}
+}
+
+func TestGetImport(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCase := []testCase{
+		{
+			name: "Testcase #1: Positive", param: "thisisjob", wantError: nil, code: http.StatusOK,
+		},
+		{
+			name: "Testcase #2: Negative", param: "thisisjob", wantError: usecase.ErrImportNotFound, code: http.StatusNotFound,
+		},
+		{
+			name: "Testcase #3: Negative", param: "thisisjob", wantError: errFoo, code: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := mockUsecase.IUsecase{}
+			mockUsecase.On("GetImport", mock.Anything, tt.param).Return(model.ImportJob{ID: tt.param, Status: usecase.DONE}, tt.wantError)
+
+			h := &Handler{
+				usecase: &mockUsecase,
+			}
+
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = httptest.NewRequest(http.MethodGet, "/v1/members/import/"+tt.param, nil)
+			ctx.Params = gin.Params{{Key: "job_id", Value: tt.param}}
+
+			h.GetImport(ctx)
+			middleware.RenderError(ctx)
 			assert.Equal(t, tt.code, w.Code)
 		})
 	}
 }
+
+func TestExport(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	members := []model.Member{{ID: 1, FirstName: "John", LastName: "Doe", Email: "john@test.com"}}
+	testCase := []struct {
+		name, queryParam string
+		rows             []model.Member
+		wantError        error
+		code             int
+		contentType      string
+		body             string
+	}{
+		{
+			name: "Testcase #1: Positive", rows: members, code: http.StatusOK, contentType: "text/csv; charset=utf-8",
+			body: "id,first_name,last_name,email,created_at\n1,John,Doe,john@test.com,0001-01-01T00:00:00Z\n",
+		},
+		{
+			name: "Testcase #2: Positive", queryParam: "?format=ndjson&email=john@test.com", rows: members, code: http.StatusOK, contentType: "application/x-ndjson",
+			body: `{"id":1,"first_name":"John","last_name":"Doe","email":"john@test.com","created_at":"0001-01-01T00:00:00Z"}` + "\n",
+		},
+		{
+			name: "Testcase #3: Positive", code: http.StatusOK, contentType: "text/csv; charset=utf-8",
+			body: "id,first_name,last_name,email,created_at\n",
+		},
+		{
+			name: "Testcase #4: Negative", queryParam: "?format=xml", code: http.StatusUnprocessableEntity, contentType: "application/json; charset=utf-8",
+		},
+		{
+			name: "Testcase #5: Negative", queryParam: "?limit=ten", code: http.StatusUnprocessableEntity, contentType: "application/json; charset=utf-8",
+		},
+		{
+			name: "Testcase #6: Negative", wantError: errFoo, code: http.StatusInternalServerError, contentType: "application/json; charset=utf-8",
+		},
+		{
+			name: "Testcase #7: Negative", queryParam: "?created_from=yesterday", wantError: fmt.Errorf("%w: created_from", param.ErrInvalidFilter), code: http.StatusUnprocessableEntity, contentType: "application/json; charset=utf-8",
+		},
+		{
+			name: "Testcase #8: Negative", rows: members, wantError: errFoo, code: http.StatusOK, contentType: "text/csv; charset=utf-8",
+			body: "id,first_name,last_name,email,created_at\n1,John,Doe,john@test.com,0001-01-01T00:00:00Z\n",
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := mockUsecase.IUsecase{}
+			mockUsecase.On("Export", mock.Anything, mock.Anything, mock.Anything).
+				Run(func(args mock.Arguments) {
+					if len(tt.rows) > 0 {
+						write := args.Get(2).(func([]model.Member) error)
+						_ = write(tt.rows)
+					}
+				}).
+				Return(tt.wantError)
+
+			h := &Handler{
+				usecase: &mockUsecase,
+			}
+
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = httptest.NewRequest(http.MethodGet, "/v1/members/export"+tt.queryParam, nil)
+
+			h.Export(ctx)
+			middleware.RenderError(ctx)
+			assert.Equal(t, tt.code, w.Code)
+			assert.Equal(t, tt.contentType, w.Header().Get("Content-Type"))
+			if tt.body != "" {
+				assert.Equal(t, tt.body, w.Body.String())
+				assert.Contains(t, w.Header().Get("Content-Disposition"), "members.")
+			}
+		})
+	}
+}
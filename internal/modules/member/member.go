@@ -7,14 +7,27 @@ import (
 	"github.com/rzfhlv/gin-example/internal/modules/member/repository"
 	"github.com/rzfhlv/gin-example/internal/modules/member/usecase"
 	"github.com/rzfhlv/gin-example/middleware/auth"
+	"github.com/rzfhlv/gin-example/pkg/rbac"
 )
 
 func Mount(route *gin.RouterGroup, h handler.IHandler, a auth.IAuth) (g *gin.RouterGroup) {
 	g = route.Group("/members")
+	g.Use(a.APIKey(), a.Bearer())
+	g.GET("", a.Can(rbac.MEMBERSREAD), h.Get)
+	g.GET("/export", a.Can(rbac.MEMBERSREAD), h.Export)
+	g.GET("/:id", a.Can(rbac.MEMBERSREAD), h.GetByID)
+	g.POST("", a.Can(rbac.MEMBERSWRITE), h.Create)
+	g.POST("/import", a.Can(rbac.MEMBERSWRITE), h.Import)
+	g.GET("/import/:job_id", a.Can(rbac.MEMBERSWRITE), h.GetImport)
+	g.PATCH("/:id", a.Can(rbac.MEMBERSWRITE), h.Update)
+	g.DELETE("/:id", a.Can(rbac.MEMBERSWRITE), h.Delete)
+	return
+}
+
+func MountAdmin(route *gin.RouterGroup, h handler.IHandler, a auth.IAuth) (g *gin.RouterGroup) {
+	g = route.Group("/admin/members")
 	g.Use(a.Bearer())
-	g.GET("", h.Get)
-	g.GET("/:id", h.GetByID)
-	g.POST("", h.Create)
+	g.POST("/:id/restore", a.Can(rbac.MEMBERSMANAGE), h.Restore)
 	return
 }
 
@@ -23,8 +36,11 @@ type Member struct {
 }
 
 func New(cfg *config.Config) *Member {
-	Repo := repository.New(cfg.MySQL)
-	Usecase := usecase.New(Repo, cfg.Pkg.Hasher)
+	Repo := repository.New(cfg.MySQL, cfg.Redis)
+	Usecase := usecase.New(Repo, cfg.Pkg.Hasher, cfg.Pkg.Policy, usecase.ImportLimits{
+		AsyncRows: cfg.Import.AsyncRows,
+		MaxRows:   cfg.Import.MaxRows,
+	})
 	Handler := handler.New(Usecase)
 
 	return &Member{
@@ -8,6 +8,7 @@ import (
 	mockAuth "github.com/rzfhlv/gin-example/shared/mocks/middleware/auth"
 	mockHandler "github.com/rzfhlv/gin-example/shared/mocks/modules/member/handler"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestNew(t *testing.T) {
@@ -28,9 +29,39 @@ func TestMount(t *testing.T) {
 			c.Next()
 		}
 	})
+	mockAuth.On("APIKey").Return(func() gin.HandlerFunc {
+		return func(c *gin.Context) {
+			c.Next()
+		}
+	})
+	mockAuth.On("Can", mock.Anything).Return(func(permission string) gin.HandlerFunc {
+		return func(c *gin.Context) {
+			c.Next()
+		}
+	})
 
 	g := gin.Default()
 	route := g.Group("/v1")
 	m := Mount(route, &mockHandler, &mockAuth)
 	assert.NotNil(t, m)
 }
+
+func TestMountAdmin(t *testing.T) {
+	mockHandler := mockHandler.IHandler{}
+	mockAuth := mockAuth.IAuth{}
+	mockAuth.On("Bearer").Return(func() gin.HandlerFunc {
+		return func(c *gin.Context) {
+			c.Next()
+		}
+	})
+	mockAuth.On("Can", mock.Anything).Return(func(permission string) gin.HandlerFunc {
+		return func(c *gin.Context) {
+			c.Next()
+		}
+	})
+
+	g := gin.Default()
+	route := g.Group("/v1")
+	m := MountAdmin(route, &mockHandler, &mockAuth)
+	assert.NotNil(t, m)
+}
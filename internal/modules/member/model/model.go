@@ -4,9 +4,49 @@ import "time"
 
 type Member struct {
 	ID        int64     `json:"id,omitempty" db:"id"`
+	UserID    *int64    `json:"-" db:"user_id"` // nil for a member without an account
 	FirstName string    `json:"first_name" db:"first_name" binding:"required"`
 	LastName  string    `json:"last_name" db:"last_name" binding:"required"`
-	Email     string    `json:"email" db:"email" binding:"required"`
+	Email     string    `json:"email" db:"email" binding:"required,email_format"`
 	Password  string    `json:"password,omitempty" binding:"required"`
 	CreatedAt time.Time `json:"created_at" db:"created_at"`
 }
+
+// UpdateMember is a partial update, a field left out keeps its value.
+type UpdateMember struct {
+	FirstName *string `json:"first_name" binding:"omitempty,min=1,max=50"`
+	LastName  *string `json:"last_name" binding:"omitempty,min=1,max=50"`
+	Email     *string `json:"email" binding:"omitempty,email_format,max=50"`
+}
+
+type ImportRow struct {
+	Line      int
+	FirstName string
+	LastName  string
+	Email     string
+}
+
+type ImportError struct {
+	Line    int    `json:"line"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+type ImportReport struct {
+	DryRun   bool          `json:"dry_run"`
+	Total    int           `json:"total"`
+	Valid    int           `json:"valid"`
+	Imported int           `json:"imported"`
+	Errors   []ImportError `json:"errors"`
+}
+
+// ImportJob tracks an import, a large one runs in the background and is
+// polled by its ID.
+type ImportJob struct {
+	ID        string        `json:"id,omitempty"`
+	Status    string        `json:"status"`
+	Report    *ImportReport `json:"report,omitempty"`
+	Error     string        `json:"error,omitempty"`
+	CreatedAt time.Time     `json:"created_at"`
+	UpdatedAt time.Time     `json:"updated_at"`
+}
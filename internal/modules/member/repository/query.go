@@ -1,15 +1,62 @@
 package repository
 
+import "github.com/rzfhlv/gin-example/pkg/param"
+
 var (
+	MemberSpec = param.Spec{
+		Filters: map[string]param.Filter{
+			"name":         {Columns: []string{"first_name", "last_name"}, Match: param.CONTAINS},
+			"email":        {Columns: []string{"email"}, Match: param.EQUAL},
+			"created_from": {Columns: []string{"created_at"}, Match: param.FROM},
+			"created_to":   {Columns: []string{"created_at"}, Match: param.TO},
+		},
+		Search: []string{"first_name", "last_name", "email"},
+		Sorts: map[string]string{
+			"id": "id", "first_name": "first_name", "last_name": "last_name",
+			"email": "email", "created_at": "created_at",
+		},
+		Default: "-id",
+	}
+
 	CreateMemberQuery = `INSERT INTO members
 		(first_name, last_name, email, password, created_at)
 		VALUES (?, ?, ?, ?, ?);`
+	// the list queries take the WHERE and ORDER BY built from MemberSpec
 	GetMemberQuery = `SELECT id, first_name,
 		last_name, email, created_at
-		FROM members ORDER BY id DESC LIMIT ? OFFSET ?;`
-	GetMemberByIDQuery = `SELECT id, first_name,
+		FROM members %s %s LIMIT ? OFFSET ?;`
+	GetMemberByIDQuery = `SELECT id, user_id, first_name,
 		last_name, email
-		FROM members WHERE id = ?;`
+		FROM members WHERE id = ? AND deleted_at IS NULL;`
 	CountMemberQuery = `SELECT count(*)
-		FROM members;`
+		FROM members %s`
+	// ExportMemberQuery walks the members by id, the WHERE comes from
+	// MemberSpec with the cursor as its first condition
+	ExportMemberQuery = `SELECT id, first_name,
+		last_name, email, created_at
+		FROM members %s ORDER BY id ASC LIMIT ?;`
+	CursorCondition     = "id > ?"
+	NotDeletedCondition = "deleted_at IS NULL"
+	// deleted members still hold their email, it is unique over every row
+	EmailExistsQuery = `SELECT count(*)
+		FROM members WHERE email = ? AND id <> ?;`
+	UpdateMemberQuery = `UPDATE members
+		SET first_name = ?, last_name = ?, email = ?
+		WHERE id = ? AND deleted_at IS NULL;`
+	DeleteMemberQuery = `UPDATE members
+		SET deleted_at = ?
+		WHERE id = ? AND deleted_at IS NULL;`
+	RejectPendingInvitationsQuery = `UPDATE invitations
+		SET status = 'reject'
+		WHERE member_id = ? AND status = 'pending';`
+	// deleted members count as well, their email is still taken
+	ExistingEmailsQuery = `SELECT email
+		FROM members WHERE email IN (?);`
+	// CreateMembersQuery takes one (?, ?, ?, ?, ?) per member
+	CreateMembersQuery = `INSERT INTO members
+		(first_name, last_name, email, password, created_at)
+		VALUES %s;`
+	RestoreMemberQuery = `UPDATE members
+		SET deleted_at = NULL
+		WHERE id = ? AND deleted_at IS NOT NULL;`
 )
@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/rzfhlv/gin-example/internal/modules/member/model"
+)
+
+func (r *Repository) SaveImportJob(ctx context.Context, job model.ImportJob, ttl time.Duration) (err error) {
+	value, err := json.Marshal(job)
+	if err != nil {
+		return
+	}
+
+	err = r.redis.Set(ctx, fmt.Sprintf(IMPORTKEY, job.ID), value, ttl).Err()
+	return
+}
+
+func (r *Repository) GetImportJob(ctx context.Context, id string) (job model.ImportJob, err error) {
+	value, err := r.redis.Get(ctx, fmt.Sprintf(IMPORTKEY, id)).Bytes()
+	if err != nil {
+		return
+	}
+
+	err = json.Unmarshal(value, &job)
+	return
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redismock/v9"
+	"github.com/redis/go-redis/v9"
+	"github.com/rzfhlv/gin-example/internal/modules/member/model"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	importJob = model.ImportJob{
+		ID:        "thisisjob",
+		Status:    "pending",
+		CreatedAt: time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC),
+		UpdatedAt: time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC),
+	}
+	importKey = "member_import:thisisjob"
+	importTTL = 24 * time.Hour
+)
+
+func TestSaveImportJob(t *testing.T) {
+	client, mock := redismock.NewClientMock()
+	r := &Repository{
+		redis: client,
+	}
+	value, _ := json.Marshal(importJob)
+
+	t.Run("Testcase #1: Positive", func(t *testing.T) {
+		mock.ExpectSet(importKey, value, importTTL).SetVal("OK")
+		err := r.SaveImportJob(ctx, importJob, importTTL)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Testcase #2: Negative", func(t *testing.T) {
+		mock.ExpectSet(importKey, value, importTTL).SetErr(errFoo)
+		err := r.SaveImportJob(ctx, importJob, importTTL)
+		assert.Equal(t, errFoo, err)
+	})
+}
+
+func TestGetImportJob(t *testing.T) {
+	client, mock := redismock.NewClientMock()
+	r := &Repository{
+		redis: client,
+	}
+	value, _ := json.Marshal(importJob)
+
+	t.Run("Testcase #1: Positive", func(t *testing.T) {
+		mock.ExpectGet(importKey).SetVal(string(value))
+		job, err := r.GetImportJob(ctx, importJob.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, importJob, job)
+	})
+
+	t.Run("Testcase #2: Negative", func(t *testing.T) {
+		mock.ExpectGet(importKey).RedisNil()
+		_, err := r.GetImportJob(ctx, importJob.ID)
+		assert.Equal(t, redis.Nil, err)
+	})
+
+	t.Run("Testcase #3: Negative", func(t *testing.T) {
+		mock.ExpectGet(importKey).SetVal("{")
+		_, err := r.GetImportJob(ctx, importJob.ID)
+		assert.Error(t, err)
+	})
+}
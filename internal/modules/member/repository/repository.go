@@ -3,45 +3,232 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/redis/go-redis/v9"
 	"github.com/rzfhlv/gin-example/internal/modules/member/model"
+	pErrors "github.com/rzfhlv/gin-example/pkg/errors"
 	"github.com/rzfhlv/gin-example/pkg/param"
 )
 
 type IRepository interface {
 	Create(ctx context.Context, member model.Member) (result sql.Result, err error)
-	Get(ctx context.Context, param param.Param) (members []model.Member, err error)
+	Get(ctx context.Context, param param.Param) (members []model.Member, page param.Page, err error)
 	GetByID(ctx context.Context, id int64) (member model.Member, err error)
-	Count(ctx context.Context) (total int64, err error)
+	Count(ctx context.Context, param param.Param) (total int64, err error)
+	Export(ctx context.Context, param param.Param, afterID int64, limit int) (members []model.Member, err error)
+	EmailExists(ctx context.Context, email string, id int64) (exists bool, err error)
+	Update(ctx context.Context, member model.Member) (err error)
+	Delete(ctx context.Context, id int64, deletedAt time.Time) (err error)
+	Restore(ctx context.Context, id int64) (result sql.Result, err error)
+	ExistingEmails(ctx context.Context, emails []string) (existing []string, err error)
+	CreateBatch(ctx context.Context, members []model.Member) (err error)
+	SaveImportJob(ctx context.Context, job model.ImportJob, ttl time.Duration) (err error)
+	GetImportJob(ctx context.Context, id string) (job model.ImportJob, err error)
 }
 
+var (
+	BATCHSIZE = 100
+	IMPORTKEY = "member_import:%s"
+)
+
 type Repository struct {
-	db *sqlx.DB
+	db    *sqlx.DB
+	redis *redis.Client
 }
 
-func New(db *sqlx.DB) IRepository {
+func New(db *sqlx.DB, redis *redis.Client) IRepository {
 	return &Repository{
-		db: db,
+		db:    db,
+		redis: redis,
 	}
 }
 
 func (r *Repository) Create(ctx context.Context, member model.Member) (result sql.Result, err error) {
 	result, err = r.db.Exec(CreateMemberQuery, member.FirstName, member.LastName, member.Email, member.Password, member.CreatedAt)
+	err = pErrors.FromSQL(err)
 	return
 }
 
-func (r *Repository) Get(ctx context.Context, param param.Param) (members []model.Member, err error) {
-	err = r.db.Select(&members, GetMemberQuery, param.Limit, param.CalculateOffset())
+func (r *Repository) Get(ctx context.Context, param param.Param) (members []model.Member, page param.Page, err error) {
+	where, args, err := MemberSpec.Seek(param, NotDeletedCondition)
+	if err != nil {
+		return
+	}
+
+	orderBy, err := MemberSpec.OrderBy(param)
+	if err != nil {
+		return
+	}
+
+	args = append(args, param.Limit, param.CalculateOffset())
+	err = r.db.Select(&members, fmt.Sprintf(GetMemberQuery, where, orderBy), args...)
+	if err != nil {
+		return
+	}
+
+	page, err = MemberSpec.Paginate(param, members, func(i int) map[string]interface{} {
+		return memberValues(members[i])
+	})
 	return
 }
 
 func (r *Repository) GetByID(ctx context.Context, id int64) (member model.Member, err error) {
 	err = r.db.Get(&member, GetMemberByIDQuery, id)
+	err = pErrors.FromSQL(err)
+	return
+}
+
+// Count applies the same filters as Get so the total matches the list.
+func (r *Repository) Count(ctx context.Context, param param.Param) (total int64, err error) {
+	where, args, err := MemberSpec.Where(param, NotDeletedCondition)
+	if err != nil {
+		return
+	}
+
+	err = r.db.Get(&total, fmt.Sprintf(CountMemberQuery, where), args...)
+	return
+}
+
+// Export is the next chunk of the filtered members after afterID, oldest
+// first. Paging on the id rather than an offset keeps every chunk as cheap as
+// the first.
+func (r *Repository) Export(ctx context.Context, param param.Param, afterID int64, limit int) (members []model.Member, err error) {
+	where, args, err := MemberSpec.Where(param, CursorCondition, NotDeletedCondition)
+	if err != nil {
+		return
+	}
+
+	args = append([]interface{}{afterID}, args...)
+	args = append(args, limit)
+	err = r.db.Select(&members, fmt.Sprintf(ExportMemberQuery, where), args...)
+	return
+}
+
+func (r *Repository) EmailExists(ctx context.Context, email string, id int64) (exists bool, err error) {
+	var total int64
+	err = r.db.Get(&total, EmailExistsQuery, email, id)
+	exists = total > 0
+	return
+}
+
+func (r *Repository) Update(ctx context.Context, member model.Member) (err error) {
+	_, err = r.db.Exec(UpdateMemberQuery, member.FirstName, member.LastName, member.Email, member.ID)
+	err = pErrors.FromSQL(err)
+	return
+}
+
+// Delete marks the member deleted and rejects the invitations they haven't
+// answered yet, answered ones are kept as they are. It fails with
+// a not found error when there is no member left to delete.
+func (r *Repository) Delete(ctx context.Context, id int64, deletedAt time.Time) (err error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	result, err := tx.Exec(DeleteMemberQuery, deletedAt, id)
+	if err != nil {
+		return
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return
+	}
+	if affected < 1 {
+		err = pErrors.ErrNotFound
+		return
+	}
+
+	_, err = tx.Exec(RejectPendingInvitationsQuery, id)
+	if err != nil {
+		return
+	}
+
+	err = tx.Commit()
 	return
 }
 
-func (r *Repository) Count(ctx context.Context) (total int64, err error) {
-	err = r.db.Get(&total, CountMemberQuery)
+func (r *Repository) Restore(ctx context.Context, id int64) (result sql.Result, err error) {
+	result, err = r.db.Exec(RestoreMemberQuery, id)
 	return
 }
+
+func (r *Repository) ExistingEmails(ctx context.Context, emails []string) (existing []string, err error) {
+	existing = []string{}
+	for start := 0; start < len(emails); start += BATCHSIZE {
+		end := start + BATCHSIZE
+		if end > len(emails) {
+			end = len(emails)
+		}
+
+		var query string
+		var args []interface{}
+		query, args, err = sqlx.In(ExistingEmailsQuery, emails[start:end])
+		if err != nil {
+			return
+		}
+
+		var found []string
+		err = r.db.Select(&found, query, args...)
+		if err != nil {
+			return
+		}
+		existing = append(existing, found...)
+	}
+	return
+}
+
+// CreateBatch inserts the members BATCHSIZE rows per statement in a single
+// transaction, either all of them are created or none.
+func (r *Repository) CreateBatch(ctx context.Context, members []model.Member) (err error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	for start := 0; start < len(members); start += BATCHSIZE {
+		end := start + BATCHSIZE
+		if end > len(members) {
+			end = len(members)
+		}
+
+		values := []string{}
+		args := []interface{}{}
+		for _, member := range members[start:end] {
+			values = append(values, "(?, ?, ?, ?, ?)")
+			args = append(args, member.FirstName, member.LastName, member.Email, member.Password, member.CreatedAt)
+		}
+
+		_, err = tx.Exec(fmt.Sprintf(CreateMembersQuery, strings.Join(values, ", ")), args...)
+		if err != nil {
+			err = pErrors.FromSQL(err)
+			return
+		}
+	}
+
+	err = tx.Commit()
+	return
+}
+
+// memberValues are the sort columns of a member, for its cursor.
+func memberValues(member model.Member) map[string]interface{} {
+	return map[string]interface{}{
+		"id": member.ID, "first_name": member.FirstName, "last_name": member.LastName,
+		"email": member.Email, "created_at": member.CreatedAt,
+	}
+}
@@ -3,12 +3,15 @@ package repository
 import (
 	"context"
 	"errors"
+	"net/url"
 	"testing"
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
 	"github.com/jmoiron/sqlx"
 	"github.com/rzfhlv/gin-example/internal/modules/member/model"
+	pErrors "github.com/rzfhlv/gin-example/pkg/errors"
 	"github.com/rzfhlv/gin-example/pkg/param"
 	"github.com/stretchr/testify/assert"
 )
@@ -59,6 +62,17 @@ func TestCreate(t *testing.T) {
 			want:      errFoo,
 			wantError: true,
 		},
+		{
+			name: "Testcase #3: Negative",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				s.ExpectExec("INSERT INTO members (first_name, last_name, email, password, created_at) VALUES (?, ?, ?, ?, ?);").
+					WithArgs(members[0].FirstName, members[0].LastName, members[0].Email, members[0].Password, members[0].CreatedAt).
+					WillReturnError(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry 'john@test.com' for key 'members.email'"})
+			},
+			want:      pErrors.ErrConflict,
+			wantError: true,
+		},
 	}
 	for _, tt := range testCase {
 		t.Run(tt.name, func(t *testing.T) {
@@ -77,7 +91,7 @@ func TestCreate(t *testing.T) {
 
 			result, err := r.Create(tt.args, members[0])
 			if tt.wantError {
-				assert.Error(t, err)
+				assert.ErrorIs(t, err, tt.want)
 				assert.Empty(t, result)
 			} else {
 				assert.NoError(t, err)
@@ -100,7 +114,7 @@ func TestGet(t *testing.T) {
 					"id", "first_name", "last_name", "email", "created_at",
 				}).
 					AddRow(members[0].ID, members[0].FirstName, members[0].LastName, members[0].Email, members[0].CreatedAt)
-				s.ExpectQuery("SELECT id, first_name, last_name, email, created_at FROM members ORDER BY id DESC LIMIT ? OFFSET ?;").
+				s.ExpectQuery("SELECT id, first_name, last_name, email, created_at FROM members WHERE deleted_at IS NULL ORDER BY id DESC LIMIT ? OFFSET ?;").
 					WithArgs(paramTest.Limit, paramTest.CalculateOffset()).
 					WillReturnRows(rows)
 			},
@@ -111,7 +125,7 @@ func TestGet(t *testing.T) {
 			name: "Testcase #2: Negative",
 			args: ctx,
 			beforeTest: func(s sqlmock.Sqlmock) {
-				s.ExpectQuery("SELECT id, first_name, last_name, email, created_at FROM members ORDER BY id DESC LIMIT ? OFFSET ?;").
+				s.ExpectQuery("SELECT id, first_name, last_name, email, created_at FROM members WHERE deleted_at IS NULL ORDER BY id DESC LIMIT ? OFFSET ?;").
 					WithArgs(paramTest.Limit, paramTest.CalculateOffset()).
 					WillReturnError(errFoo)
 			},
@@ -134,7 +148,7 @@ func TestGet(t *testing.T) {
 				tt.beforeTest(mockSQL)
 			}
 
-			members, err := r.Get(tt.args, paramTest)
+			members, _, err := r.Get(tt.args, paramTest)
 			if tt.wantError {
 				assert.Error(t, err)
 				assert.Empty(t, members)
@@ -160,7 +174,7 @@ func TestGetByID(t *testing.T) {
 					"id", "first_name", "last_name", "email", "created_at",
 				}).
 					AddRow(members[0].ID, members[0].FirstName, members[0].LastName, members[0].Email, members[0].CreatedAt)
-				s.ExpectQuery("SELECT id, first_name, last_name, email FROM members WHERE id = ?;").
+				s.ExpectQuery("SELECT id, user_id, first_name, last_name, email FROM members WHERE id = ? AND deleted_at IS NULL;").
 					WithArgs(members[0].ID).
 					WillReturnRows(rows)
 			},
@@ -171,7 +185,7 @@ func TestGetByID(t *testing.T) {
 			name: "Testcase #2: Negative",
 			args: ctx,
 			beforeTest: func(s sqlmock.Sqlmock) {
-				s.ExpectQuery("SELECT id, first_name, last_name, email FROM members WHERE id = ?;").
+				s.ExpectQuery("SELECT id, user_id, first_name, last_name, email FROM members WHERE id = ? AND deleted_at IS NULL;").
 					WithArgs(members[0].ID).
 					WillReturnError(errFoo)
 			},
@@ -219,7 +233,7 @@ func TestCount(t *testing.T) {
 			beforeTest: func(s sqlmock.Sqlmock) {
 				rows := sqlmock.NewRows([]string{"count"}).
 					AddRow(expectedCount)
-				s.ExpectQuery("SELECT count(*) FROM members;").
+				s.ExpectQuery("SELECT count(*) FROM members WHERE deleted_at IS NULL").
 					WillReturnRows(rows)
 			},
 			want:      nil,
@@ -229,7 +243,7 @@ func TestCount(t *testing.T) {
 			name: "Testcase #2: Negative",
 			args: ctx,
 			beforeTest: func(s sqlmock.Sqlmock) {
-				s.ExpectQuery("SELECT count(*) FROM members;").
+				s.ExpectQuery("SELECT count(*) FROM members WHERE deleted_at IS NULL").
 					WillReturnError(errFoo)
 			},
 			want:      errFoo,
@@ -251,7 +265,7 @@ func TestCount(t *testing.T) {
 				tt.beforeTest(mockSQL)
 			}
 
-			total, err := r.Count(tt.args)
+			total, err := r.Count(tt.args, paramTest)
 			if tt.wantError {
 				assert.Error(t, err)
 			} else {
@@ -265,3 +279,625 @@ func TestCount(t *testing.T) {
 		})
 	}
 }
+
+func TestEmailExists(t *testing.T) {
+	testCase := []testCase{
+		{
+			name: "Testcase #1: Positive",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"count"}).
+					AddRow(1)
+				s.ExpectQuery("SELECT count(*) FROM members WHERE email = ? AND id <> ?;").
+					WithArgs(members[0].Email, members[0].ID).
+					WillReturnRows(rows)
+			},
+			want:      nil,
+			wantError: false,
+		},
+		{
+			name: "Testcase #2: Negative",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				s.ExpectQuery("SELECT count(*) FROM members WHERE email = ? AND id <> ?;").
+					WithArgs(members[0].Email, members[0].ID).
+					WillReturnError(errFoo)
+			},
+			want:      errFoo,
+			wantError: true,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mockSQL, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			defer mockDB.Close()
+
+			db := sqlx.NewDb(mockDB, "sqlmock")
+
+			r := &Repository{
+				db: db,
+			}
+
+			if tt.beforeTest != nil {
+				tt.beforeTest(mockSQL)
+			}
+
+			exists, err := r.EmailExists(tt.args, members[0].Email, members[0].ID)
+			if tt.wantError {
+				assert.Error(t, err)
+				assert.False(t, exists)
+			} else {
+				assert.NoError(t, err)
+				assert.True(t, exists)
+			}
+
+			if err := mockSQL.ExpectationsWereMet(); err != nil {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	testCase := []testCase{
+		{
+			name: "Testcase #1: Positive",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				s.ExpectExec("UPDATE members SET first_name = ?, last_name = ?, email = ? WHERE id = ? AND deleted_at IS NULL;").
+					WithArgs(members[0].FirstName, members[0].LastName, members[0].Email, members[0].ID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			want:      nil,
+			wantError: false,
+		},
+		{
+			name: "Testcase #2: Negative",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				s.ExpectExec("UPDATE members SET first_name = ?, last_name = ?, email = ? WHERE id = ? AND deleted_at IS NULL;").
+					WithArgs(members[0].FirstName, members[0].LastName, members[0].Email, members[0].ID).
+					WillReturnError(errFoo)
+			},
+			want:      errFoo,
+			wantError: true,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mockSQL, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			defer mockDB.Close()
+
+			db := sqlx.NewDb(mockDB, "sqlmock")
+
+			r := &Repository{
+				db: db,
+			}
+
+			if tt.beforeTest != nil {
+				tt.beforeTest(mockSQL)
+			}
+
+			err := r.Update(tt.args, members[0])
+			if tt.wantError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			if err := mockSQL.ExpectationsWereMet(); err != nil {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	deletedAt := time.Now()
+	testCase := []testCase{
+		{
+			name: "Testcase #1: Positive",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				s.ExpectBegin()
+				s.ExpectExec("UPDATE members SET deleted_at = ? WHERE id = ? AND deleted_at IS NULL;").
+					WithArgs(deletedAt, members[0].ID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				s.ExpectExec("UPDATE invitations SET status = 'reject' WHERE member_id = ? AND status = 'pending';").
+					WithArgs(members[0].ID).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				s.ExpectCommit()
+			},
+			want:      nil,
+			wantError: false,
+		},
+		{
+			name: "Testcase #2: Negative",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				s.ExpectBegin()
+				s.ExpectExec("UPDATE members SET deleted_at = ? WHERE id = ? AND deleted_at IS NULL;").
+					WithArgs(deletedAt, members[0].ID).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				s.ExpectRollback()
+			},
+			want:      pErrors.ErrNotFound,
+			wantError: true,
+		},
+		{
+			name: "Testcase #3: Negative",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				s.ExpectBegin()
+				s.ExpectExec("UPDATE members SET deleted_at = ? WHERE id = ? AND deleted_at IS NULL;").
+					WithArgs(deletedAt, members[0].ID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				s.ExpectExec("UPDATE invitations SET status = 'reject' WHERE member_id = ? AND status = 'pending';").
+					WithArgs(members[0].ID).
+					WillReturnError(errFoo)
+				s.ExpectRollback()
+			},
+			want:      errFoo,
+			wantError: true,
+		},
+		{
+			name: "Testcase #4: Negative",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				s.ExpectBegin()
+				s.ExpectExec("UPDATE members SET deleted_at = ? WHERE id = ? AND deleted_at IS NULL;").
+					WithArgs(deletedAt, members[0].ID).
+					WillReturnResult(sqlmock.NewErrorResult(errFoo))
+				s.ExpectRollback()
+			},
+			want:      errFoo,
+			wantError: true,
+		},
+		{
+			name: "Testcase #5: Negative",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				s.ExpectBegin()
+				s.ExpectExec("UPDATE members SET deleted_at = ? WHERE id = ? AND deleted_at IS NULL;").
+					WithArgs(deletedAt, members[0].ID).
+					WillReturnError(errFoo)
+				s.ExpectRollback()
+			},
+			want:      errFoo,
+			wantError: true,
+		},
+		{
+			name: "Testcase #6: Negative",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				s.ExpectBegin().WillReturnError(errFoo)
+			},
+			want:      errFoo,
+			wantError: true,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mockSQL, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			defer mockDB.Close()
+
+			db := sqlx.NewDb(mockDB, "sqlmock")
+
+			r := &Repository{
+				db: db,
+			}
+
+			if tt.beforeTest != nil {
+				tt.beforeTest(mockSQL)
+			}
+
+			err := r.Delete(tt.args, members[0].ID, deletedAt)
+			if tt.wantError {
+				assert.Equal(t, tt.want, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.NoError(t, mockSQL.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestRestore(t *testing.T) {
+	testCase := []testCase{
+		{
+			name: "Testcase #1: Positive",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				s.ExpectExec("UPDATE members SET deleted_at = NULL WHERE id = ? AND deleted_at IS NOT NULL;").
+					WithArgs(members[0].ID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			want:      nil,
+			wantError: false,
+		},
+		{
+			name: "Testcase #2: Negative",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				s.ExpectExec("UPDATE members SET deleted_at = NULL WHERE id = ? AND deleted_at IS NOT NULL;").
+					WithArgs(members[0].ID).
+					WillReturnError(errFoo)
+			},
+			want:      errFoo,
+			wantError: true,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mockSQL, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			defer mockDB.Close()
+
+			db := sqlx.NewDb(mockDB, "sqlmock")
+
+			r := &Repository{
+				db: db,
+			}
+
+			if tt.beforeTest != nil {
+				tt.beforeTest(mockSQL)
+			}
+
+			result, err := r.Restore(tt.args, members[0].ID)
+			if tt.wantError {
+				assert.Error(t, err)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, result)
+			}
+
+			if err := mockSQL.ExpectationsWereMet(); err != nil {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestGetFilter(t *testing.T) {
+	param.Configure(param.CursorConfig{Secret: []byte("verysecret")})
+
+	filterParam := param.Param{
+		Limit:   10,
+		Page:    2,
+		Q:       "jo",
+		Sort:    "-created_at",
+		Filters: url.Values{"email": {"john@test.com"}},
+	}
+
+	mockDB, mockSQL, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	defer mockDB.Close()
+
+	db := sqlx.NewDb(mockDB, "sqlmock")
+
+	r := &Repository{
+		db: db,
+	}
+
+	rows := sqlmock.NewRows([]string{
+		"id", "first_name", "last_name", "email", "created_at",
+	}).
+		AddRow(members[0].ID, members[0].FirstName, members[0].LastName, members[0].Email, members[0].CreatedAt)
+	mockSQL.ExpectQuery("SELECT id, first_name, last_name, email, created_at FROM members WHERE deleted_at IS NULL AND email = ? AND (first_name LIKE ? OR last_name LIKE ? OR email LIKE ?) ORDER BY created_at DESC, id DESC LIMIT ? OFFSET ?;").
+		WithArgs("john@test.com", "%jo%", "%jo%", "%jo%", 10, 10).
+		WillReturnRows(rows)
+	mockSQL.ExpectQuery("SELECT count(*) FROM members WHERE deleted_at IS NULL AND email = ? AND (first_name LIKE ? OR last_name LIKE ? OR email LIKE ?)").
+		WithArgs("john@test.com", "%jo%", "%jo%", "%jo%").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(11))
+
+	result, page, err := r.Get(ctx, filterParam)
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+	assert.Empty(t, page.Next)
+	assert.NotEmpty(t, page.Prev)
+
+	total, err := r.Count(ctx, filterParam)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(11), total)
+	assert.NoError(t, mockSQL.ExpectationsWereMet())
+
+	_, _, err = r.Get(ctx, param.Param{Limit: 10, Page: 1, Sort: "password"})
+	assert.ErrorIs(t, err, param.ErrInvalidSort)
+
+	_, err = r.Count(ctx, param.Param{Filters: url.Values{"created_from": {"yesterday"}}})
+	assert.ErrorIs(t, err, param.ErrInvalidFilter)
+}
+
+func TestGetCursor(t *testing.T) {
+	param.Configure(param.CursorConfig{Secret: []byte("verysecret")})
+
+	createdAt := time.Date(2023, 11, 10, 12, 0, 0, 0, time.UTC)
+	columns := []string{"id", "first_name", "last_name", "email", "created_at"}
+	cursorParam := param.Param{Limit: 1, Page: 1, Sort: "-created_at"}
+
+	mockDB, mockSQL, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	defer mockDB.Close()
+
+	r := &Repository{
+		db: sqlx.NewDb(mockDB, "sqlmock"),
+	}
+
+	mockSQL.ExpectQuery("SELECT id, first_name, last_name, email, created_at FROM members WHERE deleted_at IS NULL ORDER BY created_at DESC, id DESC LIMIT ? OFFSET ?;").
+		WithArgs(1, 0).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(2, "Jane", "Doe", "jane@test.com", createdAt))
+	result, page, err := r.Get(ctx, cursorParam)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), result[0].ID)
+	assert.NotEmpty(t, page.Next)
+	assert.Empty(t, page.Prev)
+
+	cursorParam.Cursor = page.Next
+	mockSQL.ExpectQuery("SELECT id, first_name, last_name, email, created_at FROM members WHERE ((created_at < ?) OR (created_at = ? AND id < ?)) AND deleted_at IS NULL ORDER BY created_at DESC, id DESC LIMIT ? OFFSET ?;").
+		WithArgs("2023-11-10 12:00:00", "2023-11-10 12:00:00", "2", 1, 0).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(1, "John", "Doe", "john@test.com", createdAt))
+	result, page, err = r.Get(ctx, cursorParam)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), result[0].ID)
+	assert.NotEmpty(t, page.Next)
+	assert.NotEmpty(t, page.Prev)
+
+	// back from John the rows are read up and turned around
+	cursorParam.Cursor = page.Prev
+	mockSQL.ExpectQuery("SELECT id, first_name, last_name, email, created_at FROM members WHERE ((created_at > ?) OR (created_at = ? AND id > ?)) AND deleted_at IS NULL ORDER BY created_at ASC, id ASC LIMIT ? OFFSET ?;").
+		WithArgs("2023-11-10 12:00:00", "2023-11-10 12:00:00", "1", 1, 0).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(2, "Jane", "Doe", "jane@test.com", createdAt))
+	result, page, err = r.Get(ctx, cursorParam)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), result[0].ID)
+	assert.NotEmpty(t, page.Next)
+	assert.NotEmpty(t, page.Prev)
+	assert.NoError(t, mockSQL.ExpectationsWereMet())
+
+	cursorParam.Sort = "email"
+	_, _, err = r.Get(ctx, cursorParam)
+	assert.ErrorIs(t, err, param.ErrInvalidCursor)
+
+	cursorParam.Sort, cursorParam.Cursor = "-created_at", page.Next+"x"
+	_, _, err = r.Get(ctx, cursorParam)
+	assert.ErrorIs(t, err, param.ErrInvalidCursor)
+}
+
+func TestExistingEmails(t *testing.T) {
+	emails := []string{"john@test.com", "jane@test.com"}
+	testCase := []testCase{
+		{
+			name: "Testcase #1: Positive",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"email"}).AddRow("john@test.com")
+				s.ExpectQuery("SELECT email FROM members WHERE email IN (?, ?);").
+					WithArgs(emails[0], emails[1]).
+					WillReturnRows(rows)
+			},
+			want:      nil,
+			wantError: false,
+		},
+		{
+			name: "Testcase #2: Negative",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				s.ExpectQuery("SELECT email FROM members WHERE email IN (?, ?);").
+					WithArgs(emails[0], emails[1]).
+					WillReturnError(errFoo)
+			},
+			want:      errFoo,
+			wantError: true,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mockSQL, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			defer mockDB.Close()
+
+			db := sqlx.NewDb(mockDB, "sqlmock")
+
+			r := &Repository{
+				db: db,
+			}
+
+			if tt.beforeTest != nil {
+				tt.beforeTest(mockSQL)
+			}
+
+			existing, err := r.ExistingEmails(tt.args, emails)
+			if tt.wantError {
+				assert.Equal(t, tt.want, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"john@test.com"}, existing)
+			}
+
+			assert.NoError(t, mockSQL.ExpectationsWereMet())
+		})
+	}
+
+	t.Run("Testcase #3: Positive", func(t *testing.T) {
+		mockDB, mockSQL, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		defer mockDB.Close()
+
+		r := &Repository{
+			db: sqlx.NewDb(mockDB, "sqlmock"),
+		}
+
+		existing, err := r.ExistingEmails(ctx, []string{})
+		assert.NoError(t, err)
+		assert.Empty(t, existing)
+		assert.NoError(t, mockSQL.ExpectationsWereMet())
+	})
+}
+
+func TestCreateBatch(t *testing.T) {
+	batch := []model.Member{members[0], members[0]}
+	batch[1].Email = "jane@test.com"
+	insert := "INSERT INTO members (first_name, last_name, email, password, created_at) VALUES (?, ?, ?, ?, ?);"
+
+	testCase := []testCase{
+		{
+			name: "Testcase #1: Positive",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				s.ExpectBegin()
+				for _, member := range batch {
+					s.ExpectExec(insert).
+						WithArgs(member.FirstName, member.LastName, member.Email, member.Password, member.CreatedAt).
+						WillReturnResult(sqlmock.NewResult(1, 1))
+				}
+				s.ExpectCommit()
+			},
+			want:      nil,
+			wantError: false,
+		},
+		{
+			name: "Testcase #2: Negative",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				s.ExpectBegin()
+				s.ExpectExec(insert).
+					WithArgs(batch[0].FirstName, batch[0].LastName, batch[0].Email, batch[0].Password, batch[0].CreatedAt).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				s.ExpectExec(insert).
+					WithArgs(batch[1].FirstName, batch[1].LastName, batch[1].Email, batch[1].Password, batch[1].CreatedAt).
+					WillReturnError(errFoo)
+				s.ExpectRollback()
+			},
+			want:      errFoo,
+			wantError: true,
+		},
+		{
+			name: "Testcase #3: Negative",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				s.ExpectBegin().WillReturnError(errFoo)
+			},
+			want:      errFoo,
+			wantError: true,
+		},
+	}
+
+	// one member per statement so the batches are visible
+	BATCHSIZE = 1
+	defer func() { BATCHSIZE = 100 }()
+
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mockSQL, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			defer mockDB.Close()
+
+			db := sqlx.NewDb(mockDB, "sqlmock")
+
+			r := &Repository{
+				db: db,
+			}
+
+			if tt.beforeTest != nil {
+				tt.beforeTest(mockSQL)
+			}
+
+			err := r.CreateBatch(tt.args, batch)
+			if tt.wantError {
+				assert.Equal(t, tt.want, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.NoError(t, mockSQL.ExpectationsWereMet())
+		})
+	}
+
+	t.Run("Testcase #4: Positive", func(t *testing.T) {
+		mockDB, mockSQL, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		defer mockDB.Close()
+
+		r := &Repository{
+			db: sqlx.NewDb(mockDB, "sqlmock"),
+		}
+
+		BATCHSIZE = 2
+		mockSQL.ExpectBegin()
+		mockSQL.ExpectExec("INSERT INTO members (first_name, last_name, email, password, created_at) VALUES (?, ?, ?, ?, ?), (?, ?, ?, ?, ?);").
+			WithArgs(batch[0].FirstName, batch[0].LastName, batch[0].Email, batch[0].Password, batch[0].CreatedAt,
+				batch[1].FirstName, batch[1].LastName, batch[1].Email, batch[1].Password, batch[1].CreatedAt).
+			WillReturnResult(sqlmock.NewResult(1, 2))
+		mockSQL.ExpectCommit()
+
+		err := r.CreateBatch(ctx, batch)
+		assert.NoError(t, err)
+		assert.NoError(t, mockSQL.ExpectationsWereMet())
+	})
+}
+
+func TestExport(t *testing.T) {
+	exportParam := paramTest
+	exportParam.Filters = url.Values{"email": {"john@test.com"}}
+	testCase := []testCase{
+		{
+			name: "Testcase #1: Positive",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "first_name", "last_name", "email", "created_at"}).
+					AddRow(members[0].ID, members[0].FirstName, members[0].LastName, members[0].Email, members[0].CreatedAt)
+				s.ExpectQuery("SELECT id, first_name, last_name, email, created_at FROM members WHERE id > ? AND deleted_at IS NULL AND email = ? ORDER BY id ASC LIMIT ?;").
+					WithArgs(int64(5), "john@test.com", 100).
+					WillReturnRows(rows)
+			},
+			want:      nil,
+			wantError: false,
+		},
+		{
+			name: "Testcase #2: Negative",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				s.ExpectQuery("SELECT id, first_name, last_name, email, created_at FROM members WHERE id > ? AND deleted_at IS NULL AND email = ? ORDER BY id ASC LIMIT ?;").
+					WithArgs(int64(5), "john@test.com", 100).
+					WillReturnError(errFoo)
+			},
+			want:      errFoo,
+			wantError: true,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mockSQL, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			defer mockDB.Close()
+
+			db := sqlx.NewDb(mockDB, "sqlmock")
+
+			r := &Repository{
+				db: db,
+			}
+
+			if tt.beforeTest != nil {
+				tt.beforeTest(mockSQL)
+			}
+
+			result, err := r.Export(tt.args, exportParam, 5, 100)
+			if tt.wantError {
+				assert.Equal(t, tt.want, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, result, 1)
+			}
+
+			assert.NoError(t, mockSQL.ExpectationsWereMet())
+		})
+	}
+
+	t.Run("Testcase #3: Negative", func(t *testing.T) {
+		mockDB, _, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		defer mockDB.Close()
+
+		r := &Repository{
+			db: sqlx.NewDb(mockDB, "sqlmock"),
+		}
+
+		invalid := paramTest
+		invalid.Filters = url.Values{"created_from": {"yesterday"}}
+		_, err := r.Export(ctx, invalid, 0, 100)
+		assert.True(t, param.IsInvalid(err))
+	})
+}
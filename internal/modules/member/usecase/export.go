@@ -0,0 +1,41 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/rzfhlv/gin-example/internal/modules/member/model"
+	"github.com/rzfhlv/gin-example/pkg/param"
+)
+
+var EXPORTCHUNK = 500
+
+// Export hands the filtered members to write EXPORTCHUNK at a time, only one
+// chunk is in memory at once. It stops at the first error from write or
+// once the request is gone.
+func (u *Usecase) Export(ctx context.Context, param param.Param, write func(members []model.Member) error) (err error) {
+	var afterID int64
+	for {
+		err = ctx.Err()
+		if err != nil {
+			return
+		}
+
+		var members []model.Member
+		members, err = u.repo.Export(ctx, param, afterID, EXPORTCHUNK)
+		if err != nil {
+			return
+		}
+		if len(members) < 1 {
+			return
+		}
+
+		err = write(members)
+		if err != nil {
+			return
+		}
+		if len(members) < EXPORTCHUNK {
+			return
+		}
+		afterID = members[len(members)-1].ID
+	}
+}
@@ -0,0 +1,95 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rzfhlv/gin-example/internal/modules/member/model"
+	"github.com/rzfhlv/gin-example/pkg/param"
+	mockRepo "github.com/rzfhlv/gin-example/shared/mocks/modules/member/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestExport(t *testing.T) {
+	chunk := func(ids ...int64) (members []model.Member) {
+		for _, id := range ids {
+			members = append(members, model.Member{ID: id})
+		}
+		return
+	}
+
+	testCase := []struct {
+		name                 string
+		chunks               map[int64][]model.Member
+		wantRepoError        error
+		wantWriteError       error
+		cancel               bool
+		wantError            error
+		wantWritten, wantGot int
+	}{
+		{
+			name:        "Testcase #1: Positive",
+			chunks:      map[int64][]model.Member{0: chunk(1, 2), 2: chunk(3, 4), 4: chunk(5)},
+			wantWritten: 3, wantGot: 5,
+		},
+		{
+			name:        "Testcase #2: Positive",
+			chunks:      map[int64][]model.Member{0: chunk(1, 2), 2: {}},
+			wantWritten: 1, wantGot: 2,
+		},
+		{
+			name:   "Testcase #3: Positive",
+			chunks: map[int64][]model.Member{0: {}},
+		},
+		{
+			name:          "Testcase #4: Negative",
+			chunks:        map[int64][]model.Member{0: chunk(1, 2)},
+			wantRepoError: errFoo, wantError: errFoo,
+		},
+		{
+			name:           "Testcase #5: Negative",
+			chunks:         map[int64][]model.Member{0: chunk(1, 2)},
+			wantWriteError: errFoo, wantError: errFoo, wantWritten: 1, wantGot: 2,
+		},
+		{
+			name:      "Testcase #6: Negative",
+			chunks:    map[int64][]model.Member{0: chunk(1, 2)},
+			cancel:    true,
+			wantError: context.Canceled,
+		},
+	}
+
+	exportChunk := EXPORTCHUNK
+	EXPORTCHUNK = 2
+	defer func() { EXPORTCHUNK = exportChunk }()
+
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mockRepo.IRepository{}
+			for afterID, members := range tt.chunks {
+				mockRepo.On("Export", mock.Anything, mock.Anything, afterID, EXPORTCHUNK).Return(members, tt.wantRepoError)
+			}
+
+			u := &Usecase{
+				repo: &mockRepo,
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tt.cancel {
+				cancel()
+			}
+
+			written, got := 0, 0
+			err := u.Export(ctx, param.Param{}, func(members []model.Member) error {
+				written++
+				got += len(members)
+				return tt.wantWriteError
+			})
+			assert.Equal(t, tt.wantError, err)
+			assert.Equal(t, tt.wantWritten, written)
+			assert.Equal(t, tt.wantGot, got)
+		})
+	}
+}
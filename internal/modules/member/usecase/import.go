@@ -0,0 +1,267 @@
+package usecase
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rzfhlv/gin-example/internal/modules/member/model"
+	pErrors "github.com/rzfhlv/gin-example/pkg/errors"
+	"github.com/rzfhlv/gin-example/pkg/hasher"
+	"github.com/rzfhlv/gin-example/pkg/message"
+	pToken "github.com/rzfhlv/gin-example/pkg/token"
+	"github.com/rzfhlv/gin-example/pkg/validation"
+)
+
+var (
+	PENDING = "pending"
+	RUNNING = "running"
+	DONE    = "done"
+	FAILED  = "failed"
+
+	IMPORTJOBTTL = 24 * time.Hour
+	MAXLENGTH    = 50
+
+	IMPORTCOLUMNS = []string{"first_name", "last_name", "email"}
+
+	ErrInvalidCSV     = errors.New("invalid csv")
+	ErrTooManyRows    = errors.New("too many rows")
+	ErrImportNotFound = pErrors.NotFound(message.NOTFOUND)
+)
+
+// Import adds members from a CSV with a first_name, last_name and email
+// header. Every row is validated and the valid ones are created, a dry run
+// only reports. A file with more than AsyncRows rows is imported in the
+// background and the pending job is returned instead.
+func (u *Usecase) Import(ctx context.Context, file io.Reader, dryRun bool) (job model.ImportJob, err error) {
+	rows, err := parseCSV(file)
+	if err != nil {
+		return
+	}
+	if len(rows) > u.limits.MaxRows {
+		err = ErrTooManyRows
+		return
+	}
+
+	now := time.Now()
+	job = model.ImportJob{CreatedAt: now, UpdatedAt: now}
+
+	if dryRun || len(rows) <= u.limits.AsyncRows {
+		var report model.ImportReport
+		report, err = u.importRows(ctx, rows, dryRun)
+		if err != nil {
+			return
+		}
+		job.Status = DONE
+		job.Report = &report
+		return
+	}
+
+	job.ID, err = pToken.Generate(pToken.DEFAULTLENGTH)
+	if err != nil {
+		return
+	}
+	job.Status = PENDING
+
+	err = u.repo.SaveImportJob(ctx, job, IMPORTJOBTTL)
+	if err != nil {
+		return
+	}
+
+	u.async(func() {
+		u.runImport(job, rows)
+	})
+	return
+}
+
+func (u *Usecase) GetImport(ctx context.Context, id string) (job model.ImportJob, err error) {
+	job, err = u.repo.GetImportJob(ctx, id)
+	if err == redis.Nil {
+		err = ErrImportNotFound
+	}
+	return
+}
+
+// runImport outlives the request that started it, so it works on its own
+// context and can only log what goes wrong with the job itself.
+func (u *Usecase) runImport(job model.ImportJob, rows []model.ImportRow) {
+	ctx := context.Background()
+
+	job.Status = RUNNING
+	job.UpdatedAt = time.Now()
+	err := u.repo.SaveImportJob(ctx, job, IMPORTJOBTTL)
+	if err != nil {
+		log.Printf("Error Save Import Job %v, %v", job.ID, err.Error())
+	}
+
+	report, err := u.importRows(ctx, rows, false)
+	if err != nil {
+		job.Status = FAILED
+		job.Error = err.Error()
+	} else {
+		job.Status = DONE
+		job.Report = &report
+	}
+	job.UpdatedAt = time.Now()
+
+	err = u.repo.SaveImportJob(ctx, job, IMPORTJOBTTL)
+	if err != nil {
+		log.Printf("Error Save Import Job %v, %v", job.ID, err.Error())
+	}
+}
+
+func (u *Usecase) importRows(ctx context.Context, rows []model.ImportRow, dryRun bool) (report model.ImportReport, err error) {
+	report = model.ImportReport{DryRun: dryRun, Total: len(rows), Errors: []model.ImportError{}}
+
+	valid := []model.ImportRow{}
+	seen := map[string]int{}
+	for _, row := range rows {
+		rowErrors := validateRow(row)
+		if len(rowErrors) < 1 {
+			email := strings.ToLower(row.Email)
+			if line, ok := seen[email]; ok {
+				rowErrors = append(rowErrors, model.ImportError{
+					Line: row.Line, Field: "email", Message: fmt.Sprintf("duplicates line %d", line),
+				})
+			}
+			seen[email] = row.Line
+		}
+
+		if len(rowErrors) > 0 {
+			report.Errors = append(report.Errors, rowErrors...)
+			continue
+		}
+		valid = append(valid, row)
+	}
+
+	emails := make([]string, 0, len(valid))
+	for _, row := range valid {
+		emails = append(emails, row.Email)
+	}
+	existing, err := u.repo.ExistingEmails(ctx, emails)
+	if err != nil {
+		return
+	}
+	taken := map[string]bool{}
+	for _, email := range existing {
+		taken[strings.ToLower(email)] = true
+	}
+
+	members := []model.Member{}
+	now := time.Now()
+	for _, row := range valid {
+		if taken[strings.ToLower(row.Email)] {
+			report.Errors = append(report.Errors, model.ImportError{Line: row.Line, Field: "email", Message: "already exists"})
+			continue
+		}
+		members = append(members, model.Member{FirstName: row.FirstName, LastName: row.LastName, Email: row.Email, CreatedAt: now})
+	}
+	report.Valid = len(members)
+	sort.SliceStable(report.Errors, func(i, j int) bool {
+		return report.Errors[i].Line < report.Errors[j].Line
+	})
+
+	if dryRun || len(members) < 1 {
+		return
+	}
+
+	// an imported member has no password until one is set, nothing logs in
+	for i := range members {
+		members[i].Password = hasher.NOPASSWORD
+	}
+
+	err = u.repo.CreateBatch(ctx, members)
+	if err != nil {
+		return
+	}
+	report.Imported = len(members)
+	return
+}
+
+func parseCSV(file io.Reader) (rows []model.ImportRow, err error) {
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		err = fmt.Errorf("%w: missing header", ErrInvalidCSV)
+		return
+	}
+
+	columns := map[string]int{}
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		columns[name] = i
+	}
+	for _, name := range IMPORTCOLUMNS {
+		if _, ok := columns[name]; !ok {
+			err = fmt.Errorf("%w: missing column %s", ErrInvalidCSV, name)
+			return
+		}
+	}
+
+	rows = []model.ImportRow{}
+	for {
+		var record []string
+		record, err = reader.Read()
+		if err == io.EOF {
+			err = nil
+			return
+		}
+		if err != nil {
+			err = fmt.Errorf("%w: %s", ErrInvalidCSV, err.Error())
+			return
+		}
+
+		line, _ := reader.FieldPos(0)
+		rows = append(rows, model.ImportRow{
+			Line:      line,
+			FirstName: field(record, columns["first_name"]),
+			LastName:  field(record, columns["last_name"]),
+			Email:     field(record, columns["email"]),
+		})
+	}
+}
+
+func validateRow(row model.ImportRow) (rowErrors []model.ImportError) {
+	invalid := func(column, message string) {
+		rowErrors = append(rowErrors, model.ImportError{Line: row.Line, Field: column, Message: message})
+	}
+	tooLong := fmt.Sprintf("must be at most %d characters", MAXLENGTH)
+
+	for _, name := range [][2]string{{"first_name", row.FirstName}, {"last_name", row.LastName}} {
+		column, value := name[0], name[1]
+		switch {
+		case value == "":
+			invalid(column, "is required")
+		case utf8.RuneCountInString(value) > MAXLENGTH:
+			invalid(column, tooLong)
+		}
+	}
+
+	switch {
+	case row.Email == "":
+		invalid("email", "is required")
+	case !validation.IsEmail(row.Email):
+		invalid("email", "must be a valid email")
+	case utf8.RuneCountInString(row.Email) > MAXLENGTH:
+		invalid("email", tooLong)
+	}
+	return
+}
+
+func field(record []string, index int) string {
+	if index >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[index])
+}
@@ -0,0 +1,228 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rzfhlv/gin-example/internal/modules/member/model"
+	"github.com/rzfhlv/gin-example/pkg/hasher"
+	mockRepo "github.com/rzfhlv/gin-example/shared/mocks/modules/member/repository"
+	mockHasher "github.com/rzfhlv/gin-example/shared/mocks/pkg/hasher"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	limits   = ImportLimits{AsyncRows: 100, MaxRows: 10000}
+	csvValid = "first_name,last_name,email\n" +
+		"John,Doe,john@test.com\n" +
+		"Jane,Doe,jane@test.com\n"
+	csvInvalid = "\ufeffEmail,First_Name,Last_Name\n" +
+		"john@test.com,John,Doe\n" +
+		"not-an-email,,Doe\n" +
+		"JOHN@test.com,Johnny,Doe\n" +
+		"taken@test.com,Jack,Doe\n" +
+		"jane@test.com," + strings.Repeat("a", 51) + ",Doe\n"
+)
+
+func TestImport(t *testing.T) {
+	testCase := []struct {
+		name                  string
+		file                  string
+		dryRun                bool
+		limits                *ImportLimits
+		existing              []string
+		wantExistingError     error
+		wantBatchError        error
+		wantSaveError         error
+		wantError             error
+		wantStatus            string
+		wantValid, wantErrors int
+		wantImported          int
+	}{
+		{
+			name: "Testcase #1: Positive", file: csvValid, wantStatus: DONE, wantValid: 2, wantImported: 2,
+		},
+		{
+			name: "Testcase #2: Positive", file: csvValid, dryRun: true, wantStatus: DONE, wantValid: 2,
+		},
+		{
+			name: "Testcase #3: Positive", file: csvInvalid, existing: []string{"taken@test.com"}, wantStatus: DONE, wantValid: 1, wantErrors: 5, wantImported: 1,
+		},
+		{
+			name: "Testcase #4: Positive", file: csvValid, limits: &ImportLimits{AsyncRows: 1, MaxRows: 10000}, wantStatus: PENDING,
+		},
+		{
+			name: "Testcase #5: Positive", file: csvValid, dryRun: true, limits: &ImportLimits{AsyncRows: 1, MaxRows: 10000}, wantStatus: DONE, wantValid: 2,
+		},
+		{
+			name: "Testcase #6: Negative", file: "first_name,email\nJohn,john@test.com\n", wantError: ErrInvalidCSV,
+		},
+		{
+			name: "Testcase #7: Negative", file: "", wantError: ErrInvalidCSV,
+		},
+		{
+			name: "Testcase #8: Negative", file: csvValid + "\"Jack,Doe,jack@test.com\n", wantError: ErrInvalidCSV,
+		},
+		{
+			name: "Testcase #9: Negative", file: csvValid, limits: &ImportLimits{AsyncRows: 100, MaxRows: 1}, wantError: ErrTooManyRows,
+		},
+		{
+			name: "Testcase #10: Negative", file: csvValid, wantExistingError: errFoo, wantError: errFoo,
+		},
+		{
+			name: "Testcase #11: Negative", file: csvValid, wantBatchError: errFoo, wantError: errFoo,
+		},
+		{
+			name: "Testcase #12: Negative", file: csvValid, limits: &ImportLimits{AsyncRows: 1, MaxRows: 10000}, wantSaveError: errFoo, wantError: errFoo,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			importLimits := limits
+			if tt.limits != nil {
+				importLimits = *tt.limits
+			}
+
+			mockRepo := mockRepo.IRepository{}
+			mockHasher := mockHasher.HashPassword{}
+
+			existing := tt.existing
+			if existing == nil {
+				existing = []string{}
+			}
+			mockRepo.On("ExistingEmails", mock.Anything, mock.Anything).Return(existing, tt.wantExistingError)
+			mockRepo.On("CreateBatch", mock.Anything, mock.Anything).Return(tt.wantBatchError)
+			mockRepo.On("SaveImportJob", mock.Anything, mock.Anything, IMPORTJOBTTL).Return(tt.wantSaveError)
+
+			u := &Usecase{
+				repo:   &mockRepo,
+				hasher: &mockHasher,
+				limits: importLimits,
+				async: func(f func()) {
+					f()
+				},
+			}
+
+			job, err := u.Import(context.Background(), strings.NewReader(tt.file), tt.dryRun)
+			if tt.wantError != nil {
+				assert.True(t, errors.Is(err, tt.wantError))
+				if tt.wantBatchError == nil {
+					mockRepo.AssertNotCalled(t, "CreateBatch", mock.Anything, mock.Anything)
+				}
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, job.Status)
+			if tt.wantStatus == PENDING {
+				assert.NotEmpty(t, job.ID)
+				assert.Nil(t, job.Report)
+				for _, status := range []string{PENDING, RUNNING, DONE} {
+					status := status
+					mockRepo.AssertCalled(t, "SaveImportJob", mock.Anything, mock.MatchedBy(func(j model.ImportJob) bool {
+						return j.ID == job.ID && j.Status == status
+					}), IMPORTJOBTTL)
+				}
+				mockRepo.AssertCalled(t, "CreateBatch", mock.Anything, mock.Anything)
+				return
+			}
+
+			assert.Empty(t, job.ID)
+			assert.Equal(t, tt.dryRun, job.Report.DryRun)
+			assert.Equal(t, tt.wantValid, job.Report.Valid)
+			assert.Equal(t, tt.wantImported, job.Report.Imported)
+			assert.Len(t, job.Report.Errors, tt.wantErrors)
+			mockRepo.AssertNotCalled(t, "SaveImportJob", mock.Anything, mock.Anything, mock.Anything)
+			mockHasher.AssertNotCalled(t, "HashedPassword", mock.Anything)
+			if tt.dryRun {
+				mockRepo.AssertNotCalled(t, "CreateBatch", mock.Anything, mock.Anything)
+				return
+			}
+			mockRepo.AssertCalled(t, "CreateBatch", mock.Anything, mock.MatchedBy(func(members []model.Member) bool {
+				return len(members) == tt.wantImported && members[0].Password == hasher.NOPASSWORD
+			}))
+		})
+	}
+}
+
+func TestImportReport(t *testing.T) {
+	mockRepo := mockRepo.IRepository{}
+	mockHasher := mockHasher.HashPassword{}
+	mockRepo.On("ExistingEmails", mock.Anything, []string{"john@test.com", "taken@test.com"}).Return([]string{"taken@test.com"}, nil)
+
+	u := &Usecase{
+		repo:   &mockRepo,
+		hasher: &mockHasher,
+		limits: limits,
+	}
+
+	job, err := u.Import(context.Background(), strings.NewReader(csvInvalid), true)
+	assert.NoError(t, err)
+	assert.Equal(t, &model.ImportReport{
+		DryRun: true,
+		Total:  5,
+		Valid:  1,
+		Errors: []model.ImportError{
+			{Line: 3, Field: "first_name", Message: "is required"},
+			{Line: 3, Field: "email", Message: "must be a valid email"},
+			{Line: 4, Field: "email", Message: "duplicates line 2"},
+			{Line: 5, Field: "email", Message: "already exists"},
+			{Line: 6, Field: "first_name", Message: "must be at most 50 characters"},
+		},
+	}, job.Report)
+}
+
+func TestRunImport(t *testing.T) {
+	mockRepo := mockRepo.IRepository{}
+	mockHasher := mockHasher.HashPassword{}
+	mockRepo.On("ExistingEmails", mock.Anything, mock.Anything).Return([]string{}, nil)
+	mockRepo.On("CreateBatch", mock.Anything, mock.Anything).Return(errFoo)
+	mockRepo.On("SaveImportJob", mock.Anything, mock.Anything, IMPORTJOBTTL).Return(errFoo)
+
+	u := &Usecase{
+		repo:   &mockRepo,
+		hasher: &mockHasher,
+	}
+
+	u.runImport(model.ImportJob{ID: "thisisjob", Status: PENDING}, []model.ImportRow{{Line: 2, FirstName: "John", LastName: "Doe", Email: "john@test.com"}})
+	mockRepo.AssertCalled(t, "SaveImportJob", mock.Anything, mock.MatchedBy(func(j model.ImportJob) bool {
+		return j.Status == FAILED && j.Error == errFoo.Error() && j.Report == nil
+	}), IMPORTJOBTTL)
+}
+
+func TestGetImport(t *testing.T) {
+	testCase := []struct {
+		name                string
+		wantRepoError, want error
+	}{
+		{
+			name: "Testcase #1: Positive",
+		},
+		{
+			name: "Testcase #2: Negative", wantRepoError: redis.Nil, want: ErrImportNotFound,
+		},
+		{
+			name: "Testcase #3: Negative", wantRepoError: errFoo, want: errFoo,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mockRepo.IRepository{}
+			mockRepo.On("GetImportJob", mock.Anything, "thisisjob").Return(model.ImportJob{ID: "thisisjob", Status: DONE}, tt.wantRepoError)
+
+			u := &Usecase{
+				repo: &mockRepo,
+			}
+
+			job, err := u.GetImport(context.Background(), "thisisjob")
+			assert.Equal(t, tt.want, err)
+			if tt.want == nil {
+				assert.Equal(t, DONE, job.Status)
+			}
+		})
+	}
+}
@@ -2,32 +2,69 @@ package usecase
 
 import (
 	"context"
+	"io"
+	"strings"
+	"time"
 
 	"github.com/rzfhlv/gin-example/internal/modules/member/model"
 	"github.com/rzfhlv/gin-example/internal/modules/member/repository"
+	pErrors "github.com/rzfhlv/gin-example/pkg/errors"
 	"github.com/rzfhlv/gin-example/pkg/hasher"
+	"github.com/rzfhlv/gin-example/pkg/identity"
+	"github.com/rzfhlv/gin-example/pkg/message"
 	"github.com/rzfhlv/gin-example/pkg/param"
+	"github.com/rzfhlv/gin-example/pkg/password"
+	"github.com/rzfhlv/gin-example/pkg/rbac"
 )
 
+var ErrEmailExists = pErrors.Conflict(message.EMAILEXIST)
+
 type IUsecase interface {
 	Create(ctx context.Context, memberPayload model.Member) (member model.Member, err error)
-	Get(ctx context.Context, param param.Param) (members []model.Member, total int64, err error)
+	Get(ctx context.Context, param param.Param) (members []model.Member, page param.Page, err error)
 	GetByID(ctx context.Context, id int64) (member model.Member, err error)
+	Update(ctx context.Context, id int64, update model.UpdateMember) (member model.Member, err error)
+	Delete(ctx context.Context, id int64) (err error)
+	Restore(ctx context.Context, id int64) (member model.Member, err error)
+	Import(ctx context.Context, file io.Reader, dryRun bool) (job model.ImportJob, err error)
+	GetImport(ctx context.Context, id string) (job model.ImportJob, err error)
+	Export(ctx context.Context, param param.Param, write func(members []model.Member) error) (err error)
+}
+
+// ImportLimits bound a CSV import, config reads them once at startup.
+type ImportLimits struct {
+	// AsyncRows is the most rows imported within the request
+	AsyncRows int
+	MaxRows   int
 }
 
 type Usecase struct {
 	repo   repository.IRepository
 	hasher hasher.HashPassword
+	policy password.Checker
+	limits ImportLimits
+	// async runs background imports, tests run them in place
+	async func(func())
 }
 
-func New(repo repository.IRepository, hasher hasher.HashPassword) IUsecase {
+func New(repo repository.IRepository, hasher hasher.HashPassword, policy password.Checker, limits ImportLimits) IUsecase {
 	return &Usecase{
 		repo:   repo,
 		hasher: hasher,
+		policy: policy,
+		limits: limits,
+		async: func(f func()) {
+			go f()
+		},
 	}
 }
 
 func (u *Usecase) Create(ctx context.Context, memberPayload model.Member) (member model.Member, err error) {
+	err = u.policy.Check(memberPayload.Password, memberPayload.FirstName, memberPayload.LastName, memberPayload.Email)
+	if err != nil {
+		return
+	}
+
 	hashPassword, err := u.hasher.HashedPassword(memberPayload.Password)
 	if err != nil {
 		return
@@ -48,8 +85,8 @@ func (u *Usecase) Create(ctx context.Context, memberPayload model.Member) (membe
 	return
 }
 
-func (u *Usecase) Get(ctx context.Context, param param.Param) (members []model.Member, total int64, err error) {
-	members, err = u.repo.Get(ctx, param)
+func (u *Usecase) Get(ctx context.Context, param param.Param) (members []model.Member, page param.Page, err error) {
+	members, page, err = u.repo.Get(ctx, param)
 	if err != nil {
 		return
 	}
@@ -57,7 +94,12 @@ func (u *Usecase) Get(ctx context.Context, param param.Param) (members []model.M
 	if len(members) < 1 {
 		members = []model.Member{}
 	}
-	total, err = u.repo.Count(ctx)
+	if !param.Counts() {
+		return
+	}
+
+	total, err := u.repo.Count(ctx, param)
+	page.Total = &total
 	return
 }
 
@@ -65,3 +107,92 @@ func (u *Usecase) GetByID(ctx context.Context, id int64) (member model.Member, e
 	member, err = u.repo.GetByID(ctx, id)
 	return
 }
+
+func (u *Usecase) Update(ctx context.Context, id int64, update model.UpdateMember) (member model.Member, err error) {
+	member, err = u.own(ctx, id)
+	if err != nil {
+		return
+	}
+
+	if update.FirstName != nil {
+		member.FirstName = *update.FirstName
+	}
+	if update.LastName != nil {
+		member.LastName = *update.LastName
+	}
+	if update.Email != nil && !strings.EqualFold(member.Email, *update.Email) {
+		var exists bool
+		exists, err = u.repo.EmailExists(ctx, *update.Email, id)
+		if err != nil {
+			return
+		}
+		if exists {
+			err = ErrEmailExists
+			return
+		}
+		member.Email = *update.Email
+	}
+
+	err = u.repo.Update(ctx, member)
+	return
+}
+
+// Delete is a soft delete, the member is hidden from every read until an
+// admin restores it.
+func (u *Usecase) Delete(ctx context.Context, id int64) (err error) {
+	_, err = u.own(ctx, id)
+	if err != nil {
+		return
+	}
+
+	err = u.repo.Delete(ctx, id, time.Now())
+	return
+}
+
+// Restore brings back a deleted member, invitations rejected by the delete
+// stay rejected.
+func (u *Usecase) Restore(ctx context.Context, id int64) (member model.Member, err error) {
+	result, err := u.repo.Restore(ctx, id)
+	if err != nil {
+		return
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return
+	}
+	if affected < 1 {
+		err = pErrors.ErrNotFound
+		return
+	}
+
+	member, err = u.repo.GetByID(ctx, id)
+	return
+}
+
+// own returns the member when the caller may change it. A member linked to a
+// user is that user's profile, only the user or someone allowed to manage
+// every member can touch it.
+func (u *Usecase) own(ctx context.Context, id int64) (member model.Member, err error) {
+	member, err = u.repo.GetByID(ctx, id)
+	if err != nil {
+		return
+	}
+
+	if member.UserID == nil {
+		return
+	}
+
+	caller, ok := identity.FromContext(ctx)
+	if !ok {
+		err = rbac.ErrForbidden
+		return
+	}
+
+	if caller.Can(rbac.MEMBERSMANAGE) || caller.ID == *member.UserID {
+		return
+	}
+
+	err = rbac.ErrForbidden
+	return
+}
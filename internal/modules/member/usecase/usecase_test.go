@@ -7,9 +7,14 @@ import (
 	"time"
 
 	"github.com/rzfhlv/gin-example/internal/modules/member/model"
+	pErrors "github.com/rzfhlv/gin-example/pkg/errors"
+	"github.com/rzfhlv/gin-example/pkg/identity"
 	"github.com/rzfhlv/gin-example/pkg/param"
+	"github.com/rzfhlv/gin-example/pkg/password"
+	"github.com/rzfhlv/gin-example/pkg/rbac"
 	mockRepo "github.com/rzfhlv/gin-example/shared/mocks/modules/member/repository"
 	mockHasher "github.com/rzfhlv/gin-example/shared/mocks/pkg/hasher"
+	mockPassword "github.com/rzfhlv/gin-example/shared/mocks/pkg/password"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -17,6 +22,7 @@ import (
 type testCase struct {
 	name                                    string
 	wantError, wantHasherError, wantIDError error
+	wantPolicyError                         error
 	isErr                                   bool
 	result                                  CustomResult
 }
@@ -31,6 +37,16 @@ var (
 		Password:  "password",
 		CreatedAt: time.Now(),
 	}
+	linkedID  = int64(3)
+	organizer = identity.NewContext(context.Background(), identity.Identity{
+		ID: 1, Username: "johndoe", Email: "john@test.com", Roles: []string{rbac.ORGANIZER},
+	})
+	admin = identity.NewContext(context.Background(), identity.Identity{
+		ID: 2, Username: "admin", Email: "admin@test.com", Roles: []string{rbac.ADMIN},
+	})
+	linked = identity.NewContext(context.Background(), identity.Identity{
+		ID: linkedID, Username: "janedoe", Email: "jane@test.com", Roles: []string{rbac.MEMBER},
+	})
 )
 
 type CustomResult struct {
@@ -50,8 +66,9 @@ func (r *CustomResult) RowsAffected() (int64, error) {
 func TestNew(t *testing.T) {
 	mockRepo := mockRepo.IRepository{}
 	mockHaser := mockHasher.HashPassword{}
+	mockPassword := mockPassword.Checker{}
 
-	u := New(&mockRepo, &mockHaser)
+	u := New(&mockRepo, &mockHaser, &mockPassword, limits)
 	assert.NotNil(t, u)
 }
 
@@ -69,21 +86,30 @@ func TestCreate(t *testing.T) {
 		{
 			name: "Testcase #4: Negative", wantError: nil, wantHasherError: nil, wantIDError: errFoo, isErr: true, result: CustomResult{lastInsertID: 1, rowsAffected: 1, err: errFoo},
 		},
+		{
+			name: "Testcase #5: Negative", wantError: nil, wantHasherError: nil, wantPolicyError: &password.Error{}, isErr: true, result: CustomResult{lastInsertID: 1, rowsAffected: 1, err: nil},
+		},
 	}
 	for _, tt := range testCase {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := mockRepo.IRepository{}
 			mockHasher := mockHasher.HashPassword{}
+			mockPassword := mockPassword.Checker{}
+			mockPassword.On("Check", memberPayload.Password, memberPayload.FirstName, memberPayload.LastName, memberPayload.Email).Return(tt.wantPolicyError)
 			mockHasher.On("HashedPassword", mock.Anything).Return("", tt.wantHasherError)
 			mockRepo.On("Create", mock.Anything, mock.Anything).Return(&tt.result, tt.wantError)
 
 			u := &Usecase{
 				repo:   &mockRepo,
 				hasher: &mockHasher,
+				policy: &mockPassword,
 			}
 
 			_, err := u.Create(context.Background(), memberPayload)
-			if tt.wantError != nil {
+			if tt.wantPolicyError != nil {
+				assert.EqualValues(t, err, tt.wantPolicyError)
+				mockHasher.AssertNotCalled(t, "HashedPassword", mock.Anything)
+			} else if tt.wantError != nil {
 				assert.EqualValues(t, err, tt.wantError)
 			} else if tt.wantHasherError != nil {
 				assert.EqualValues(t, err, tt.wantHasherError)
@@ -108,8 +134,8 @@ func TestGet(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := mockRepo.IRepository{}
 			mockHasher := mockHasher.HashPassword{}
-			mockRepo.On("Get", mock.Anything, mock.Anything).Return([]model.Member{}, tt.wantError)
-			mockRepo.On("Count", mock.Anything).Return(expectedCount, tt.wantError)
+			mockRepo.On("Get", mock.Anything, mock.Anything).Return([]model.Member{}, param.Page{}, tt.wantError)
+			mockRepo.On("Count", mock.Anything, mock.Anything).Return(expectedCount, tt.wantError)
 
 			u := &Usecase{
 				repo:   &mockRepo,
@@ -122,6 +148,49 @@ func TestGet(t *testing.T) {
 	}
 }
 
+func TestGetCount(t *testing.T) {
+	expectedCount := int64(10)
+	yes, no := true, false
+	testCase := []struct {
+		name      string
+		param     param.Param
+		wantTotal *int64
+	}{
+		{
+			name: "Testcase #1: Positive", param: param.Param{Limit: 10, Page: 1}, wantTotal: &expectedCount,
+		},
+		{
+			name: "Testcase #2: Positive", param: param.Param{Limit: 10, Page: 1, Count: &no},
+		},
+		{
+			name: "Testcase #3: Positive", param: param.Param{Limit: 10, Cursor: "cursor"},
+		},
+		{
+			name: "Testcase #4: Positive", param: param.Param{Limit: 10, Cursor: "cursor", Count: &yes}, wantTotal: &expectedCount,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mockRepo.IRepository{}
+			mockRepo.On("Get", mock.Anything, mock.Anything).Return([]model.Member{}, param.Page{Next: "next"}, nil)
+			mockRepo.On("Count", mock.Anything, mock.Anything).Return(expectedCount, nil)
+
+			u := &Usecase{
+				repo: &mockRepo,
+			}
+
+			members, page, err := u.Get(context.Background(), tt.param)
+			assert.NoError(t, err)
+			assert.NotNil(t, members)
+			assert.Equal(t, "next", page.Next)
+			assert.Equal(t, tt.wantTotal, page.Total)
+			if tt.wantTotal == nil {
+				mockRepo.AssertNotCalled(t, "Count", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
 func TestGetByID(t *testing.T) {
 	testCase := []testCase{
 		{
@@ -147,3 +216,193 @@ func TestGetByID(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdate(t *testing.T) {
+	jane, janeEmail, johnEmail := "Jane", "jane@test.com", "JOHN@test.com"
+
+	testCase := []struct {
+		name                          string
+		update                        model.UpdateMember
+		userID                        *int64
+		exists                        bool
+		wantGetError, wantExistsError error
+		wantUpdateError               error
+		wantError                     error
+		want                          model.Member
+	}{
+		{
+			name: "Testcase #1: Positive", update: model.UpdateMember{FirstName: &jane},
+			want: model.Member{ID: 1, FirstName: jane, LastName: "Doe", Email: memberPayload.Email},
+		},
+		{
+			name: "Testcase #2: Positive", update: model.UpdateMember{Email: &janeEmail},
+			want: model.Member{ID: 1, FirstName: "John", LastName: "Doe", Email: janeEmail},
+		},
+		{
+			name: "Testcase #3: Positive", update: model.UpdateMember{Email: &johnEmail},
+			want: model.Member{ID: 1, FirstName: "John", LastName: "Doe", Email: memberPayload.Email},
+		},
+		{
+			name: "Testcase #4: Negative", update: model.UpdateMember{Email: &janeEmail}, exists: true, wantError: ErrEmailExists,
+		},
+		{
+			name: "Testcase #5: Negative", update: model.UpdateMember{Email: &janeEmail}, wantExistsError: errFoo, wantError: errFoo,
+		},
+		{
+			name: "Testcase #6: Negative", update: model.UpdateMember{FirstName: &jane}, wantGetError: pErrors.ErrNotFound, wantError: pErrors.ErrNotFound,
+		},
+		{
+			name: "Testcase #7: Negative", update: model.UpdateMember{FirstName: &jane}, wantUpdateError: errFoo, wantError: errFoo,
+		},
+		{
+			name: "Testcase #8: Negative", update: model.UpdateMember{FirstName: &jane}, userID: &linkedID, wantError: rbac.ErrForbidden,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mockRepo.IRepository{}
+			mockRepo.On("GetByID", mock.Anything, int64(1)).
+				Return(model.Member{ID: 1, UserID: tt.userID, FirstName: "John", LastName: "Doe", Email: memberPayload.Email}, tt.wantGetError)
+			mockRepo.On("EmailExists", mock.Anything, janeEmail, int64(1)).Return(tt.exists, tt.wantExistsError)
+			mockRepo.On("Update", mock.Anything, mock.Anything).Return(tt.wantUpdateError)
+
+			u := &Usecase{
+				repo: &mockRepo,
+			}
+
+			member, err := u.Update(organizer, 1, tt.update)
+			assert.Equal(t, tt.wantError, err)
+			if tt.wantError == rbac.ErrForbidden {
+				mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+			}
+			if tt.wantError == nil {
+				assert.Equal(t, tt.want, member)
+				mockRepo.AssertCalled(t, "Update", mock.Anything, tt.want)
+			}
+			if tt.update.Email == nil || *tt.update.Email == johnEmail {
+				mockRepo.AssertNotCalled(t, "EmailExists", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	testCase := []struct {
+		name                       string
+		userID                     *int64
+		wantGetError, wantDelError error
+		wantError                  error
+	}{
+		{
+			name: "Testcase #1: Positive",
+		},
+		{
+			name: "Testcase #2: Negative", wantDelError: pErrors.ErrNotFound, wantError: pErrors.ErrNotFound,
+		},
+		{
+			name: "Testcase #3: Negative", wantGetError: pErrors.ErrNotFound, wantError: pErrors.ErrNotFound,
+		},
+		{
+			name: "Testcase #4: Negative", userID: &linkedID, wantError: rbac.ErrForbidden,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mockRepo.IRepository{}
+			mockRepo.On("GetByID", mock.Anything, int64(1)).Return(model.Member{ID: 1, UserID: tt.userID}, tt.wantGetError)
+			mockRepo.On("Delete", mock.Anything, int64(1), mock.Anything).Return(tt.wantDelError)
+
+			u := &Usecase{
+				repo: &mockRepo,
+			}
+
+			err := u.Delete(organizer, 1)
+			assert.Equal(t, tt.wantError, err)
+			if tt.wantGetError != nil || tt.wantError == rbac.ErrForbidden {
+				mockRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestOwn(t *testing.T) {
+	testCase := []struct {
+		name      string
+		ctx       context.Context
+		userID    *int64
+		wantError error
+	}{
+		{
+			name: "Testcase #1: Positive", ctx: organizer,
+		},
+		{
+			name: "Testcase #2: Positive", ctx: linked, userID: &linkedID,
+		},
+		{
+			name: "Testcase #3: Positive", ctx: admin, userID: &linkedID,
+		},
+		{
+			name: "Testcase #4: Negative", ctx: organizer, userID: &linkedID, wantError: rbac.ErrForbidden,
+		},
+		{
+			name: "Testcase #5: Negative", ctx: context.Background(), userID: &linkedID, wantError: rbac.ErrForbidden,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			want := model.Member{ID: 1, UserID: tt.userID}
+			mockRepo := mockRepo.IRepository{}
+			mockRepo.On("GetByID", mock.Anything, int64(1)).Return(want, nil)
+
+			u := &Usecase{
+				repo: &mockRepo,
+			}
+
+			member, err := u.own(tt.ctx, 1)
+			assert.Equal(t, tt.wantError, err)
+			if tt.wantError == nil {
+				assert.Equal(t, want, member)
+			}
+		})
+	}
+}
+
+func TestRestore(t *testing.T) {
+	testCase := []struct {
+		name                           string
+		result                         CustomResult
+		wantRestoreError, wantGetError error
+		wantError                      error
+	}{
+		{
+			name: "Testcase #1: Positive", result: CustomResult{rowsAffected: 1},
+		},
+		{
+			name: "Testcase #2: Negative", result: CustomResult{rowsAffected: 0}, wantError: pErrors.ErrNotFound,
+		},
+		{
+			name: "Testcase #3: Negative", wantRestoreError: errFoo, wantError: errFoo,
+		},
+		{
+			name: "Testcase #4: Negative", result: CustomResult{rowsAffected: 1}, wantGetError: errFoo, wantError: errFoo,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.result
+			mockRepo := mockRepo.IRepository{}
+			mockRepo.On("Restore", mock.Anything, int64(1)).Return(&result, tt.wantRestoreError)
+			mockRepo.On("GetByID", mock.Anything, int64(1)).Return(memberPayload, tt.wantGetError)
+
+			u := &Usecase{
+				repo: &mockRepo,
+			}
+
+			member, err := u.Restore(context.Background(), 1)
+			assert.Equal(t, tt.wantError, err)
+			if tt.wantError == nil {
+				assert.Equal(t, memberPayload, member)
+			}
+		})
+	}
+}
@@ -1,28 +1,62 @@
 package handler
 
 import (
-	"database/sql"
-	"fmt"
+	"errors"
 	"log"
+	"math"
 	"net/http"
 	"strconv"
-	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/rzfhlv/gin-example/internal/modules/user/model"
 	"github.com/rzfhlv/gin-example/internal/modules/user/usecase"
+	"github.com/rzfhlv/gin-example/pkg/lockout"
 	"github.com/rzfhlv/gin-example/pkg/message"
+	"github.com/rzfhlv/gin-example/pkg/oidc"
 	"github.com/rzfhlv/gin-example/pkg/param"
+	"github.com/rzfhlv/gin-example/pkg/password"
+	"github.com/rzfhlv/gin-example/pkg/rbac"
 	"github.com/rzfhlv/gin-example/pkg/response"
+	"github.com/rzfhlv/gin-example/pkg/session"
+	"github.com/rzfhlv/gin-example/pkg/validation"
 )
 
 type IHandler interface {
 	Register(g *gin.Context)
 	Login(g *gin.Context)
 	Logout(g *gin.Context)
+	Refresh(g *gin.Context)
+	GetSessions(g *gin.Context)
+	RevokeSession(g *gin.Context)
+	RevokeSessions(g *gin.Context)
 	GetAll(g *gin.Context)
 	GetByID(g *gin.Context)
+	JWKS(g *gin.Context)
+	GetRoles(g *gin.Context)
+	GrantRole(g *gin.Context)
+	RevokeRole(g *gin.Context)
+	GetMember(g *gin.Context)
+	ClaimMember(g *gin.Context)
+	ForgotPassword(g *gin.Context)
+	ResetPassword(g *gin.Context)
+	VerifyEmail(g *gin.Context)
+	ResendVerification(g *gin.Context)
+	LoginMFA(g *gin.Context)
+	EnrollMFA(g *gin.Context)
+	ConfirmMFA(g *gin.Context)
+	DisableMFA(g *gin.Context)
+	Unlock(g *gin.Context)
+	Impersonate(g *gin.Context)
+	OIDCLogin(g *gin.Context)
+	OIDCCallback(g *gin.Context)
+	GetMe(g *gin.Context)
+	UpdateMe(g *gin.Context)
+	ChangePassword(g *gin.Context)
+	ChangeEmail(g *gin.Context)
+	DeleteMe(g *gin.Context)
+	Introspect(g *gin.Context)
+	RevokeToken(g *gin.Context)
 }
 
 type Handler struct {
@@ -42,15 +76,22 @@ func (h *Handler) Register(g *gin.Context) {
 	err := g.ShouldBindJSON(&register)
 	if err != nil {
 		log.Printf("Error Binding and Validation Register, %v", err.Error())
-		g.JSON(http.StatusUnprocessableEntity, response.Set(message.ERROR, err.Error(), nil, nil))
+		response.Error(g, http.StatusUnprocessableEntity, message.UNPROCESSABLEENTITY, validation.Translate(err, g.GetHeader("Accept-Language"))...)
 		return
 	}
 	register.CreatedAt = time.Now()
+	register.IP = g.ClientIP()
+	register.UserAgent = g.Request.UserAgent()
 
 	jwt, err := h.usecase.Register(ctx, register)
 	if err != nil {
 		log.Printf("Error Register User, %v", err.Error())
-		g.JSON(http.StatusInternalServerError, response.Set(message.ERROR, message.SOMETHINGWENTWRONG, nil, nil))
+		var invalid *password.Error
+		if errors.As(err, &invalid) {
+			response.Error(g, http.StatusUnprocessableEntity, message.WEAKPASSWORD, invalid.Fields...)
+			return
+		}
+		g.Error(err)
 		return
 	}
 
@@ -64,14 +105,27 @@ func (h *Handler) Login(g *gin.Context) {
 	err := g.ShouldBindJSON(&login)
 	if err != nil {
 		log.Printf("Error Binding and Validation Login, %v", err.Error())
-		g.JSON(http.StatusUnprocessableEntity, response.Set(message.ERROR, err.Error(), nil, nil))
+		response.Error(g, http.StatusUnprocessableEntity, message.UNPROCESSABLEENTITY, validation.Translate(err, g.GetHeader("Accept-Language"))...)
 		return
 	}
+	login.IP = g.ClientIP()
+	login.UserAgent = g.Request.UserAgent()
 
 	jwt, err := h.usecase.Login(ctx, login)
 	if err != nil {
 		log.Printf("Error Login User, %v", err.Error())
-		g.JSON(http.StatusInternalServerError, response.Set(message.ERROR, message.SOMETHINGWENTWRONG, nil, nil))
+		switch {
+		case limited(g, err):
+		case err == usecase.ErrInvalidCredentials:
+			response.Error(g, http.StatusUnauthorized, message.INVALIDCREDENTIALS)
+		default:
+			g.Error(err)
+		}
+		return
+	}
+
+	if jwt.MFAToken != "" {
+		g.JSON(http.StatusOK, response.Set(message.SUCCESS, message.MFAREQUIRED, nil, jwt))
 		return
 	}
 
@@ -81,24 +135,108 @@ func (h *Handler) Login(g *gin.Context) {
 func (h *Handler) Logout(g *gin.Context) {
 	ctx := g.Request.Context()
 
-	auth := strings.Split(g.Request.Header.Get("Authorization"), " ")
-	if len(auth) < 2 {
-		log.Printf("Error Logout User, %v", auth)
-		g.JSON(http.StatusUnauthorized, response.Set(message.ERROR, message.UNAUTHORIZED, nil, nil))
+	userID, sessionID, ok := identity(g)
+	if !ok {
+		log.Printf("Error Get Context Identity %v and %v", userID, sessionID)
+		response.Error(g, http.StatusUnauthorized, message.UNAUTHORIZED)
+		return
+	}
+
+	err := h.usecase.Logout(ctx, userID, sessionID)
+	if err != nil {
+		log.Printf("Error Logout User, %v", err.Error())
+		g.Error(err)
+		return
+	}
+
+	g.JSON(http.StatusOK, response.Set(message.SUCCESS, message.OK, nil, nil))
+}
+
+func (h *Handler) Refresh(g *gin.Context) {
+	ctx := g.Request.Context()
+
+	refresh := model.Refresh{}
+	err := g.ShouldBindJSON(&refresh)
+	if err != nil {
+		log.Printf("Error Binding and Validation Refresh, %v", err.Error())
+		response.Error(g, http.StatusUnprocessableEntity, message.UNPROCESSABLEENTITY, validation.Translate(err, g.GetHeader("Accept-Language"))...)
+		return
+	}
+	refresh.IP = g.ClientIP()
+	refresh.UserAgent = g.Request.UserAgent()
+
+	jwt, err := h.usecase.Refresh(ctx, refresh)
+	if err != nil {
+		log.Printf("Error Refresh Token, %v", err.Error())
+		if err == usecase.ErrInvalidRefreshToken || err == usecase.ErrRefreshTokenReused {
+			response.Error(g, http.StatusUnauthorized, message.INVALIDREFRESHTOKEN)
+			return
+		}
+		g.Error(err)
+		return
+	}
+
+	g.JSON(http.StatusOK, response.Set(message.SUCCESS, message.OK, nil, jwt))
+}
+
+func (h *Handler) GetSessions(g *gin.Context) {
+	ctx := g.Request.Context()
+
+	userID, sessionID, ok := identity(g)
+	if !ok {
+		log.Printf("Error Get Context Identity %v and %v", userID, sessionID)
+		response.Error(g, http.StatusUnauthorized, message.UNAUTHORIZED)
 		return
 	}
 
-	value, ok := g.Get("username")
+	sessions, err := h.usecase.GetSessions(ctx, userID, sessionID)
+	if err != nil {
+		log.Printf("Error Get Sessions, %v", err.Error())
+		g.Error(err)
+		return
+	}
+
+	g.JSON(http.StatusOK, response.Set(message.SUCCESS, message.OK, nil, sessions))
+}
+
+func (h *Handler) RevokeSession(g *gin.Context) {
+	ctx := g.Request.Context()
+
+	userID, sessionID, ok := identity(g)
 	if !ok {
-		log.Printf("Error Get Context Username %v and %v", value, ok)
-		g.JSON(http.StatusUnauthorized, response.Set(message.ERROR, message.UNAUTHORIZED, nil, nil))
+		log.Printf("Error Get Context Identity %v and %v", userID, sessionID)
+		response.Error(g, http.StatusUnauthorized, message.UNAUTHORIZED)
 		return
 	}
-	username := fmt.Sprintf("%v", value)
-	err := h.usecase.Logout(ctx, username)
+
+	err := h.usecase.RevokeSession(ctx, userID, g.Param("session_id"))
 	if err != nil {
-		log.Printf("Error Logout User, %v", err.Error())
-		g.JSON(http.StatusInternalServerError, response.Set(message.ERROR, message.SOMETHINGWENTWRONG, nil, nil))
+		log.Printf("Error Revoke Session, %v", err.Error())
+		if err == session.ErrNotFound {
+			response.Error(g, http.StatusNotFound, message.NOTFOUND)
+			return
+		}
+		g.Error(err)
+		return
+	}
+
+	g.JSON(http.StatusOK, response.Set(message.SUCCESS, message.OK, nil, nil))
+}
+
+func (h *Handler) RevokeSessions(g *gin.Context) {
+	ctx := g.Request.Context()
+
+	userID, sessionID, ok := identity(g)
+	if !ok {
+		log.Printf("Error Get Context Identity %v and %v", userID, sessionID)
+		response.Error(g, http.StatusUnauthorized, message.UNAUTHORIZED)
+		return
+	}
+
+	err := h.usecase.RevokeSessions(ctx, userID)
+	if err != nil {
+		log.Printf("Error Revoke Sessions, %v", err.Error())
+		g.Error(err)
 		return
 	}
 
@@ -114,18 +252,26 @@ func (h *Handler) GetAll(g *gin.Context) {
 	err := g.ShouldBind(&queryParam)
 	if err != nil {
 		log.Printf("Error Binding Query Param Gathering, %v", err.Error())
-		g.JSON(http.StatusUnprocessableEntity, response.Set(message.ERROR, message.UNPROCESSABLEENTITY, nil, nil))
+		response.Error(g, http.StatusUnprocessableEntity, message.UNPROCESSABLEENTITY, validation.Translate(err, g.GetHeader("Accept-Language"))...)
 		return
 	}
+	queryParam.Filters = g.Request.URL.Query()
 
-	users, total, err := h.usecase.GetAll(ctx, queryParam)
+	users, page, err := h.usecase.GetAll(ctx, queryParam)
 	if err != nil {
 		log.Printf("Error Get Member, %v", err.Error())
-		g.JSON(http.StatusInternalServerError, response.Set(message.ERROR, message.SOMETHINGWENTWRONG, nil, nil))
+		if errors.Is(err, param.ErrCursorDisabled) {
+			response.Error(g, http.StatusBadRequest, err.Error())
+			return
+		}
+		if param.IsInvalid(err) {
+			response.Error(g, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		g.Error(err)
 		return
 	}
-	queryParam.Total = total
-	meta := response.BuildMeta(queryParam, len(users))
+	meta := response.BuildMeta(queryParam, page, len(users), g.Request.URL)
 
 	g.JSON(http.StatusOK, response.Set(message.SUCCESS, message.OK, meta, users))
 }
@@ -137,20 +283,693 @@ func (h *Handler) GetByID(g *gin.Context) {
 	userID, err := strconv.ParseInt(id, 10, 64)
 	if err != nil {
 		log.Printf("Error Parse Member ID, %v", err.Error())
-		g.JSON(http.StatusUnprocessableEntity, response.Set(message.ERROR, message.UNPROCESSABLEENTITY, nil, nil))
+		response.Error(g, http.StatusUnprocessableEntity, message.UNPROCESSABLEENTITY)
 		return
 	}
 
 	user, err := h.usecase.GetByID(ctx, userID)
 	if err != nil {
 		log.Printf("Error Get By ID Member, %v", err.Error())
-		if err == sql.ErrNoRows {
-			g.JSON(http.StatusNotFound, response.Set(message.ERROR, message.NOTFOUND, nil, nil))
+		g.Error(err)
+		return
+	}
+
+	g.JSON(http.StatusOK, response.Set(message.SUCCESS, message.OK, nil, user))
+}
+
+func (h *Handler) JWKS(g *gin.Context) {
+	ctx := g.Request.Context()
+
+	jwks := h.usecase.JWKS(ctx)
+
+	g.Header("Cache-Control", "public, max-age=300")
+	g.JSON(http.StatusOK, jwks)
+}
+
+// limited answers a request the login lockout blocks, Retry-After tells the
+// client when to try again.
+func limited(g *gin.Context, err error) bool {
+	var limit *lockout.Error
+	if !errors.As(err, &limit) {
+		return false
+	}
+
+	g.Header("Retry-After", strconv.Itoa(int(math.Ceil(limit.RetryAfter.Seconds()))))
+	if limit.Locked {
+		response.Error(g, http.StatusLocked, message.ACCOUNTLOCKED)
+		return true
+	}
+	response.Error(g, http.StatusTooManyRequests, message.TOOMANYREQUESTS)
+	return true
+}
+
+func identity(g *gin.Context) (userID int64, sessionID string, ok bool) {
+	userID, ok = g.Value("id").(int64)
+	if !ok {
+		return
+	}
+	sessionID, ok = g.Value("session_id").(string)
+	return
+}
+
+func (h *Handler) GetRoles(g *gin.Context) {
+	ctx := g.Request.Context()
+
+	userID, err := strconv.ParseInt(g.Param("id"), 10, 64)
+	if err != nil {
+		log.Printf("Error Parse User ID, %v", err.Error())
+		response.Error(g, http.StatusUnprocessableEntity, message.UNPROCESSABLEENTITY)
+		return
+	}
+
+	roles, err := h.usecase.GetRoles(ctx, userID)
+	if err != nil {
+		log.Printf("Error Get Roles, %v", err.Error())
+		h.roleError(g, err)
+		return
+	}
+
+	g.JSON(http.StatusOK, response.Set(message.SUCCESS, message.OK, nil, roles))
+}
+
+func (h *Handler) GrantRole(g *gin.Context) {
+	ctx := g.Request.Context()
+
+	userID, err := strconv.ParseInt(g.Param("id"), 10, 64)
+	if err != nil {
+		log.Printf("Error Parse User ID, %v", err.Error())
+		response.Error(g, http.StatusUnprocessableEntity, message.UNPROCESSABLEENTITY)
+		return
+	}
+
+	role := model.Role{}
+	err = g.ShouldBindJSON(&role)
+	if err != nil {
+		log.Printf("Error Binding and Validation Role, %v", err.Error())
+		response.Error(g, http.StatusUnprocessableEntity, message.UNPROCESSABLEENTITY, validation.Translate(err, g.GetHeader("Accept-Language"))...)
+		return
+	}
+
+	roles, err := h.usecase.GrantRole(ctx, userID, role.Role)
+	if err != nil {
+		log.Printf("Error Grant Role, %v", err.Error())
+		h.roleError(g, err)
+		return
+	}
+
+	g.JSON(http.StatusOK, response.Set(message.SUCCESS, message.OK, nil, roles))
+}
+
+func (h *Handler) RevokeRole(g *gin.Context) {
+	ctx := g.Request.Context()
+
+	userID, err := strconv.ParseInt(g.Param("id"), 10, 64)
+	if err != nil {
+		log.Printf("Error Parse User ID, %v", err.Error())
+		response.Error(g, http.StatusUnprocessableEntity, message.UNPROCESSABLEENTITY)
+		return
+	}
+
+	roles, err := h.usecase.RevokeRole(ctx, userID, g.Param("role"))
+	if err != nil {
+		log.Printf("Error Revoke Role, %v", err.Error())
+		h.roleError(g, err)
+		return
+	}
+
+	g.JSON(http.StatusOK, response.Set(message.SUCCESS, message.OK, nil, roles))
+}
+
+func (h *Handler) roleError(g *gin.Context, err error) {
+	switch err {
+	case rbac.ErrUnknownRole:
+		response.Error(g, http.StatusUnprocessableEntity, message.UNKNOWNROLE)
+	default:
+		g.Error(err)
+	}
+}
+
+func (h *Handler) GetMember(g *gin.Context) {
+	ctx := g.Request.Context()
+
+	userID, sessionID, ok := identity(g)
+	if !ok {
+		log.Printf("Error Get Context Identity %v and %v", userID, sessionID)
+		response.Error(g, http.StatusUnauthorized, message.UNAUTHORIZED)
+		return
+	}
+
+	member, err := h.usecase.GetMember(ctx, userID)
+	if err != nil {
+		log.Printf("Error Get Member User, %v", err.Error())
+		g.Error(err)
+		return
+	}
+
+	g.JSON(http.StatusOK, response.Set(message.SUCCESS, message.OK, nil, member))
+}
+
+func (h *Handler) ClaimMember(g *gin.Context) {
+	ctx := g.Request.Context()
+
+	userID, sessionID, ok := identity(g)
+	if !ok {
+		log.Printf("Error Get Context Identity %v and %v", userID, sessionID)
+		response.Error(g, http.StatusUnauthorized, message.UNAUTHORIZED)
+		return
+	}
+
+	member, err := h.usecase.ClaimMember(ctx, userID)
+	if err != nil {
+		log.Printf("Error Claim Member User, %v", err.Error())
+		g.Error(err)
+		return
+	}
+
+	g.JSON(http.StatusOK, response.Set(message.SUCCESS, message.OK, nil, member))
+}
+
+func (h *Handler) ForgotPassword(g *gin.Context) {
+	ctx := g.Request.Context()
+
+	forgot := model.ForgotPassword{}
+	err := g.ShouldBindJSON(&forgot)
+	if err != nil {
+		log.Printf("Error Binding and Validation Forgot Password, %v", err.Error())
+		response.Error(g, http.StatusUnprocessableEntity, message.UNPROCESSABLEENTITY, validation.Translate(err, g.GetHeader("Accept-Language"))...)
+		return
+	}
+
+	err = h.usecase.ForgotPassword(ctx, forgot)
+	if err != nil {
+		log.Printf("Error Forgot Password, %v", err.Error())
+		g.Error(err)
+		return
+	}
+
+	g.JSON(http.StatusOK, response.Set(message.SUCCESS, message.PASSWORDRESETSENT, nil, nil))
+}
+
+func (h *Handler) ResetPassword(g *gin.Context) {
+	ctx := g.Request.Context()
+
+	reset := model.ResetPassword{}
+	err := g.ShouldBindJSON(&reset)
+	if err != nil {
+		log.Printf("Error Binding and Validation Reset Password, %v", err.Error())
+		response.Error(g, http.StatusUnprocessableEntity, message.UNPROCESSABLEENTITY, validation.Translate(err, g.GetHeader("Accept-Language"))...)
+		return
+	}
+
+	err = h.usecase.ResetPassword(ctx, reset)
+	if err != nil {
+		log.Printf("Error Reset Password, %v", err.Error())
+		var invalid *password.Error
+		switch {
+		case errors.As(err, &invalid):
+			response.Error(g, http.StatusUnprocessableEntity, message.WEAKPASSWORD, invalid.Fields...)
+		case err == usecase.ErrInvalidResetToken:
+			response.Error(g, http.StatusBadRequest, message.INVALIDRESETTOKEN)
+		default:
+			g.Error(err)
+		}
+		return
+	}
+
+	g.JSON(http.StatusOK, response.Set(message.SUCCESS, message.OK, nil, nil))
+}
+
+func (h *Handler) VerifyEmail(g *gin.Context) {
+	ctx := g.Request.Context()
+
+	verifyToken := g.Query("token")
+	if verifyToken == "" {
+		log.Printf("Error Validation Verify Email, empty token")
+		response.Error(g, http.StatusUnprocessableEntity, message.UNPROCESSABLEENTITY)
+		return
+	}
+
+	err := h.usecase.VerifyEmail(ctx, verifyToken)
+	if err != nil {
+		log.Printf("Error Verify Email, %v", err.Error())
+		if err == usecase.ErrInvalidVerifyToken {
+			response.Error(g, http.StatusBadRequest, message.INVALIDVERIFYTOKEN)
 			return
 		}
-		g.JSON(http.StatusInternalServerError, response.Set(message.SUCCESS, message.SOMETHINGWENTWRONG, nil, nil))
+		g.Error(err)
+		return
+	}
+
+	g.JSON(http.StatusOK, response.Set(message.SUCCESS, message.OK, nil, nil))
+}
+
+func (h *Handler) ResendVerification(g *gin.Context) {
+	ctx := g.Request.Context()
+
+	resend := model.ResendVerification{}
+	err := g.ShouldBindJSON(&resend)
+	if err != nil {
+		log.Printf("Error Binding and Validation Resend Verification, %v", err.Error())
+		response.Error(g, http.StatusUnprocessableEntity, message.UNPROCESSABLEENTITY, validation.Translate(err, g.GetHeader("Accept-Language"))...)
+		return
+	}
+
+	err = h.usecase.ResendVerification(ctx, resend)
+	if err != nil {
+		log.Printf("Error Resend Verification, %v", err.Error())
+		if err == usecase.ErrResendTooSoon {
+			response.Error(g, http.StatusTooManyRequests, message.TOOMANYREQUESTS)
+			return
+		}
+		g.Error(err)
+		return
+	}
+
+	g.JSON(http.StatusOK, response.Set(message.SUCCESS, message.VERIFICATIONSENT, nil, nil))
+}
+
+func (h *Handler) LoginMFA(g *gin.Context) {
+	ctx := g.Request.Context()
+
+	login := model.LoginMFA{}
+	err := g.ShouldBindJSON(&login)
+	if err != nil {
+		log.Printf("Error Binding and Validation Login MFA, %v", err.Error())
+		response.Error(g, http.StatusUnprocessableEntity, message.UNPROCESSABLEENTITY, validation.Translate(err, g.GetHeader("Accept-Language"))...)
+		return
+	}
+	login.IP = g.ClientIP()
+	login.UserAgent = g.Request.UserAgent()
+
+	jwt, err := h.usecase.LoginMFA(ctx, login)
+	if err != nil {
+		log.Printf("Error Login MFA User, %v", err.Error())
+		switch {
+		case limited(g, err):
+		case err == usecase.ErrInvalidMFAToken || err == usecase.ErrInvalidMFACode:
+			response.Error(g, http.StatusUnauthorized, message.INVALIDMFACODE)
+		default:
+			g.Error(err)
+		}
+		return
+	}
+
+	g.JSON(http.StatusOK, response.Set(message.SUCCESS, message.OK, nil, jwt))
+}
+
+func (h *Handler) EnrollMFA(g *gin.Context) {
+	ctx := g.Request.Context()
+
+	userID, sessionID, ok := identity(g)
+	if !ok {
+		log.Printf("Error Get Context Identity %v and %v", userID, sessionID)
+		response.Error(g, http.StatusUnauthorized, message.UNAUTHORIZED)
+		return
+	}
+
+	enrollment, err := h.usecase.EnrollMFA(ctx, userID)
+	if err != nil {
+		log.Printf("Error Enroll MFA User, %v", err.Error())
+		if err == usecase.ErrMFAEnabled {
+			response.Error(g, http.StatusConflict, message.MFAENABLED)
+			return
+		}
+		g.Error(err)
+		return
+	}
+
+	g.JSON(http.StatusOK, response.Set(message.SUCCESS, message.OK, nil, enrollment))
+}
+
+func (h *Handler) ConfirmMFA(g *gin.Context) {
+	ctx := g.Request.Context()
+
+	userID, sessionID, ok := identity(g)
+	if !ok {
+		log.Printf("Error Get Context Identity %v and %v", userID, sessionID)
+		response.Error(g, http.StatusUnauthorized, message.UNAUTHORIZED)
+		return
+	}
+
+	code := model.MFACode{}
+	err := g.ShouldBindJSON(&code)
+	if err != nil {
+		log.Printf("Error Binding and Validation Confirm MFA, %v", err.Error())
+		response.Error(g, http.StatusUnprocessableEntity, message.UNPROCESSABLEENTITY, validation.Translate(err, g.GetHeader("Accept-Language"))...)
+		return
+	}
+
+	codes, err := h.usecase.ConfirmMFA(ctx, userID, code)
+	if err != nil {
+		log.Printf("Error Confirm MFA User, %v", err.Error())
+		switch err {
+		case usecase.ErrInvalidMFACode:
+			response.Error(g, http.StatusUnprocessableEntity, message.INVALIDMFACODE)
+		default:
+			g.Error(err)
+		}
+		return
+	}
+
+	g.JSON(http.StatusOK, response.Set(message.SUCCESS, message.OK, nil, codes))
+}
+
+func (h *Handler) DisableMFA(g *gin.Context) {
+	ctx := g.Request.Context()
+
+	userID, sessionID, ok := identity(g)
+	if !ok {
+		log.Printf("Error Get Context Identity %v and %v", userID, sessionID)
+		response.Error(g, http.StatusUnauthorized, message.UNAUTHORIZED)
+		return
+	}
+
+	disable := model.DisableMFA{}
+	err := g.ShouldBindJSON(&disable)
+	if err != nil {
+		log.Printf("Error Binding and Validation Disable MFA, %v", err.Error())
+		response.Error(g, http.StatusUnprocessableEntity, message.UNPROCESSABLEENTITY, validation.Translate(err, g.GetHeader("Accept-Language"))...)
+		return
+	}
+
+	err = h.usecase.DisableMFA(ctx, userID, disable)
+	if err != nil {
+		log.Printf("Error Disable MFA User, %v", err.Error())
+		switch {
+		case limited(g, err):
+		case err == usecase.ErrInvalidCredentials:
+			response.Error(g, http.StatusForbidden, message.INCORRECTPASSWORD)
+		case err == usecase.ErrInvalidMFACode:
+			response.Error(g, http.StatusForbidden, message.INVALIDMFACODE)
+		default:
+			g.Error(err)
+		}
+		return
+	}
+
+	g.JSON(http.StatusOK, response.Set(message.SUCCESS, message.OK, nil, nil))
+}
+
+func (h *Handler) Unlock(g *gin.Context) {
+	ctx := g.Request.Context()
+
+	userID, err := strconv.ParseInt(g.Param("id"), 10, 64)
+	if err != nil {
+		log.Printf("Error Parse User ID, %v", err.Error())
+		response.Error(g, http.StatusUnprocessableEntity, message.UNPROCESSABLEENTITY)
+		return
+	}
+
+	err = h.usecase.Unlock(ctx, userID)
+	if err != nil {
+		log.Printf("Error Unlock User, %v", err.Error())
+		g.Error(err)
+		return
+	}
+
+	g.JSON(http.StatusOK, response.Set(message.SUCCESS, message.OK, nil, nil))
+}
+
+func (h *Handler) Impersonate(g *gin.Context) {
+	ctx := g.Request.Context()
+
+	userID, err := strconv.ParseInt(g.Param("id"), 10, 64)
+	if err != nil {
+		log.Printf("Error Parse User ID, %v", err.Error())
+		response.Error(g, http.StatusUnprocessableEntity, message.UNPROCESSABLEENTITY)
+		return
+	}
+
+	jwt, err := h.usecase.Impersonate(ctx, model.Impersonate{
+		UserID:    userID,
+		IP:        g.ClientIP(),
+		UserAgent: g.Request.UserAgent(),
+	})
+	if err != nil {
+		log.Printf("Error Impersonate User, %v", err.Error())
+		g.Error(err)
+		return
+	}
+
+	g.JSON(http.StatusOK, response.Set(message.SUCCESS, message.OK, nil, jwt))
+}
+
+func (h *Handler) OIDCLogin(g *gin.Context) {
+	ctx := g.Request.Context()
+
+	authURL, err := h.usecase.OIDCLogin(ctx)
+	if err != nil {
+		log.Printf("Error OIDC Login, %v", err.Error())
+		if err == usecase.ErrOIDCDisabled {
+			response.Error(g, http.StatusNotFound, message.NOTFOUND)
+			return
+		}
+		g.Error(err)
+		return
+	}
+
+	g.Redirect(http.StatusFound, authURL)
+}
+
+func (h *Handler) OIDCCallback(g *gin.Context) {
+	ctx := g.Request.Context()
+
+	// the provider reports a denied or failed login as an error parameter
+	// instead of a code
+	if providerError := g.Query("error"); providerError != "" {
+		log.Printf("Error OIDC Callback, provider %v", providerError)
+		response.Error(g, http.StatusUnauthorized, message.UNAUTHORIZED)
+		return
+	}
+
+	callback := model.OIDCCallback{}
+	err := g.ShouldBindQuery(&callback)
+	if err != nil {
+		log.Printf("Error Binding and Validation OIDC Callback, %v", err.Error())
+		response.Error(g, http.StatusUnprocessableEntity, message.UNPROCESSABLEENTITY, validation.Translate(err, g.GetHeader("Accept-Language"))...)
+		return
+	}
+	callback.IP = g.ClientIP()
+	callback.UserAgent = g.Request.UserAgent()
+
+	jwt, err := h.usecase.OIDCCallback(ctx, callback)
+	if err != nil {
+		log.Printf("Error OIDC Callback, %v", err.Error())
+		switch {
+		case err == usecase.ErrOIDCDisabled:
+			response.Error(g, http.StatusNotFound, message.NOTFOUND)
+		case err == usecase.ErrInvalidOIDCState:
+			response.Error(g, http.StatusBadRequest, message.INVALIDOIDCSTATE)
+		case err == usecase.ErrOIDCAccountExists:
+			response.Error(g, http.StatusConflict, message.OIDCACCOUNTEXISTS)
+		case err == usecase.ErrOIDCEmailMissing:
+			response.Error(g, http.StatusUnprocessableEntity, message.UNPROCESSABLEENTITY)
+		case errors.Is(err, oidc.ErrTokenExchange), errors.Is(err, oidc.ErrMissingIDToken), errors.Is(err, oidc.ErrInvalidIDToken),
+			errors.Is(err, oidc.ErrNonceMismatch), errors.Is(err, oidc.ErrUnknownSigningKey):
+			response.Error(g, http.StatusUnauthorized, message.UNAUTHORIZED)
+		default:
+			g.Error(err)
+		}
+		return
+	}
+
+	g.JSON(http.StatusOK, response.Set(message.SUCCESS, message.OK, nil, jwt))
+}
+
+func (h *Handler) GetMe(g *gin.Context) {
+	ctx := g.Request.Context()
+
+	userID, sessionID, ok := identity(g)
+	if !ok {
+		log.Printf("Error Get Context Identity %v and %v", userID, sessionID)
+		response.Error(g, http.StatusUnauthorized, message.UNAUTHORIZED)
+		return
+	}
+
+	user, err := h.usecase.GetByID(ctx, userID)
+	if err != nil {
+		log.Printf("Error Get Me User, %v", err.Error())
+		g.Error(err)
 		return
 	}
 
 	g.JSON(http.StatusOK, response.Set(message.SUCCESS, message.OK, nil, user))
 }
+
+func (h *Handler) UpdateMe(g *gin.Context) {
+	ctx := g.Request.Context()
+
+	userID, sessionID, ok := identity(g)
+	if !ok {
+		log.Printf("Error Get Context Identity %v and %v", userID, sessionID)
+		response.Error(g, http.StatusUnauthorized, message.UNAUTHORIZED)
+		return
+	}
+
+	update := model.UpdateUser{}
+	err := g.ShouldBindJSON(&update)
+	if err != nil {
+		log.Printf("Error Binding and Validation Update Me, %v", err.Error())
+		response.Error(g, http.StatusUnprocessableEntity, message.UNPROCESSABLEENTITY, validation.Translate(err, g.GetHeader("Accept-Language"))...)
+		return
+	}
+
+	user, err := h.usecase.UpdateMe(ctx, userID, update)
+	if err != nil {
+		log.Printf("Error Update Me User, %v", err.Error())
+		g.Error(err)
+		return
+	}
+
+	g.JSON(http.StatusOK, response.Set(message.SUCCESS, message.OK, nil, user))
+}
+
+func (h *Handler) ChangePassword(g *gin.Context) {
+	ctx := g.Request.Context()
+
+	userID, sessionID, ok := identity(g)
+	if !ok {
+		log.Printf("Error Get Context Identity %v and %v", userID, sessionID)
+		response.Error(g, http.StatusUnauthorized, message.UNAUTHORIZED)
+		return
+	}
+
+	change := model.ChangePassword{}
+	err := g.ShouldBindJSON(&change)
+	if err != nil {
+		log.Printf("Error Binding and Validation Change Password, %v", err.Error())
+		response.Error(g, http.StatusUnprocessableEntity, message.UNPROCESSABLEENTITY, validation.Translate(err, g.GetHeader("Accept-Language"))...)
+		return
+	}
+
+	err = h.usecase.ChangePassword(ctx, userID, sessionID, change)
+	if err != nil {
+		log.Printf("Error Change Password User, %v", err.Error())
+		var invalid *password.Error
+		switch {
+		case errors.As(err, &invalid):
+			response.Error(g, http.StatusUnprocessableEntity, message.WEAKPASSWORD, invalid.Fields...)
+		case limited(g, err):
+		case err == usecase.ErrInvalidCredentials:
+			response.Error(g, http.StatusForbidden, message.INCORRECTPASSWORD)
+		default:
+			g.Error(err)
+		}
+		return
+	}
+
+	g.JSON(http.StatusOK, response.Set(message.SUCCESS, message.OK, nil, nil))
+}
+
+func (h *Handler) ChangeEmail(g *gin.Context) {
+	ctx := g.Request.Context()
+
+	userID, sessionID, ok := identity(g)
+	if !ok {
+		log.Printf("Error Get Context Identity %v and %v", userID, sessionID)
+		response.Error(g, http.StatusUnauthorized, message.UNAUTHORIZED)
+		return
+	}
+
+	change := model.ChangeEmail{}
+	err := g.ShouldBindJSON(&change)
+	if err != nil {
+		log.Printf("Error Binding and Validation Change Email, %v", err.Error())
+		response.Error(g, http.StatusUnprocessableEntity, message.UNPROCESSABLEENTITY, validation.Translate(err, g.GetHeader("Accept-Language"))...)
+		return
+	}
+
+	err = h.usecase.ChangeEmail(ctx, userID, sessionID, change)
+	if err != nil {
+		log.Printf("Error Change Email User, %v", err.Error())
+		switch {
+		case limited(g, err):
+		case err == usecase.ErrInvalidCredentials:
+			response.Error(g, http.StatusForbidden, message.INCORRECTPASSWORD)
+		default:
+			g.Error(err)
+		}
+		return
+	}
+
+	g.JSON(http.StatusOK, response.Set(message.SUCCESS, message.VERIFICATIONSENT, nil, nil))
+}
+
+func (h *Handler) DeleteMe(g *gin.Context) {
+	ctx := g.Request.Context()
+
+	userID, sessionID, ok := identity(g)
+	if !ok {
+		log.Printf("Error Get Context Identity %v and %v", userID, sessionID)
+		response.Error(g, http.StatusUnauthorized, message.UNAUTHORIZED)
+		return
+	}
+
+	remove := model.DeleteUser{}
+	err := g.ShouldBindJSON(&remove)
+	if err != nil {
+		log.Printf("Error Binding and Validation Delete Me, %v", err.Error())
+		response.Error(g, http.StatusUnprocessableEntity, message.UNPROCESSABLEENTITY, validation.Translate(err, g.GetHeader("Accept-Language"))...)
+		return
+	}
+
+	err = h.usecase.DeleteMe(ctx, userID, remove)
+	if err != nil {
+		log.Printf("Error Delete Me User, %v", err.Error())
+		switch {
+		case limited(g, err):
+		case err == usecase.ErrInvalidCredentials:
+			response.Error(g, http.StatusForbidden, message.INCORRECTPASSWORD)
+		default:
+			g.Error(err)
+		}
+		return
+	}
+
+	g.JSON(http.StatusOK, response.Set(message.SUCCESS, message.OK, nil, nil))
+}
+
+// Introspect answers with the bare RFC 7662 body other services expect
+// instead of the usual response envelope.
+func (h *Handler) Introspect(g *gin.Context) {
+	ctx := g.Request.Context()
+
+	request := model.TokenRequest{}
+	err := g.ShouldBind(&request)
+	if err != nil {
+		log.Printf("Error Binding and Validation Introspect, %v", err.Error())
+		response.Error(g, http.StatusUnprocessableEntity, message.UNPROCESSABLEENTITY, validation.Translate(err, g.GetHeader("Accept-Language"))...)
+		return
+	}
+
+	introspection, err := h.usecase.Introspect(ctx, request)
+	if err != nil {
+		log.Printf("Error Introspect Token, %v", err.Error())
+		g.Error(err)
+		return
+	}
+
+	g.Header("Cache-Control", "no-store")
+	g.JSON(http.StatusOK, introspection)
+}
+
+func (h *Handler) RevokeToken(g *gin.Context) {
+	ctx := g.Request.Context()
+
+	request := model.TokenRequest{}
+	err := g.ShouldBind(&request)
+	if err != nil {
+		log.Printf("Error Binding and Validation Revoke Token, %v", err.Error())
+		response.Error(g, http.StatusUnprocessableEntity, message.UNPROCESSABLEENTITY, validation.Translate(err, g.GetHeader("Accept-Language"))...)
+		return
+	}
+
+	err = h.usecase.RevokeToken(ctx, request)
+	if err != nil {
+		log.Printf("Error Revoke Token, %v", err.Error())
+		g.Error(err)
+		return
+	}
+
+	g.JSON(http.StatusOK, response.Set(message.SUCCESS, message.OK, nil, nil))
+}
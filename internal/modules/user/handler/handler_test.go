@@ -2,14 +2,28 @@ package handler
 
 import (
 	"database/sql"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/rzfhlv/gin-example/internal/modules/user/model"
+	"github.com/rzfhlv/gin-example/internal/modules/user/usecase"
+	"github.com/rzfhlv/gin-example/middleware"
+	pErrors "github.com/rzfhlv/gin-example/pkg/errors"
+	pJwt "github.com/rzfhlv/gin-example/pkg/jwt"
+	"github.com/rzfhlv/gin-example/pkg/lockout"
+	"github.com/rzfhlv/gin-example/pkg/message"
+	"github.com/rzfhlv/gin-example/pkg/oidc"
+	"github.com/rzfhlv/gin-example/pkg/param"
+	"github.com/rzfhlv/gin-example/pkg/password"
+	"github.com/rzfhlv/gin-example/pkg/rbac"
+	"github.com/rzfhlv/gin-example/pkg/session"
 	mockUsecase "github.com/rzfhlv/gin-example/shared/mocks/modules/user/usecase"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -27,6 +41,8 @@ var (
 	payloadFail    = `{"id":1,"username":"","email":"john@test.com","password":"password"}`
 	loginSuccess   = `{"username":"johndoe","password":"password"}`
 	loginFail      = `{"username":"","password":"password"}`
+	refreshSuccess = `{"refresh_token":"thisisrefreshtoken"}`
+	refreshFail    = `{"refresh_token":""}`
 )
 
 func TestNew(t *testing.T) {
@@ -49,6 +65,12 @@ func TestRegister(t *testing.T) {
 		{
 			name: "Testcase #3: Negative", body: payloadFail, wantError: errFoo, code: http.StatusUnprocessableEntity,
 		},
+		{
+			name: "Testcase #4: Negative", body: payloadSuccess, wantError: &password.Error{Fields: []password.FieldError{{Field: password.FIELD, Message: "must contain a digit"}}}, code: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "Testcase #5: Negative", body: payloadSuccess, wantError: pErrors.Conflict(message.USERNAMEEXIST), code: http.StatusConflict,
+		},
 	}
 	for _, tt := range testCase {
 		t.Run(tt.name, func(t *testing.T) {
@@ -65,6 +87,7 @@ func TestRegister(t *testing.T) {
 			ctx.Request.Header.Set("Content-Type", "application/json")
 
 			h.Register(ctx)
+			middleware.RenderError(ctx)
 			assert.EqualValues(t, tt.code, w.Code)
 		})
 	}
@@ -83,6 +106,9 @@ func TestLogin(t *testing.T) {
 		{
 			name: "Testcase #3: Negative", body: loginFail, wantError: errFoo, code: http.StatusUnprocessableEntity,
 		},
+		{
+			name: "Testcase #4: Negative", body: loginSuccess, wantError: usecase.ErrInvalidCredentials, code: http.StatusUnauthorized,
+		},
 	}
 	for _, tt := range testCase {
 		t.Run(tt.name, func(t *testing.T) {
@@ -99,32 +125,90 @@ func TestLogin(t *testing.T) {
 			ctx.Request.Header.Set("Content-Type", "application/json")
 
 			h.Login(ctx)
+			middleware.RenderError(ctx)
 			assert.EqualValues(t, tt.code, w.Code)
 		})
 	}
 }
 
-func TestLogout(t *testing.T) {
+func TestLoginMFARequired(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
-	testCase := []testCase{
+	mockUsecase := mockUsecase.IUsecase{}
+	mockUsecase.On("Login", mock.Anything, mock.Anything).Return(model.JWT{MFAToken: "thisismfatoken", MFAExpired: "5 Minute"}, nil)
+
+	h := &Handler{
+		usecase: &mockUsecase,
+	}
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = httptest.NewRequest(http.MethodPost, "/v1/users/login", strings.NewReader(loginSuccess))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+
+	h.Login(ctx)
+	middleware.RenderError(ctx)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"mfa_token":"thisismfatoken"`)
+	assert.NotContains(t, w.Body.String(), `"token":`)
+}
+
+func TestLoginLockout(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCase := []struct {
+		name       string
+		wantError  error
+		code       int
+		retryAfter string
+	}{
+		{
+			name: "Testcase #1: Negative", wantError: &lockout.Error{RetryAfter: 1500 * time.Millisecond}, code: http.StatusTooManyRequests, retryAfter: "2",
+		},
 		{
-			name: "Testcase #1: Positive", body: "Bearer thisistoken", wantError: nil, setContext: "username", code: http.StatusOK,
+			name: "Testcase #2: Negative", wantError: &lockout.Error{Locked: true, RetryAfter: 15 * time.Minute}, code: http.StatusLocked, retryAfter: "900",
 		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := mockUsecase.IUsecase{}
+			mockUsecase.On("Login", mock.Anything, mock.Anything).Return(model.JWT{}, tt.wantError)
+
+			h := &Handler{
+				usecase: &mockUsecase,
+			}
+
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = httptest.NewRequest(http.MethodPost, "/v1/users/login", strings.NewReader(loginSuccess))
+			ctx.Request.Header.Set("Content-Type", "application/json")
+
+			h.Login(ctx)
+			middleware.RenderError(ctx)
+			assert.Equal(t, tt.code, w.Code)
+			assert.Equal(t, tt.retryAfter, w.Header().Get("Retry-After"))
+		})
+	}
+}
+
+func TestLogout(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCase := []testCase{
 		{
-			name: "Testcase #2: Negative", body: "Bearer", wantError: nil, setContext: "username", code: http.StatusUnauthorized,
+			name: "Testcase #1: Positive", wantError: nil, setContext: "session_id", code: http.StatusOK,
 		},
 		{
-			name: "Testcase #3: Negative", body: "Bearer thisistoken", wantError: nil, setContext: "any", code: http.StatusUnauthorized,
+			name: "Testcase #2: Negative", wantError: nil, setContext: "any", code: http.StatusUnauthorized,
 		},
 		{
-			name: "Testcase #4: Negative", body: "Bearer thisistoken", wantError: errFoo, setContext: "username", code: http.StatusInternalServerError,
+			name: "Testcase #3: Negative", wantError: errFoo, setContext: "session_id", code: http.StatusInternalServerError,
 		},
 	}
 	for _, tt := range testCase {
 		t.Run(tt.name, func(t *testing.T) {
 			mockUsecase := mockUsecase.IUsecase{}
-			mockUsecase.On("Logout", mock.Anything, mock.Anything).Return(tt.wantError)
+			mockUsecase.On("Logout", mock.Anything, int64(1), "thisissession").Return(tt.wantError)
 
 			h := &Handler{
 				usecase: &mockUsecase,
@@ -134,10 +218,167 @@ func TestLogout(t *testing.T) {
 			ctx, _ := gin.CreateTestContext(w)
 			ctx.Request = httptest.NewRequest(http.MethodPost, "/v1/users/logout", nil)
 			ctx.Request.Header.Set("Content-Type", "application/json")
-			ctx.Request.Header.Set("Authorization", tt.body)
-			ctx.Set(tt.setContext, "johndoe")
+			ctx.Set("id", int64(1))
+			ctx.Set(tt.setContext, "thisissession")
 
 			h.Logout(ctx)
+			middleware.RenderError(ctx)
+			assert.EqualValues(t, tt.code, w.Code)
+		})
+	}
+}
+
+func TestRefresh(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCase := []testCase{
+		{
+			name: "Testcase #1: Positive", body: refreshSuccess, wantError: nil, code: http.StatusOK,
+		},
+		{
+			name: "Testcase #2: Negative", body: refreshSuccess, wantError: errFoo, code: http.StatusInternalServerError,
+		},
+		{
+			name: "Testcase #3: Negative", body: refreshFail, wantError: errFoo, code: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "Testcase #4: Negative", body: refreshSuccess, wantError: usecase.ErrInvalidRefreshToken, code: http.StatusUnauthorized,
+		},
+		{
+			name: "Testcase #5: Negative", body: refreshSuccess, wantError: usecase.ErrRefreshTokenReused, code: http.StatusUnauthorized,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := mockUsecase.IUsecase{}
+			mockUsecase.On("Refresh", mock.Anything, mock.Anything).Return(model.JWT{}, tt.wantError)
+
+			h := &Handler{
+				usecase: &mockUsecase,
+			}
+
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = httptest.NewRequest(http.MethodPost, "/v1/users/token/refresh", strings.NewReader(tt.body))
+			ctx.Request.Header.Set("Content-Type", "application/json")
+
+			h.Refresh(ctx)
+			middleware.RenderError(ctx)
+			assert.EqualValues(t, tt.code, w.Code)
+		})
+	}
+}
+
+func TestGetSessions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCase := []testCase{
+		{
+			name: "Testcase #1: Positive", wantError: nil, setContext: "session_id", code: http.StatusOK,
+		},
+		{
+			name: "Testcase #2: Negative", wantError: nil, setContext: "any", code: http.StatusUnauthorized,
+		},
+		{
+			name: "Testcase #3: Negative", wantError: errFoo, setContext: "session_id", code: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := mockUsecase.IUsecase{}
+			mockUsecase.On("GetSessions", mock.Anything, int64(1), "thisissession").Return([]model.Session{}, tt.wantError)
+
+			h := &Handler{
+				usecase: &mockUsecase,
+			}
+
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = httptest.NewRequest(http.MethodGet, "/v1/users/me/sessions", nil)
+			ctx.Request.Header.Set("Content-Type", "application/json")
+			ctx.Set("id", int64(1))
+			ctx.Set(tt.setContext, "thisissession")
+
+			h.GetSessions(ctx)
+			middleware.RenderError(ctx)
+			assert.EqualValues(t, tt.code, w.Code)
+		})
+	}
+}
+
+func TestRevokeSession(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCase := []testCase{
+		{
+			name: "Testcase #1: Positive", param: "othersession", wantError: nil, setContext: "session_id", code: http.StatusOK,
+		},
+		{
+			name: "Testcase #2: Negative", param: "othersession", wantError: nil, setContext: "any", code: http.StatusUnauthorized,
+		},
+		{
+			name: "Testcase #3: Negative", param: "othersession", wantError: errFoo, setContext: "session_id", code: http.StatusInternalServerError,
+		},
+		{
+			name: "Testcase #4: Negative", param: "othersession", wantError: session.ErrNotFound, setContext: "session_id", code: http.StatusNotFound,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := mockUsecase.IUsecase{}
+			mockUsecase.On("RevokeSession", mock.Anything, int64(1), tt.param).Return(tt.wantError)
+
+			h := &Handler{
+				usecase: &mockUsecase,
+			}
+
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = httptest.NewRequest(http.MethodDelete, "/v1/users/me/sessions/"+tt.param, nil)
+			ctx.Request.Header.Set("Content-Type", "application/json")
+			ctx.Params = gin.Params{{Key: "session_id", Value: tt.param}}
+			ctx.Set("id", int64(1))
+			ctx.Set(tt.setContext, "thisissession")
+
+			h.RevokeSession(ctx)
+			middleware.RenderError(ctx)
+			assert.EqualValues(t, tt.code, w.Code)
+		})
+	}
+}
+
+func TestRevokeSessions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCase := []testCase{
+		{
+			name: "Testcase #1: Positive", wantError: nil, setContext: "session_id", code: http.StatusOK,
+		},
+		{
+			name: "Testcase #2: Negative", wantError: nil, setContext: "any", code: http.StatusUnauthorized,
+		},
+		{
+			name: "Testcase #3: Negative", wantError: errFoo, setContext: "session_id", code: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := mockUsecase.IUsecase{}
+			mockUsecase.On("RevokeSessions", mock.Anything, int64(1)).Return(tt.wantError)
+
+			h := &Handler{
+				usecase: &mockUsecase,
+			}
+
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = httptest.NewRequest(http.MethodDelete, "/v1/users/me/sessions", nil)
+			ctx.Request.Header.Set("Content-Type", "application/json")
+			ctx.Set("id", int64(1))
+			ctx.Set(tt.setContext, "thisissession")
+
+			h.RevokeSessions(ctx)
+			middleware.RenderError(ctx)
 			assert.EqualValues(t, tt.code, w.Code)
 		})
 	}
@@ -157,11 +398,17 @@ func TestGetAll(t *testing.T) {
 		{
 			name: "Testcase #3: Negative", queryParam: "?page=one", wantError: errFoo, code: http.StatusUnprocessableEntity,
 		},
+		{
+			name: "Testcase #4: Negative", queryParam: "?sort=password", wantError: fmt.Errorf("%w: password", param.ErrInvalidSort), code: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "Testcase #5: Negative", queryParam: "?cursor=e30.", wantError: param.ErrCursorDisabled, code: http.StatusBadRequest,
+		},
 	}
 	for _, tt := range testCase {
 		t.Run(tt.name, func(t *testing.T) {
 			mockUsecase := mockUsecase.IUsecase{}
-			mockUsecase.On("GetAll", mock.Anything, mock.Anything).Return([]model.User{}, expectedCount, tt.wantError)
+			mockUsecase.On("GetAll", mock.Anything, mock.Anything).Return([]model.User{}, param.Page{Total: &expectedCount}, tt.wantError)
 
 			h := &Handler{
 				usecase: &mockUsecase,
@@ -173,6 +420,7 @@ func TestGetAll(t *testing.T) {
 			ctx.Request.Header.Set("Content-Type", "application/json")
 
 			h.GetAll(ctx)
+			middleware.RenderError(ctx)
 			assert.Equal(t, tt.code, w.Code)
 		})
 	}
@@ -211,6 +459,1076 @@ func TestGetByID(t *testing.T) {
 			ctx.Params = gin.Params{{Key: "id", Value: tt.param}}
 
 			h.GetByID(ctx)
+			middleware.RenderError(ctx)
+			assert.Equal(t, tt.code, w.Code)
+		})
+	}
+}
+
+func TestJWKS(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockUsecase := mockUsecase.IUsecase{}
+	mockUsecase.On("JWKS", mock.Anything).Return(pJwt.JWKS{Keys: []pJwt.JWK{{Kty: "OKP", Kid: "ed-1", Use: "sig", Alg: "EdDSA"}}})
+
+	h := &Handler{
+		usecase: &mockUsecase,
+	}
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil)
+
+	h.JWKS(ctx)
+	middleware.RenderError(ctx)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "public, max-age=300", w.Header().Get("Cache-Control"))
+	assert.JSONEq(t, `{"keys":[{"kty":"OKP","kid":"ed-1","use":"sig","alg":"EdDSA"}]}`, w.Body.String())
+}
+
+func TestGetRoles(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCase := []testCase{
+		{
+			name: "Testcase #1: Positive", param: "1", wantError: nil, code: http.StatusOK,
+		},
+		{
+			name: "Testcase #2: Negative", param: "one", wantError: nil, code: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "Testcase #3: Negative", param: "1", wantError: sql.ErrNoRows, code: http.StatusNotFound,
+		},
+		{
+			name: "Testcase #4: Negative", param: "1", wantError: errFoo, code: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := mockUsecase.IUsecase{}
+			mockUsecase.On("GetRoles", mock.Anything, int64(1)).Return([]string{rbac.MEMBER}, tt.wantError)
+
+			h := &Handler{
+				usecase: &mockUsecase,
+			}
+
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = httptest.NewRequest(http.MethodGet, "/v1/admin/users/"+tt.param+"/roles", nil)
+			ctx.Params = gin.Params{{Key: "id", Value: tt.param}}
+
+			h.GetRoles(ctx)
+			middleware.RenderError(ctx)
+			assert.Equal(t, tt.code, w.Code)
+		})
+	}
+}
+
+func TestGrantRole(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCase := []testCase{
+		{
+			name: "Testcase #1: Positive", param: "1", body: `{"role":"admin"}`, wantError: nil, code: http.StatusOK,
+		},
+		{
+			name: "Testcase #2: Negative", param: "one", body: `{"role":"admin"}`, wantError: nil, code: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "Testcase #3: Negative", param: "1", body: `{"role":""}`, wantError: nil, code: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "Testcase #4: Negative", param: "1", body: `{"role":"admin"}`, wantError: rbac.ErrUnknownRole, code: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "Testcase #5: Negative", param: "1", body: `{"role":"admin"}`, wantError: sql.ErrNoRows, code: http.StatusNotFound,
+		},
+		{
+			name: "Testcase #6: Negative", param: "1", body: `{"role":"admin"}`, wantError: errFoo, code: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := mockUsecase.IUsecase{}
+			mockUsecase.On("GrantRole", mock.Anything, int64(1), rbac.ADMIN).Return([]string{rbac.ADMIN}, tt.wantError)
+
+			h := &Handler{
+				usecase: &mockUsecase,
+			}
+
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = httptest.NewRequest(http.MethodPost, "/v1/admin/users/"+tt.param+"/roles", strings.NewReader(tt.body))
+			ctx.Request.Header.Set("Content-Type", "application/json")
+			ctx.Params = gin.Params{{Key: "id", Value: tt.param}}
+
+			h.GrantRole(ctx)
+			middleware.RenderError(ctx)
+			assert.Equal(t, tt.code, w.Code)
+		})
+	}
+}
+
+func TestRevokeRole(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCase := []testCase{
+		{
+			name: "Testcase #1: Positive", param: "1", wantError: nil, code: http.StatusOK,
+		},
+		{
+			name: "Testcase #2: Negative", param: "one", wantError: nil, code: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "Testcase #3: Negative", param: "1", wantError: rbac.ErrUnknownRole, code: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "Testcase #4: Negative", param: "1", wantError: errFoo, code: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := mockUsecase.IUsecase{}
+			mockUsecase.On("RevokeRole", mock.Anything, int64(1), rbac.ADMIN).Return([]string{}, tt.wantError)
+
+			h := &Handler{
+				usecase: &mockUsecase,
+			}
+
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = httptest.NewRequest(http.MethodDelete, "/v1/admin/users/"+tt.param+"/roles/admin", nil)
+			ctx.Params = gin.Params{{Key: "id", Value: tt.param}, {Key: "role", Value: rbac.ADMIN}}
+
+			h.RevokeRole(ctx)
+			middleware.RenderError(ctx)
+			assert.Equal(t, tt.code, w.Code)
+		})
+	}
+}
+
+func TestGetMember(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCase := []testCase{
+		{
+			name: "Testcase #1: Positive", wantError: nil, setContext: "session_id", code: http.StatusOK,
+		},
+		{
+			name: "Testcase #2: Negative", wantError: nil, setContext: "any", code: http.StatusUnauthorized,
+		},
+		{
+			name: "Testcase #3: Negative", wantError: sql.ErrNoRows, setContext: "session_id", code: http.StatusNotFound,
+		},
+		{
+			name: "Testcase #4: Negative", wantError: errFoo, setContext: "session_id", code: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := mockUsecase.IUsecase{}
+			mockUsecase.On("GetMember", mock.Anything, int64(1)).Return(model.Member{}, tt.wantError)
+
+			h := &Handler{
+				usecase: &mockUsecase,
+			}
+
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = httptest.NewRequest(http.MethodGet, "/v1/users/me/member", nil)
+			ctx.Set("id", int64(1))
+			ctx.Set(tt.setContext, "thisissession")
+
+			h.GetMember(ctx)
+			middleware.RenderError(ctx)
+			assert.Equal(t, tt.code, w.Code)
+		})
+	}
+}
+
+func TestClaimMember(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCase := []testCase{
+		{
+			name: "Testcase #1: Positive", wantError: nil, setContext: "session_id", code: http.StatusOK,
+		},
+		{
+			name: "Testcase #2: Negative", wantError: nil, setContext: "any", code: http.StatusUnauthorized,
+		},
+		{
+			name: "Testcase #3: Negative", wantError: usecase.ErrEmailNotVerified, setContext: "session_id", code: http.StatusForbidden,
+		},
+		{
+			name: "Testcase #4: Negative", wantError: sql.ErrNoRows, setContext: "session_id", code: http.StatusNotFound,
+		},
+		{
+			name: "Testcase #5: Negative", wantError: rbac.ErrForbidden, setContext: "session_id", code: http.StatusForbidden,
+		},
+		{
+			name: "Testcase #6: Negative", wantError: usecase.ErrMemberClaimed, setContext: "session_id", code: http.StatusConflict,
+		},
+		{
+			name: "Testcase #7: Negative", wantError: errFoo, setContext: "session_id", code: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := mockUsecase.IUsecase{}
+			mockUsecase.On("ClaimMember", mock.Anything, int64(1)).Return(model.Member{}, tt.wantError)
+
+			h := &Handler{
+				usecase: &mockUsecase,
+			}
+
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = httptest.NewRequest(http.MethodPost, "/v1/users/me/member", nil)
+			ctx.Set("id", int64(1))
+			ctx.Set(tt.setContext, "thisissession")
+
+			h.ClaimMember(ctx)
+			middleware.RenderError(ctx)
+			assert.Equal(t, tt.code, w.Code)
+		})
+	}
+}
+
+func TestForgotPassword(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCase := []testCase{
+		{
+			name: "Testcase #1: Positive", body: `{"email":"john@test.com"}`, wantError: nil, code: http.StatusOK,
+		},
+		{
+			name: "Testcase #2: Negative", body: `{"email":"john"}`, wantError: nil, code: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "Testcase #3: Negative", body: `{"email":"john@test.com"}`, wantError: errFoo, code: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := mockUsecase.IUsecase{}
+			mockUsecase.On("ForgotPassword", mock.Anything, model.ForgotPassword{Email: "john@test.com"}).Return(tt.wantError)
+
+			h := &Handler{
+				usecase: &mockUsecase,
+			}
+
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = httptest.NewRequest(http.MethodPost, "/v1/users/password/forgot", strings.NewReader(tt.body))
+			ctx.Request.Header.Set("Content-Type", "application/json")
+
+			h.ForgotPassword(ctx)
+			middleware.RenderError(ctx)
+			assert.Equal(t, tt.code, w.Code)
+		})
+	}
+}
+
+func TestResetPassword(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCase := []testCase{
+		{
+			name: "Testcase #1: Positive", body: `{"token":"thisisresettoken","password":"newpassword"}`, wantError: nil, code: http.StatusOK,
+		},
+		{
+			name: "Testcase #2: Negative", body: `{"token":"","password":"newpassword"}`, wantError: nil, code: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "Testcase #3: Negative", body: `{"token":"thisisresettoken","password":"newpassword"}`, wantError: usecase.ErrInvalidResetToken, code: http.StatusBadRequest,
+		},
+		{
+			name: "Testcase #4: Negative", body: `{"token":"thisisresettoken","password":"newpassword"}`, wantError: errFoo, code: http.StatusInternalServerError,
+		},
+		{
+			name: "Testcase #5: Negative", body: `{"token":"thisisresettoken","password":"newpassword"}`, wantError: &password.Error{}, code: http.StatusUnprocessableEntity,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := mockUsecase.IUsecase{}
+			mockUsecase.On("ResetPassword", mock.Anything, model.ResetPassword{Token: "thisisresettoken", Password: "newpassword"}).Return(tt.wantError)
+
+			h := &Handler{
+				usecase: &mockUsecase,
+			}
+
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = httptest.NewRequest(http.MethodPost, "/v1/users/password/reset", strings.NewReader(tt.body))
+			ctx.Request.Header.Set("Content-Type", "application/json")
+
+			h.ResetPassword(ctx)
+			middleware.RenderError(ctx)
+			assert.Equal(t, tt.code, w.Code)
+		})
+	}
+}
+
+func TestVerifyEmail(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCase := []testCase{
+		{
+			name: "Testcase #1: Positive", queryParam: "?token=thisisverifytoken", wantError: nil, code: http.StatusOK,
+		},
+		{
+			name: "Testcase #2: Negative", queryParam: "", wantError: nil, code: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "Testcase #3: Negative", queryParam: "?token=thisisverifytoken", wantError: usecase.ErrInvalidVerifyToken, code: http.StatusBadRequest,
+		},
+		{
+			name: "Testcase #4: Negative", queryParam: "?token=thisisverifytoken", wantError: errFoo, code: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := mockUsecase.IUsecase{}
+			mockUsecase.On("VerifyEmail", mock.Anything, "thisisverifytoken").Return(tt.wantError)
+
+			h := &Handler{
+				usecase: &mockUsecase,
+			}
+
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = httptest.NewRequest(http.MethodGet, "/v1/users/verify"+tt.queryParam, nil)
+
+			h.VerifyEmail(ctx)
+			middleware.RenderError(ctx)
+			assert.Equal(t, tt.code, w.Code)
+		})
+	}
+}
+
+func TestResendVerification(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCase := []testCase{
+		{
+			name: "Testcase #1: Positive", body: `{"email":"john@test.com"}`, wantError: nil, code: http.StatusOK,
+		},
+		{
+			name: "Testcase #2: Negative", body: `{"email":""}`, wantError: nil, code: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "Testcase #3: Negative", body: `{"email":"john@test.com"}`, wantError: usecase.ErrResendTooSoon, code: http.StatusTooManyRequests,
+		},
+		{
+			name: "Testcase #4: Negative", body: `{"email":"john@test.com"}`, wantError: errFoo, code: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := mockUsecase.IUsecase{}
+			mockUsecase.On("ResendVerification", mock.Anything, model.ResendVerification{Email: "john@test.com"}).Return(tt.wantError)
+
+			h := &Handler{
+				usecase: &mockUsecase,
+			}
+
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = httptest.NewRequest(http.MethodPost, "/v1/users/verify/resend", strings.NewReader(tt.body))
+			ctx.Request.Header.Set("Content-Type", "application/json")
+
+			h.ResendVerification(ctx)
+			middleware.RenderError(ctx)
+			assert.Equal(t, tt.code, w.Code)
+		})
+	}
+}
+
+func TestLoginMFA(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCase := []testCase{
+		{
+			name: "Testcase #1: Positive", body: `{"mfa_token":"thisismfatoken","code":"123456"}`, wantError: nil, code: http.StatusOK,
+		},
+		{
+			name: "Testcase #2: Negative", body: `{"mfa_token":"thisismfatoken","code":""}`, wantError: nil, code: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "Testcase #3: Negative", body: `{"mfa_token":"thisismfatoken","code":"123456"}`, wantError: usecase.ErrInvalidMFAToken, code: http.StatusUnauthorized,
+		},
+		{
+			name: "Testcase #4: Negative", body: `{"mfa_token":"thisismfatoken","code":"123456"}`, wantError: usecase.ErrInvalidMFACode, code: http.StatusUnauthorized,
+		},
+		{
+			name: "Testcase #5: Negative", body: `{"mfa_token":"thisismfatoken","code":"123456"}`, wantError: errFoo, code: http.StatusInternalServerError,
+		},
+		{
+			name: "Testcase #6: Negative", body: `{"mfa_token":"thisismfatoken","code":"123456"}`, wantError: &lockout.Error{RetryAfter: time.Second}, code: http.StatusTooManyRequests,
+		},
+		{
+			name: "Testcase #7: Negative", body: `{"mfa_token":"thisismfatoken","code":"123456"}`, wantError: &lockout.Error{Locked: true, RetryAfter: time.Minute}, code: http.StatusLocked,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := mockUsecase.IUsecase{}
+			mockUsecase.On("LoginMFA", mock.Anything, mock.Anything).Return(model.JWT{}, tt.wantError)
+
+			h := &Handler{
+				usecase: &mockUsecase,
+			}
+
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = httptest.NewRequest(http.MethodPost, "/v1/users/login/mfa", strings.NewReader(tt.body))
+			ctx.Request.Header.Set("Content-Type", "application/json")
+
+			h.LoginMFA(ctx)
+			middleware.RenderError(ctx)
+			assert.Equal(t, tt.code, w.Code)
+		})
+	}
+}
+
+func TestEnrollMFA(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCase := []testCase{
+		{
+			name: "Testcase #1: Positive", wantError: nil, setContext: "session_id", code: http.StatusOK,
+		},
+		{
+			name: "Testcase #2: Negative", wantError: nil, setContext: "any", code: http.StatusUnauthorized,
+		},
+		{
+			name: "Testcase #3: Negative", wantError: usecase.ErrMFAEnabled, setContext: "session_id", code: http.StatusConflict,
+		},
+		{
+			name: "Testcase #4: Negative", wantError: errFoo, setContext: "session_id", code: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := mockUsecase.IUsecase{}
+			mockUsecase.On("EnrollMFA", mock.Anything, int64(1)).Return(model.MFAEnrollment{}, tt.wantError)
+
+			h := &Handler{
+				usecase: &mockUsecase,
+			}
+
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = httptest.NewRequest(http.MethodPost, "/v1/users/me/mfa", nil)
+			ctx.Set("id", int64(1))
+			ctx.Set(tt.setContext, "thisissession")
+
+			h.EnrollMFA(ctx)
+			middleware.RenderError(ctx)
+			assert.Equal(t, tt.code, w.Code)
+		})
+	}
+}
+
+func TestConfirmMFA(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCase := []testCase{
+		{
+			name: "Testcase #1: Positive", body: `{"code":"123456"}`, wantError: nil, setContext: "session_id", code: http.StatusOK,
+		},
+		{
+			name: "Testcase #2: Negative", body: `{"code":"123456"}`, wantError: nil, setContext: "any", code: http.StatusUnauthorized,
+		},
+		{
+			name: "Testcase #3: Negative", body: `{"code":""}`, wantError: nil, setContext: "session_id", code: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "Testcase #4: Negative", body: `{"code":"123456"}`, wantError: sql.ErrNoRows, setContext: "session_id", code: http.StatusNotFound,
+		},
+		{
+			name: "Testcase #5: Negative", body: `{"code":"123456"}`, wantError: usecase.ErrMFAEnabled, setContext: "session_id", code: http.StatusConflict,
+		},
+		{
+			name: "Testcase #6: Negative", body: `{"code":"123456"}`, wantError: usecase.ErrInvalidMFACode, setContext: "session_id", code: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "Testcase #7: Negative", body: `{"code":"123456"}`, wantError: errFoo, setContext: "session_id", code: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := mockUsecase.IUsecase{}
+			mockUsecase.On("ConfirmMFA", mock.Anything, int64(1), model.MFACode{Code: "123456"}).Return(model.RecoveryCodes{}, tt.wantError)
+
+			h := &Handler{
+				usecase: &mockUsecase,
+			}
+
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = httptest.NewRequest(http.MethodPost, "/v1/users/me/mfa/verify", strings.NewReader(tt.body))
+			ctx.Request.Header.Set("Content-Type", "application/json")
+			ctx.Set("id", int64(1))
+			ctx.Set(tt.setContext, "thisissession")
+
+			h.ConfirmMFA(ctx)
+			middleware.RenderError(ctx)
+			assert.Equal(t, tt.code, w.Code)
+		})
+	}
+}
+
+func TestDisableMFA(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	body := `{"password":"password","code":"123456"}`
+	testCase := []testCase{
+		{
+			name: "Testcase #1: Positive", body: body, wantError: nil, setContext: "session_id", code: http.StatusOK,
+		},
+		{
+			name: "Testcase #2: Negative", body: body, wantError: nil, setContext: "any", code: http.StatusUnauthorized,
+		},
+		{
+			name: "Testcase #3: Negative", body: `{"password":"password"}`, wantError: nil, setContext: "session_id", code: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "Testcase #4: Negative", body: body, wantError: usecase.ErrInvalidCredentials, setContext: "session_id", code: http.StatusForbidden,
+		},
+		{
+			name: "Testcase #5: Negative", body: body, wantError: pErrors.ErrNotFound, setContext: "session_id", code: http.StatusNotFound,
+		},
+		{
+			name: "Testcase #6: Negative", body: body, wantError: errFoo, setContext: "session_id", code: http.StatusInternalServerError,
+		},
+		{
+			name: "Testcase #7: Negative", body: body, wantError: &lockout.Error{RetryAfter: time.Second}, setContext: "session_id", code: http.StatusTooManyRequests,
+		},
+		{
+			name: "Testcase #8: Negative", body: body, wantError: &lockout.Error{Locked: true, RetryAfter: time.Minute}, setContext: "session_id", code: http.StatusLocked,
+		},
+		{
+			name: "Testcase #9: Negative", body: body, wantError: usecase.ErrInvalidMFACode, setContext: "session_id", code: http.StatusForbidden,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := mockUsecase.IUsecase{}
+			mockUsecase.On("DisableMFA", mock.Anything, int64(1), model.DisableMFA{Password: "password", Code: "123456"}).Return(tt.wantError)
+
+			h := &Handler{
+				usecase: &mockUsecase,
+			}
+
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = httptest.NewRequest(http.MethodDelete, "/v1/users/me/mfa", strings.NewReader(tt.body))
+			ctx.Request.Header.Set("Content-Type", "application/json")
+			ctx.Set("id", int64(1))
+			ctx.Set(tt.setContext, "thisissession")
+
+			h.DisableMFA(ctx)
+			middleware.RenderError(ctx)
+			assert.Equal(t, tt.code, w.Code)
+		})
+	}
+}
+
+func TestUnlock(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCase := []testCase{
+		{
+			name: "Testcase #1: Positive", param: "1", wantError: nil, code: http.StatusOK,
+		},
+		{
+			name: "Testcase #2: Negative", param: "1", wantError: errFoo, code: http.StatusInternalServerError,
+		},
+		{
+			name: "Testcase #3: Negative", param: "one", wantError: nil, code: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "Testcase #4: Negative", param: "1", wantError: sql.ErrNoRows, code: http.StatusNotFound,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := mockUsecase.IUsecase{}
+			mockUsecase.On("Unlock", mock.Anything, int64(1)).Return(tt.wantError)
+
+			h := &Handler{
+				usecase: &mockUsecase,
+			}
+
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = httptest.NewRequest(http.MethodDelete, "/v1/users/"+tt.param+"/lock", nil)
+			ctx.Params = gin.Params{{Key: "id", Value: tt.param}}
+
+			h.Unlock(ctx)
+			middleware.RenderError(ctx)
+			assert.Equal(t, tt.code, w.Code)
+		})
+	}
+}
+
+func TestImpersonate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCase := []testCase{
+		{
+			name: "Testcase #1: Positive", param: "1", wantError: nil, code: http.StatusOK,
+		},
+		{
+			name: "Testcase #2: Negative", param: "1", wantError: errFoo, code: http.StatusInternalServerError,
+		},
+		{
+			name: "Testcase #3: Negative", param: "one", wantError: nil, code: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "Testcase #4: Negative", param: "1", wantError: sql.ErrNoRows, code: http.StatusNotFound,
+		},
+		{
+			name: "Testcase #5: Negative", param: "1", wantError: rbac.ErrForbidden, code: http.StatusForbidden,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := mockUsecase.IUsecase{}
+			mockUsecase.On("Impersonate", mock.Anything, mock.MatchedBy(func(i model.Impersonate) bool {
+				return i.UserID == 1
+			})).Return(model.JWT{Token: "thisistoken", Expired: "15 Minute"}, tt.wantError)
+
+			h := &Handler{
+				usecase: &mockUsecase,
+			}
+
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = httptest.NewRequest(http.MethodPost, "/v1/admin/users/"+tt.param+"/impersonate", nil)
+			ctx.Params = gin.Params{{Key: "id", Value: tt.param}}
+
+			h.Impersonate(ctx)
+			middleware.RenderError(ctx)
+			assert.Equal(t, tt.code, w.Code)
+			if tt.code == http.StatusOK {
+				var body struct {
+					Result model.JWT `json:"result"`
+				}
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+				assert.Equal(t, "thisistoken", body.Result.Token)
+			}
+		})
+	}
+}
+
+func TestOIDCLogin(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCase := []testCase{
+		{
+			name: "Testcase #1: Positive", wantError: nil, code: http.StatusFound,
+		},
+		{
+			name: "Testcase #2: Negative", wantError: usecase.ErrOIDCDisabled, code: http.StatusNotFound,
+		},
+		{
+			name: "Testcase #3: Negative", wantError: errFoo, code: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := mockUsecase.IUsecase{}
+			mockUsecase.On("OIDCLogin", mock.Anything).Return("https://idp.test/authorize?state=thisisstate", tt.wantError)
+
+			h := &Handler{
+				usecase: &mockUsecase,
+			}
+
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = httptest.NewRequest(http.MethodGet, "/v1/users/oidc/login", nil)
+
+			h.OIDCLogin(ctx)
+			middleware.RenderError(ctx)
+			assert.Equal(t, tt.code, w.Code)
+			if tt.wantError == nil {
+				assert.Equal(t, "https://idp.test/authorize?state=thisisstate", w.Header().Get("Location"))
+			}
+		})
+	}
+}
+
+func TestOIDCCallback(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	callbackParam := "?code=thisiscode&state=thisisstate"
+	testCase := []testCase{
+		{
+			name: "Testcase #1: Positive", queryParam: callbackParam, wantError: nil, code: http.StatusOK,
+		},
+		{
+			name: "Testcase #2: Negative", queryParam: "?error=access_denied&state=thisisstate", wantError: nil, code: http.StatusUnauthorized,
+		},
+		{
+			name: "Testcase #3: Negative", queryParam: "?state=thisisstate", wantError: nil, code: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "Testcase #4: Negative", queryParam: callbackParam, wantError: usecase.ErrInvalidOIDCState, code: http.StatusBadRequest,
+		},
+		{
+			name: "Testcase #5: Negative", queryParam: callbackParam, wantError: oidc.ErrNonceMismatch, code: http.StatusUnauthorized,
+		},
+		{
+			name: "Testcase #6: Negative", queryParam: callbackParam, wantError: fmt.Errorf("%w: status 400", oidc.ErrTokenExchange), code: http.StatusUnauthorized,
+		},
+		{
+			name: "Testcase #7: Negative", queryParam: callbackParam, wantError: usecase.ErrOIDCAccountExists, code: http.StatusConflict,
+		},
+		{
+			name: "Testcase #8: Negative", queryParam: callbackParam, wantError: usecase.ErrOIDCEmailMissing, code: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "Testcase #9: Negative", queryParam: callbackParam, wantError: usecase.ErrOIDCDisabled, code: http.StatusNotFound,
+		},
+		{
+			name: "Testcase #10: Negative", queryParam: callbackParam, wantError: errFoo, code: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := mockUsecase.IUsecase{}
+			mockUsecase.On("OIDCCallback", mock.Anything, mock.MatchedBy(func(c model.OIDCCallback) bool {
+				return c.Code == "thisiscode" && c.State == "thisisstate"
+			})).Return(model.JWT{Token: "thisistoken"}, tt.wantError)
+
+			h := &Handler{
+				usecase: &mockUsecase,
+			}
+
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = httptest.NewRequest(http.MethodGet, "/v1/users/oidc/callback"+tt.queryParam, nil)
+
+			h.OIDCCallback(ctx)
+			middleware.RenderError(ctx)
+			assert.Equal(t, tt.code, w.Code)
+		})
+	}
+}
+
+func TestGetMe(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCase := []testCase{
+		{
+			name: "Testcase #1: Positive", wantError: nil, setContext: "session_id", code: http.StatusOK,
+		},
+		{
+			name: "Testcase #2: Negative", wantError: nil, setContext: "any", code: http.StatusUnauthorized,
+		},
+		{
+			name: "Testcase #3: Negative", wantError: sql.ErrNoRows, setContext: "session_id", code: http.StatusNotFound,
+		},
+		{
+			name: "Testcase #4: Negative", wantError: errFoo, setContext: "session_id", code: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := mockUsecase.IUsecase{}
+			mockUsecase.On("GetByID", mock.Anything, int64(1)).Return(model.User{ID: 1}, tt.wantError)
+
+			h := &Handler{
+				usecase: &mockUsecase,
+			}
+
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = httptest.NewRequest(http.MethodGet, "/v1/users/me", nil)
+			ctx.Set("id", int64(1))
+			ctx.Set(tt.setContext, "thisissession")
+
+			h.GetMe(ctx)
+			middleware.RenderError(ctx)
+			assert.Equal(t, tt.code, w.Code)
+		})
+	}
+}
+
+func TestUpdateMe(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCase := []testCase{
+		{
+			name: "Testcase #1: Positive", body: `{"username":"janedoe"}`, wantError: nil, setContext: "session_id", code: http.StatusOK,
+		},
+		{
+			name: "Testcase #2: Negative", body: `{"username":"janedoe"}`, wantError: nil, setContext: "any", code: http.StatusUnauthorized,
+		},
+		{
+			name: "Testcase #3: Negative", body: `{"username":""}`, wantError: nil, setContext: "session_id", code: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "Testcase #4: Negative", body: `{"username":"janedoe"}`, wantError: usecase.ErrUsernameExists, setContext: "session_id", code: http.StatusConflict,
+		},
+		{
+			name: "Testcase #5: Negative", body: `{"username":"janedoe"}`, wantError: sql.ErrNoRows, setContext: "session_id", code: http.StatusNotFound,
+		},
+		{
+			name: "Testcase #6: Negative", body: `{"username":"janedoe"}`, wantError: errFoo, setContext: "session_id", code: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := mockUsecase.IUsecase{}
+			mockUsecase.On("UpdateMe", mock.Anything, int64(1), model.UpdateUser{Username: "janedoe"}).Return(model.User{ID: 1, Username: "janedoe"}, tt.wantError)
+
+			h := &Handler{
+				usecase: &mockUsecase,
+			}
+
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = httptest.NewRequest(http.MethodPatch, "/v1/users/me", strings.NewReader(tt.body))
+			ctx.Request.Header.Set("Content-Type", "application/json")
+			ctx.Set("id", int64(1))
+			ctx.Set(tt.setContext, "thisissession")
+
+			h.UpdateMe(ctx)
+			middleware.RenderError(ctx)
+			assert.Equal(t, tt.code, w.Code)
+		})
+	}
+}
+
+func TestChangePassword(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	body := `{"current_password":"password","new_password":"n3w-Password"}`
+	testCase := []testCase{
+		{
+			name: "Testcase #1: Positive", body: body, wantError: nil, setContext: "session_id", code: http.StatusOK,
+		},
+		{
+			name: "Testcase #2: Negative", body: body, wantError: nil, setContext: "any", code: http.StatusUnauthorized,
+		},
+		{
+			name: "Testcase #3: Negative", body: `{"current_password":"password"}`, wantError: nil, setContext: "session_id", code: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "Testcase #4: Negative", body: body, wantError: usecase.ErrInvalidCredentials, setContext: "session_id", code: http.StatusForbidden,
+		},
+		{
+			name: "Testcase #5: Negative", body: body, wantError: &password.Error{}, setContext: "session_id", code: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "Testcase #6: Negative", body: body, wantError: errFoo, setContext: "session_id", code: http.StatusInternalServerError,
+		},
+		{
+			name: "Testcase #7: Negative", body: body, wantError: &lockout.Error{RetryAfter: time.Second}, setContext: "session_id", code: http.StatusTooManyRequests,
+		},
+		{
+			name: "Testcase #8: Negative", body: body, wantError: &lockout.Error{Locked: true, RetryAfter: time.Minute}, setContext: "session_id", code: http.StatusLocked,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := mockUsecase.IUsecase{}
+			mockUsecase.On("ChangePassword", mock.Anything, int64(1), "thisissession",
+				model.ChangePassword{CurrentPassword: "password", NewPassword: "n3w-Password"}).Return(tt.wantError)
+
+			h := &Handler{
+				usecase: &mockUsecase,
+			}
+
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = httptest.NewRequest(http.MethodPut, "/v1/users/me/password", strings.NewReader(tt.body))
+			ctx.Request.Header.Set("Content-Type", "application/json")
+			ctx.Set("id", int64(1))
+			ctx.Set(tt.setContext, "thisissession")
+
+			h.ChangePassword(ctx)
+			middleware.RenderError(ctx)
+			assert.Equal(t, tt.code, w.Code)
+		})
+	}
+}
+
+func TestChangeEmail(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	body := `{"email":"jane@test.com","password":"password"}`
+	testCase := []testCase{
+		{
+			name: "Testcase #1: Positive", body: body, wantError: nil, setContext: "session_id", code: http.StatusOK,
+		},
+		{
+			name: "Testcase #2: Negative", body: body, wantError: nil, setContext: "any", code: http.StatusUnauthorized,
+		},
+		{
+			name: "Testcase #3: Negative", body: `{"email":"jane","password":"password"}`, wantError: nil, setContext: "session_id", code: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "Testcase #4: Negative", body: body, wantError: usecase.ErrInvalidCredentials, setContext: "session_id", code: http.StatusForbidden,
+		},
+		{
+			name: "Testcase #5: Negative", body: body, wantError: usecase.ErrEmailExists, setContext: "session_id", code: http.StatusConflict,
+		},
+		{
+			name: "Testcase #6: Negative", body: body, wantError: errFoo, setContext: "session_id", code: http.StatusInternalServerError,
+		},
+		{
+			name: "Testcase #7: Negative", body: body, wantError: &lockout.Error{RetryAfter: time.Second}, setContext: "session_id", code: http.StatusTooManyRequests,
+		},
+		{
+			name: "Testcase #8: Negative", body: body, wantError: &lockout.Error{Locked: true, RetryAfter: time.Minute}, setContext: "session_id", code: http.StatusLocked,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := mockUsecase.IUsecase{}
+			mockUsecase.On("ChangeEmail", mock.Anything, int64(1), "thisissession", model.ChangeEmail{Email: "jane@test.com", Password: "password"}).Return(tt.wantError)
+
+			h := &Handler{
+				usecase: &mockUsecase,
+			}
+
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = httptest.NewRequest(http.MethodPut, "/v1/users/me/email", strings.NewReader(tt.body))
+			ctx.Request.Header.Set("Content-Type", "application/json")
+			ctx.Set("id", int64(1))
+			ctx.Set(tt.setContext, "thisissession")
+
+			h.ChangeEmail(ctx)
+			middleware.RenderError(ctx)
+			assert.Equal(t, tt.code, w.Code)
+		})
+	}
+}
+
+func TestDeleteMe(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCase := []testCase{
+		{
+			name: "Testcase #1: Positive", body: `{"password":"password"}`, wantError: nil, setContext: "session_id", code: http.StatusOK,
+		},
+		{
+			name: "Testcase #2: Negative", body: `{"password":"password"}`, wantError: nil, setContext: "any", code: http.StatusUnauthorized,
+		},
+		{
+			name: "Testcase #3: Negative", body: `{"password":""}`, wantError: nil, setContext: "session_id", code: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "Testcase #4: Negative", body: `{"password":"password"}`, wantError: usecase.ErrInvalidCredentials, setContext: "session_id", code: http.StatusForbidden,
+		},
+		{
+			name: "Testcase #5: Negative", body: `{"password":"password"}`, wantError: errFoo, setContext: "session_id", code: http.StatusInternalServerError,
+		},
+		{
+			name: "Testcase #6: Negative", body: `{"password":"password"}`, wantError: &lockout.Error{RetryAfter: time.Second}, setContext: "session_id", code: http.StatusTooManyRequests,
+		},
+		{
+			name: "Testcase #7: Negative", body: `{"password":"password"}`, wantError: &lockout.Error{Locked: true, RetryAfter: time.Minute}, setContext: "session_id", code: http.StatusLocked,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := mockUsecase.IUsecase{}
+			mockUsecase.On("DeleteMe", mock.Anything, int64(1), model.DeleteUser{Password: "password"}).Return(tt.wantError)
+
+			h := &Handler{
+				usecase: &mockUsecase,
+			}
+
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = httptest.NewRequest(http.MethodDelete, "/v1/users/me", strings.NewReader(tt.body))
+			ctx.Request.Header.Set("Content-Type", "application/json")
+			ctx.Set("id", int64(1))
+			ctx.Set(tt.setContext, "thisissession")
+
+			h.DeleteMe(ctx)
+			middleware.RenderError(ctx)
+			assert.Equal(t, tt.code, w.Code)
+		})
+	}
+}
+
+func TestIntrospect(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCase := []testCase{
+		{
+			name: "Testcase #1: Positive", body: "token=thisistoken", wantError: nil, code: http.StatusOK,
+		},
+		{
+			name: "Testcase #2: Negative", body: "token_type_hint=access_token", wantError: nil, code: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "Testcase #3: Negative", body: "token=thisistoken", wantError: errFoo, code: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := mockUsecase.IUsecase{}
+			mockUsecase.On("Introspect", mock.Anything, model.TokenRequest{Token: "thisistoken"}).Return(model.Introspection{Active: true, Subject: "johndoe"}, tt.wantError)
+
+			h := &Handler{
+				usecase: &mockUsecase,
+			}
+
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = httptest.NewRequest(http.MethodPost, "/v1/oauth/introspect", strings.NewReader(tt.body))
+			ctx.Request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+			h.Introspect(ctx)
+			middleware.RenderError(ctx)
+			assert.Equal(t, tt.code, w.Code)
+			if tt.code == http.StatusOK {
+				assert.JSONEq(t, `{"active":true,"sub":"johndoe"}`, w.Body.String())
+				assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+			}
+		})
+	}
+}
+
+func TestRevokeToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCase := []testCase{
+		{
+			name: "Testcase #1: Positive", body: "token=thisistoken&token_type_hint=refresh_token", wantError: nil, code: http.StatusOK,
+		},
+		{
+			name: "Testcase #2: Negative", body: "token_type_hint=refresh_token", wantError: nil, code: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "Testcase #3: Negative", body: "token=thisistoken&token_type_hint=refresh_token", wantError: errFoo, code: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := mockUsecase.IUsecase{}
+			mockUsecase.On("RevokeToken", mock.Anything, model.TokenRequest{Token: "thisistoken", TokenTypeHint: "refresh_token"}).Return(tt.wantError)
+
+			h := &Handler{
+				usecase: &mockUsecase,
+			}
+
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = httptest.NewRequest(http.MethodPost, "/v1/oauth/revoke", strings.NewReader(tt.body))
+			ctx.Request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+			h.RevokeToken(ctx)
+			middleware.RenderError(ctx)
 			assert.Equal(t, tt.code, w.Code)
 		})
 	}
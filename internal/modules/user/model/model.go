@@ -1,28 +1,194 @@
 package model
 
-import "time"
+import (
+	"database/sql"
+	"time"
+)
 
 type Register struct {
 	ID        int64     `json:"id" db:"id"`
 	Username  string    `json:"username" db:"username" binding:"required"`
-	Email     string    `json:"email" db:"email" binding:"required"`
+	Email     string    `json:"email" db:"email" binding:"required,email_format"`
 	Password  string    `json:"password" db:"password" binding:"required"`
 	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	IP        string    `json:"-" db:"-"`
+	UserAgent string    `json:"-" db:"-"`
 }
 
 type User struct {
-	ID        int64     `json:"id" db:"id"`
-	Username  string    `json:"username" db:"username"`
-	Email     string    `json:"email" db:"email"`
-	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	ID              int64      `json:"id" db:"id"`
+	Username        string     `json:"username" db:"username"`
+	Email           string     `json:"email" db:"email"`
+	EmailVerifiedAt *time.Time `json:"email_verified_at" db:"email_verified_at"`
+	CreatedAt       time.Time  `json:"created_at" db:"created_at"`
 }
 
 type JWT struct {
-	Token   string `json:"token"`
-	Expired string `json:"expired"`
+	Token          string `json:"token,omitempty"`
+	Expired        string `json:"expired,omitempty"`
+	RefreshToken   string `json:"refresh_token,omitempty"`
+	RefreshExpired string `json:"refresh_expired,omitempty"`
+	MFAToken       string `json:"mfa_token,omitempty"`
+	MFAExpired     string `json:"mfa_expired,omitempty"`
+}
+
+type Refresh struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+	IP           string `json:"-"`
+	UserAgent    string `json:"-"`
 }
 
 type Login struct {
-	Username string `json:"username" db:"username" binding:"required"`
-	Password string `json:"password" db:"password" binding:"required"`
+	Username  string `json:"username" db:"username" binding:"required"`
+	Password  string `json:"password" db:"password" binding:"required"`
+	IP        string `json:"-" db:"-"`
+	UserAgent string `json:"-" db:"-"`
+}
+
+type Session struct {
+	ID           string    `json:"id"`
+	IP           string    `json:"ip"`
+	UserAgent    string    `json:"user_agent"`
+	CreatedAt    time.Time `json:"created_at"`
+	LastSeenAt   time.Time `json:"last_seen_at"`
+	Current      bool      `json:"current"`
+	Impersonated bool      `json:"impersonated"`
+}
+
+type Impersonate struct {
+	UserID    int64  `json:"-" db:"-"`
+	IP        string `json:"-" db:"-"`
+	UserAgent string `json:"-" db:"-"`
+}
+
+type Role struct {
+	Role string `json:"role" binding:"required"`
+}
+
+type Member struct {
+	ID        int64         `json:"id" db:"id"`
+	UserID    sql.NullInt64 `json:"-" db:"user_id"`
+	FirstName string        `json:"first_name" db:"first_name"`
+	LastName  string        `json:"last_name" db:"last_name"`
+	Email     string        `json:"email" db:"email"`
+	Password  string        `json:"-" db:"password"`
+	CreatedAt time.Time     `json:"created_at" db:"created_at"`
+}
+
+type ForgotPassword struct {
+	Email string `json:"email" binding:"required,email_format"`
+}
+
+type ResetPassword struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+type ResendVerification struct {
+	Email string `json:"email" binding:"required,email_format"`
+}
+
+type MFA struct {
+	UserID    int64      `json:"-" db:"user_id"`
+	Secret    string     `json:"-" db:"secret"`
+	EnabledAt *time.Time `json:"enabled_at" db:"enabled_at"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+}
+
+type MFAEnrollment struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"`
+}
+
+type MFACode struct {
+	Code string `json:"code" binding:"required"`
+}
+
+type DisableMFA struct {
+	Password string `json:"password" binding:"required"`
+	Code     string `json:"code" binding:"required"`
+}
+
+type LoginMFA struct {
+	MFAToken  string `json:"mfa_token" binding:"required"`
+	Code      string `json:"code" binding:"required"`
+	IP        string `json:"-"`
+	UserAgent string `json:"-"`
+}
+
+type RecoveryCode struct {
+	ID        int64      `db:"id"`
+	UserID    int64      `db:"user_id"`
+	Code      string     `db:"code"`
+	UsedAt    *time.Time `db:"used_at"`
+	CreatedAt time.Time  `db:"created_at"`
+}
+
+type RecoveryCodes struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+type ExternalIdentity struct {
+	ID        int64     `db:"id"`
+	UserID    int64     `db:"user_id"`
+	Issuer    string    `db:"issuer"`
+	Subject   string    `db:"subject"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+type OIDCState struct {
+	Nonce    string `json:"nonce"`
+	Verifier string `json:"verifier"`
+}
+
+type OIDCCallback struct {
+	Code      string `form:"code" binding:"required"`
+	State     string `form:"state" binding:"required"`
+	IP        string `form:"-"`
+	UserAgent string `form:"-"`
+}
+
+type UpdateUser struct {
+	Username string `json:"username" binding:"required,max=50"`
+}
+
+type ChangePassword struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required"`
+}
+
+type ChangeEmail struct {
+	Email    string `json:"email" binding:"required,email_format,max=50"`
+	Password string `json:"password" binding:"required"`
+}
+
+type DeleteUser struct {
+	Password string `json:"password" binding:"required"`
+}
+
+type TokenRequest struct {
+	Token         string `form:"token" binding:"required"`
+	TokenTypeHint string `form:"token_type_hint"`
+}
+
+// Introspection is the RFC 7662 answer, an inactive token carries nothing but
+// the active flag.
+type Introspection struct {
+	Active    bool   `json:"active"`
+	Scope     string `json:"scope,omitempty"`
+	Username  string `json:"username,omitempty"`
+	TokenType string `json:"token_type,omitempty"`
+	Expired   int64  `json:"exp,omitempty"`
+	IssuedAt  int64  `json:"iat,omitempty"`
+	Subject   string `json:"sub,omitempty"`
+	Issuer    string `json:"iss,omitempty"`
+	SessionID string `json:"jti,omitempty"`
+	Actor     *Actor `json:"act,omitempty"`
+}
+
+// Actor is who really acts with a token issued to impersonate its subject,
+// the act claim of RFC 8693.
+type Actor struct {
+	ID      int64  `json:"id"`
+	Subject string `json:"sub"`
 }
@@ -3,32 +3,290 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"time"
 
 	"github.com/rzfhlv/gin-example/internal/modules/user/model"
+	pErrors "github.com/rzfhlv/gin-example/pkg/errors"
 	"github.com/rzfhlv/gin-example/pkg/param"
 )
 
 func (r *Repository) Register(ctx context.Context, register model.Register) (result sql.Result, err error) {
 	result, err = r.db.Exec(RegisterUserQuery, register.Username, register.Email, register.Password, register.CreatedAt)
+	err = pErrors.FromSQL(err)
 	return
 }
 
 func (r *Repository) Login(ctx context.Context, login model.Login) (register model.Register, err error) {
 	err = r.db.Get(&register, LoginUserQuery, login.Username)
+	err = pErrors.FromSQL(err)
 	return
 }
 
-func (r *Repository) GetAll(ctx context.Context, param param.Param) (users []model.User, err error) {
-	err = r.db.Select(&users, GetUserQuery, param.Limit, param.CalculateOffset())
+func (r *Repository) GetAll(ctx context.Context, param param.Param) (users []model.User, page param.Page, err error) {
+	where, args, err := UserSpec.Seek(param)
+	if err != nil {
+		return
+	}
+
+	orderBy, err := UserSpec.OrderBy(param)
+	if err != nil {
+		return
+	}
+
+	args = append(args, param.Limit, param.CalculateOffset())
+	err = r.db.Select(&users, fmt.Sprintf(GetUserQuery, where, orderBy), args...)
+	if err != nil {
+		return
+	}
+
+	page, err = UserSpec.Paginate(param, users, func(i int) map[string]interface{} {
+		return userValues(users[i])
+	})
 	return
 }
 
 func (r *Repository) GetByID(ctx context.Context, id int64) (user model.User, err error) {
 	err = r.db.Get(&user, GetUserByIDQuery, id)
+	err = pErrors.FromSQL(err)
+	return
+}
+
+func (r *Repository) GetByEmail(ctx context.Context, email string) (user model.User, err error) {
+	err = r.db.Get(&user, GetUserByEmailQuery, email)
+	err = pErrors.FromSQL(err)
+	return
+}
+
+func (r *Repository) GetPassword(ctx context.Context, id int64) (password string, err error) {
+	err = r.db.Get(&password, GetPasswordQuery, id)
+	err = pErrors.FromSQL(err)
+	return
+}
+
+func (r *Repository) UpdatePassword(ctx context.Context, id int64, password string) (err error) {
+	_, err = r.db.Exec(UpdatePasswordQuery, password, id)
+	err = pErrors.FromSQL(err)
+	return
+}
+
+func (r *Repository) UpdateUsername(ctx context.Context, id int64, username string) (err error) {
+	_, err = r.db.Exec(UpdateUsernameQuery, username, id)
+	err = pErrors.FromSQL(err)
+	return
+}
+
+// UpdateEmail clears the verification too, the new address has to be
+// verified again.
+func (r *Repository) UpdateEmail(ctx context.Context, id int64, email string) (err error) {
+	_, err = r.db.Exec(UpdateEmailQuery, email, id)
+	err = pErrors.FromSQL(err)
+	return
+}
+
+// Delete keeps the member profile of the user but unlinks it first, the rest
+// of the user rows go with the cascade.
+func (r *Repository) Delete(ctx context.Context, id int64) (err error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	_, err = tx.Exec(UnlinkMembersQuery, id)
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec(DeleteUserQuery, id)
+	if err != nil {
+		return
+	}
+
+	err = tx.Commit()
+	return
+}
+
+func (r *Repository) VerifyEmail(ctx context.Context, id int64, verifiedAt time.Time) (err error) {
+	_, err = r.db.Exec(VerifyEmailQuery, verifiedAt, id)
+	err = pErrors.FromSQL(err)
+	return
+}
+
+// Count applies the same filters as GetAll so the total matches the list.
+func (r *Repository) Count(ctx context.Context, param param.Param) (total int64, err error) {
+	where, args, err := UserSpec.Where(param)
+	if err != nil {
+		return
+	}
+
+	err = r.db.Get(&total, fmt.Sprintf(CountUserQuery, where), args...)
+	return
+}
+
+func (r *Repository) GetRoles(ctx context.Context, userID int64) (roles []string, err error) {
+	err = r.db.Select(&roles, GetRolesQuery, userID)
+	return
+}
+
+func (r *Repository) GrantRole(ctx context.Context, userID int64, role string) (err error) {
+	_, err = r.db.Exec(GrantRoleQuery, userID, role)
+	err = pErrors.FromSQL(err)
+	return
+}
+
+func (r *Repository) RevokeRole(ctx context.Context, userID int64, role string) (err error) {
+	_, err = r.db.Exec(RevokeRoleQuery, userID, role)
+	err = pErrors.FromSQL(err)
+	return
+}
+
+func (r *Repository) CreateMember(ctx context.Context, member model.Member) (result sql.Result, err error) {
+	result, err = r.db.Exec(CreateMemberQuery, member.UserID, member.FirstName,
+		member.LastName, member.Email, member.Password, member.CreatedAt)
+	err = pErrors.FromSQL(err)
+	return
+}
+
+func (r *Repository) GetMemberByEmail(ctx context.Context, email string) (member model.Member, err error) {
+	err = r.db.Get(&member, GetMemberByEmailQuery, email)
+	err = pErrors.FromSQL(err)
+	return
+}
+
+func (r *Repository) GetMemberByUserID(ctx context.Context, userID int64) (member model.Member, err error) {
+	err = r.db.Get(&member, GetMemberByUserIDQuery, userID)
+	err = pErrors.FromSQL(err)
+	return
+}
+
+// LinkMember only links a member that no user has claimed yet, the caller
+// checks RowsAffected to detect a lost race.
+func (r *Repository) LinkMember(ctx context.Context, memberID, userID int64) (result sql.Result, err error) {
+	result, err = r.db.Exec(LinkMemberQuery, userID, memberID)
+	err = pErrors.FromSQL(err)
+	return
+}
+
+func (r *Repository) GetMFA(ctx context.Context, userID int64) (mfa model.MFA, err error) {
+	err = r.db.Get(&mfa, GetMFAQuery, userID)
+	err = pErrors.FromSQL(err)
 	return
 }
 
-func (r *Repository) Count(ctx context.Context) (total int64, err error) {
-	err = r.db.Get(&total, CountUserQuery)
+// SaveMFA starts a new enrollment, a secret that was never confirmed is simply
+// replaced.
+func (r *Repository) SaveMFA(ctx context.Context, mfa model.MFA) (err error) {
+	_, err = r.db.Exec(SaveMFAQuery, mfa.UserID, mfa.Secret, mfa.CreatedAt)
+	err = pErrors.FromSQL(err)
 	return
 }
+
+func (r *Repository) EnableMFA(ctx context.Context, userID int64, enabledAt time.Time) (result sql.Result, err error) {
+	result, err = r.db.Exec(EnableMFAQuery, enabledAt, userID)
+	err = pErrors.FromSQL(err)
+	return
+}
+
+// UseMFAStep only moves the last used time step forward, the caller checks
+// RowsAffected to refuse a code whose step was already used.
+func (r *Repository) UseMFAStep(ctx context.Context, userID, step int64) (result sql.Result, err error) {
+	result, err = r.db.Exec(UseMFAStepQuery, step, userID, step)
+	err = pErrors.FromSQL(err)
+	return
+}
+
+// DeleteMFA turns 2FA off, the secret and every recovery code go in one
+// transaction.
+func (r *Repository) DeleteMFA(ctx context.Context, userID int64) (err error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	_, err = tx.Exec(DeleteRecoveryCodesQuery, userID)
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec(DeleteMFAQuery, userID)
+	if err != nil {
+		return
+	}
+
+	err = tx.Commit()
+	return
+}
+
+// SaveRecoveryCodes replaces every recovery code of the user in one
+// transaction, codes are expected to be hashed already.
+func (r *Repository) SaveRecoveryCodes(ctx context.Context, userID int64, codes []string, createdAt time.Time) (err error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	_, err = tx.Exec(DeleteRecoveryCodesQuery, userID)
+	if err != nil {
+		return
+	}
+
+	for _, code := range codes {
+		_, err = tx.Exec(CreateRecoveryCodeQuery, userID, code, createdAt)
+		if err != nil {
+			return
+		}
+	}
+
+	err = tx.Commit()
+	return
+}
+
+func (r *Repository) GetRecoveryCodes(ctx context.Context, userID int64) (codes []model.RecoveryCode, err error) {
+	err = r.db.Select(&codes, GetRecoveryCodesQuery, userID)
+	return
+}
+
+func (r *Repository) UseRecoveryCode(ctx context.Context, id int64, usedAt time.Time) (result sql.Result, err error) {
+	result, err = r.db.Exec(UseRecoveryCodeQuery, usedAt, id)
+	err = pErrors.FromSQL(err)
+	return
+}
+
+func (r *Repository) UsernameExists(ctx context.Context, username string) (exists bool, err error) {
+	err = r.db.Get(&exists, UsernameExistsQuery, username)
+	return
+}
+
+func (r *Repository) GetIdentity(ctx context.Context, issuer, subject string) (userID int64, err error) {
+	err = r.db.Get(&userID, GetIdentityQuery, issuer, subject)
+	err = pErrors.FromSQL(err)
+	return
+}
+
+func (r *Repository) CreateIdentity(ctx context.Context, identity model.ExternalIdentity) (err error) {
+	_, err = r.db.Exec(CreateIdentityQuery, identity.UserID, identity.Issuer, identity.Subject, identity.CreatedAt)
+	err = pErrors.FromSQL(err)
+	return
+}
+
+// userValues are the sort columns of a user, for its cursor.
+func userValues(user model.User) map[string]interface{} {
+	return map[string]interface{}{
+		"id": user.ID, "username": user.Username, "email": user.Email, "created_at": user.CreatedAt,
+	}
+}
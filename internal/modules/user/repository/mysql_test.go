@@ -2,13 +2,16 @@ package repository
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"testing"
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
 	"github.com/jmoiron/sqlx"
 	"github.com/rzfhlv/gin-example/internal/modules/user/model"
+	pErrors "github.com/rzfhlv/gin-example/pkg/errors"
 	"github.com/rzfhlv/gin-example/pkg/param"
 	"github.com/stretchr/testify/assert"
 )
@@ -69,6 +72,17 @@ func TestRegister(t *testing.T) {
 			want:      errFoo,
 			wantError: true,
 		},
+		{
+			name: "Testcase #3: Negative",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				s.ExpectExec("INSERT INTO users (username, email, password, created_at) VALUES (?, ?, ?, ?);").
+					WithArgs(register.Username, register.Email, register.Password, register.CreatedAt).
+					WillReturnError(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry 'john' for key 'users.username'"})
+			},
+			want:      pErrors.ErrConflict,
+			wantError: true,
+		},
 	}
 	for _, tt := range testCase {
 		t.Run(tt.name, func(t *testing.T) {
@@ -87,7 +101,7 @@ func TestRegister(t *testing.T) {
 
 			result, err := r.Register(tt.args, register)
 			if tt.wantError {
-				assert.Error(t, err)
+				assert.ErrorIs(t, err, tt.want)
 				assert.Empty(t, result)
 			} else {
 				assert.NoError(t, err)
@@ -170,7 +184,7 @@ func TestGetAll(t *testing.T) {
 					"id", "username", "email", "created_at",
 				}).
 					AddRow(users[0].ID, users[0].Username, users[0].Email, users[0].CreatedAt)
-				s.ExpectQuery("SELECT id, username, email, created_at FROM users ORDER BY id DESC LIMIT ? OFFSET ?;").
+				s.ExpectQuery("SELECT id, username, email, email_verified_at, created_at FROM users ORDER BY id DESC LIMIT ? OFFSET ?;").
 					WithArgs(paramTest.Limit, paramTest.CalculateOffset()).
 					WillReturnRows(rows)
 			},
@@ -181,7 +195,7 @@ func TestGetAll(t *testing.T) {
 			name: "Testcase #2: Negative",
 			args: ctx,
 			beforeTest: func(s sqlmock.Sqlmock) {
-				s.ExpectQuery("SELECT id, username, email, created_at FROM users ORDER BY id DESC LIMIT ? OFFSET ?;").
+				s.ExpectQuery("SELECT id, username, email, email_verified_at, created_at FROM users ORDER BY id DESC LIMIT ? OFFSET ?;").
 					WithArgs(paramTest.Limit, paramTest.CalculateOffset()).
 					WillReturnError(errFoo)
 			},
@@ -204,7 +218,7 @@ func TestGetAll(t *testing.T) {
 				tt.beforeTest(mockSQL)
 			}
 
-			users, err := r.GetAll(tt.args, paramTest)
+			users, _, err := r.GetAll(tt.args, paramTest)
 			if tt.wantError {
 				assert.Error(t, err)
 				assert.Empty(t, users)
@@ -230,7 +244,7 @@ func TestGetByID(t *testing.T) {
 					"id", "username", "email", "created_at",
 				}).
 					AddRow(users[0].ID, users[0].Username, users[0].Email, users[0].CreatedAt)
-				s.ExpectQuery("SELECT id, username, email, created_at FROM users WHERE id = ?;").
+				s.ExpectQuery("SELECT id, username, email, email_verified_at, created_at FROM users WHERE id = ?;").
 					WithArgs(users[0].ID).
 					WillReturnRows(rows)
 			},
@@ -241,7 +255,7 @@ func TestGetByID(t *testing.T) {
 			name: "Testcase #2: Negative",
 			args: ctx,
 			beforeTest: func(s sqlmock.Sqlmock) {
-				s.ExpectQuery("SELECT id, username, email, created_at FROM users WHERE id = ?;").
+				s.ExpectQuery("SELECT id, username, email, email_verified_at, created_at FROM users WHERE id = ?;").
 					WithArgs(users[0].ID).
 					WillReturnError(errFoo)
 			},
@@ -280,6 +294,176 @@ func TestGetByID(t *testing.T) {
 	}
 }
 
+func TestGetByEmail(t *testing.T) {
+	testCase := []testCase{
+		{
+			name: "Testcase #1: Positive",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{
+					"id", "username", "email", "created_at",
+				}).
+					AddRow(users[0].ID, users[0].Username, users[0].Email, users[0].CreatedAt)
+				s.ExpectQuery("SELECT id, username, email, email_verified_at, created_at FROM users WHERE email = ?;").
+					WithArgs(users[0].Email).
+					WillReturnRows(rows)
+			},
+			want:      nil,
+			wantError: false,
+		},
+		{
+			name: "Testcase #2: Negative",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				s.ExpectQuery("SELECT id, username, email, email_verified_at, created_at FROM users WHERE email = ?;").
+					WithArgs(users[0].Email).
+					WillReturnError(sql.ErrNoRows)
+			},
+			want:      pErrors.ErrNotFound,
+			wantError: true,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mockSQL, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			defer mockDB.Close()
+
+			db := sqlx.NewDb(mockDB, "sqlmock")
+
+			r := &Repository{
+				db: db,
+			}
+
+			if tt.beforeTest != nil {
+				tt.beforeTest(mockSQL)
+			}
+
+			user, err := r.GetByEmail(tt.args, users[0].Email)
+			if tt.wantError {
+				assert.ErrorIs(t, err, tt.want)
+				assert.Empty(t, user)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, users[0].ID, user.ID)
+			}
+
+			if err := mockSQL.ExpectationsWereMet(); err != nil {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestUpdatePassword(t *testing.T) {
+	testCase := []testCase{
+		{
+			name: "Testcase #1: Positive",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				s.ExpectExec("UPDATE users SET password = ? WHERE id = ?;").
+					WithArgs("hashed", users[0].ID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			want:      nil,
+			wantError: false,
+		},
+		{
+			name: "Testcase #2: Negative",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				s.ExpectExec("UPDATE users SET password = ? WHERE id = ?;").
+					WithArgs("hashed", users[0].ID).
+					WillReturnError(errFoo)
+			},
+			want:      errFoo,
+			wantError: true,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mockSQL, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			defer mockDB.Close()
+
+			db := sqlx.NewDb(mockDB, "sqlmock")
+
+			r := &Repository{
+				db: db,
+			}
+
+			if tt.beforeTest != nil {
+				tt.beforeTest(mockSQL)
+			}
+
+			err := r.UpdatePassword(tt.args, users[0].ID, "hashed")
+			if tt.wantError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			if err := mockSQL.ExpectationsWereMet(); err != nil {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestVerifyEmail(t *testing.T) {
+	verifiedAt := time.Now()
+
+	testCase := []testCase{
+		{
+			name: "Testcase #1: Positive",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				s.ExpectExec("UPDATE users SET email_verified_at = ? WHERE id = ? AND email_verified_at IS NULL;").
+					WithArgs(verifiedAt, users[0].ID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			want:      nil,
+			wantError: false,
+		},
+		{
+			name: "Testcase #2: Negative",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				s.ExpectExec("UPDATE users SET email_verified_at = ? WHERE id = ? AND email_verified_at IS NULL;").
+					WithArgs(verifiedAt, users[0].ID).
+					WillReturnError(errFoo)
+			},
+			want:      errFoo,
+			wantError: true,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mockSQL, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			defer mockDB.Close()
+
+			db := sqlx.NewDb(mockDB, "sqlmock")
+
+			r := &Repository{
+				db: db,
+			}
+
+			if tt.beforeTest != nil {
+				tt.beforeTest(mockSQL)
+			}
+
+			err := r.VerifyEmail(tt.args, users[0].ID, verifiedAt)
+			if tt.wantError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			if err := mockSQL.ExpectationsWereMet(); err != nil {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
 func TestCount(t *testing.T) {
 	expectedCount := int64(10)
 	testCase := []testCase{
@@ -289,7 +473,7 @@ func TestCount(t *testing.T) {
 			beforeTest: func(s sqlmock.Sqlmock) {
 				rows := sqlmock.NewRows([]string{"count"}).
 					AddRow(expectedCount)
-				s.ExpectQuery("SELECT count(*) FROM users;").
+				s.ExpectQuery("SELECT count(*) FROM users").
 					WillReturnRows(rows)
 			},
 			want:      nil,
@@ -299,7 +483,7 @@ func TestCount(t *testing.T) {
 			name: "Testcase #2: Negative",
 			args: ctx,
 			beforeTest: func(s sqlmock.Sqlmock) {
-				s.ExpectQuery("SELECT count(*) FROM users;").
+				s.ExpectQuery("SELECT count(*) FROM users").
 					WillReturnError(errFoo)
 			},
 			want:      errFoo,
@@ -321,7 +505,7 @@ func TestCount(t *testing.T) {
 				tt.beforeTest(mockSQL)
 			}
 
-			total, err := r.Count(tt.args)
+			total, err := r.Count(tt.args, paramTest)
 			if tt.wantError {
 				assert.Error(t, err)
 			} else {
@@ -335,3 +519,1315 @@ func TestCount(t *testing.T) {
 		})
 	}
 }
+
+func TestGetRoles(t *testing.T) {
+	testCase := []testCase{
+		{
+			name: "Testcase #1: Positive",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"name"}).
+					AddRow("admin").
+					AddRow("member")
+				s.ExpectQuery("SELECT roles.name FROM roles JOIN user_roles ON user_roles.role_id = roles.id WHERE user_roles.user_id = ? ORDER BY roles.name;").
+					WithArgs(users[0].ID).
+					WillReturnRows(rows)
+			},
+			want:      nil,
+			wantError: false,
+		},
+		{
+			name: "Testcase #2: Negative",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				s.ExpectQuery("SELECT roles.name FROM roles JOIN user_roles ON user_roles.role_id = roles.id WHERE user_roles.user_id = ? ORDER BY roles.name;").
+					WithArgs(users[0].ID).
+					WillReturnError(errFoo)
+			},
+			want:      errFoo,
+			wantError: true,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mockSQL, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			defer mockDB.Close()
+
+			db := sqlx.NewDb(mockDB, "sqlmock")
+
+			r := &Repository{
+				db: db,
+			}
+
+			if tt.beforeTest != nil {
+				tt.beforeTest(mockSQL)
+			}
+
+			roles, err := r.GetRoles(tt.args, users[0].ID)
+			if tt.wantError {
+				assert.Error(t, err)
+				assert.Empty(t, roles)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"admin", "member"}, roles)
+			}
+
+			if err := mockSQL.ExpectationsWereMet(); err != nil {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestGrantRole(t *testing.T) {
+	testCase := []testCase{
+		{
+			name: "Testcase #1: Positive",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				s.ExpectExec("INSERT IGNORE INTO user_roles (user_id, role_id) SELECT ?, id FROM roles WHERE name = ?;").
+					WithArgs(users[0].ID, "admin").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			want:      nil,
+			wantError: false,
+		},
+		{
+			name: "Testcase #2: Negative",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				s.ExpectExec("INSERT IGNORE INTO user_roles (user_id, role_id) SELECT ?, id FROM roles WHERE name = ?;").
+					WithArgs(users[0].ID, "admin").
+					WillReturnError(errFoo)
+			},
+			want:      errFoo,
+			wantError: true,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mockSQL, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			defer mockDB.Close()
+
+			db := sqlx.NewDb(mockDB, "sqlmock")
+
+			r := &Repository{
+				db: db,
+			}
+
+			if tt.beforeTest != nil {
+				tt.beforeTest(mockSQL)
+			}
+
+			err := r.GrantRole(tt.args, users[0].ID, "admin")
+			if tt.wantError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			if err := mockSQL.ExpectationsWereMet(); err != nil {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestRevokeRole(t *testing.T) {
+	testCase := []testCase{
+		{
+			name: "Testcase #1: Positive",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				s.ExpectExec("DELETE user_roles FROM user_roles JOIN roles ON roles.id = user_roles.role_id WHERE user_roles.user_id = ? AND roles.name = ?;").
+					WithArgs(users[0].ID, "admin").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			want:      nil,
+			wantError: false,
+		},
+		{
+			name: "Testcase #2: Negative",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				s.ExpectExec("DELETE user_roles FROM user_roles JOIN roles ON roles.id = user_roles.role_id WHERE user_roles.user_id = ? AND roles.name = ?;").
+					WithArgs(users[0].ID, "admin").
+					WillReturnError(errFoo)
+			},
+			want:      errFoo,
+			wantError: true,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mockSQL, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			defer mockDB.Close()
+
+			db := sqlx.NewDb(mockDB, "sqlmock")
+
+			r := &Repository{
+				db: db,
+			}
+
+			if tt.beforeTest != nil {
+				tt.beforeTest(mockSQL)
+			}
+
+			err := r.RevokeRole(tt.args, users[0].ID, "admin")
+			if tt.wantError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			if err := mockSQL.ExpectationsWereMet(); err != nil {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestCreateMember(t *testing.T) {
+	member := model.Member{
+		UserID: sql.NullInt64{Int64: 1, Valid: true}, FirstName: "johndoe", Email: "johndoe@test.com", Password: "password", CreatedAt: time.Now(),
+	}
+	testCase := []testCase{
+		{
+			name: "Testcase #1: Positive",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				s.ExpectExec("INSERT INTO members (user_id, first_name, last_name, email, password, created_at) VALUES (?, ?, ?, ?, ?, ?);").
+					WithArgs(member.UserID, member.FirstName, member.LastName, member.Email, member.Password, member.CreatedAt).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			want:      nil,
+			wantError: false,
+		},
+		{
+			name: "Testcase #2: Negative",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				s.ExpectExec("INSERT INTO members (user_id, first_name, last_name, email, password, created_at) VALUES (?, ?, ?, ?, ?, ?);").
+					WithArgs(member.UserID, member.FirstName, member.LastName, member.Email, member.Password, member.CreatedAt).
+					WillReturnError(errFoo)
+			},
+			want:      errFoo,
+			wantError: true,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mockSQL, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			defer mockDB.Close()
+
+			db := sqlx.NewDb(mockDB, "sqlmock")
+
+			r := &Repository{
+				db: db,
+			}
+
+			if tt.beforeTest != nil {
+				tt.beforeTest(mockSQL)
+			}
+
+			result, err := r.CreateMember(tt.args, member)
+			if tt.wantError {
+				assert.Error(t, err)
+				assert.Empty(t, result)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			if err := mockSQL.ExpectationsWereMet(); err != nil {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestGetMemberByEmail(t *testing.T) {
+	testCase := []testCase{
+		{
+			name: "Testcase #1: Positive",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{
+					"id", "user_id", "first_name", "last_name", "email", "password", "created_at",
+				}).
+					AddRow(1, nil, "John", "Doe", register.Email, register.Password, register.CreatedAt)
+				s.ExpectQuery("SELECT id, user_id, first_name, last_name, email, password, created_at FROM members WHERE email = ? AND deleted_at IS NULL;").
+					WithArgs(register.Email).
+					WillReturnRows(rows)
+			},
+			want:      nil,
+			wantError: false,
+		},
+		{
+			name: "Testcase #2: Negative",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				s.ExpectQuery("SELECT id, user_id, first_name, last_name, email, password, created_at FROM members WHERE email = ? AND deleted_at IS NULL;").
+					WithArgs(register.Email).
+					WillReturnError(errFoo)
+			},
+			want:      errFoo,
+			wantError: true,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mockSQL, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			defer mockDB.Close()
+
+			db := sqlx.NewDb(mockDB, "sqlmock")
+
+			r := &Repository{
+				db: db,
+			}
+
+			if tt.beforeTest != nil {
+				tt.beforeTest(mockSQL)
+			}
+
+			member, err := r.GetMemberByEmail(tt.args, register.Email)
+			if tt.wantError {
+				assert.Error(t, err)
+				assert.Empty(t, member)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, int64(1), member.ID)
+				assert.False(t, member.UserID.Valid)
+			}
+
+			if err := mockSQL.ExpectationsWereMet(); err != nil {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestGetMemberByUserID(t *testing.T) {
+	testCase := []testCase{
+		{
+			name: "Testcase #1: Positive",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{
+					"id", "user_id", "first_name", "last_name", "email", "password", "created_at",
+				}).
+					AddRow(1, register.ID, "John", "Doe", register.Email, register.Password, register.CreatedAt)
+				s.ExpectQuery("SELECT id, user_id, first_name, last_name, email, password, created_at FROM members WHERE user_id = ? AND deleted_at IS NULL;").
+					WithArgs(register.ID).
+					WillReturnRows(rows)
+			},
+			want:      nil,
+			wantError: false,
+		},
+		{
+			name: "Testcase #2: Negative",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				s.ExpectQuery("SELECT id, user_id, first_name, last_name, email, password, created_at FROM members WHERE user_id = ? AND deleted_at IS NULL;").
+					WithArgs(register.ID).
+					WillReturnError(errFoo)
+			},
+			want:      errFoo,
+			wantError: true,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mockSQL, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			defer mockDB.Close()
+
+			db := sqlx.NewDb(mockDB, "sqlmock")
+
+			r := &Repository{
+				db: db,
+			}
+
+			if tt.beforeTest != nil {
+				tt.beforeTest(mockSQL)
+			}
+
+			member, err := r.GetMemberByUserID(tt.args, register.ID)
+			if tt.wantError {
+				assert.Error(t, err)
+				assert.Empty(t, member)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, register.ID, member.UserID.Int64)
+			}
+
+			if err := mockSQL.ExpectationsWereMet(); err != nil {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestLinkMember(t *testing.T) {
+	testCase := []testCase{
+		{
+			name: "Testcase #1: Positive",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				s.ExpectExec("UPDATE members SET user_id = ? WHERE id = ? AND user_id IS NULL AND deleted_at IS NULL;").
+					WithArgs(register.ID, int64(2)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			want:      nil,
+			wantError: false,
+		},
+		{
+			name: "Testcase #2: Negative",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				s.ExpectExec("UPDATE members SET user_id = ? WHERE id = ? AND user_id IS NULL AND deleted_at IS NULL;").
+					WithArgs(register.ID, int64(2)).
+					WillReturnError(errFoo)
+			},
+			want:      errFoo,
+			wantError: true,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mockSQL, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			defer mockDB.Close()
+
+			db := sqlx.NewDb(mockDB, "sqlmock")
+
+			r := &Repository{
+				db: db,
+			}
+
+			if tt.beforeTest != nil {
+				tt.beforeTest(mockSQL)
+			}
+
+			result, err := r.LinkMember(tt.args, 2, register.ID)
+			if tt.wantError {
+				assert.Error(t, err)
+				assert.Empty(t, result)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			if err := mockSQL.ExpectationsWereMet(); err != nil {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestGetMFA(t *testing.T) {
+	testCase := []testCase{
+		{
+			name: "Testcase #1: Positive",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{
+					"user_id", "secret", "enabled_at", "created_at",
+				}).
+					AddRow(register.ID, "SECRET", register.CreatedAt, register.CreatedAt)
+				s.ExpectQuery("SELECT user_id, secret, enabled_at, created_at FROM user_mfa WHERE user_id = ?;").
+					WithArgs(register.ID).
+					WillReturnRows(rows)
+			},
+			want:      nil,
+			wantError: false,
+		},
+		{
+			name: "Testcase #2: Negative",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				s.ExpectQuery("SELECT user_id, secret, enabled_at, created_at FROM user_mfa WHERE user_id = ?;").
+					WithArgs(register.ID).
+					WillReturnError(sql.ErrNoRows)
+			},
+			want:      pErrors.ErrNotFound,
+			wantError: true,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mockSQL, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			defer mockDB.Close()
+
+			db := sqlx.NewDb(mockDB, "sqlmock")
+
+			r := &Repository{
+				db: db,
+			}
+
+			if tt.beforeTest != nil {
+				tt.beforeTest(mockSQL)
+			}
+
+			mfa, err := r.GetMFA(tt.args, register.ID)
+			if tt.wantError {
+				assert.ErrorIs(t, err, tt.want)
+				assert.Empty(t, mfa)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "SECRET", mfa.Secret)
+				assert.NotNil(t, mfa.EnabledAt)
+			}
+
+			if err := mockSQL.ExpectationsWereMet(); err != nil {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestSaveMFA(t *testing.T) {
+	mfa := model.MFA{UserID: register.ID, Secret: "SECRET", CreatedAt: register.CreatedAt}
+
+	testCase := []testCase{
+		{
+			name: "Testcase #1: Positive",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				s.ExpectExec("INSERT INTO user_mfa (user_id, secret, enabled_at, created_at) VALUES (?, ?, NULL, ?) ON DUPLICATE KEY UPDATE secret = VALUES(secret), enabled_at = NULL, last_step = NULL, created_at = VALUES(created_at);").
+					WithArgs(mfa.UserID, mfa.Secret, mfa.CreatedAt).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			want:      nil,
+			wantError: false,
+		},
+		{
+			name: "Testcase #2: Negative",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				s.ExpectExec("INSERT INTO user_mfa (user_id, secret, enabled_at, created_at) VALUES (?, ?, NULL, ?) ON DUPLICATE KEY UPDATE secret = VALUES(secret), enabled_at = NULL, last_step = NULL, created_at = VALUES(created_at);").
+					WithArgs(mfa.UserID, mfa.Secret, mfa.CreatedAt).
+					WillReturnError(errFoo)
+			},
+			want:      errFoo,
+			wantError: true,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mockSQL, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			defer mockDB.Close()
+
+			db := sqlx.NewDb(mockDB, "sqlmock")
+
+			r := &Repository{
+				db: db,
+			}
+
+			if tt.beforeTest != nil {
+				tt.beforeTest(mockSQL)
+			}
+
+			err := r.SaveMFA(tt.args, mfa)
+			if tt.wantError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			if err := mockSQL.ExpectationsWereMet(); err != nil {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestEnableMFA(t *testing.T) {
+	enabledAt := time.Now()
+
+	testCase := []testCase{
+		{
+			name: "Testcase #1: Positive",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				s.ExpectExec("UPDATE user_mfa SET enabled_at = ? WHERE user_id = ? AND enabled_at IS NULL;").
+					WithArgs(enabledAt, register.ID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			want:      nil,
+			wantError: false,
+		},
+		{
+			name: "Testcase #2: Negative",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				s.ExpectExec("UPDATE user_mfa SET enabled_at = ? WHERE user_id = ? AND enabled_at IS NULL;").
+					WithArgs(enabledAt, register.ID).
+					WillReturnError(errFoo)
+			},
+			want:      errFoo,
+			wantError: true,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mockSQL, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			defer mockDB.Close()
+
+			db := sqlx.NewDb(mockDB, "sqlmock")
+
+			r := &Repository{
+				db: db,
+			}
+
+			if tt.beforeTest != nil {
+				tt.beforeTest(mockSQL)
+			}
+
+			result, err := r.EnableMFA(tt.args, register.ID, enabledAt)
+			if tt.wantError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				affected, _ := result.RowsAffected()
+				assert.Equal(t, int64(1), affected)
+			}
+
+			if err := mockSQL.ExpectationsWereMet(); err != nil {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestUseMFAStep(t *testing.T) {
+	testCase := []testCase{
+		{
+			name: "Testcase #1: Positive",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				s.ExpectExec("UPDATE user_mfa SET last_step = ? WHERE user_id = ? AND (last_step IS NULL OR last_step < ?);").
+					WithArgs(int64(42), register.ID, int64(42)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			want:      nil,
+			wantError: false,
+		},
+		{
+			name: "Testcase #2: Negative",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				s.ExpectExec("UPDATE user_mfa SET last_step = ? WHERE user_id = ? AND (last_step IS NULL OR last_step < ?);").
+					WithArgs(int64(42), register.ID, int64(42)).
+					WillReturnError(errFoo)
+			},
+			want:      errFoo,
+			wantError: true,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mockSQL, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			defer mockDB.Close()
+
+			db := sqlx.NewDb(mockDB, "sqlmock")
+
+			r := &Repository{
+				db: db,
+			}
+
+			if tt.beforeTest != nil {
+				tt.beforeTest(mockSQL)
+			}
+
+			result, err := r.UseMFAStep(tt.args, register.ID, 42)
+			if tt.wantError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				affected, _ := result.RowsAffected()
+				assert.Equal(t, int64(1), affected)
+			}
+
+			if err := mockSQL.ExpectationsWereMet(); err != nil {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestDeleteMFA(t *testing.T) {
+	testCase := []testCase{
+		{
+			name: "Testcase #1: Positive",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				s.ExpectBegin()
+				s.ExpectExec("DELETE FROM user_recovery_codes WHERE user_id = ?;").
+					WithArgs(register.ID).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				s.ExpectExec("DELETE FROM user_mfa WHERE user_id = ?;").
+					WithArgs(register.ID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				s.ExpectCommit()
+			},
+			want:      nil,
+			wantError: false,
+		},
+		{
+			name: "Testcase #2: Negative",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				s.ExpectBegin()
+				s.ExpectExec("DELETE FROM user_recovery_codes WHERE user_id = ?;").
+					WithArgs(register.ID).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				s.ExpectExec("DELETE FROM user_mfa WHERE user_id = ?;").
+					WithArgs(register.ID).
+					WillReturnError(errFoo)
+				s.ExpectRollback()
+			},
+			want:      errFoo,
+			wantError: true,
+		},
+		{
+			name: "Testcase #3: Negative",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				s.ExpectBegin().WillReturnError(errFoo)
+			},
+			want:      errFoo,
+			wantError: true,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mockSQL, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			defer mockDB.Close()
+
+			db := sqlx.NewDb(mockDB, "sqlmock")
+
+			r := &Repository{
+				db: db,
+			}
+
+			if tt.beforeTest != nil {
+				tt.beforeTest(mockSQL)
+			}
+
+			err := r.DeleteMFA(tt.args, register.ID)
+			if tt.wantError {
+				assert.Equal(t, tt.want, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			if err := mockSQL.ExpectationsWereMet(); err != nil {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestSaveRecoveryCodes(t *testing.T) {
+	codes := []string{"hashedone", "hashedtwo"}
+	createdAt := time.Now()
+
+	testCase := []testCase{
+		{
+			name: "Testcase #1: Positive",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				s.ExpectBegin()
+				s.ExpectExec("DELETE FROM user_recovery_codes WHERE user_id = ?;").
+					WithArgs(register.ID).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				for _, code := range codes {
+					s.ExpectExec("INSERT INTO user_recovery_codes (user_id, code, created_at) VALUES (?, ?, ?);").
+						WithArgs(register.ID, code, createdAt).
+						WillReturnResult(sqlmock.NewResult(1, 1))
+				}
+				s.ExpectCommit()
+			},
+			want:      nil,
+			wantError: false,
+		},
+		{
+			name: "Testcase #2: Negative",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				s.ExpectBegin()
+				s.ExpectExec("DELETE FROM user_recovery_codes WHERE user_id = ?;").
+					WithArgs(register.ID).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				s.ExpectExec("INSERT INTO user_recovery_codes (user_id, code, created_at) VALUES (?, ?, ?);").
+					WithArgs(register.ID, codes[0], createdAt).
+					WillReturnError(errFoo)
+				s.ExpectRollback()
+			},
+			want:      errFoo,
+			wantError: true,
+		},
+		{
+			name: "Testcase #3: Negative",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				s.ExpectBegin()
+				s.ExpectExec("DELETE FROM user_recovery_codes WHERE user_id = ?;").
+					WithArgs(register.ID).
+					WillReturnError(errFoo)
+				s.ExpectRollback()
+			},
+			want:      errFoo,
+			wantError: true,
+		},
+		{
+			name: "Testcase #4: Negative",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				s.ExpectBegin().WillReturnError(errFoo)
+			},
+			want:      errFoo,
+			wantError: true,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mockSQL, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			defer mockDB.Close()
+
+			db := sqlx.NewDb(mockDB, "sqlmock")
+
+			r := &Repository{
+				db: db,
+			}
+
+			if tt.beforeTest != nil {
+				tt.beforeTest(mockSQL)
+			}
+
+			err := r.SaveRecoveryCodes(tt.args, register.ID, codes, createdAt)
+			if tt.wantError {
+				assert.Equal(t, tt.want, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.NoError(t, mockSQL.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGetRecoveryCodes(t *testing.T) {
+	testCase := []testCase{
+		{
+			name: "Testcase #1: Positive",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{
+					"id", "user_id", "code", "used_at", "created_at",
+				}).
+					AddRow(1, register.ID, "hashedone", nil, register.CreatedAt)
+				s.ExpectQuery("SELECT id, user_id, code, used_at, created_at FROM user_recovery_codes WHERE user_id = ? AND used_at IS NULL;").
+					WithArgs(register.ID).
+					WillReturnRows(rows)
+			},
+			want:      nil,
+			wantError: false,
+		},
+		{
+			name: "Testcase #2: Negative",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				s.ExpectQuery("SELECT id, user_id, code, used_at, created_at FROM user_recovery_codes WHERE user_id = ? AND used_at IS NULL;").
+					WithArgs(register.ID).
+					WillReturnError(errFoo)
+			},
+			want:      errFoo,
+			wantError: true,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mockSQL, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			defer mockDB.Close()
+
+			db := sqlx.NewDb(mockDB, "sqlmock")
+
+			r := &Repository{
+				db: db,
+			}
+
+			if tt.beforeTest != nil {
+				tt.beforeTest(mockSQL)
+			}
+
+			codes, err := r.GetRecoveryCodes(tt.args, register.ID)
+			if tt.wantError {
+				assert.Error(t, err)
+				assert.Empty(t, codes)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, codes, 1)
+				assert.Nil(t, codes[0].UsedAt)
+			}
+
+			if err := mockSQL.ExpectationsWereMet(); err != nil {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestUseRecoveryCode(t *testing.T) {
+	usedAt := time.Now()
+
+	testCase := []testCase{
+		{
+			name: "Testcase #1: Positive",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				s.ExpectExec("UPDATE user_recovery_codes SET used_at = ? WHERE id = ? AND used_at IS NULL;").
+					WithArgs(usedAt, int64(1)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			want:      nil,
+			wantError: false,
+		},
+		{
+			name: "Testcase #2: Negative",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				s.ExpectExec("UPDATE user_recovery_codes SET used_at = ? WHERE id = ? AND used_at IS NULL;").
+					WithArgs(usedAt, int64(1)).
+					WillReturnError(errFoo)
+			},
+			want:      errFoo,
+			wantError: true,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mockSQL, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			defer mockDB.Close()
+
+			db := sqlx.NewDb(mockDB, "sqlmock")
+
+			r := &Repository{
+				db: db,
+			}
+
+			if tt.beforeTest != nil {
+				tt.beforeTest(mockSQL)
+			}
+
+			result, err := r.UseRecoveryCode(tt.args, int64(1), usedAt)
+			if tt.wantError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				affected, _ := result.RowsAffected()
+				assert.Equal(t, int64(1), affected)
+			}
+
+			if err := mockSQL.ExpectationsWereMet(); err != nil {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestUsernameExists(t *testing.T) {
+	testCase := []testCase{
+		{
+			name: "Testcase #1: Positive",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				s.ExpectQuery("SELECT EXISTS(SELECT 1 FROM users WHERE username = ?);").
+					WithArgs("johndoe").
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+			},
+			want:      nil,
+			wantError: false,
+		},
+		{
+			name: "Testcase #2: Negative",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				s.ExpectQuery("SELECT EXISTS(SELECT 1 FROM users WHERE username = ?);").
+					WithArgs("johndoe").
+					WillReturnError(errFoo)
+			},
+			want:      errFoo,
+			wantError: true,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mockSQL, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			defer mockDB.Close()
+
+			db := sqlx.NewDb(mockDB, "sqlmock")
+
+			r := &Repository{
+				db: db,
+			}
+
+			if tt.beforeTest != nil {
+				tt.beforeTest(mockSQL)
+			}
+
+			exists, err := r.UsernameExists(tt.args, "johndoe")
+			if tt.wantError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.True(t, exists)
+			}
+		})
+	}
+}
+
+func TestGetIdentity(t *testing.T) {
+	testCase := []testCase{
+		{
+			name: "Testcase #1: Positive",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				s.ExpectQuery("SELECT user_id FROM user_identities WHERE issuer = ? AND subject = ?;").
+					WithArgs("https://idp.test", "248289761001").
+					WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow(1))
+			},
+			want:      nil,
+			wantError: false,
+		},
+		{
+			name: "Testcase #2: Negative",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				s.ExpectQuery("SELECT user_id FROM user_identities WHERE issuer = ? AND subject = ?;").
+					WithArgs("https://idp.test", "248289761001").
+					WillReturnError(sql.ErrNoRows)
+			},
+			want:      pErrors.ErrNotFound,
+			wantError: true,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mockSQL, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			defer mockDB.Close()
+
+			db := sqlx.NewDb(mockDB, "sqlmock")
+
+			r := &Repository{
+				db: db,
+			}
+
+			if tt.beforeTest != nil {
+				tt.beforeTest(mockSQL)
+			}
+
+			userID, err := r.GetIdentity(tt.args, "https://idp.test", "248289761001")
+			if tt.wantError {
+				assert.ErrorIs(t, err, tt.want)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, int64(1), userID)
+			}
+		})
+	}
+}
+
+func TestCreateIdentity(t *testing.T) {
+	identity := model.ExternalIdentity{UserID: 1, Issuer: "https://idp.test", Subject: "248289761001", CreatedAt: time.Now()}
+
+	testCase := []testCase{
+		{
+			name: "Testcase #1: Positive",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				s.ExpectExec("INSERT INTO user_identities (user_id, issuer, subject, created_at) VALUES (?, ?, ?, ?);").
+					WithArgs(identity.UserID, identity.Issuer, identity.Subject, identity.CreatedAt).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			want:      nil,
+			wantError: false,
+		},
+		{
+			name: "Testcase #2: Negative",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				s.ExpectExec("INSERT INTO user_identities (user_id, issuer, subject, created_at) VALUES (?, ?, ?, ?);").
+					WithArgs(identity.UserID, identity.Issuer, identity.Subject, identity.CreatedAt).
+					WillReturnError(errFoo)
+			},
+			want:      errFoo,
+			wantError: true,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mockSQL, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			defer mockDB.Close()
+
+			db := sqlx.NewDb(mockDB, "sqlmock")
+
+			r := &Repository{
+				db: db,
+			}
+
+			if tt.beforeTest != nil {
+				tt.beforeTest(mockSQL)
+			}
+
+			err := r.CreateIdentity(tt.args, identity)
+			if tt.wantError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestGetPassword(t *testing.T) {
+	testCase := []testCase{
+		{
+			name: "Testcase #1: Positive",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				s.ExpectQuery("SELECT password FROM users WHERE id = ?;").
+					WithArgs(users[0].ID).
+					WillReturnRows(sqlmock.NewRows([]string{"password"}).AddRow("hashed"))
+			},
+			want:      nil,
+			wantError: false,
+		},
+		{
+			name: "Testcase #2: Negative",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				s.ExpectQuery("SELECT password FROM users WHERE id = ?;").
+					WithArgs(users[0].ID).
+					WillReturnError(errFoo)
+			},
+			want:      errFoo,
+			wantError: true,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mockSQL, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			defer mockDB.Close()
+
+			db := sqlx.NewDb(mockDB, "sqlmock")
+
+			r := &Repository{
+				db: db,
+			}
+
+			if tt.beforeTest != nil {
+				tt.beforeTest(mockSQL)
+			}
+
+			password, err := r.GetPassword(tt.args, users[0].ID)
+			if tt.wantError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "hashed", password)
+			}
+			if err := mockSQL.ExpectationsWereMet(); err != nil {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestUpdateUsername(t *testing.T) {
+	testCase := []testCase{
+		{
+			name: "Testcase #1: Positive",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				s.ExpectExec("UPDATE users SET username = ? WHERE id = ?;").
+					WithArgs("janedoe", users[0].ID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			want:      nil,
+			wantError: false,
+		},
+		{
+			name: "Testcase #2: Negative",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				s.ExpectExec("UPDATE users SET username = ? WHERE id = ?;").
+					WithArgs("janedoe", users[0].ID).
+					WillReturnError(errFoo)
+			},
+			want:      errFoo,
+			wantError: true,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mockSQL, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			defer mockDB.Close()
+
+			db := sqlx.NewDb(mockDB, "sqlmock")
+
+			r := &Repository{
+				db: db,
+			}
+
+			if tt.beforeTest != nil {
+				tt.beforeTest(mockSQL)
+			}
+
+			err := r.UpdateUsername(tt.args, users[0].ID, "janedoe")
+			if tt.wantError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			if err := mockSQL.ExpectationsWereMet(); err != nil {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestUpdateEmail(t *testing.T) {
+	testCase := []testCase{
+		{
+			name: "Testcase #1: Positive",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				s.ExpectExec("UPDATE users SET email = ?, email_verified_at = NULL WHERE id = ?;").
+					WithArgs("jane@test.com", users[0].ID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			want:      nil,
+			wantError: false,
+		},
+		{
+			name: "Testcase #2: Negative",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				s.ExpectExec("UPDATE users SET email = ?, email_verified_at = NULL WHERE id = ?;").
+					WithArgs("jane@test.com", users[0].ID).
+					WillReturnError(errFoo)
+			},
+			want:      errFoo,
+			wantError: true,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mockSQL, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			defer mockDB.Close()
+
+			db := sqlx.NewDb(mockDB, "sqlmock")
+
+			r := &Repository{
+				db: db,
+			}
+
+			if tt.beforeTest != nil {
+				tt.beforeTest(mockSQL)
+			}
+
+			err := r.UpdateEmail(tt.args, users[0].ID, "jane@test.com")
+			if tt.wantError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			if err := mockSQL.ExpectationsWereMet(); err != nil {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	testCase := []testCase{
+		{
+			name: "Testcase #1: Positive",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				s.ExpectBegin()
+				s.ExpectExec("UPDATE members SET user_id = NULL WHERE user_id = ?;").
+					WithArgs(users[0].ID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				s.ExpectExec("DELETE FROM users WHERE id = ?;").
+					WithArgs(users[0].ID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				s.ExpectCommit()
+			},
+			want:      nil,
+			wantError: false,
+		},
+		{
+			name: "Testcase #2: Negative",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				s.ExpectBegin()
+				s.ExpectExec("UPDATE members SET user_id = NULL WHERE user_id = ?;").
+					WithArgs(users[0].ID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				s.ExpectExec("DELETE FROM users WHERE id = ?;").
+					WithArgs(users[0].ID).
+					WillReturnError(errFoo)
+				s.ExpectRollback()
+			},
+			want:      errFoo,
+			wantError: true,
+		},
+		{
+			name: "Testcase #3: Negative",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				s.ExpectBegin()
+				s.ExpectExec("UPDATE members SET user_id = NULL WHERE user_id = ?;").
+					WithArgs(users[0].ID).
+					WillReturnError(errFoo)
+				s.ExpectRollback()
+			},
+			want:      errFoo,
+			wantError: true,
+		},
+		{
+			name: "Testcase #4: Negative",
+			args: ctx,
+			beforeTest: func(s sqlmock.Sqlmock) {
+				s.ExpectBegin().WillReturnError(errFoo)
+			},
+			want:      errFoo,
+			wantError: true,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mockSQL, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			defer mockDB.Close()
+
+			db := sqlx.NewDb(mockDB, "sqlmock")
+
+			r := &Repository{
+				db: db,
+			}
+
+			if tt.beforeTest != nil {
+				tt.beforeTest(mockSQL)
+			}
+
+			err := r.Delete(tt.args, users[0].ID)
+			if tt.wantError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			if err := mockSQL.ExpectationsWereMet(); err != nil {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
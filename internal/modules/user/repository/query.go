@@ -1,6 +1,22 @@
 package repository
 
+import "github.com/rzfhlv/gin-example/pkg/param"
+
 var (
+	UserSpec = param.Spec{
+		Filters: map[string]param.Filter{
+			"username":     {Columns: []string{"username"}, Match: param.CONTAINS},
+			"email":        {Columns: []string{"email"}, Match: param.EQUAL},
+			"created_from": {Columns: []string{"created_at"}, Match: param.FROM},
+			"created_to":   {Columns: []string{"created_at"}, Match: param.TO},
+		},
+		Search: []string{"username", "email"},
+		Sorts: map[string]string{
+			"id": "id", "username": "username", "email": "email", "created_at": "created_at",
+		},
+		Default: "-id",
+	}
+
 	RegisterUserQuery = `INSERT INTO users
 		(username, email, password, created_at)
 		VALUES (?, ?, ?, ?);`
@@ -8,11 +24,87 @@ var (
 		email, password, created_at
 		FROM users WHERE username = ?;`
 	GetUserQuery = `SELECT id, username,
-		email, created_at
-		FROM users ORDER BY id DESC LIMIT ? OFFSET ?;`
+		email, email_verified_at, created_at
+		FROM users %s %s LIMIT ? OFFSET ?;`
 	GetUserByIDQuery = `SELECT id, username,
-		email, created_at
+		email, email_verified_at, created_at
 		FROM users WHERE id = ?;`
+	GetUserByEmailQuery = `SELECT id, username,
+		email, email_verified_at, created_at
+		FROM users WHERE email = ?;`
+	GetPasswordQuery = `SELECT password
+		FROM users WHERE id = ?;`
+	UpdateUsernameQuery = `UPDATE users
+		SET username = ?
+		WHERE id = ?;`
+	UpdateEmailQuery = `UPDATE users
+		SET email = ?, email_verified_at = NULL
+		WHERE id = ?;`
+	UnlinkMembersQuery = `UPDATE members
+		SET user_id = NULL
+		WHERE user_id = ?;`
+	DeleteUserQuery = `DELETE FROM users
+		WHERE id = ?;`
+	UpdatePasswordQuery = `UPDATE users
+		SET password = ?
+		WHERE id = ?;`
+	VerifyEmailQuery = `UPDATE users
+		SET email_verified_at = ?
+		WHERE id = ? AND email_verified_at IS NULL;`
 	CountUserQuery = `SELECT count(*)
-		FROM users;`
+		FROM users %s`
+	GetRolesQuery = `SELECT roles.name
+		FROM roles JOIN user_roles ON user_roles.role_id = roles.id
+		WHERE user_roles.user_id = ? ORDER BY roles.name;`
+	GrantRoleQuery = `INSERT IGNORE INTO user_roles
+		(user_id, role_id)
+		SELECT ?, id FROM roles WHERE name = ?;`
+	RevokeRoleQuery = `DELETE user_roles FROM user_roles
+		JOIN roles ON roles.id = user_roles.role_id
+		WHERE user_roles.user_id = ? AND roles.name = ?;`
+	CreateMemberQuery = `INSERT INTO members
+		(user_id, first_name, last_name, email, password, created_at)
+		VALUES (?, ?, ?, ?, ?, ?);`
+	GetMemberByEmailQuery = `SELECT id, user_id, first_name,
+		last_name, email, password, created_at
+		FROM members WHERE email = ? AND deleted_at IS NULL;`
+	GetMemberByUserIDQuery = `SELECT id, user_id, first_name,
+		last_name, email, password, created_at
+		FROM members WHERE user_id = ? AND deleted_at IS NULL;`
+	LinkMemberQuery = `UPDATE members
+		SET user_id = ?
+		WHERE id = ? AND user_id IS NULL AND deleted_at IS NULL;`
+	GetMFAQuery = `SELECT user_id, secret,
+		enabled_at, created_at
+		FROM user_mfa WHERE user_id = ?;`
+	SaveMFAQuery = `INSERT INTO user_mfa
+		(user_id, secret, enabled_at, created_at)
+		VALUES (?, ?, NULL, ?)
+		ON DUPLICATE KEY UPDATE secret = VALUES(secret), enabled_at = NULL, last_step = NULL, created_at = VALUES(created_at);`
+	EnableMFAQuery = `UPDATE user_mfa
+		SET enabled_at = ?
+		WHERE user_id = ? AND enabled_at IS NULL;`
+	UseMFAStepQuery = `UPDATE user_mfa
+		SET last_step = ?
+		WHERE user_id = ? AND (last_step IS NULL OR last_step < ?);`
+	DeleteMFAQuery = `DELETE FROM user_mfa
+		WHERE user_id = ?;`
+	DeleteRecoveryCodesQuery = `DELETE FROM user_recovery_codes
+		WHERE user_id = ?;`
+	CreateRecoveryCodeQuery = `INSERT INTO user_recovery_codes
+		(user_id, code, created_at)
+		VALUES (?, ?, ?);`
+	GetRecoveryCodesQuery = `SELECT id, user_id,
+		code, used_at, created_at
+		FROM user_recovery_codes WHERE user_id = ? AND used_at IS NULL;`
+	UseRecoveryCodeQuery = `UPDATE user_recovery_codes
+		SET used_at = ?
+		WHERE id = ? AND used_at IS NULL;`
+	UsernameExistsQuery = `SELECT EXISTS(SELECT 1
+		FROM users WHERE username = ?);`
+	GetIdentityQuery = `SELECT user_id
+		FROM user_identities WHERE issuer = ? AND subject = ?;`
+	CreateIdentityQuery = `INSERT INTO user_identities
+		(user_id, issuer, subject, created_at)
+		VALUES (?, ?, ?, ?);`
 )
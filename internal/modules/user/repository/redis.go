@@ -17,3 +17,11 @@ func (r *Repository) Del(ctx context.Context, key string) (err error) {
 	err = r.redis.Del(ctx, key).Err()
 	return
 }
+func (r *Repository) GetDel(ctx context.Context, key string) (value string, err error) {
+	value, err = r.redis.GetDel(ctx, key).Result()
+	return
+}
+func (r *Repository) SetNX(ctx context.Context, key, value string, ttl time.Duration) (ok bool, err error) {
+	ok, err = r.redis.SetNX(ctx, key, value, ttl).Result()
+	return
+}
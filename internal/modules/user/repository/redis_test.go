@@ -65,3 +65,43 @@ func TestDel(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func TestGetDel(t *testing.T) {
+	client, mock := redismock.NewClientMock()
+	r := &Repository{
+		redis: client,
+	}
+
+	t.Run("Testcase #1: Positive", func(t *testing.T) {
+		mock.ExpectGetDel(key).SetVal(value)
+		result, err := r.GetDel(ctx, key)
+		assert.NoError(t, err)
+		assert.Equal(t, value, result)
+	})
+
+	t.Run("Testcase #2: Negative", func(t *testing.T) {
+		mock.ExpectGetDel(key).SetErr(errors.New("error"))
+		_, err := r.GetDel(ctx, key)
+		assert.Error(t, err)
+	})
+}
+
+func TestSetNX(t *testing.T) {
+	client, mock := redismock.NewClientMock()
+	r := &Repository{
+		redis: client,
+	}
+
+	t.Run("Testcase #1: Positive", func(t *testing.T) {
+		mock.ExpectSetNX(key, value, ttl).SetVal(true)
+		ok, err := r.SetNX(ctx, key, value, ttl)
+		assert.NoError(t, err)
+		assert.True(t, ok)
+	})
+
+	t.Run("Testcase #2: Negative", func(t *testing.T) {
+		mock.ExpectSetNX(key, value, ttl).SetErr(errors.New("error"))
+		_, err := r.SetNX(ctx, key, value, ttl)
+		assert.Error(t, err)
+	})
+}
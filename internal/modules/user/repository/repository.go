@@ -14,12 +14,39 @@ import (
 type IRepository interface {
 	Register(ctx context.Context, register model.Register) (result sql.Result, err error)
 	Login(ctx context.Context, login model.Login) (register model.Register, err error)
-	GetAll(ctx context.Context, param param.Param) (users []model.User, err error)
+	GetAll(ctx context.Context, param param.Param) (users []model.User, page param.Page, err error)
 	GetByID(ctx context.Context, id int64) (user model.User, err error)
-	Count(ctx context.Context) (total int64, err error)
+	GetByEmail(ctx context.Context, email string) (user model.User, err error)
+	GetPassword(ctx context.Context, id int64) (password string, err error)
+	UpdatePassword(ctx context.Context, id int64, password string) (err error)
+	UpdateUsername(ctx context.Context, id int64, username string) (err error)
+	UpdateEmail(ctx context.Context, id int64, email string) (err error)
+	Delete(ctx context.Context, id int64) (err error)
+	VerifyEmail(ctx context.Context, id int64, verifiedAt time.Time) (err error)
+	Count(ctx context.Context, param param.Param) (total int64, err error)
+	GetRoles(ctx context.Context, userID int64) (roles []string, err error)
+	GrantRole(ctx context.Context, userID int64, role string) (err error)
+	RevokeRole(ctx context.Context, userID int64, role string) (err error)
+	CreateMember(ctx context.Context, member model.Member) (result sql.Result, err error)
+	GetMemberByEmail(ctx context.Context, email string) (member model.Member, err error)
+	GetMemberByUserID(ctx context.Context, userID int64) (member model.Member, err error)
+	LinkMember(ctx context.Context, memberID, userID int64) (result sql.Result, err error)
+	GetMFA(ctx context.Context, userID int64) (mfa model.MFA, err error)
+	SaveMFA(ctx context.Context, mfa model.MFA) (err error)
+	EnableMFA(ctx context.Context, userID int64, enabledAt time.Time) (result sql.Result, err error)
+	UseMFAStep(ctx context.Context, userID, step int64) (result sql.Result, err error)
+	DeleteMFA(ctx context.Context, userID int64) (err error)
+	SaveRecoveryCodes(ctx context.Context, userID int64, codes []string, createdAt time.Time) (err error)
+	GetRecoveryCodes(ctx context.Context, userID int64) (codes []model.RecoveryCode, err error)
+	UseRecoveryCode(ctx context.Context, id int64, usedAt time.Time) (result sql.Result, err error)
+	UsernameExists(ctx context.Context, username string) (exists bool, err error)
+	GetIdentity(ctx context.Context, issuer, subject string) (userID int64, err error)
+	CreateIdentity(ctx context.Context, identity model.ExternalIdentity) (err error)
 	Set(ctx context.Context, key, value string, ttl time.Duration) (err error)
 	Get(ctx context.Context, key string) (value string, err error)
 	Del(ctx context.Context, key string) (err error)
+	GetDel(ctx context.Context, key string) (value string, err error)
+	SetNX(ctx context.Context, key, value string, ttl time.Duration) (ok bool, err error)
 }
 
 type Repository struct {
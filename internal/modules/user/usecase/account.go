@@ -0,0 +1,167 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/rzfhlv/gin-example/internal/modules/user/model"
+	pErrors "github.com/rzfhlv/gin-example/pkg/errors"
+	"github.com/rzfhlv/gin-example/pkg/hasher"
+	"github.com/rzfhlv/gin-example/pkg/message"
+)
+
+var (
+	ErrUsernameExists = pErrors.Conflict(message.USERNAMEEXIST)
+	ErrEmailExists    = pErrors.Conflict(message.EMAILEXIST)
+)
+
+func (u *Usecase) UpdateMe(ctx context.Context, userID int64, update model.UpdateUser) (user model.User, err error) {
+	user, err = u.repo.GetByID(ctx, userID)
+	if err != nil || user.Username == update.Username {
+		return
+	}
+
+	exists, err := u.repo.UsernameExists(ctx, update.Username)
+	if err != nil {
+		return
+	}
+	if exists {
+		err = ErrUsernameExists
+		return
+	}
+
+	err = u.repo.UpdateUsername(ctx, userID, update.Username)
+	if err != nil {
+		return
+	}
+	user.Username = update.Username
+
+	err = u.session.Rename(ctx, userID, user.Username, user.Email)
+	return
+}
+
+// ChangePassword keeps the session it is called from and signs every other
+// one out, like a reset does for all of them.
+func (u *Usecase) ChangePassword(ctx context.Context, userID int64, sessionID string, change model.ChangePassword) (err error) {
+	user, err := u.confirmPassword(ctx, userID, change.CurrentPassword)
+	if err != nil {
+		return
+	}
+
+	err = u.policy.Check(change.NewPassword, user.Username, user.Email)
+	if err != nil {
+		return
+	}
+
+	hashPassword, err := u.hasher.HashedPassword(change.NewPassword)
+	if err != nil {
+		return
+	}
+
+	err = u.repo.UpdatePassword(ctx, userID, hashPassword)
+	if err != nil {
+		return
+	}
+
+	err = u.session.RevokeOthers(ctx, userID, sessionID)
+	return
+}
+
+// ChangeEmail switches the account to the new address right away but marks
+// it unverified and mails a verification token to it, a token still pending
+// for the old address is dropped. Every other session is signed out, their
+// tokens still claim the old address verified.
+func (u *Usecase) ChangeEmail(ctx context.Context, userID int64, sessionID string, change model.ChangeEmail) (err error) {
+	user, err := u.confirmPassword(ctx, userID, change.Password)
+	if err != nil || strings.EqualFold(user.Email, change.Email) {
+		return
+	}
+
+	_, err = u.repo.GetByEmail(ctx, change.Email)
+	if err == nil {
+		err = ErrEmailExists
+		return
+	}
+	if !errors.Is(err, pErrors.ErrNotFound) {
+		return
+	}
+
+	err = u.repo.UpdateEmail(ctx, userID, change.Email)
+	if err != nil {
+		return
+	}
+	user.Email = change.Email
+	user.EmailVerifiedAt = nil
+
+	err = u.session.Rename(ctx, userID, user.Username, user.Email)
+	if err != nil {
+		return
+	}
+
+	err = u.session.RevokeOthers(ctx, userID, sessionID)
+	if err != nil {
+		return
+	}
+
+	err = u.verification(ctx, user)
+	return
+}
+
+func (u *Usecase) DeleteMe(ctx context.Context, userID int64, remove model.DeleteUser) (err error) {
+	_, err = u.confirmPassword(ctx, userID, remove.Password)
+	if err != nil {
+		return
+	}
+
+	err = u.repo.Delete(ctx, userID)
+	if err != nil {
+		return
+	}
+
+	err = u.session.RevokeAll(ctx, userID)
+	return
+}
+
+// confirmPassword asks a signed in user for the password again before a
+// sensitive change. A wrong one counts against the same lockout as a login,
+// a stolen session can't guess the password any faster than the login form.
+func (u *Usecase) confirmPassword(ctx context.Context, userID int64, password string) (user model.User, err error) {
+	user, err = u.checkPassword(ctx, userID, password)
+	if err != nil {
+		return
+	}
+
+	err = u.loginUsers.Reset(ctx, strings.ToLower(user.Username))
+	return
+}
+
+// checkPassword is confirmPassword without clearing the lockout, for a change
+// that asks for a second factor too and only resets once both passed.
+func (u *Usecase) checkPassword(ctx context.Context, userID int64, password string) (user model.User, err error) {
+	user, err = u.repo.GetByID(ctx, userID)
+	if err != nil {
+		return
+	}
+	username := strings.ToLower(user.Username)
+
+	err = u.loginUsers.Check(ctx, username)
+	if err != nil {
+		return
+	}
+
+	hashed, err := u.repo.GetPassword(ctx, userID)
+	if err != nil {
+		return
+	}
+
+	err = u.hasher.VerifyPassword(hashed, password)
+	if err == hasher.ErrMismatchedHashAndPassword {
+		err = u.loginUsers.Fail(ctx, username)
+		if err == nil {
+			err = ErrInvalidCredentials
+		}
+		return
+	}
+	return
+}
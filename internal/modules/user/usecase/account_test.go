@@ -0,0 +1,356 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rzfhlv/gin-example/internal/modules/user/model"
+	pErrors "github.com/rzfhlv/gin-example/pkg/errors"
+	"github.com/rzfhlv/gin-example/pkg/lockout"
+	"github.com/rzfhlv/gin-example/pkg/password"
+	mockRepo "github.com/rzfhlv/gin-example/shared/mocks/modules/user/repository"
+	mockHasher "github.com/rzfhlv/gin-example/shared/mocks/pkg/hasher"
+	mockLockout "github.com/rzfhlv/gin-example/shared/mocks/pkg/lockout"
+	mockMailer "github.com/rzfhlv/gin-example/shared/mocks/pkg/mailer"
+	mockPassword "github.com/rzfhlv/gin-example/shared/mocks/pkg/password"
+	mockSession "github.com/rzfhlv/gin-example/shared/mocks/pkg/session"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var me = model.User{ID: 1, Username: "johndoe", Email: "johndoe@test.com"}
+
+func TestUpdateMe(t *testing.T) {
+	testCase := []struct {
+		name                                          string
+		username                                      string
+		exists                                        bool
+		wantUserError, wantUpdateError, wantRenameErr error
+		wantError                                     error
+		wantUpdate                                    bool
+	}{
+		{
+			name: "Testcase #1: Positive", username: "janedoe", wantUpdate: true,
+		},
+		{
+			name: "Testcase #2: Positive", username: me.Username,
+		},
+		{
+			name: "Testcase #3: Negative", username: "janedoe", exists: true, wantError: ErrUsernameExists,
+		},
+		{
+			name: "Testcase #4: Negative", username: "janedoe", wantUserError: pErrors.ErrNotFound, wantError: pErrors.ErrNotFound,
+		},
+		{
+			name: "Testcase #5: Negative", username: "janedoe", wantUpdateError: errFoo, wantError: errFoo, wantUpdate: true,
+		},
+		{
+			name: "Testcase #6: Negative", username: "janedoe", wantRenameErr: errFoo, wantError: errFoo, wantUpdate: true,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mockRepo.IRepository{}
+			mockSession := mockSession.Store{}
+
+			mockRepo.On("GetByID", mock.Anything, me.ID).Return(me, tt.wantUserError)
+			mockRepo.On("UsernameExists", mock.Anything, tt.username).Return(tt.exists, nil)
+			mockRepo.On("UpdateUsername", mock.Anything, me.ID, tt.username).Return(tt.wantUpdateError)
+			mockSession.On("Rename", mock.Anything, me.ID, tt.username, me.Email).Return(tt.wantRenameErr)
+
+			u := &Usecase{
+				expiry:  expiry,
+				repo:    &mockRepo,
+				session: &mockSession,
+			}
+
+			user, err := u.UpdateMe(context.Background(), me.ID, model.UpdateUser{Username: tt.username})
+			assert.Equal(t, tt.wantError, err)
+			if tt.wantError == nil {
+				assert.Equal(t, tt.username, user.Username)
+			}
+			if !tt.wantUpdate {
+				mockRepo.AssertNotCalled(t, "UpdateUsername", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestChangePassword(t *testing.T) {
+	change := model.ChangePassword{CurrentPassword: "password", NewPassword: "n3w-Password"}
+
+	testCase := []struct {
+		name                                                string
+		wantPasswordError, wantVerifyError, wantPolicyError error
+		wantHashError, wantUpdateError, wantRevokeError     error
+		wantError                                           error
+	}{
+		{
+			name: "Testcase #1: Positive",
+		},
+		{
+			name: "Testcase #2: Negative", wantVerifyError: bcrypt.ErrMismatchedHashAndPassword, wantError: ErrInvalidCredentials,
+		},
+		{
+			name: "Testcase #3: Negative", wantPasswordError: errFoo, wantError: errFoo,
+		},
+		{
+			name: "Testcase #4: Negative", wantPolicyError: &password.Error{}, wantError: &password.Error{},
+		},
+		{
+			name: "Testcase #5: Negative", wantHashError: errFoo, wantError: errFoo,
+		},
+		{
+			name: "Testcase #6: Negative", wantUpdateError: errFoo, wantError: errFoo,
+		},
+		{
+			name: "Testcase #7: Negative", wantRevokeError: errFoo, wantError: errFoo,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mockRepo.IRepository{}
+			mockHasher := mockHasher.HashPassword{}
+			mockPassword := mockPassword.Checker{}
+			mockSession := mockSession.Store{}
+			mockLockout := mockLockout.Limiter{}
+
+			mockLockout.On("Check", mock.Anything, me.Username).Return(nil)
+			mockLockout.On("Fail", mock.Anything, me.Username).Return(nil)
+			mockLockout.On("Reset", mock.Anything, me.Username).Return(nil)
+			mockRepo.On("GetPassword", mock.Anything, me.ID).Return("hashed", tt.wantPasswordError)
+			mockHasher.On("VerifyPassword", "hashed", change.CurrentPassword).Return(tt.wantVerifyError)
+			mockRepo.On("GetByID", mock.Anything, me.ID).Return(me, nil)
+			mockPassword.On("Check", change.NewPassword, me.Username, me.Email).Return(tt.wantPolicyError)
+			mockHasher.On("HashedPassword", change.NewPassword).Return("newhashed", tt.wantHashError)
+			mockRepo.On("UpdatePassword", mock.Anything, me.ID, "newhashed").Return(tt.wantUpdateError)
+			mockSession.On("RevokeOthers", mock.Anything, me.ID, sessionID).Return(tt.wantRevokeError)
+
+			u := &Usecase{
+				expiry:     expiry,
+				repo:       &mockRepo,
+				hasher:     &mockHasher,
+				policy:     &mockPassword,
+				session:    &mockSession,
+				loginUsers: &mockLockout,
+			}
+
+			err := u.ChangePassword(context.Background(), me.ID, sessionID, change)
+			assert.Equal(t, tt.wantError, err)
+			if tt.wantError != nil && tt.wantRevokeError == nil {
+				mockSession.AssertNotCalled(t, "RevokeOthers", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestChangeEmail(t *testing.T) {
+	change := model.ChangeEmail{Email: "jane@test.com", Password: "password"}
+
+	testCase := []struct {
+		name                                             string
+		email                                            string
+		wantVerifyError, wantEmailError, wantUpdateError error
+		wantRenameError, wantRevokeError, wantMailError  error
+		wantError                                        error
+		wantMail                                         bool
+	}{
+		{
+			name: "Testcase #1: Positive", email: change.Email, wantEmailError: pErrors.ErrNotFound, wantMail: true,
+		},
+		{
+			name: "Testcase #2: Positive", email: "JohnDoe@test.com",
+		},
+		{
+			name: "Testcase #3: Negative", email: change.Email, wantVerifyError: bcrypt.ErrMismatchedHashAndPassword, wantError: ErrInvalidCredentials,
+		},
+		{
+			name: "Testcase #4: Negative", email: change.Email, wantError: ErrEmailExists,
+		},
+		{
+			name: "Testcase #5: Negative", email: change.Email, wantEmailError: errFoo, wantError: errFoo,
+		},
+		{
+			name: "Testcase #6: Negative", email: change.Email, wantEmailError: pErrors.ErrNotFound, wantUpdateError: errFoo, wantError: errFoo,
+		},
+		{
+			name: "Testcase #7: Negative", email: change.Email, wantEmailError: pErrors.ErrNotFound, wantRenameError: errFoo, wantError: errFoo,
+		},
+		{
+			name: "Testcase #8: Negative", email: change.Email, wantEmailError: pErrors.ErrNotFound, wantMailError: errFoo, wantError: errFoo,
+		},
+		{
+			name: "Testcase #9: Negative", email: change.Email, wantEmailError: pErrors.ErrNotFound, wantRevokeError: errFoo, wantError: errFoo,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mockRepo.IRepository{}
+			mockHasher := mockHasher.HashPassword{}
+			mockSession := mockSession.Store{}
+			mockMailer := mockMailer.Sender{}
+			mockLockout := mockLockout.Limiter{}
+
+			mockLockout.On("Check", mock.Anything, me.Username).Return(nil)
+			mockLockout.On("Fail", mock.Anything, me.Username).Return(nil)
+			mockLockout.On("Reset", mock.Anything, me.Username).Return(nil)
+			mockRepo.On("GetPassword", mock.Anything, me.ID).Return("hashed", nil)
+			mockHasher.On("VerifyPassword", "hashed", change.Password).Return(tt.wantVerifyError)
+			mockRepo.On("GetByID", mock.Anything, me.ID).Return(me, nil)
+			mockRepo.On("GetByEmail", mock.Anything, tt.email).Return(model.User{ID: 2, Email: tt.email}, tt.wantEmailError)
+			mockRepo.On("UpdateEmail", mock.Anything, me.ID, tt.email).Return(tt.wantUpdateError)
+			mockSession.On("Rename", mock.Anything, me.ID, me.Username, tt.email).Return(tt.wantRenameError)
+			mockSession.On("RevokeOthers", mock.Anything, me.ID, sessionID).Return(tt.wantRevokeError)
+			mockRepo.On("GetDel", mock.Anything, VERIFYUSERKEY+"1").Return(VERIFYKEY+"oldtoken", nil)
+			mockRepo.On("Del", mock.Anything, VERIFYKEY+"oldtoken").Return(nil)
+			mockRepo.On("Set", mock.Anything, mock.Anything, "1:"+tt.email, mock.Anything).Return(nil)
+			mockRepo.On("Set", mock.Anything, VERIFYUSERKEY+"1", mock.Anything, mock.Anything).Return(nil)
+			mockMailer.On("Send", mock.Anything, mock.Anything).Return(tt.wantMailError)
+
+			u := &Usecase{
+				expiry:     expiry,
+				repo:       &mockRepo,
+				hasher:     &mockHasher,
+				session:    &mockSession,
+				mailer:     &mockMailer,
+				loginUsers: &mockLockout,
+			}
+
+			err := u.ChangeEmail(context.Background(), me.ID, sessionID, model.ChangeEmail{Email: tt.email, Password: change.Password})
+			assert.Equal(t, tt.wantError, err)
+			if tt.wantMail {
+				mockMailer.AssertCalled(t, "Send", mock.Anything, mock.Anything)
+				mockRepo.AssertCalled(t, "Del", mock.Anything, VERIFYKEY+"oldtoken")
+				mockSession.AssertCalled(t, "RevokeOthers", mock.Anything, me.ID, sessionID)
+			} else if tt.wantError == nil {
+				mockRepo.AssertNotCalled(t, "UpdateEmail", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestDeleteMe(t *testing.T) {
+	testCase := []struct {
+		name                                              string
+		wantVerifyError, wantDeleteError, wantRevokeError error
+		wantError                                         error
+	}{
+		{
+			name: "Testcase #1: Positive",
+		},
+		{
+			name: "Testcase #2: Negative", wantVerifyError: bcrypt.ErrMismatchedHashAndPassword, wantError: ErrInvalidCredentials,
+		},
+		{
+			name: "Testcase #3: Negative", wantDeleteError: errFoo, wantError: errFoo,
+		},
+		{
+			name: "Testcase #4: Negative", wantRevokeError: errFoo, wantError: errFoo,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mockRepo.IRepository{}
+			mockHasher := mockHasher.HashPassword{}
+			mockSession := mockSession.Store{}
+			mockLockout := mockLockout.Limiter{}
+
+			mockLockout.On("Check", mock.Anything, me.Username).Return(nil)
+			mockLockout.On("Fail", mock.Anything, me.Username).Return(nil)
+			mockLockout.On("Reset", mock.Anything, me.Username).Return(nil)
+			mockRepo.On("GetByID", mock.Anything, me.ID).Return(me, nil)
+			mockRepo.On("GetPassword", mock.Anything, me.ID).Return("hashed", nil)
+			mockHasher.On("VerifyPassword", "hashed", "password").Return(tt.wantVerifyError)
+			mockRepo.On("Delete", mock.Anything, me.ID).Return(tt.wantDeleteError)
+			mockSession.On("RevokeAll", mock.Anything, me.ID).Return(tt.wantRevokeError)
+
+			u := &Usecase{
+				expiry:     expiry,
+				repo:       &mockRepo,
+				hasher:     &mockHasher,
+				session:    &mockSession,
+				loginUsers: &mockLockout,
+			}
+
+			err := u.DeleteMe(context.Background(), me.ID, model.DeleteUser{Password: "password"})
+			assert.Equal(t, tt.wantError, err)
+			if tt.wantVerifyError != nil {
+				mockRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestConfirmPassword(t *testing.T) {
+	locked := &lockout.Error{Locked: true, RetryAfter: time.Minute}
+	renamed := me
+	renamed.Username = "JohnDoe"
+
+	testCase := []struct {
+		name                           string
+		wantUserError, wantCheckError  error
+		wantVerifyError, wantFailError error
+		wantError                      error
+		failed                         bool
+	}{
+		{
+			name: "Testcase #1: Positive",
+		},
+		{
+			name: "Testcase #2: Negative", wantVerifyError: bcrypt.ErrMismatchedHashAndPassword, wantError: ErrInvalidCredentials, failed: true,
+		},
+		{
+			name: "Testcase #3: Negative", wantVerifyError: bcrypt.ErrMismatchedHashAndPassword, wantFailError: locked, wantError: locked, failed: true,
+		},
+		{
+			name: "Testcase #4: Negative", wantCheckError: locked, wantError: locked,
+		},
+		{
+			name: "Testcase #5: Negative", wantUserError: errFoo, wantError: errFoo,
+		},
+		{
+			name: "Testcase #6: Negative", wantVerifyError: bcrypt.ErrHashTooShort, wantError: bcrypt.ErrHashTooShort,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mockRepo.IRepository{}
+			mockHasher := mockHasher.HashPassword{}
+			mockLockout := mockLockout.Limiter{}
+
+			// the lockout is keyed like a login, by the lowercase username
+			mockLockout.On("Check", mock.Anything, me.Username).Return(tt.wantCheckError)
+			mockLockout.On("Fail", mock.Anything, me.Username).Return(tt.wantFailError)
+			mockLockout.On("Reset", mock.Anything, me.Username).Return(nil)
+			mockRepo.On("GetByID", mock.Anything, me.ID).Return(renamed, tt.wantUserError)
+			mockRepo.On("GetPassword", mock.Anything, me.ID).Return("hashed", nil)
+			mockHasher.On("VerifyPassword", "hashed", "password").Return(tt.wantVerifyError)
+
+			u := &Usecase{
+				expiry:     expiry,
+				repo:       &mockRepo,
+				hasher:     &mockHasher,
+				loginUsers: &mockLockout,
+			}
+
+			user, err := u.confirmPassword(context.Background(), me.ID, "password")
+			assert.Equal(t, tt.wantError, err)
+			if tt.wantError == nil {
+				assert.Equal(t, renamed, user)
+				mockLockout.AssertCalled(t, "Reset", mock.Anything, me.Username)
+			} else {
+				mockLockout.AssertNotCalled(t, "Reset", mock.Anything, mock.Anything)
+			}
+			if tt.failed {
+				mockLockout.AssertCalled(t, "Fail", mock.Anything, me.Username)
+			} else {
+				mockLockout.AssertNotCalled(t, "Fail", mock.Anything, mock.Anything)
+			}
+			if tt.wantCheckError != nil {
+				mockRepo.AssertNotCalled(t, "GetPassword", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
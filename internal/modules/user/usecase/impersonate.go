@@ -0,0 +1,94 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rzfhlv/gin-example/internal/modules/user/model"
+	"github.com/rzfhlv/gin-example/pkg/audit"
+	"github.com/rzfhlv/gin-example/pkg/identity"
+	pJwt "github.com/rzfhlv/gin-example/pkg/jwt"
+	"github.com/rzfhlv/gin-example/pkg/rbac"
+	"github.com/rzfhlv/gin-example/pkg/session"
+	pToken "github.com/rzfhlv/gin-example/pkg/token"
+)
+
+// Impersonate starts a short-lived session as another user for the caller.
+// It comes without a refresh token and can't be chained, nor used against
+// someone who may impersonate as well.
+func (u *Usecase) Impersonate(ctx context.Context, impersonate model.Impersonate) (jwt model.JWT, err error) {
+	caller, ok := identity.FromContext(ctx)
+	if !ok || caller.ActorID != 0 || caller.ID == impersonate.UserID {
+		err = rbac.ErrForbidden
+		return
+	}
+
+	user, err := u.repo.GetByID(ctx, impersonate.UserID)
+	if err != nil {
+		return
+	}
+
+	roles, err := u.repo.GetRoles(ctx, user.ID)
+	if err != nil {
+		return
+	}
+	if rbac.Can(roles, rbac.USERSIMPERSONATE) {
+		err = rbac.ErrForbidden
+		return
+	}
+
+	ttl := u.expiry.Impersonate
+
+	sessionID, err := pToken.Generate(pToken.DEFAULTLENGTH)
+	if err != nil {
+		return
+	}
+
+	accessToken, err := u.jwtImpl.Generate(pJwt.Payload{
+		ID:            user.ID,
+		Username:      user.Username,
+		Email:         user.Email,
+		SessionID:     sessionID,
+		Roles:         roles,
+		EmailVerified: user.EmailVerifiedAt != nil,
+		Actor:         &pJwt.Actor{ID: caller.ID, Username: caller.Username},
+		Expired:       ttl,
+	})
+	if err != nil {
+		return
+	}
+
+	now := time.Now()
+	err = u.session.Save(ctx, session.Session{
+		ID:         sessionID,
+		UserID:     user.ID,
+		Username:   user.Username,
+		Email:      user.Email,
+		IP:         impersonate.IP,
+		UserAgent:  impersonate.UserAgent,
+		ActorID:    caller.ID,
+		CreatedAt:  now,
+		LastSeenAt: now,
+	}, ttl)
+	if err != nil {
+		return
+	}
+
+	err = u.audit.Record(ctx, audit.Entry{
+		ActorID:   caller.ID,
+		UserID:    user.ID,
+		SessionID: sessionID,
+		Action:    audit.START,
+		IP:        impersonate.IP,
+		UserAgent: impersonate.UserAgent,
+		CreatedAt: now,
+	})
+	if err != nil {
+		return
+	}
+
+	jwt.Token = accessToken
+	jwt.Expired = fmt.Sprintf("%d Minute", int(ttl/time.Minute))
+	return
+}
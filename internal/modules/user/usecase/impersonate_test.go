@@ -0,0 +1,177 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-redis/redismock/v9"
+
+	"github.com/rzfhlv/gin-example/internal/modules/user/model"
+	"github.com/rzfhlv/gin-example/pkg/audit"
+	pErrors "github.com/rzfhlv/gin-example/pkg/errors"
+	"github.com/rzfhlv/gin-example/pkg/identity"
+	pJwt "github.com/rzfhlv/gin-example/pkg/jwt"
+	"github.com/rzfhlv/gin-example/pkg/rbac"
+	"github.com/rzfhlv/gin-example/pkg/session"
+	pToken "github.com/rzfhlv/gin-example/pkg/token"
+	mockRepo "github.com/rzfhlv/gin-example/shared/mocks/modules/user/repository"
+	mockAudit "github.com/rzfhlv/gin-example/shared/mocks/pkg/audit"
+	mockHasher "github.com/rzfhlv/gin-example/shared/mocks/pkg/hasher"
+	mockJwt "github.com/rzfhlv/gin-example/shared/mocks/pkg/jwt"
+	mockPassword "github.com/rzfhlv/gin-example/shared/mocks/pkg/password"
+	mockSession "github.com/rzfhlv/gin-example/shared/mocks/pkg/session"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestImpersonate(t *testing.T) {
+	admin := identity.Identity{ID: 9, Username: "admin", Roles: []string{rbac.ADMIN}}
+	impersonating := admin
+	impersonating.ActorID = 10
+	impersonate := model.Impersonate{UserID: me.ID, IP: "127.0.0.1", UserAgent: "test-agent"}
+
+	testCase := []struct {
+		name                             string
+		caller                           *identity.Identity
+		userID                           int64
+		targetRoles                      []string
+		wantUserError, wantJwtError      error
+		wantSessionError, wantAuditError error
+		wantError                        error
+	}{
+		{
+			name: "Testcase #1: Positive", caller: &admin, userID: me.ID, targetRoles: roles,
+		},
+		{
+			name: "Testcase #2: Negative", userID: me.ID, targetRoles: roles, wantError: rbac.ErrForbidden,
+		},
+		{
+			name: "Testcase #3: Negative", caller: &impersonating, userID: me.ID, targetRoles: roles, wantError: rbac.ErrForbidden,
+		},
+		{
+			name: "Testcase #4: Negative", caller: &admin, userID: admin.ID, targetRoles: roles, wantError: rbac.ErrForbidden,
+		},
+		{
+			name: "Testcase #5: Negative", caller: &admin, userID: me.ID, targetRoles: []string{rbac.ADMIN}, wantError: rbac.ErrForbidden,
+		},
+		{
+			name: "Testcase #6: Negative", caller: &admin, userID: me.ID, targetRoles: roles, wantUserError: pErrors.ErrNotFound, wantError: pErrors.ErrNotFound,
+		},
+		{
+			name: "Testcase #7: Negative", caller: &admin, userID: me.ID, targetRoles: roles, wantJwtError: errFoo, wantError: errFoo,
+		},
+		{
+			name: "Testcase #8: Negative", caller: &admin, userID: me.ID, targetRoles: roles, wantSessionError: errFoo, wantError: errFoo,
+		},
+		{
+			name: "Testcase #9: Negative", caller: &admin, userID: me.ID, targetRoles: roles, wantAuditError: errFoo, wantError: errFoo,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mockRepo.IRepository{}
+			mockJwt := mockJwt.JWTInterface{}
+			mockSession := mockSession.Store{}
+			mockAudit := mockAudit.Store{}
+
+			mockRepo.On("GetByID", mock.Anything, tt.userID).Return(me, tt.wantUserError)
+			mockRepo.On("GetRoles", mock.Anything, me.ID).Return(tt.targetRoles, nil)
+			mockJwt.On("Generate", mock.Anything).Return("thisistoken", tt.wantJwtError)
+			mockSession.On("Save", mock.Anything, mock.Anything, mock.Anything).Return(tt.wantSessionError)
+			mockAudit.On("Record", mock.Anything, mock.Anything).Return(tt.wantAuditError)
+
+			u := &Usecase{
+				expiry:  expiry,
+				repo:    &mockRepo,
+				jwtImpl: &mockJwt,
+				session: &mockSession,
+				audit:   &mockAudit,
+			}
+
+			ctx := context.Background()
+			if tt.caller != nil {
+				ctx = identity.NewContext(ctx, *tt.caller)
+			}
+
+			request := impersonate
+			request.UserID = tt.userID
+			jwt, err := u.Impersonate(ctx, request)
+			assert.Equal(t, tt.wantError, err)
+			if tt.wantError != nil {
+				if tt.wantError == rbac.ErrForbidden {
+					mockJwt.AssertNotCalled(t, "Generate", mock.Anything)
+				}
+				return
+			}
+
+			assert.Equal(t, "thisistoken", jwt.Token)
+			assert.Equal(t, "15 Minute", jwt.Expired)
+			assert.Empty(t, jwt.RefreshToken)
+			mockJwt.AssertCalled(t, "Generate", mock.MatchedBy(func(p pJwt.Payload) bool {
+				return p.ID == me.ID && p.Actor != nil && p.Actor.ID == admin.ID && p.Actor.Username == admin.Username &&
+					p.Expired == expiry.Impersonate
+			}))
+			mockSession.AssertCalled(t, "Save", mock.Anything, mock.MatchedBy(func(s session.Session) bool {
+				return s.UserID == me.ID && s.ActorID == admin.ID && s.Refresh == "" && s.IP == impersonate.IP
+			}), expiry.Impersonate)
+			mockAudit.AssertCalled(t, "Record", mock.Anything, mock.MatchedBy(func(e audit.Entry) bool {
+				return e.ActorID == admin.ID && e.UserID == me.ID && e.Action == audit.START && e.UserAgent == impersonate.UserAgent
+			}))
+		})
+	}
+}
+
+// An impersonation session is short, saving it must not shorten the index of
+// the sessions the user already has, a password reset afterwards still finds
+// and revokes them.
+func TestImpersonateThenResetPassword(t *testing.T) {
+	admin := identity.Identity{ID: 9, Username: "admin", Roles: []string{rbac.ADMIN}}
+	reset := model.ResetPassword{Token: "thisisresettoken", Password: "newpassword"}
+	resetKey := RESETKEY + pToken.Hash(reset.Token)
+	userKey := "user_sessions:1"
+
+	client, redisMock := redismock.NewClientMock()
+	mockRepo := mockRepo.IRepository{}
+	mockJwt := mockJwt.JWTInterface{}
+	mockAudit := mockAudit.Store{}
+	mockHasher := mockHasher.HashPassword{}
+	mockPassword := mockPassword.Checker{}
+
+	mockRepo.On("GetByID", mock.Anything, me.ID).Return(me, nil)
+	mockRepo.On("GetRoles", mock.Anything, me.ID).Return(roles, nil)
+	mockJwt.On("Generate", mock.Anything).Return("thisistoken", nil)
+	mockAudit.On("Record", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("Get", mock.Anything, resetKey).Return("1", nil)
+	mockRepo.On("GetDel", mock.Anything, resetKey).Return("1", nil)
+	mockPassword.On("Check", reset.Password, me.Username, me.Email).Return(nil)
+	mockHasher.On("HashedPassword", reset.Password).Return("hashed", nil)
+	mockRepo.On("UpdatePassword", mock.Anything, me.ID, "hashed").Return(nil)
+	mockRepo.On("DeleteMFA", mock.Anything, me.ID).Return(nil)
+
+	// the index already outlives the impersonation session, it is kept
+	regexp := redisMock.Regexp()
+	regexp.ExpectSet("^session:", `"actor_id":9`, expiry.Impersonate).SetVal("OK")
+	regexp.ExpectSAdd(userKey, ".+").SetVal(1)
+	redisMock.ExpectEvalSha(session.EXTEND.Hash(), []string{userKey}, expiry.Impersonate.Milliseconds()).SetVal(int64(0))
+	redisMock.ExpectSMembers(userKey).SetVal([]string{sessionID})
+	redisMock.ExpectDel("session:" + sessionID).SetVal(1)
+	redisMock.ExpectDel(userKey).SetVal(1)
+
+	u := &Usecase{
+		expiry:  expiry,
+		repo:    &mockRepo,
+		hasher:  &mockHasher,
+		jwtImpl: &mockJwt,
+		session: session.New(client),
+		policy:  &mockPassword,
+		audit:   &mockAudit,
+	}
+
+	ctx := identity.NewContext(context.Background(), admin)
+	_, err := u.Impersonate(ctx, model.Impersonate{UserID: me.ID})
+	assert.NoError(t, err)
+
+	err = u.ResetPassword(context.Background(), reset)
+	assert.NoError(t, err)
+	assert.NoError(t, redisMock.ExpectationsWereMet())
+}
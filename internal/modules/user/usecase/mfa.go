@@ -0,0 +1,343 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rzfhlv/gin-example/internal/modules/user/model"
+	pErrors "github.com/rzfhlv/gin-example/pkg/errors"
+	"github.com/rzfhlv/gin-example/pkg/message"
+	"github.com/rzfhlv/gin-example/pkg/session"
+	pToken "github.com/rzfhlv/gin-example/pkg/token"
+	"github.com/rzfhlv/gin-example/pkg/totp"
+)
+
+var (
+	RECOVERYCODES = 10
+
+	MFAKEY = "mfa_pending:"
+
+	ErrMFAEnabled      = pErrors.Conflict(message.MFAENABLED)
+	ErrInvalidMFAToken = errors.New("invalid mfa token")
+	ErrInvalidMFACode  = errors.New("invalid mfa code")
+)
+
+func (u *Usecase) EnrollMFA(ctx context.Context, userID int64) (enrollment model.MFAEnrollment, err error) {
+	user, err := u.repo.GetByID(ctx, userID)
+	if err != nil {
+		return
+	}
+
+	enabled, err := u.mfaEnabled(ctx, userID)
+	if err != nil {
+		return
+	}
+	if enabled {
+		err = ErrMFAEnabled
+		return
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return
+	}
+
+	err = u.repo.SaveMFA(ctx, model.MFA{UserID: userID, Secret: secret, CreatedAt: time.Now()})
+	if err != nil {
+		return
+	}
+
+	enrollment.Secret = secret
+	enrollment.URI = totp.URI(u.app.Name, user.Email, secret)
+	return
+}
+
+// ConfirmMFA turns 2FA on once the user proves the authenticator works, the
+// recovery codes are only ever returned here.
+func (u *Usecase) ConfirmMFA(ctx context.Context, userID int64, code model.MFACode) (codes model.RecoveryCodes, err error) {
+	mfa, err := u.repo.GetMFA(ctx, userID)
+	if err != nil {
+		return
+	}
+	if mfa.EnabledAt != nil {
+		err = ErrMFAEnabled
+		return
+	}
+
+	step, ok := totp.Match(mfa.Secret, code.Code, time.Now())
+	if !ok {
+		err = ErrInvalidMFACode
+		return
+	}
+
+	// the code proving the authenticator can't log in again afterwards
+	err = u.useStep(ctx, userID, step)
+	if err != nil {
+		return
+	}
+
+	now := time.Now()
+	hashed := make([]string, 0, RECOVERYCODES)
+	codes.RecoveryCodes = make([]string, 0, RECOVERYCODES)
+	for i := 0; i < RECOVERYCODES; i++ {
+		var recoveryCode, hashedCode string
+		recoveryCode, err = generateRecoveryCode()
+		if err != nil {
+			return
+		}
+
+		hashedCode, err = u.hasher.HashedPassword(normalizeRecoveryCode(recoveryCode))
+		if err != nil {
+			return
+		}
+
+		codes.RecoveryCodes = append(codes.RecoveryCodes, recoveryCode)
+		hashed = append(hashed, hashedCode)
+	}
+
+	err = u.repo.SaveRecoveryCodes(ctx, userID, hashed, now)
+	if err != nil {
+		return
+	}
+
+	result, err := u.repo.EnableMFA(ctx, userID, now)
+	if err != nil {
+		return
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return
+	}
+	if affected < 1 {
+		err = ErrMFAEnabled
+	}
+	return
+}
+
+// LoginMFA exchanges the pending token from Login plus a TOTP or recovery
+// code for the real tokens. The pending token is single use, a wrong code
+// means logging in with the password again.
+func (u *Usecase) LoginMFA(ctx context.Context, login model.LoginMFA) (jwt model.JWT, err error) {
+	value, err := u.repo.GetDel(ctx, MFAKEY+pToken.Hash(login.MFAToken))
+	if err != nil {
+		if err == redis.Nil {
+			err = ErrInvalidMFAToken
+		}
+		return
+	}
+
+	userID, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return
+	}
+
+	user, err := u.repo.GetByID(ctx, userID)
+	if err != nil {
+		return
+	}
+	username := strings.ToLower(user.Username)
+
+	err = u.loginIPs.Check(ctx, login.IP)
+	if err != nil {
+		return
+	}
+
+	err = u.loginUsers.Check(ctx, username)
+	if err != nil {
+		return
+	}
+
+	mfa, err := u.repo.GetMFA(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pErrors.ErrNotFound) {
+			err = ErrInvalidMFAToken
+		}
+		return
+	}
+
+	// a wrong second factor counts like a wrong password, otherwise the
+	// password alone would buy unlimited guesses at the code
+	err = u.verifyMFA(ctx, mfa, login.Code)
+	if err != nil {
+		if err == ErrInvalidMFACode {
+			err = u.failLogin(ctx, username, login.IP, ErrInvalidMFACode)
+		}
+		return
+	}
+
+	err = u.loginUsers.Reset(ctx, username)
+	if err != nil {
+		return
+	}
+
+	jwt, err = u.issue(ctx, session.Session{
+		UserID:    user.ID,
+		Username:  user.Username,
+		Email:     user.Email,
+		IP:        login.IP,
+		UserAgent: login.UserAgent,
+	})
+	return
+}
+
+// DisableMFA turns 2FA off with the password and a current TOTP or recovery
+// code, a stolen session with the password still can't strip the second
+// factor. A user who lost the authenticator and the recovery codes goes
+// through the password reset instead. A wrong code counts against the same
+// lockout as a login.
+func (u *Usecase) DisableMFA(ctx context.Context, userID int64, disable model.DisableMFA) (err error) {
+	user, err := u.checkPassword(ctx, userID, disable.Password)
+	if err != nil {
+		return
+	}
+	username := strings.ToLower(user.Username)
+
+	mfa, err := u.repo.GetMFA(ctx, userID)
+	if err != nil {
+		return
+	}
+	// a pending enrollment isn't 2FA yet, enrolling again replaces it
+	if mfa.EnabledAt == nil {
+		err = pErrors.ErrNotFound
+		return
+	}
+
+	err = u.verifyMFA(ctx, mfa, disable.Code)
+	if err == ErrInvalidMFACode {
+		err = u.loginUsers.Fail(ctx, username)
+		if err == nil {
+			err = ErrInvalidMFACode
+		}
+		return
+	}
+	if err != nil {
+		return
+	}
+
+	err = u.loginUsers.Reset(ctx, username)
+	if err != nil {
+		return
+	}
+
+	err = u.repo.DeleteMFA(ctx, userID)
+	return
+}
+
+func (u *Usecase) mfaEnabled(ctx context.Context, userID int64) (enabled bool, err error) {
+	mfa, err := u.repo.GetMFA(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pErrors.ErrNotFound) {
+			err = nil
+		}
+		return
+	}
+
+	enabled = mfa.EnabledAt != nil
+	return
+}
+
+// challenge parks a password-verified login until the second factor is given.
+func (u *Usecase) challenge(ctx context.Context, userID int64) (jwt model.JWT, err error) {
+	mfaToken, err := pToken.Generate(pToken.DEFAULTLENGTH)
+	if err != nil {
+		return
+	}
+
+	err = u.repo.Set(ctx, MFAKEY+pToken.Hash(mfaToken), strconv.FormatInt(userID, 10), u.expiry.MFA)
+	if err != nil {
+		return
+	}
+
+	jwt.MFAToken = mfaToken
+	jwt.MFAExpired = fmt.Sprintf("%d Minute", int(u.expiry.MFA/time.Minute))
+	return
+}
+
+func (u *Usecase) verifyMFA(ctx context.Context, mfa model.MFA, code string) (err error) {
+	if mfa.EnabledAt == nil {
+		err = ErrInvalidMFAToken
+		return
+	}
+
+	if step, ok := totp.Match(mfa.Secret, code, time.Now()); ok {
+		err = u.useStep(ctx, mfa.UserID, step)
+		return
+	}
+
+	recoveryCodes, err := u.repo.GetRecoveryCodes(ctx, mfa.UserID)
+	if err != nil {
+		return
+	}
+
+	normalized := normalizeRecoveryCode(code)
+	for _, recoveryCode := range recoveryCodes {
+		if u.hasher.VerifyPassword(recoveryCode.Code, normalized) != nil {
+			continue
+		}
+
+		// marking the code used only succeeds once, so a code raced by two
+		// logins lets one of them through
+		var result sql.Result
+		result, err = u.repo.UseRecoveryCode(ctx, recoveryCode.ID, time.Now())
+		if err != nil {
+			return
+		}
+
+		var affected int64
+		affected, err = result.RowsAffected()
+		if err != nil {
+			return
+		}
+		if affected < 1 {
+			err = ErrInvalidMFACode
+		}
+		return
+	}
+
+	err = ErrInvalidMFACode
+	return
+}
+
+// useStep records the time step of an accepted TOTP code, a code from that
+// step or an earlier one is refused from then on even while still in SKEW.
+func (u *Usecase) useStep(ctx context.Context, userID, step int64) (err error) {
+	result, err := u.repo.UseMFAStep(ctx, userID, step)
+	if err != nil {
+		return
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return
+	}
+	if affected < 1 {
+		err = ErrInvalidMFACode
+	}
+	return
+}
+
+func generateRecoveryCode() (code string, err error) {
+	b := make([]byte, 5)
+	_, err = rand.Read(b)
+	if err != nil {
+		return
+	}
+
+	encoded := base32.StdEncoding.EncodeToString(b)
+	code = encoded[:4] + "-" + encoded[4:]
+	return
+}
+
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	return strings.ReplaceAll(code, "-", "")
+}
@@ -0,0 +1,402 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rzfhlv/gin-example/internal/modules/user/model"
+	pErrors "github.com/rzfhlv/gin-example/pkg/errors"
+	"github.com/rzfhlv/gin-example/pkg/lockout"
+	pToken "github.com/rzfhlv/gin-example/pkg/token"
+	"github.com/rzfhlv/gin-example/pkg/totp"
+	mockRepo "github.com/rzfhlv/gin-example/shared/mocks/modules/user/repository"
+	mockHasher "github.com/rzfhlv/gin-example/shared/mocks/pkg/hasher"
+	mockJwt "github.com/rzfhlv/gin-example/shared/mocks/pkg/jwt"
+	mockLockout "github.com/rzfhlv/gin-example/shared/mocks/pkg/lockout"
+	mockSession "github.com/rzfhlv/gin-example/shared/mocks/pkg/session"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var (
+	mfaSecret, _ = totp.GenerateSecret()
+	enabledAt    = time.Now()
+	pendingMFA   = model.MFA{UserID: 1, Secret: mfaSecret, CreatedAt: enabledAt}
+	enabledMFA   = model.MFA{UserID: 1, Secret: mfaSecret, EnabledAt: &enabledAt, CreatedAt: enabledAt}
+)
+
+// stepResult is what UseMFAStep answers, nothing changes when the step of the
+// code was already used.
+func stepResult(replayed bool) *CustomResult {
+	if replayed {
+		return &CustomResult{rowsAffected: 0}
+	}
+	return &CustomResult{rowsAffected: 1}
+}
+
+func TestEnrollMFA(t *testing.T) {
+	testCase := []struct {
+		name                                                  string
+		mfa                                                   model.MFA
+		wantUserError, wantMFAError, wantSaveError, wantError error
+	}{
+		{
+			name: "Testcase #1: Positive", wantMFAError: pErrors.ErrNotFound,
+		},
+		{
+			name: "Testcase #2: Positive", mfa: pendingMFA,
+		},
+		{
+			name: "Testcase #3: Negative", mfa: enabledMFA, wantError: ErrMFAEnabled,
+		},
+		{
+			name: "Testcase #4: Negative", wantUserError: pErrors.ErrNotFound, wantError: pErrors.ErrNotFound,
+		},
+		{
+			name: "Testcase #5: Negative", wantMFAError: errFoo, wantError: errFoo,
+		},
+		{
+			name: "Testcase #6: Negative", wantMFAError: pErrors.ErrNotFound, wantSaveError: errFoo, wantError: errFoo,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mockRepo.IRepository{}
+			mockRepo.On("GetByID", mock.Anything, int64(1)).Return(model.User{ID: 1, Email: register.Email}, tt.wantUserError)
+			mockRepo.On("GetMFA", mock.Anything, int64(1)).Return(tt.mfa, tt.wantMFAError)
+			mockRepo.On("SaveMFA", mock.Anything, mock.Anything).Return(tt.wantSaveError)
+
+			u := &Usecase{
+				expiry: expiry,
+				app:    app,
+				repo:   &mockRepo,
+			}
+
+			enrollment, err := u.EnrollMFA(context.Background(), 1)
+			assert.Equal(t, tt.wantError, err)
+			if tt.wantError == nil {
+				assert.NotEmpty(t, enrollment.Secret)
+				assert.Contains(t, enrollment.URI, "issuer="+app.Name)
+				assert.Contains(t, enrollment.URI, "secret="+enrollment.Secret)
+				assert.Contains(t, enrollment.URI, register.Email)
+			}
+		})
+	}
+}
+
+func TestConfirmMFA(t *testing.T) {
+	code, _ := totp.Code(mfaSecret, time.Now())
+
+	testCase := []struct {
+		name                                                                   string
+		mfa                                                                    model.MFA
+		code                                                                   string
+		result                                                                 CustomResult
+		replayed                                                               bool
+		wantMFAError, wantHashError, wantSaveError, wantEnableError, wantError error
+		wantStepError                                                          error
+	}{
+		{
+			name: "Testcase #1: Positive", mfa: pendingMFA, code: code, result: CustomResult{rowsAffected: 1},
+		},
+		{
+			name: "Testcase #2: Negative", wantMFAError: pErrors.ErrNotFound, code: code, wantError: pErrors.ErrNotFound,
+		},
+		{
+			name: "Testcase #3: Negative", mfa: enabledMFA, code: code, wantError: ErrMFAEnabled,
+		},
+		{
+			name: "Testcase #4: Negative", mfa: pendingMFA, code: "000000", wantError: ErrInvalidMFACode,
+		},
+		{
+			name: "Testcase #5: Negative", mfa: pendingMFA, code: code, wantHashError: errFoo, wantError: errFoo,
+		},
+		{
+			name: "Testcase #6: Negative", mfa: pendingMFA, code: code, wantSaveError: errFoo, wantError: errFoo,
+		},
+		{
+			name: "Testcase #7: Negative", mfa: pendingMFA, code: code, wantEnableError: errFoo, wantError: errFoo,
+		},
+		{
+			name: "Testcase #8: Negative", mfa: pendingMFA, code: code, result: CustomResult{rowsAffected: 0}, wantError: ErrMFAEnabled,
+		},
+		{
+			name: "Testcase #9: Negative", mfa: pendingMFA, code: code, replayed: true, wantError: ErrInvalidMFACode,
+		},
+		{
+			name: "Testcase #10: Negative", mfa: pendingMFA, code: code, wantStepError: errFoo, wantError: errFoo,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mockRepo.IRepository{}
+			mockHasher := mockHasher.HashPassword{}
+			mockRepo.On("GetMFA", mock.Anything, int64(1)).Return(tt.mfa, tt.wantMFAError)
+			mockRepo.On("UseMFAStep", mock.Anything, int64(1), mock.Anything).Return(stepResult(tt.replayed), tt.wantStepError)
+			mockRepo.On("SaveRecoveryCodes", mock.Anything, int64(1), mock.Anything, mock.Anything).Return(tt.wantSaveError)
+			mockRepo.On("EnableMFA", mock.Anything, int64(1), mock.Anything).Return(&tt.result, tt.wantEnableError)
+			mockHasher.On("HashedPassword", mock.Anything).Return("hashed", tt.wantHashError)
+
+			u := &Usecase{
+				expiry: expiry,
+				repo:   &mockRepo,
+				hasher: &mockHasher,
+			}
+
+			codes, err := u.ConfirmMFA(context.Background(), 1, model.MFACode{Code: tt.code})
+			assert.Equal(t, tt.wantError, err)
+			if tt.wantError == nil {
+				assert.Len(t, codes.RecoveryCodes, RECOVERYCODES)
+				assert.Regexp(t, "^[A-Z2-7]{4}-[A-Z2-7]{4}$", codes.RecoveryCodes[0])
+				mockRepo.AssertCalled(t, "SaveRecoveryCodes", mock.Anything, int64(1), mock.MatchedBy(func(hashed []string) bool {
+					return len(hashed) == RECOVERYCODES && hashed[0] == "hashed"
+				}), mock.Anything)
+			}
+		})
+	}
+}
+
+func TestLoginMFA(t *testing.T) {
+	mfaToken := "thisismfatoken"
+	mfaKey := MFAKEY + pToken.Hash(mfaToken)
+	code, _ := totp.Code(mfaSecret, time.Now())
+	locked := &lockout.Error{Locked: true, RetryAfter: time.Minute}
+	recoveryCodes := []model.RecoveryCode{
+		{ID: 1, UserID: 1, Code: "hashedone"}, {ID: 2, UserID: 1, Code: "hashedtwo"},
+	}
+
+	testCase := []struct {
+		name                                                                         string
+		mfa                                                                          model.MFA
+		code                                                                         string
+		result                                                                       CustomResult
+		replayed                                                                     bool
+		wantRedisError, wantMFAError, wantRecoveryError, wantUserError, wantJwtError error
+		wantCheckError, wantFailError                                                error
+		wantError                                                                    error
+	}{
+		{
+			name: "Testcase #1: Positive", mfa: enabledMFA, code: code,
+		},
+		{
+			name: "Testcase #2: Positive", mfa: enabledMFA, code: "abcd-2345", result: CustomResult{rowsAffected: 1},
+		},
+		{
+			name: "Testcase #3: Negative", wantRedisError: redis.Nil, wantError: ErrInvalidMFAToken,
+		},
+		{
+			name: "Testcase #4: Negative", wantMFAError: pErrors.ErrNotFound, wantError: ErrInvalidMFAToken,
+		},
+		{
+			name: "Testcase #5: Negative", mfa: pendingMFA, code: code, wantError: ErrInvalidMFAToken,
+		},
+		{
+			name: "Testcase #6: Negative", mfa: enabledMFA, code: "000000", wantError: ErrInvalidMFACode,
+		},
+		{
+			name: "Testcase #7: Negative", mfa: enabledMFA, code: "ABCD2345", result: CustomResult{rowsAffected: 0}, wantError: ErrInvalidMFACode,
+		},
+		{
+			name: "Testcase #8: Negative", mfa: enabledMFA, code: "000000", wantRecoveryError: errFoo, wantError: errFoo,
+		},
+		{
+			name: "Testcase #9: Negative", mfa: enabledMFA, code: code, wantUserError: errFoo, wantError: errFoo,
+		},
+		{
+			name: "Testcase #10: Negative", mfa: enabledMFA, code: code, wantJwtError: errFoo, wantError: errFoo,
+		},
+		{
+			name: "Testcase #11: Negative", mfa: enabledMFA, code: code, replayed: true, wantError: ErrInvalidMFACode,
+		},
+		{
+			name: "Testcase #12: Negative", mfa: enabledMFA, code: code, wantCheckError: locked, wantError: locked,
+		},
+		{
+			name: "Testcase #13: Negative", mfa: enabledMFA, code: "000000", wantFailError: locked, wantError: locked,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mockRepo.IRepository{}
+			mockHasher := mockHasher.HashPassword{}
+			mockJwt := mockJwt.JWTInterface{}
+			mockSession := mockSession.Store{}
+			mockUsers := mockLockout.Limiter{}
+			mockIPs := mockLockout.Limiter{}
+
+			mockRepo.On("GetDel", mock.Anything, mfaKey).Return("1", tt.wantRedisError)
+			mockRepo.On("GetMFA", mock.Anything, int64(1)).Return(tt.mfa, tt.wantMFAError)
+			mockRepo.On("GetRecoveryCodes", mock.Anything, int64(1)).Return(recoveryCodes, tt.wantRecoveryError)
+			mockRepo.On("UseRecoveryCode", mock.Anything, int64(2), mock.Anything).Return(&tt.result, nil)
+			mockRepo.On("UseMFAStep", mock.Anything, int64(1), mock.Anything).Return(stepResult(tt.replayed), nil)
+			mockRepo.On("GetByID", mock.Anything, int64(1)).Return(model.User{ID: 1, Username: register.Username, Email: register.Email}, tt.wantUserError)
+			mockRepo.On("GetRoles", mock.Anything, int64(1)).Return(roles, nil)
+			mockRepo.On("Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			mockHasher.On("VerifyPassword", "hashedtwo", "ABCD2345").Return(nil)
+			mockHasher.On("VerifyPassword", mock.Anything, mock.Anything).Return(bcrypt.ErrMismatchedHashAndPassword)
+			mockJwt.On("Generate", mock.Anything).Return("thisistoken", tt.wantJwtError)
+			mockSession.On("Save", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			mockIPs.On("Check", mock.Anything, mock.Anything).Return(nil)
+			mockIPs.On("Fail", mock.Anything, mock.Anything).Return(nil)
+			mockUsers.On("Check", mock.Anything, register.Username).Return(tt.wantCheckError)
+			mockUsers.On("Fail", mock.Anything, register.Username).Return(tt.wantFailError)
+			mockUsers.On("Reset", mock.Anything, register.Username).Return(nil)
+
+			u := &Usecase{
+				expiry:     expiry,
+				repo:       &mockRepo,
+				hasher:     &mockHasher,
+				jwtImpl:    &mockJwt,
+				session:    &mockSession,
+				loginUsers: &mockUsers,
+				loginIPs:   &mockIPs,
+			}
+
+			jwt, err := u.LoginMFA(context.Background(), model.LoginMFA{MFAToken: mfaToken, Code: tt.code})
+			assert.Equal(t, tt.wantError, err)
+			if tt.wantError == nil {
+				assert.Equal(t, "thisistoken", jwt.Token)
+				assert.Empty(t, jwt.MFAToken)
+				mockUsers.AssertCalled(t, "Reset", mock.Anything, register.Username)
+			}
+			if tt.wantError == ErrInvalidMFACode || tt.wantFailError != nil {
+				mockUsers.AssertCalled(t, "Fail", mock.Anything, register.Username)
+				mockUsers.AssertNotCalled(t, "Reset", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestLoginChallenge(t *testing.T) {
+	testCase := []struct {
+		name                         string
+		wantRedisError, wantMFAError error
+		wantError                    error
+	}{
+		{
+			name: "Testcase #1: Positive",
+		},
+		{
+			name: "Testcase #2: Negative", wantRedisError: errFoo, wantError: errFoo,
+		},
+		{
+			name: "Testcase #3: Negative", wantMFAError: errFoo, wantError: errFoo,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mockRepo.IRepository{}
+			mockHasher := mockHasher.HashPassword{}
+			mockLockout := mockLockout.Limiter{}
+
+			mockLockout.On("Check", mock.Anything, mock.Anything).Return(nil)
+			mockLockout.On("Reset", mock.Anything, mock.Anything).Return(nil)
+			mockRepo.On("Login", mock.Anything, login).Return(model.Register{ID: 1, Password: "hashed"}, nil)
+			mockRepo.On("GetMFA", mock.Anything, int64(1)).Return(enabledMFA, tt.wantMFAError)
+			mockRepo.On("Set", mock.Anything, mock.Anything, "1", expiry.MFA).Return(tt.wantRedisError)
+			mockHasher.On("VerifyPassword", "hashed", login.Password).Return(nil)
+			mockHasher.On("NeedsRehash", "hashed").Return(false)
+
+			u := &Usecase{
+				expiry:     expiry,
+				repo:       &mockRepo,
+				hasher:     &mockHasher,
+				loginUsers: &mockLockout,
+				loginIPs:   &mockLockout,
+			}
+
+			jwt, err := u.Login(context.Background(), login)
+			assert.Equal(t, tt.wantError, err)
+			if tt.wantError == nil {
+				assert.NotEmpty(t, jwt.MFAToken)
+				assert.Empty(t, jwt.Token)
+				assert.Empty(t, jwt.RefreshToken)
+			}
+			mockLockout.AssertNotCalled(t, "Reset", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestDisableMFA(t *testing.T) {
+	code, _ := totp.Code(mfaSecret, time.Now())
+	locked := &lockout.Error{Locked: true, RetryAfter: time.Minute}
+	pendingMFA := model.MFA{UserID: 1, Secret: mfaSecret, CreatedAt: enabledAt}
+
+	testCase := []struct {
+		name                            string
+		mfa                             model.MFA
+		code                            string
+		wantPasswordError, wantMFAError error
+		wantFailError                   error
+		wantDeleteError, wantError      error
+		failed                          bool
+	}{
+		{
+			name: "Testcase #1: Positive", mfa: enabledMFA, code: code,
+		},
+		{
+			name: "Testcase #2: Positive", mfa: enabledMFA, code: "ABCD2345",
+		},
+		{
+			name: "Testcase #3: Negative", mfa: enabledMFA, code: code, wantPasswordError: bcrypt.ErrMismatchedHashAndPassword, wantError: ErrInvalidCredentials, failed: true,
+		},
+		{
+			name: "Testcase #4: Negative", mfa: enabledMFA, code: code, wantMFAError: pErrors.ErrNotFound, wantError: pErrors.ErrNotFound,
+		},
+		{
+			name: "Testcase #5: Negative", mfa: enabledMFA, code: code, wantDeleteError: errFoo, wantError: errFoo,
+		},
+		{
+			name: "Testcase #6: Negative", mfa: enabledMFA, code: "000000", wantError: ErrInvalidMFACode, failed: true,
+		},
+		{
+			name: "Testcase #7: Negative", mfa: enabledMFA, code: "000000", wantFailError: locked, wantError: locked, failed: true,
+		},
+		{
+			name: "Testcase #8: Negative", mfa: pendingMFA, code: code, wantError: pErrors.ErrNotFound,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mockRepo.IRepository{}
+			mockHasher := mockHasher.HashPassword{}
+			mockLockout := mockLockout.Limiter{}
+			mockLockout.On("Check", mock.Anything, me.Username).Return(nil)
+			mockLockout.On("Fail", mock.Anything, me.Username).Return(tt.wantFailError)
+			mockLockout.On("Reset", mock.Anything, me.Username).Return(nil)
+			mockRepo.On("GetByID", mock.Anything, int64(1)).Return(me, nil)
+			mockRepo.On("GetPassword", mock.Anything, int64(1)).Return("hashed", nil)
+			mockRepo.On("GetMFA", mock.Anything, int64(1)).Return(tt.mfa, tt.wantMFAError)
+			mockRepo.On("UseMFAStep", mock.Anything, int64(1), mock.Anything).Return(stepResult(false), nil)
+			mockRepo.On("GetRecoveryCodes", mock.Anything, int64(1)).Return([]model.RecoveryCode{{ID: 2, UserID: 1, Code: "hashedtwo"}}, nil)
+			mockRepo.On("UseRecoveryCode", mock.Anything, int64(2), mock.Anything).Return(&CustomResult{rowsAffected: 1}, nil)
+			mockRepo.On("DeleteMFA", mock.Anything, int64(1)).Return(tt.wantDeleteError)
+			mockHasher.On("VerifyPassword", "hashed", "password").Return(tt.wantPasswordError)
+			mockHasher.On("VerifyPassword", "hashedtwo", "ABCD2345").Return(nil)
+			mockHasher.On("VerifyPassword", mock.Anything, mock.Anything).Return(bcrypt.ErrMismatchedHashAndPassword)
+
+			u := &Usecase{
+				expiry:     expiry,
+				repo:       &mockRepo,
+				hasher:     &mockHasher,
+				loginUsers: &mockLockout,
+			}
+
+			err := u.DisableMFA(context.Background(), 1, model.DisableMFA{Password: "password", Code: tt.code})
+			assert.Equal(t, tt.wantError, err)
+			if tt.wantError != nil && tt.wantDeleteError == nil {
+				mockRepo.AssertNotCalled(t, "DeleteMFA", mock.Anything, mock.Anything)
+				// the lockout is only cleared once both factors passed
+				mockLockout.AssertNotCalled(t, "Reset", mock.Anything, mock.Anything)
+			}
+			if tt.failed {
+				mockLockout.AssertCalled(t, "Fail", mock.Anything, me.Username)
+			} else {
+				mockLockout.AssertNotCalled(t, "Fail", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
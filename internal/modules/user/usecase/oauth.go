@@ -0,0 +1,164 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rzfhlv/gin-example/internal/modules/user/model"
+	"github.com/rzfhlv/gin-example/pkg/rbac"
+	"github.com/rzfhlv/gin-example/pkg/session"
+	pToken "github.com/rzfhlv/gin-example/pkg/token"
+)
+
+var (
+	ACCESSTOKEN  = "access_token"
+	REFRESHTOKEN = "refresh_token"
+)
+
+// Introspect answers whether a token is still usable. An access token has to
+// validate and still have its session, exactly like Bearer checks it, and a
+// refresh token has to be the current one of its session.
+func (u *Usecase) Introspect(ctx context.Context, request model.TokenRequest) (introspection model.Introspection, err error) {
+	introspection, _, err = u.lookupToken(ctx, request)
+	return
+}
+
+// RevokeToken ends the session behind either kind of token, so the access
+// and refresh tokens of that session stop working together. An unknown token
+// is not an error, there is nothing left to revoke.
+func (u *Usecase) RevokeToken(ctx context.Context, request model.TokenRequest) (err error) {
+	introspection, current, err := u.lookupToken(ctx, request)
+	if err != nil || !introspection.Active {
+		return
+	}
+
+	err = u.session.Revoke(ctx, current.UserID, current.ID)
+	if err == session.ErrNotFound {
+		err = nil
+	}
+	return
+}
+
+// lookupToken tries the hinted token type first, the hint is only a
+// shortcut so the other type is tried as well.
+func (u *Usecase) lookupToken(ctx context.Context, request model.TokenRequest) (introspection model.Introspection, current session.Session, err error) {
+	lookups := []func(context.Context, string) (model.Introspection, session.Session, error){u.introspectAccess, u.introspectRefresh}
+	if request.TokenTypeHint == REFRESHTOKEN {
+		lookups[0], lookups[1] = lookups[1], lookups[0]
+	}
+
+	for _, lookup := range lookups {
+		introspection, current, err = lookup(ctx, request.Token)
+		if err != nil || introspection.Active {
+			return
+		}
+	}
+	return
+}
+
+func (u *Usecase) introspectAccess(ctx context.Context, token string) (introspection model.Introspection, current session.Session, err error) {
+	claims, errValidate := u.jwtImpl.ValidateToken(token)
+	if errValidate != nil {
+		return
+	}
+
+	current, err = u.session.Get(ctx, claims.RegisteredClaims.ID)
+	if err != nil {
+		if err == session.ErrNotFound {
+			err = nil
+		}
+		return
+	}
+	if !current.Fits(claims.ID, claims.ActorID()) {
+		return
+	}
+
+	// the session names the user for both token types, a rename since the
+	// token was signed is already there
+	introspection = model.Introspection{
+		Active:    true,
+		Scope:     strings.Join(rbac.Grants(claims.Roles), " "),
+		Username:  current.Username,
+		TokenType: ACCESSTOKEN,
+		Subject:   current.Username,
+		Issuer:    claims.Issuer,
+		SessionID: current.ID,
+	}
+	if claims.ExpiresAt != nil {
+		introspection.Expired = claims.ExpiresAt.Unix()
+	}
+	if claims.IssuedAt != nil {
+		introspection.IssuedAt = claims.IssuedAt.Unix()
+	}
+
+	introspection.Actor, err = u.actor(ctx, current.ActorID)
+	return
+}
+
+func (u *Usecase) introspectRefresh(ctx context.Context, token string) (introspection model.Introspection, current session.Session, err error) {
+	hashed := pToken.Hash(token)
+
+	sessionID, err := u.repo.Get(ctx, REFRESHKEY+hashed)
+	if err != nil {
+		if err == redis.Nil {
+			err = nil
+		}
+		return
+	}
+
+	current, err = u.session.Get(ctx, sessionID)
+	if err != nil {
+		if err == session.ErrNotFound {
+			err = nil
+		}
+		return
+	}
+	if current.Refresh != hashed {
+		return
+	}
+
+	roles, err := u.repo.GetRoles(ctx, current.UserID)
+	if err != nil {
+		return
+	}
+
+	// the refresh token lives exactly as long as its session
+	ttl, err := u.session.TTL(ctx, current.ID)
+	if err != nil {
+		if err == session.ErrNotFound {
+			err = nil
+		}
+		return
+	}
+
+	introspection = model.Introspection{
+		Active:    true,
+		Scope:     strings.Join(rbac.Grants(roles), " "),
+		Username:  current.Username,
+		TokenType: REFRESHTOKEN,
+		Subject:   current.Username,
+		SessionID: current.ID,
+	}
+	if ttl > 0 {
+		introspection.Expired = time.Now().Add(ttl).Unix()
+	}
+
+	introspection.Actor, err = u.actor(ctx, current.ActorID)
+	return
+}
+
+// actor names who impersonates the user in the session, nil when nobody does.
+func (u *Usecase) actor(ctx context.Context, actorID int64) (actor *model.Actor, err error) {
+	if actorID == 0 {
+		return
+	}
+
+	user, err := u.repo.GetByID(ctx, actorID)
+	if err != nil {
+		return
+	}
+	actor = &model.Actor{ID: user.ID, Subject: user.Username}
+	return
+}
@@ -0,0 +1,224 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/redis/go-redis/v9"
+	"github.com/rzfhlv/gin-example/internal/modules/user/model"
+	pJwt "github.com/rzfhlv/gin-example/pkg/jwt"
+	"github.com/rzfhlv/gin-example/pkg/rbac"
+	"github.com/rzfhlv/gin-example/pkg/session"
+	pToken "github.com/rzfhlv/gin-example/pkg/token"
+	mockRepo "github.com/rzfhlv/gin-example/shared/mocks/modules/user/repository"
+	mockJwt "github.com/rzfhlv/gin-example/shared/mocks/pkg/jwt"
+	mockSession "github.com/rzfhlv/gin-example/shared/mocks/pkg/session"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	accessToken  = "thisisaccesstoken"
+	refreshToken = "thisisrefreshtoken"
+	issuedAt     = time.Date(2026, time.October, 18, 10, 0, 0, 0, time.UTC)
+	accessClaims = &pJwt.JWTClaim{
+		ID: 1, Username: "johndoe", Roles: []string{rbac.MEMBER},
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID: sessionID, Subject: "johndoe", Issuer: "gin-example",
+			IssuedAt: jwt.NewNumericDate(issuedAt), ExpiresAt: jwt.NewNumericDate(issuedAt.Add(time.Hour)),
+		},
+	}
+	tokenSession = session.Session{ID: sessionID, UserID: 1, Username: "johndoe", Refresh: pToken.Hash(refreshToken)}
+)
+
+func TestIntrospect(t *testing.T) {
+	otherOwner := tokenSession
+	otherOwner.UserID = 2
+	rotated := tokenSession
+	rotated.Refresh = "rotated"
+	otherActor := tokenSession
+	otherActor.ActorID = 9
+	renamed := tokenSession
+	renamed.Username = "janedoe"
+	impersonateClaims := *accessClaims
+	impersonateClaims.Actor = &pJwt.Actor{ID: 9, Username: "admin"}
+	refreshExpired := time.Now().Add(time.Hour).Unix()
+
+	testCase := []struct {
+		name                                string
+		request                             model.TokenRequest
+		claims                              *pJwt.JWTClaim
+		current                             session.Session
+		wantValidateError, wantSessionError error
+		wantRefreshError, wantTTLError      error
+		wantError                           error
+		want                                model.Introspection
+	}{
+		{
+			name: "Testcase #1: Positive", request: model.TokenRequest{Token: accessToken}, current: tokenSession,
+			want: model.Introspection{
+				Active: true, Scope: "gatherings:read invitations:respond", Username: "johndoe", TokenType: ACCESSTOKEN,
+				Expired: issuedAt.Add(time.Hour).Unix(), IssuedAt: issuedAt.Unix(), Subject: "johndoe", Issuer: "gin-example", SessionID: sessionID,
+			},
+		},
+		{
+			name: "Testcase #2: Positive", request: model.TokenRequest{Token: refreshToken, TokenTypeHint: REFRESHTOKEN}, current: tokenSession,
+			want: model.Introspection{
+				Active: true, Scope: "gatherings:read invitations:respond", Username: "johndoe", TokenType: REFRESHTOKEN,
+				Expired: refreshExpired, Subject: "johndoe", SessionID: sessionID,
+			},
+		},
+		{
+			name: "Testcase #3: Positive", request: model.TokenRequest{Token: refreshToken}, current: tokenSession, wantValidateError: errFoo,
+			want: model.Introspection{
+				Active: true, Scope: "gatherings:read invitations:respond", Username: "johndoe", TokenType: REFRESHTOKEN,
+				Expired: refreshExpired, Subject: "johndoe", SessionID: sessionID,
+			},
+		},
+		{
+			name: "Testcase #4: Positive", request: model.TokenRequest{Token: accessToken}, wantValidateError: errFoo, wantRefreshError: redis.Nil,
+			want: model.Introspection{Active: false},
+		},
+		{
+			name: "Testcase #5: Positive", request: model.TokenRequest{Token: accessToken}, wantSessionError: session.ErrNotFound, wantRefreshError: redis.Nil,
+			want: model.Introspection{Active: false},
+		},
+		{
+			name: "Testcase #6: Positive", request: model.TokenRequest{Token: accessToken}, current: otherOwner, wantRefreshError: redis.Nil,
+			want: model.Introspection{Active: false},
+		},
+		{
+			name: "Testcase #7: Positive", request: model.TokenRequest{Token: refreshToken, TokenTypeHint: REFRESHTOKEN}, current: rotated,
+			wantValidateError: errFoo, want: model.Introspection{Active: false},
+		},
+		{
+			name: "Testcase #8: Negative", request: model.TokenRequest{Token: accessToken}, wantSessionError: errFoo, wantError: errFoo,
+		},
+		{
+			name: "Testcase #9: Negative", request: model.TokenRequest{Token: refreshToken}, wantValidateError: errFoo, wantRefreshError: errFoo, wantError: errFoo,
+		},
+		{
+			name: "Testcase #10: Positive", request: model.TokenRequest{Token: accessToken}, current: otherActor, wantRefreshError: redis.Nil,
+			want: model.Introspection{Active: false},
+		},
+		{
+			name: "Testcase #11: Positive", request: model.TokenRequest{Token: accessToken}, claims: &impersonateClaims, current: otherActor,
+			want: model.Introspection{
+				Active: true, Scope: "gatherings:read invitations:respond", Username: "johndoe", TokenType: ACCESSTOKEN,
+				Expired: issuedAt.Add(time.Hour).Unix(), IssuedAt: issuedAt.Unix(), Subject: "johndoe", Issuer: "gin-example", SessionID: sessionID,
+				Actor: &model.Actor{ID: 9, Subject: "admin"},
+			},
+		},
+		{
+			name: "Testcase #12: Positive", request: model.TokenRequest{Token: accessToken}, current: renamed,
+			want: model.Introspection{
+				Active: true, Scope: "gatherings:read invitations:respond", Username: "janedoe", TokenType: ACCESSTOKEN,
+				Expired: issuedAt.Add(time.Hour).Unix(), IssuedAt: issuedAt.Unix(), Subject: "janedoe", Issuer: "gin-example", SessionID: sessionID,
+			},
+		},
+		{
+			name: "Testcase #13: Positive", request: model.TokenRequest{Token: refreshToken, TokenTypeHint: REFRESHTOKEN}, current: tokenSession,
+			wantValidateError: errFoo, wantTTLError: session.ErrNotFound, want: model.Introspection{Active: false},
+		},
+		{
+			name: "Testcase #14: Negative", request: model.TokenRequest{Token: refreshToken, TokenTypeHint: REFRESHTOKEN}, current: tokenSession,
+			wantTTLError: errFoo, wantError: errFoo,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mockRepo.IRepository{}
+			mockJwt := mockJwt.JWTInterface{}
+			mockSession := mockSession.Store{}
+
+			claims := accessClaims
+			if tt.claims != nil {
+				claims = tt.claims
+			}
+
+			mockJwt.On("ValidateToken", tt.request.Token).Return(claims, tt.wantValidateError)
+			mockRepo.On("Get", mock.Anything, REFRESHKEY+pToken.Hash(tt.request.Token)).Return(sessionID, tt.wantRefreshError)
+			mockSession.On("Get", mock.Anything, sessionID).Return(tt.current, tt.wantSessionError)
+			mockSession.On("TTL", mock.Anything, sessionID).Return(time.Hour, tt.wantTTLError)
+			mockRepo.On("GetRoles", mock.Anything, int64(1)).Return(roles, nil)
+			mockRepo.On("GetByID", mock.Anything, int64(9)).Return(model.User{ID: 9, Username: "admin"}, nil)
+
+			u := &Usecase{
+				expiry:  expiry,
+				repo:    &mockRepo,
+				jwtImpl: &mockJwt,
+				session: &mockSession,
+			}
+
+			introspection, err := u.Introspect(context.Background(), tt.request)
+			assert.Equal(t, tt.wantError, err)
+			if tt.wantError == nil {
+				// the refresh token expiry is counted from now
+				assert.InDelta(t, tt.want.Expired, introspection.Expired, 1)
+				introspection.Expired = tt.want.Expired
+				assert.Equal(t, tt.want, introspection)
+			}
+		})
+	}
+}
+
+func TestRevokeToken(t *testing.T) {
+	testCase := []struct {
+		name                                string
+		request                             model.TokenRequest
+		wantValidateError, wantRefreshError error
+		wantRevokeError                     error
+		wantError                           error
+		wantRevoke                          bool
+	}{
+		{
+			name: "Testcase #1: Positive", request: model.TokenRequest{Token: accessToken}, wantRevoke: true,
+		},
+		{
+			name: "Testcase #2: Positive", request: model.TokenRequest{Token: refreshToken, TokenTypeHint: REFRESHTOKEN}, wantValidateError: errFoo, wantRevoke: true,
+		},
+		{
+			name: "Testcase #3: Positive", request: model.TokenRequest{Token: accessToken}, wantValidateError: errFoo, wantRefreshError: redis.Nil,
+		},
+		{
+			name: "Testcase #4: Positive", request: model.TokenRequest{Token: accessToken}, wantRevokeError: session.ErrNotFound, wantRevoke: true,
+		},
+		{
+			name: "Testcase #5: Negative", request: model.TokenRequest{Token: accessToken}, wantRevokeError: errFoo, wantError: errFoo, wantRevoke: true,
+		},
+		{
+			name: "Testcase #6: Negative", request: model.TokenRequest{Token: accessToken}, wantValidateError: errFoo, wantRefreshError: errFoo, wantError: errFoo,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mockRepo.IRepository{}
+			mockJwt := mockJwt.JWTInterface{}
+			mockSession := mockSession.Store{}
+
+			mockJwt.On("ValidateToken", tt.request.Token).Return(accessClaims, tt.wantValidateError)
+			mockRepo.On("Get", mock.Anything, REFRESHKEY+pToken.Hash(tt.request.Token)).Return(sessionID, tt.wantRefreshError)
+			mockSession.On("Get", mock.Anything, sessionID).Return(tokenSession, nil)
+			mockSession.On("TTL", mock.Anything, sessionID).Return(time.Hour, nil)
+			mockRepo.On("GetRoles", mock.Anything, int64(1)).Return(roles, nil)
+			mockSession.On("Revoke", mock.Anything, int64(1), sessionID).Return(tt.wantRevokeError)
+
+			u := &Usecase{
+				expiry:  expiry,
+				repo:    &mockRepo,
+				jwtImpl: &mockJwt,
+				session: &mockSession,
+			}
+
+			err := u.RevokeToken(context.Background(), tt.request)
+			assert.Equal(t, tt.wantError, err)
+			if tt.wantRevoke {
+				mockSession.AssertCalled(t, "Revoke", mock.Anything, int64(1), sessionID)
+			} else {
+				mockSession.AssertNotCalled(t, "Revoke", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
@@ -0,0 +1,224 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rzfhlv/gin-example/internal/modules/user/model"
+	pErrors "github.com/rzfhlv/gin-example/pkg/errors"
+	"github.com/rzfhlv/gin-example/pkg/hasher"
+	"github.com/rzfhlv/gin-example/pkg/oidc"
+	"github.com/rzfhlv/gin-example/pkg/rbac"
+	"github.com/rzfhlv/gin-example/pkg/session"
+	pToken "github.com/rzfhlv/gin-example/pkg/token"
+)
+
+var (
+	MAXUSERNAME = 40
+
+	OIDCKEY = "oidc_state:"
+
+	ErrOIDCDisabled      = errors.New("oidc login disabled")
+	ErrInvalidOIDCState  = errors.New("invalid oidc state")
+	ErrOIDCEmailMissing  = errors.New("oidc email claim missing")
+	ErrOIDCAccountExists = errors.New("account exists with unverified oidc email")
+)
+
+// OIDCLogin starts the authorization code flow, the state is the redis key
+// of the nonce and PKCE verifier the callback needs.
+func (u *Usecase) OIDCLogin(ctx context.Context) (authURL string, err error) {
+	if u.oidc == nil {
+		err = ErrOIDCDisabled
+		return
+	}
+
+	state, err := pToken.Generate(pToken.DEFAULTLENGTH)
+	if err != nil {
+		return
+	}
+
+	nonce, err := pToken.Generate(pToken.DEFAULTLENGTH)
+	if err != nil {
+		return
+	}
+
+	verifier, err := oidc.NewVerifier()
+	if err != nil {
+		return
+	}
+
+	value, err := json.Marshal(model.OIDCState{Nonce: nonce, Verifier: verifier})
+	if err != nil {
+		return
+	}
+
+	err = u.repo.Set(ctx, OIDCKEY+pToken.Hash(state), string(value), u.expiry.OIDCState)
+	if err != nil {
+		return
+	}
+
+	authURL, err = u.oidc.AuthCodeURL(ctx, state, nonce, oidc.Challenge(verifier))
+	return
+}
+
+// OIDCCallback consumes the state so a callback can't be replayed, then maps
+// the verified claims to a user and issues our own tokens. A user with 2FA
+// still has to give a code, like after a password login.
+func (u *Usecase) OIDCCallback(ctx context.Context, callback model.OIDCCallback) (jwt model.JWT, err error) {
+	if u.oidc == nil {
+		err = ErrOIDCDisabled
+		return
+	}
+
+	value, err := u.repo.GetDel(ctx, OIDCKEY+pToken.Hash(callback.State))
+	if err != nil {
+		if err == redis.Nil {
+			err = ErrInvalidOIDCState
+		}
+		return
+	}
+
+	state := model.OIDCState{}
+	err = json.Unmarshal([]byte(value), &state)
+	if err != nil {
+		return
+	}
+
+	claims, err := u.oidc.Exchange(ctx, callback.Code, state.Verifier, state.Nonce)
+	if err != nil {
+		return
+	}
+
+	user, err := u.oidcUser(ctx, claims)
+	if err != nil {
+		return
+	}
+
+	enabled, err := u.mfaEnabled(ctx, user.ID)
+	if err != nil {
+		return
+	}
+	if enabled {
+		jwt, err = u.challenge(ctx, user.ID)
+		return
+	}
+
+	jwt, err = u.issue(ctx, session.Session{
+		UserID:    user.ID,
+		Username:  user.Username,
+		Email:     user.Email,
+		IP:        callback.IP,
+		UserAgent: callback.UserAgent,
+	})
+	return
+}
+
+// oidcUser finds the user linked to the issuer and subject. On first login an
+// existing account is only linked when the provider verified the email,
+// otherwise anyone could claim an account by its address.
+func (u *Usecase) oidcUser(ctx context.Context, claims oidc.Claims) (user model.User, err error) {
+	userID, err := u.repo.GetIdentity(ctx, claims.Issuer, claims.Subject)
+	if err == nil {
+		user, err = u.repo.GetByID(ctx, userID)
+		return
+	}
+	if !errors.Is(err, pErrors.ErrNotFound) {
+		return
+	}
+
+	if claims.Email == "" {
+		err = ErrOIDCEmailMissing
+		return
+	}
+
+	user, err = u.repo.GetByEmail(ctx, claims.Email)
+	switch {
+	case err == nil && !claims.EmailVerified:
+		err = ErrOIDCAccountExists
+		return
+	case errors.Is(err, pErrors.ErrNotFound):
+		user, err = u.oidcRegister(ctx, claims)
+	}
+	if err != nil {
+		return
+	}
+
+	err = u.repo.CreateIdentity(ctx, model.ExternalIdentity{
+		UserID:    user.ID,
+		Issuer:    claims.Issuer,
+		Subject:   claims.Subject,
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		return
+	}
+
+	if claims.EmailVerified && user.EmailVerifiedAt == nil {
+		now := time.Now()
+		err = u.repo.VerifyEmail(ctx, user.ID, now)
+		user.EmailVerifiedAt = &now
+	}
+	return
+}
+
+// oidcRegister creates the user without a usable password, the account can
+// still get one through the password reset.
+func (u *Usecase) oidcRegister(ctx context.Context, claims oidc.Claims) (user model.User, err error) {
+	username, err := u.oidcUsername(ctx, claims)
+	if err != nil {
+		return
+	}
+
+	register := model.Register{
+		Username:  username,
+		Email:     claims.Email,
+		Password:  hasher.NOPASSWORD,
+		CreatedAt: time.Now(),
+	}
+	result, err := u.repo.Register(ctx, register)
+	if err != nil {
+		return
+	}
+
+	register.ID, err = result.LastInsertId()
+	if err != nil {
+		return
+	}
+
+	err = u.repo.GrantRole(ctx, register.ID, rbac.DEFAULTROLE)
+	if err != nil {
+		return
+	}
+
+	err = u.profile(ctx, register)
+	if err != nil {
+		return
+	}
+
+	user = model.User{ID: register.ID, Username: register.Username, Email: register.Email, CreatedAt: register.CreatedAt}
+	return
+}
+
+// oidcUsername prefers the provider username and falls back to the email
+// local part, a taken name gets a suffix derived from the subject.
+func (u *Usecase) oidcUsername(ctx context.Context, claims oidc.Claims) (username string, err error) {
+	username = claims.PreferredUsername
+	if username == "" {
+		username = strings.SplitN(claims.Email, "@", 2)[0]
+	}
+	if len(username) > MAXUSERNAME {
+		username = username[:MAXUSERNAME]
+	}
+
+	exists, err := u.repo.UsernameExists(ctx, username)
+	if err != nil || !exists {
+		return
+	}
+
+	username = username + "-" + pToken.Hash(claims.Issuer + claims.Subject)[:8]
+	return
+}
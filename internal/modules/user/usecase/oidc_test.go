@@ -0,0 +1,262 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/redis/go-redis/v9"
+	"github.com/rzfhlv/gin-example/internal/modules/user/model"
+	pErrors "github.com/rzfhlv/gin-example/pkg/errors"
+	"github.com/rzfhlv/gin-example/pkg/hasher"
+	"github.com/rzfhlv/gin-example/pkg/oidc"
+	"github.com/rzfhlv/gin-example/pkg/rbac"
+	pToken "github.com/rzfhlv/gin-example/pkg/token"
+	mockRepo "github.com/rzfhlv/gin-example/shared/mocks/modules/user/repository"
+	mockHasher "github.com/rzfhlv/gin-example/shared/mocks/pkg/hasher"
+	mockJwt "github.com/rzfhlv/gin-example/shared/mocks/pkg/jwt"
+	mockOidc "github.com/rzfhlv/gin-example/shared/mocks/pkg/oidc"
+	mockSession "github.com/rzfhlv/gin-example/shared/mocks/pkg/session"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	oidcIssuer = "https://idp.test"
+	oidcClaims = oidc.Claims{
+		Email:             register.Email,
+		EmailVerified:     true,
+		PreferredUsername: register.Username,
+		RegisteredClaims:  jwt.RegisteredClaims{Issuer: oidcIssuer, Subject: "248289761001"},
+	}
+)
+
+func TestOIDCLogin(t *testing.T) {
+	testCase := []struct {
+		name                         string
+		disabled                     bool
+		wantRedisError, wantURLError error
+		wantError                    error
+	}{
+		{
+			name: "Testcase #1: Positive",
+		},
+		{
+			name: "Testcase #2: Negative", disabled: true, wantError: ErrOIDCDisabled,
+		},
+		{
+			name: "Testcase #3: Negative", wantRedisError: errFoo, wantError: errFoo,
+		},
+		{
+			name: "Testcase #4: Negative", wantURLError: errFoo, wantError: errFoo,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mockRepo.IRepository{}
+			mockOidc := mockOidc.Provider{}
+
+			var stored model.OIDCState
+			mockRepo.On("Set", mock.Anything, mock.Anything, mock.Anything, expiry.OIDCState).
+				Run(func(args mock.Arguments) {
+					_ = json.Unmarshal([]byte(args.String(2)), &stored)
+				}).Return(tt.wantRedisError)
+			mockOidc.On("AuthCodeURL", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+				Return(oidcIssuer+"/authorize?state=thisisstate", tt.wantURLError)
+
+			u := &Usecase{
+				expiry: expiry,
+				repo:   &mockRepo,
+				oidc:   &mockOidc,
+			}
+			if tt.disabled {
+				u.oidc = nil
+			}
+
+			authURL, err := u.OIDCLogin(context.Background())
+			assert.Equal(t, tt.wantError, err)
+			if tt.wantError == nil {
+				assert.Equal(t, oidcIssuer+"/authorize?state=thisisstate", authURL)
+
+				call := mockOidc.Calls[0]
+				state := call.Arguments.String(1)
+				mockRepo.AssertCalled(t, "Set", mock.Anything, OIDCKEY+pToken.Hash(state), mock.Anything, mock.Anything)
+				assert.Equal(t, stored.Nonce, call.Arguments.String(2))
+				assert.Equal(t, oidc.Challenge(stored.Verifier), call.Arguments.String(3))
+			}
+		})
+	}
+}
+
+func TestOIDCCallback(t *testing.T) {
+	callback := model.OIDCCallback{Code: "thisiscode", State: "thisisstate"}
+	stateKey := OIDCKEY + pToken.Hash(callback.State)
+	state, _ := json.Marshal(model.OIDCState{Nonce: "thisisnonce", Verifier: "thisisverifier"})
+	user := model.User{ID: 1, Username: register.Username, Email: register.Email}
+	unverified := oidcClaims
+	unverified.EmailVerified = false
+	noEmail := oidcClaims
+	noEmail.Email = ""
+
+	testCase := []struct {
+		name                                      string
+		disabled                                  bool
+		claims                                    oidc.Claims
+		identityError, emailError                 error
+		usernameExists                            bool
+		wantRedisError, wantExchangeError         error
+		wantRegisterError, wantCreateIdentity     error
+		wantError                                 error
+		wantRegister, wantVerify, wantUsernameTag bool
+		mfaEnabled                                bool
+		wantMFAError                              error
+	}{
+		{
+			name: "Testcase #1: Positive", claims: oidcClaims,
+		},
+		{
+			name: "Testcase #2: Positive", claims: oidcClaims, identityError: pErrors.ErrNotFound, wantVerify: true,
+		},
+		{
+			name: "Testcase #3: Positive", claims: oidcClaims, identityError: pErrors.ErrNotFound, emailError: pErrors.ErrNotFound,
+			wantRegister: true, wantVerify: true,
+		},
+		{
+			name: "Testcase #4: Positive", claims: oidcClaims, identityError: pErrors.ErrNotFound, emailError: pErrors.ErrNotFound,
+			usernameExists: true, wantRegister: true, wantVerify: true, wantUsernameTag: true,
+		},
+		{
+			name: "Testcase #5: Negative", disabled: true, wantError: ErrOIDCDisabled,
+		},
+		{
+			name: "Testcase #6: Negative", wantRedisError: redis.Nil, wantError: ErrInvalidOIDCState,
+		},
+		{
+			name: "Testcase #7: Negative", wantExchangeError: oidc.ErrNonceMismatch, wantError: oidc.ErrNonceMismatch,
+		},
+		{
+			name: "Testcase #8: Negative", claims: unverified, identityError: pErrors.ErrNotFound, wantError: ErrOIDCAccountExists,
+		},
+		{
+			name: "Testcase #9: Negative", claims: noEmail, identityError: pErrors.ErrNotFound, wantError: ErrOIDCEmailMissing,
+		},
+		{
+			name: "Testcase #10: Negative", claims: oidcClaims, identityError: errFoo, wantError: errFoo,
+		},
+		{
+			name: "Testcase #11: Negative", claims: oidcClaims, identityError: pErrors.ErrNotFound, emailError: pErrors.ErrNotFound,
+			wantRegisterError: errFoo, wantError: errFoo,
+		},
+		{
+			name: "Testcase #12: Negative", claims: oidcClaims, identityError: pErrors.ErrNotFound, wantCreateIdentity: errFoo, wantError: errFoo,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mockRepo.IRepository{}
+			mockHasher := mockHasher.HashPassword{}
+			mockJwt := mockJwt.JWTInterface{}
+			mockSession := mockSession.Store{}
+			mockOidc := mockOidc.Provider{}
+
+			mockRepo.On("GetDel", mock.Anything, stateKey).Return(string(state), tt.wantRedisError)
+			mockOidc.On("Exchange", mock.Anything, callback.Code, "thisisverifier", "thisisnonce").Return(tt.claims, tt.wantExchangeError)
+			mockRepo.On("GetIdentity", mock.Anything, oidcIssuer, "248289761001").Return(int64(1), tt.identityError)
+			mockRepo.On("GetByEmail", mock.Anything, register.Email).Return(user, tt.emailError)
+			mockRepo.On("UsernameExists", mock.Anything, mock.Anything).Return(tt.usernameExists, nil)
+			mockRepo.On("Register", mock.Anything, mock.Anything).Return(&CustomResult{lastInsertID: 1}, tt.wantRegisterError)
+			mockRepo.On("GrantRole", mock.Anything, int64(1), rbac.DEFAULTROLE).Return(nil)
+			mockRepo.On("GetMemberByEmail", mock.Anything, register.Email).Return(model.Member{}, pErrors.ErrNotFound)
+			mockRepo.On("CreateMember", mock.Anything, mock.Anything).Return(&CustomResult{lastInsertID: 1}, nil)
+			mockRepo.On("CreateIdentity", mock.Anything, mock.Anything).Return(tt.wantCreateIdentity)
+			mockRepo.On("VerifyEmail", mock.Anything, int64(1), mock.Anything).Return(nil)
+			mfa, mfaError := model.MFA{}, error(pErrors.ErrNotFound)
+			if tt.mfaEnabled {
+				enabledAt := time.Now()
+				mfa, mfaError = model.MFA{UserID: 1, EnabledAt: &enabledAt}, nil
+			}
+			if tt.wantMFAError != nil {
+				mfaError = tt.wantMFAError
+			}
+			mockRepo.On("GetMFA", mock.Anything, int64(1)).Return(mfa, mfaError)
+			mockRepo.On("GetByID", mock.Anything, int64(1)).Return(user, nil)
+			mockRepo.On("GetRoles", mock.Anything, int64(1)).Return(roles, nil)
+			mockRepo.On("Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			mockJwt.On("Generate", mock.Anything).Return("thisistoken", nil)
+			mockSession.On("Save", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+			u := &Usecase{
+				expiry:  expiry,
+				repo:    &mockRepo,
+				hasher:  &mockHasher,
+				jwtImpl: &mockJwt,
+				session: &mockSession,
+				oidc:    &mockOidc,
+			}
+			if tt.disabled {
+				u.oidc = nil
+			}
+
+			jwt, err := u.OIDCCallback(context.Background(), callback)
+			assert.Equal(t, tt.wantError, err)
+			if tt.wantError != nil {
+				return
+			}
+			if tt.mfaEnabled {
+				assert.Empty(t, jwt.Token)
+				assert.NotEmpty(t, jwt.MFAToken)
+				mockRepo.AssertCalled(t, "Set", mock.Anything, MFAKEY+pToken.Hash(jwt.MFAToken), "1", expiry.MFA)
+				mockJwt.AssertNotCalled(t, "Generate", mock.Anything)
+				mockSession.AssertNotCalled(t, "Save", mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+			assert.Equal(t, "thisistoken", jwt.Token)
+
+			if tt.wantRegister {
+				username := register.Username
+				if tt.wantUsernameTag {
+					username += "-" + pToken.Hash(oidcIssuer + "248289761001")[:8]
+				}
+				mockRepo.AssertCalled(t, "Register", mock.Anything, mock.MatchedBy(func(r model.Register) bool {
+					return r.Username == username && r.Email == register.Email && r.Password == hasher.NOPASSWORD
+				}))
+				mockHasher.AssertNotCalled(t, "HashedPassword", mock.Anything)
+			} else {
+				mockRepo.AssertNotCalled(t, "Register", mock.Anything, mock.Anything)
+			}
+
+			if tt.wantVerify {
+				mockRepo.AssertCalled(t, "CreateIdentity", mock.Anything, mock.MatchedBy(func(i model.ExternalIdentity) bool {
+					return i.UserID == 1 && i.Issuer == oidcIssuer && i.Subject == "248289761001"
+				}))
+				mockRepo.AssertCalled(t, "VerifyEmail", mock.Anything, int64(1), mock.Anything)
+			} else {
+				mockRepo.AssertNotCalled(t, "CreateIdentity", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestOIDCUsername(t *testing.T) {
+	mockRepo := mockRepo.IRepository{}
+	mockRepo.On("UsernameExists", mock.Anything, mock.Anything).Return(false, nil)
+
+	u := &Usecase{
+		expiry: expiry,
+		repo:   &mockRepo,
+	}
+
+	claims := oidcClaims
+	claims.PreferredUsername = ""
+	claims.Email = "jane.doe@idp.test"
+	username, err := u.oidcUsername(context.Background(), claims)
+	assert.NoError(t, err)
+	assert.Equal(t, "jane.doe", username)
+
+	claims.PreferredUsername = "averyveryveryveryveryverylongusernamefromtheprovider"
+	username, err = u.oidcUsername(context.Background(), claims)
+	assert.NoError(t, err)
+	assert.Len(t, username, MAXUSERNAME)
+}
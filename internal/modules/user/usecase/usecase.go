@@ -2,41 +2,159 @@ package usecase
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
-	"os"
+	"log"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/redis/go-redis/v9"
 	"github.com/rzfhlv/gin-example/internal/modules/user/model"
 	"github.com/rzfhlv/gin-example/internal/modules/user/repository"
+	"github.com/rzfhlv/gin-example/pkg/audit"
+	pErrors "github.com/rzfhlv/gin-example/pkg/errors"
 	"github.com/rzfhlv/gin-example/pkg/hasher"
 	pJwt "github.com/rzfhlv/gin-example/pkg/jwt"
+	"github.com/rzfhlv/gin-example/pkg/lockout"
+	"github.com/rzfhlv/gin-example/pkg/mailer"
+	"github.com/rzfhlv/gin-example/pkg/message"
+	"github.com/rzfhlv/gin-example/pkg/oidc"
 	"github.com/rzfhlv/gin-example/pkg/param"
+	"github.com/rzfhlv/gin-example/pkg/password"
+	"github.com/rzfhlv/gin-example/pkg/rbac"
+	"github.com/rzfhlv/gin-example/pkg/session"
+	pToken "github.com/rzfhlv/gin-example/pkg/token"
 	"golang.org/x/crypto/bcrypt"
 )
 
+var (
+	REFRESHKEY    = "refresh:"
+	RESETKEY      = "password_reset:"
+	RESETUSERKEY  = "reset_user:"
+	VERIFYKEY     = "email_verify:"
+	VERIFYUSERKEY = "email_verify_user:"
+	RESENDKEY     = "email_verify_resend:"
+
+	// DUMMYPASSWORD only feeds the hash checked for unknown usernames
+	DUMMYPASSWORD = "dummy password"
+
+	ErrInvalidCredentials  = errors.New("invalid credentials")
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+	ErrRefreshTokenReused  = errors.New("refresh token reused")
+	ErrMemberClaimed       = pErrors.Conflict(message.MEMBERCLAIMED)
+	ErrEmailNotVerified    = pErrors.Forbidden(message.EMAILNOTVERIFIED)
+	ErrInvalidResetToken   = errors.New("invalid reset token")
+	ErrInvalidVerifyToken  = errors.New("invalid verification token")
+	ErrResendTooSoon       = errors.New("verification resent too soon")
+)
+
 type IUsecase interface {
 	Register(ctx context.Context, register model.Register) (jwt model.JWT, err error)
 	Login(ctx context.Context, login model.Login) (jwt model.JWT, err error)
-	Logout(ctx context.Context, username string) (err error)
-	GetAll(ctx context.Context, param param.Param) (users []model.User, total int64, err error)
+	Logout(ctx context.Context, userID int64, sessionID string) (err error)
+	Refresh(ctx context.Context, refresh model.Refresh) (jwt model.JWT, err error)
+	GetSessions(ctx context.Context, userID int64, sessionID string) (sessions []model.Session, err error)
+	RevokeSession(ctx context.Context, userID int64, sessionID string) (err error)
+	RevokeSessions(ctx context.Context, userID int64) (err error)
+	GetAll(ctx context.Context, param param.Param) (users []model.User, page param.Page, err error)
 	GetByID(ctx context.Context, id int64) (user model.User, err error)
+	JWKS(ctx context.Context) (jwks pJwt.JWKS)
+	GetRoles(ctx context.Context, userID int64) (roles []string, err error)
+	GrantRole(ctx context.Context, userID int64, role string) (roles []string, err error)
+	RevokeRole(ctx context.Context, userID int64, role string) (roles []string, err error)
+	GetMember(ctx context.Context, userID int64) (member model.Member, err error)
+	ClaimMember(ctx context.Context, userID int64) (member model.Member, err error)
+	ForgotPassword(ctx context.Context, forgot model.ForgotPassword) (err error)
+	ResetPassword(ctx context.Context, reset model.ResetPassword) (err error)
+	VerifyEmail(ctx context.Context, verifyToken string) (err error)
+	ResendVerification(ctx context.Context, resend model.ResendVerification) (err error)
+	EnrollMFA(ctx context.Context, userID int64) (enrollment model.MFAEnrollment, err error)
+	ConfirmMFA(ctx context.Context, userID int64, code model.MFACode) (codes model.RecoveryCodes, err error)
+	LoginMFA(ctx context.Context, login model.LoginMFA) (jwt model.JWT, err error)
+	DisableMFA(ctx context.Context, userID int64, disable model.DisableMFA) (err error)
+	Unlock(ctx context.Context, userID int64) (err error)
+	OIDCLogin(ctx context.Context) (authURL string, err error)
+	OIDCCallback(ctx context.Context, callback model.OIDCCallback) (jwt model.JWT, err error)
+	UpdateMe(ctx context.Context, userID int64, update model.UpdateUser) (user model.User, err error)
+	ChangePassword(ctx context.Context, userID int64, sessionID string, change model.ChangePassword) (err error)
+	ChangeEmail(ctx context.Context, userID int64, sessionID string, change model.ChangeEmail) (err error)
+	DeleteMe(ctx context.Context, userID int64, remove model.DeleteUser) (err error)
+	Introspect(ctx context.Context, request model.TokenRequest) (introspection model.Introspection, err error)
+	RevokeToken(ctx context.Context, request model.TokenRequest) (err error)
+	Impersonate(ctx context.Context, impersonate model.Impersonate) (jwt model.JWT, err error)
+}
+
+// Expiry is how long each token the usecase hands out stays valid, config
+// reads it once at startup.
+type Expiry struct {
+	Access         time.Duration
+	Refresh        time.Duration
+	PasswordReset  time.Duration
+	EmailVerify    time.Duration
+	ResendInterval time.Duration
+	MFA            time.Duration
+	Impersonate    time.Duration
+	OIDCState      time.Duration
+}
+
+// App is how the usecase names the service to users, config reads it once at
+// startup.
+type App struct {
+	Name     string
+	MailFrom string
 }
 
 type Usecase struct {
 	repo    repository.IRepository
 	hasher  hasher.HashPassword
 	jwtImpl pJwt.JWTInterface
+	session session.Store
+	mailer  mailer.Sender
+	policy  password.Checker
+	oidc    oidc.Provider
+	audit   audit.Store
+
+	loginUsers lockout.Limiter
+	loginIPs   lockout.Limiter
+	expiry     Expiry
+	app        App
+
+	// dummyHash is verified for an unknown username so the reply takes as
+	// long as for a wrong password
+	dummyHash string
 }
 
-func New(repo repository.IRepository, hasher hasher.HashPassword, jwtImpl pJwt.JWTInterface) IUsecase {
+func New(repo repository.IRepository, hasher hasher.HashPassword, jwtImpl pJwt.JWTInterface, session session.Store, mailer mailer.Sender, policy password.Checker, oidc oidc.Provider, audit audit.Store, loginUsers, loginIPs lockout.Limiter, expiry Expiry, app App) IUsecase {
+	dummyHash, err := hasher.HashedPassword(DUMMYPASSWORD)
+	if err != nil {
+		log.Printf("Error Hash Dummy Password, %v", err.Error())
+	}
+
 	return &Usecase{
-		repo:    repo,
-		hasher:  hasher,
-		jwtImpl: jwtImpl,
+		repo:       repo,
+		hasher:     hasher,
+		jwtImpl:    jwtImpl,
+		session:    session,
+		mailer:     mailer,
+		policy:     policy,
+		oidc:       oidc,
+		audit:      audit,
+		loginUsers: loginUsers,
+		loginIPs:   loginIPs,
+		expiry:     expiry,
+		app:        app,
+		dummyHash:  dummyHash,
 	}
 }
 
 func (u *Usecase) Register(ctx context.Context, register model.Register) (jwt model.JWT, err error) {
+	err = u.policy.Check(register.Password, register.Username, register.Email)
+	if err != nil {
+		return
+	}
+
 	hashPassword, err := u.hasher.HashedPassword(register.Password)
 	if err != nil {
 		return
@@ -53,54 +171,228 @@ func (u *Usecase) Register(ctx context.Context, register model.Register) (jwt mo
 		return
 	}
 
-	token, err := u.jwtImpl.Generate(register.ID, register.Username, register.Email)
+	err = u.repo.GrantRole(ctx, register.ID, rbac.DEFAULTROLE)
+	if err != nil {
+		return
+	}
+
+	err = u.profile(ctx, register)
 	if err != nil {
 		return
 	}
 
-	err = u.repo.Set(ctx, register.Username, token, time.Duration(1*time.Hour))
+	err = u.verification(ctx, model.User{ID: register.ID, Username: register.Username, Email: register.Email})
 	if err != nil {
 		return
 	}
 
-	jwt.Token = token
-	jwt.Expired = fmt.Sprintf("%s Hour", os.Getenv("JWT_EXPIRED"))
+	jwt, err = u.issue(ctx, session.Session{
+		UserID:    register.ID,
+		Username:  register.Username,
+		Email:     register.Email,
+		IP:        register.IP,
+		UserAgent: register.UserAgent,
+	})
 	return
 }
 
+// Login is throttled per username and per client IP, an unknown username and
+// a wrong password count alike and look the same to the caller.
 func (u *Usecase) Login(ctx context.Context, login model.Login) (jwt model.JWT, err error) {
+	username := strings.ToLower(login.Username)
+
+	err = u.loginIPs.Check(ctx, login.IP)
+	if err != nil {
+		return
+	}
+
+	err = u.loginUsers.Check(ctx, username)
+	if err != nil {
+		return
+	}
+
 	register, err := u.repo.Login(ctx, login)
 	if err != nil {
+		if errors.Is(err, pErrors.ErrNotFound) {
+			_ = u.hasher.VerifyPassword(u.dummyHash, login.Password)
+			err = u.failLogin(ctx, username, login.IP, ErrInvalidCredentials)
+		}
 		return
 	}
 
 	err = u.hasher.VerifyPassword(register.Password, login.Password)
-	if err != nil && err == bcrypt.ErrMismatchedHashAndPassword {
+	if err != nil {
+		if err == bcrypt.ErrMismatchedHashAndPassword {
+			err = u.failLogin(ctx, username, login.IP, ErrInvalidCredentials)
+		}
+		return
+	}
+
+	if u.hasher.NeedsRehash(register.Password) {
+		u.rehash(ctx, register.ID, login.Password)
+	}
+
+	enabled, err := u.mfaEnabled(ctx, register.ID)
+	if err != nil {
+		return
+	}
+	if enabled {
+		jwt, err = u.challenge(ctx, register.ID)
+		return
+	}
+
+	// the failures only clear once the user is fully authenticated, a right
+	// password in front of a wrong second factor keeps counting
+	err = u.loginUsers.Reset(ctx, username)
+	if err != nil {
+		return
+	}
+
+	jwt, err = u.issue(ctx, session.Session{
+		UserID:    register.ID,
+		Username:  register.Username,
+		Email:     register.Email,
+		IP:        login.IP,
+		UserAgent: login.UserAgent,
+	})
+	return
+}
+
+// Unlock clears the failed logins of the user so an admin can let them back in
+// before the lockout runs out.
+func (u *Usecase) Unlock(ctx context.Context, userID int64) (err error) {
+	user, err := u.repo.GetByID(ctx, userID)
+	if err != nil {
+		return
+	}
+
+	err = u.loginUsers.Reset(ctx, strings.ToLower(user.Username))
+	return
+}
+
+// rehash upgrades an outdated stored hash while the plain password is at hand,
+// a failure only leaves the old hash in place for the next login.
+func (u *Usecase) rehash(ctx context.Context, userID int64, password string) {
+	hashed, err := u.hasher.HashedPassword(password)
+	if err != nil {
+		log.Printf("Error Rehash Password User %v, %v", userID, err.Error())
+		return
+	}
+
+	err = u.repo.UpdatePassword(ctx, userID, hashed)
+	if err != nil {
+		log.Printf("Error Update Rehashed Password User %v, %v", userID, err.Error())
+	}
+}
+
+// failLogin counts the failure against both keys, a lockout reached by this
+// very attempt is reported instead of the cause.
+func (u *Usecase) failLogin(ctx context.Context, username, ip string, cause error) (err error) {
+	err = u.loginIPs.Fail(ctx, ip)
+	if err != nil {
+		return
+	}
+
+	err = u.loginUsers.Fail(ctx, username)
+	if err != nil {
+		return
+	}
+
+	err = cause
+	return
+}
+
+func (u *Usecase) Logout(ctx context.Context, userID int64, sessionID string) (err error) {
+	err = u.session.Revoke(ctx, userID, sessionID)
+	return
+}
+
+func (u *Usecase) Refresh(ctx context.Context, refresh model.Refresh) (jwt model.JWT, err error) {
+	hashed := pToken.Hash(refresh.RefreshToken)
+
+	sessionID, err := u.repo.Get(ctx, REFRESHKEY+hashed)
+	if err != nil {
+		if err == redis.Nil {
+			err = ErrInvalidRefreshToken
+		}
+		return
+	}
+
+	stored, err := u.session.Get(ctx, sessionID)
+	if err != nil {
+		if err == session.ErrNotFound {
+			err = ErrInvalidRefreshToken
+		}
 		return
 	}
 
-	token, err := u.jwtImpl.Generate(register.ID, register.Username, register.Email)
+	// the database is read before the token is consumed, a failure here
+	// leaves the token valid for the client to retry
+	claims, err := u.loadClaims(ctx, stored.UserID)
 	if err != nil {
 		return
 	}
 
-	err = u.repo.Set(ctx, register.Username, token, time.Duration(1*time.Hour))
+	// a refresh token is single use, so presenting one that is no longer the
+	// current token of its session means it was stolen and replayed
+	current, err := u.session.Consume(ctx, sessionID, hashed)
+	if err == session.ErrConsumed {
+		err = u.session.Revoke(ctx, current.UserID, current.ID)
+		if err != nil {
+			return
+		}
+		err = ErrRefreshTokenReused
+		return
+	}
 	if err != nil {
+		if err == session.ErrNotFound {
+			err = ErrInvalidRefreshToken
+		}
 		return
 	}
 
-	jwt.Token = token
-	jwt.Expired = fmt.Sprintf("%s Hour", os.Getenv("JWT_EXPIRED"))
+	current.IP = refresh.IP
+	current.UserAgent = refresh.UserAgent
+	jwt, err = u.sign(ctx, current, claims)
+	if err == session.ErrNotFound {
+		err = ErrInvalidRefreshToken
+	}
 	return
 }
 
-func (u *Usecase) Logout(ctx context.Context, username string) (err error) {
-	err = u.repo.Del(ctx, username)
+func (u *Usecase) GetSessions(ctx context.Context, userID int64, sessionID string) (sessions []model.Session, err error) {
+	actives, err := u.session.List(ctx, userID)
+	if err != nil {
+		return
+	}
+
+	sessions = []model.Session{}
+	for _, active := range actives {
+		sessions = append(sessions, model.Session{
+			ID:           active.ID,
+			IP:           active.IP,
+			UserAgent:    active.UserAgent,
+			CreatedAt:    active.CreatedAt,
+			LastSeenAt:   active.LastSeenAt,
+			Current:      active.ID == sessionID,
+			Impersonated: active.ActorID != 0,
+		})
+	}
 	return
 }
 
-func (u *Usecase) GetAll(ctx context.Context, param param.Param) (users []model.User, total int64, err error) {
-	users, err = u.repo.GetAll(ctx, param)
+func (u *Usecase) RevokeSession(ctx context.Context, userID int64, sessionID string) (err error) {
+	err = u.session.Revoke(ctx, userID, sessionID)
+	return
+}
+
+func (u *Usecase) RevokeSessions(ctx context.Context, userID int64) (err error) {
+	err = u.session.RevokeAll(ctx, userID)
+	return
+}
+
+func (u *Usecase) GetAll(ctx context.Context, param param.Param) (users []model.User, page param.Page, err error) {
+	users, page, err = u.repo.GetAll(ctx, param)
 	if err != nil {
 		return
 	}
@@ -108,10 +400,441 @@ func (u *Usecase) GetAll(ctx context.Context, param param.Param) (users []model.
 	if len(users) < 1 {
 		users = []model.User{}
 	}
-	total, err = u.repo.Count(ctx)
+	if !param.Counts() {
+		return
+	}
+
+	total, err := u.repo.Count(ctx, param)
+	page.Total = &total
 	return
 }
 func (u *Usecase) GetByID(ctx context.Context, id int64) (user model.User, err error) {
 	user, err = u.repo.GetByID(ctx, id)
 	return
 }
+
+func (u *Usecase) JWKS(ctx context.Context) (jwks pJwt.JWKS) {
+	jwks = u.jwtImpl.JWKS()
+	return
+}
+
+func (u *Usecase) GetRoles(ctx context.Context, userID int64) (roles []string, err error) {
+	_, err = u.repo.GetByID(ctx, userID)
+	if err != nil {
+		return
+	}
+
+	roles, err = u.roles(ctx, userID)
+	return
+}
+
+func (u *Usecase) GrantRole(ctx context.Context, userID int64, role string) (roles []string, err error) {
+	if !rbac.IsRole(role) {
+		err = rbac.ErrUnknownRole
+		return
+	}
+
+	_, err = u.repo.GetByID(ctx, userID)
+	if err != nil {
+		return
+	}
+
+	err = u.repo.GrantRole(ctx, userID, role)
+	if err != nil {
+		return
+	}
+
+	roles, err = u.roles(ctx, userID)
+	return
+}
+
+func (u *Usecase) RevokeRole(ctx context.Context, userID int64, role string) (roles []string, err error) {
+	if !rbac.IsRole(role) {
+		err = rbac.ErrUnknownRole
+		return
+	}
+
+	_, err = u.repo.GetByID(ctx, userID)
+	if err != nil {
+		return
+	}
+
+	err = u.repo.RevokeRole(ctx, userID, role)
+	if err != nil {
+		return
+	}
+
+	roles, err = u.roles(ctx, userID)
+	return
+}
+
+func (u *Usecase) roles(ctx context.Context, userID int64) (roles []string, err error) {
+	roles, err = u.repo.GetRoles(ctx, userID)
+	if err != nil {
+		return
+	}
+
+	if len(roles) < 1 {
+		roles = []string{}
+	}
+	return
+}
+
+func (u *Usecase) GetMember(ctx context.Context, userID int64) (member model.Member, err error) {
+	member, err = u.repo.GetMemberByUserID(ctx, userID)
+	return
+}
+
+// ClaimMember links the member sharing the user's email to the user, only a
+// verified email proves the user owns that profile.
+func (u *Usecase) ClaimMember(ctx context.Context, userID int64) (member model.Member, err error) {
+	user, err := u.repo.GetByID(ctx, userID)
+	if err != nil {
+		return
+	}
+	if user.EmailVerifiedAt == nil {
+		err = ErrEmailNotVerified
+		return
+	}
+
+	member, err = u.repo.GetMemberByEmail(ctx, user.Email)
+	if err != nil {
+		return
+	}
+
+	if member.UserID.Valid {
+		if member.UserID.Int64 != userID {
+			err = ErrMemberClaimed
+		}
+		return
+	}
+
+	result, err := u.repo.LinkMember(ctx, member.ID, userID)
+	if err != nil {
+		return
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return
+	}
+	if affected < 1 {
+		err = ErrMemberClaimed
+		return
+	}
+
+	member.UserID = sql.NullInt64{Int64: userID, Valid: true}
+	return
+}
+
+// ForgotPassword mails a single use reset token, an unknown email is not an
+// error so the endpoint can't be used to find out who has an account.
+func (u *Usecase) ForgotPassword(ctx context.Context, forgot model.ForgotPassword) (err error) {
+	user, err := u.repo.GetByEmail(ctx, forgot.Email)
+	if err != nil {
+		if errors.Is(err, pErrors.ErrNotFound) {
+			err = nil
+		}
+		return
+	}
+
+	resetToken, err := pToken.Generate(pToken.DEFAULTLENGTH)
+	if err != nil {
+		return
+	}
+
+	// asking again replaces the token, an older mail no longer resets
+	err = u.pending(ctx, RESETKEY+pToken.Hash(resetToken), RESETUSERKEY+strconv.FormatInt(user.ID, 10),
+		strconv.FormatInt(user.ID, 10), u.expiry.PasswordReset)
+	if err != nil {
+		return
+	}
+
+	err = u.mailer.Send(ctx, mailer.Message{
+		From:    u.app.MailFrom,
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("Hi %s,\n\nUse this token to reset your password: %s\n\nThe token expires in %d minutes, ignore this email if you didn't ask for it.",
+			user.Username, resetToken, int(u.expiry.PasswordReset/time.Minute)),
+	})
+	return
+}
+
+// ResetPassword consumes the reset token and signs the user out everywhere.
+// A password the policy rejects leaves the token for another try, otherwise
+// the token is deleted before the password changes so it can't be replayed.
+// 2FA is turned off too, it's the way back in for a user who lost the
+// authenticator and the recovery codes.
+func (u *Usecase) ResetPassword(ctx context.Context, reset model.ResetPassword) (err error) {
+	resetKey := RESETKEY + pToken.Hash(reset.Token)
+	value, err := u.repo.Get(ctx, resetKey)
+	if err != nil {
+		if err == redis.Nil {
+			err = ErrInvalidResetToken
+		}
+		return
+	}
+
+	userID, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return
+	}
+
+	user, err := u.repo.GetByID(ctx, userID)
+	if err != nil {
+		return
+	}
+
+	err = u.policy.Check(reset.Password, user.Username, user.Email)
+	if err != nil {
+		return
+	}
+
+	// only one of two resets racing with the same token gets to delete it
+	_, err = u.repo.GetDel(ctx, resetKey)
+	if err != nil {
+		if err == redis.Nil {
+			err = ErrInvalidResetToken
+		}
+		return
+	}
+
+	hashPassword, err := u.hasher.HashedPassword(reset.Password)
+	if err != nil {
+		return
+	}
+
+	err = u.repo.UpdatePassword(ctx, userID, hashPassword)
+	if err != nil {
+		return
+	}
+
+	err = u.repo.DeleteMFA(ctx, userID)
+	if err != nil {
+		return
+	}
+
+	err = u.session.RevokeAll(ctx, userID)
+	return
+}
+
+// VerifyEmail only verifies the address the token was mailed to, a token
+// issued before an email change is no proof of the new one.
+func (u *Usecase) VerifyEmail(ctx context.Context, verifyToken string) (err error) {
+	value, err := u.repo.GetDel(ctx, VERIFYKEY+pToken.Hash(verifyToken))
+	if err != nil {
+		if err == redis.Nil {
+			err = ErrInvalidVerifyToken
+		}
+		return
+	}
+
+	id, email, ok := strings.Cut(value, ":")
+	if !ok {
+		err = ErrInvalidVerifyToken
+		return
+	}
+
+	userID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return
+	}
+
+	user, err := u.repo.GetByID(ctx, userID)
+	if err != nil {
+		return
+	}
+	if !strings.EqualFold(user.Email, email) {
+		err = ErrInvalidVerifyToken
+		return
+	}
+
+	err = u.repo.VerifyEmail(ctx, userID, time.Now())
+	return
+}
+
+// ResendVerification is limited per email before the user is looked up, so
+// the limit answers the same whether the email is registered or not.
+func (u *Usecase) ResendVerification(ctx context.Context, resend model.ResendVerification) (err error) {
+	ok, err := u.repo.SetNX(ctx, RESENDKEY+pToken.Hash(strings.ToLower(resend.Email)), "1", u.expiry.ResendInterval)
+	if err != nil {
+		return
+	}
+	if !ok {
+		err = ErrResendTooSoon
+		return
+	}
+
+	user, err := u.repo.GetByEmail(ctx, resend.Email)
+	if err != nil {
+		if errors.Is(err, pErrors.ErrNotFound) {
+			err = nil
+		}
+		return
+	}
+
+	if user.EmailVerifiedAt != nil {
+		return
+	}
+
+	err = u.verification(ctx, user)
+	return
+}
+
+// verification mails a token that proves the user owns the email, the token
+// is stored hashed like the reset token along with the address it went to.
+func (u *Usecase) verification(ctx context.Context, user model.User) (err error) {
+	verifyToken, err := pToken.Generate(pToken.DEFAULTLENGTH)
+	if err != nil {
+		return
+	}
+
+	err = u.pending(ctx, VERIFYKEY+pToken.Hash(verifyToken), VERIFYUSERKEY+strconv.FormatInt(user.ID, 10),
+		strconv.FormatInt(user.ID, 10)+":"+user.Email, u.expiry.EmailVerify)
+	if err != nil {
+		return
+	}
+
+	err = u.mailer.Send(ctx, mailer.Message{
+		From:    u.app.MailFrom,
+		To:      user.Email,
+		Subject: "Verify your email",
+		Body: fmt.Sprintf("Hi %s,\n\nUse this token to verify your email: %s\n\nOr open /v1/users/verify?token=%s, the token expires in %d hours.",
+			user.Username, verifyToken, verifyToken, int(u.expiry.EmailVerify/time.Hour)),
+	})
+	return
+}
+
+// pending stores a one-time token and keeps only the latest of a user, the
+// key under userKey names the token it replaces, which stops working at once.
+func (u *Usecase) pending(ctx context.Context, key, userKey, value string, ttl time.Duration) (err error) {
+	previous, err := u.repo.GetDel(ctx, userKey)
+	if err == nil {
+		err = u.repo.Del(ctx, previous)
+	}
+	if err != nil && err != redis.Nil {
+		return
+	}
+
+	err = u.repo.Set(ctx, key, value, ttl)
+	if err != nil {
+		return
+	}
+
+	err = u.repo.Set(ctx, userKey, key, ttl)
+	return
+}
+
+// profile creates the member profile of a new user, an existing member with
+// the same email is left alone until the user claims it. A deleted member
+// still holds its email, the user goes without a profile until it's restored.
+// The user signs in with its own password, so the profile gets no usable
+// copy that would go stale on the next password change.
+func (u *Usecase) profile(ctx context.Context, register model.Register) (err error) {
+	_, err = u.repo.GetMemberByEmail(ctx, register.Email)
+	if !errors.Is(err, pErrors.ErrNotFound) {
+		return
+	}
+
+	_, err = u.repo.CreateMember(ctx, model.Member{
+		UserID:    sql.NullInt64{Int64: register.ID, Valid: true},
+		FirstName: register.Username,
+		Email:     register.Email,
+		Password:  hasher.NOPASSWORD,
+		CreatedAt: register.CreatedAt,
+	})
+	if errors.Is(err, pErrors.ErrConflict) {
+		err = nil
+	}
+	return
+}
+
+// issue signs a new access token for the session and rotates its refresh
+// token, a new session is started when the ID is empty.
+func (u *Usecase) issue(ctx context.Context, current session.Session) (jwt model.JWT, err error) {
+	claims, err := u.loadClaims(ctx, current.UserID)
+	if err != nil {
+		return
+	}
+
+	jwt, err = u.sign(ctx, current, claims)
+	return
+}
+
+// claims is what the access token says about the user beyond the session.
+type claims struct {
+	roles         []string
+	emailVerified bool
+}
+
+// loadClaims reads the roles and the verified email on every issue so a change
+// takes effect on the next login or refresh.
+func (u *Usecase) loadClaims(ctx context.Context, userID int64) (c claims, err error) {
+	c.roles, err = u.repo.GetRoles(ctx, userID)
+	if err != nil {
+		return
+	}
+
+	user, err := u.repo.GetByID(ctx, userID)
+	if err != nil {
+		return
+	}
+	c.emailVerified = user.EmailVerifiedAt != nil
+	return
+}
+
+// sign starts or rotates the session and hands out its tokens, nothing in
+// here reads the database.
+func (u *Usecase) sign(ctx context.Context, current session.Session, c claims) (jwt model.JWT, err error) {
+	now := time.Now()
+	started := current.ID == ""
+	if started {
+		current.ID, err = pToken.Generate(pToken.DEFAULTLENGTH)
+		if err != nil {
+			return
+		}
+		current.CreatedAt = now
+	}
+	current.LastSeenAt = now
+
+	refreshToken, err := pToken.Generate(pToken.DEFAULTLENGTH)
+	if err != nil {
+		return
+	}
+	current.Refresh = pToken.Hash(refreshToken)
+
+	refreshTTL := u.expiry.Refresh
+	err = u.repo.Set(ctx, REFRESHKEY+current.Refresh, current.ID, refreshTTL)
+	if err != nil {
+		return
+	}
+
+	// an existing session is rotated in place so a revoke or rename racing
+	// the refresh isn't undone, the token is signed from what was stored
+	if started {
+		err = u.session.Save(ctx, current, refreshTTL)
+	} else {
+		current, err = u.session.Rotate(ctx, current, refreshTTL)
+	}
+	if err != nil {
+		return
+	}
+
+	accessToken, err := u.jwtImpl.Generate(pJwt.Payload{
+		ID:            current.UserID,
+		Username:      current.Username,
+		Email:         current.Email,
+		SessionID:     current.ID,
+		Roles:         c.roles,
+		EmailVerified: c.emailVerified,
+	})
+	if err != nil {
+		return
+	}
+
+	jwt.Token = accessToken
+	jwt.Expired = fmt.Sprintf("%d Hour", int(u.expiry.Access/time.Hour))
+	jwt.RefreshToken = refreshToken
+	jwt.RefreshExpired = fmt.Sprintf("%d Hour", int(refreshTTL/time.Hour))
+	return
+}
@@ -2,15 +2,33 @@ package usecase
 
 import (
 	"context"
+	"database/sql"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/redis/go-redis/v9"
 	"github.com/rzfhlv/gin-example/internal/modules/user/model"
+	pErrors "github.com/rzfhlv/gin-example/pkg/errors"
+	"github.com/rzfhlv/gin-example/pkg/hasher"
+	pJwt "github.com/rzfhlv/gin-example/pkg/jwt"
+	"github.com/rzfhlv/gin-example/pkg/lockout"
+	"github.com/rzfhlv/gin-example/pkg/mailer"
 	"github.com/rzfhlv/gin-example/pkg/param"
+	"github.com/rzfhlv/gin-example/pkg/password"
+	"github.com/rzfhlv/gin-example/pkg/rbac"
+	"github.com/rzfhlv/gin-example/pkg/session"
+	pToken "github.com/rzfhlv/gin-example/pkg/token"
 	mockRepo "github.com/rzfhlv/gin-example/shared/mocks/modules/user/repository"
+	mockAudit "github.com/rzfhlv/gin-example/shared/mocks/pkg/audit"
 	mockHasher "github.com/rzfhlv/gin-example/shared/mocks/pkg/hasher"
 	mockJwt "github.com/rzfhlv/gin-example/shared/mocks/pkg/jwt"
+	mockLockout "github.com/rzfhlv/gin-example/shared/mocks/pkg/lockout"
+	mockMailer "github.com/rzfhlv/gin-example/shared/mocks/pkg/mailer"
+	mockOidc "github.com/rzfhlv/gin-example/shared/mocks/pkg/oidc"
+	mockPassword "github.com/rzfhlv/gin-example/shared/mocks/pkg/password"
+	mockSession "github.com/rzfhlv/gin-example/shared/mocks/pkg/session"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"golang.org/x/crypto/bcrypt"
@@ -19,15 +37,19 @@ import (
 type testCase struct {
 	name                                                                string
 	wantError, wantIDError, wantJwtError, wantRedisError, wantHashError error
+	wantSessionError, wantRoleError, wantMemberError, wantMailError     error
+	wantPolicyError                                                     error
 	result                                                              CustomResult
 	payload                                                             model.Register
 	isErr                                                               bool
 }
 
 var (
-	token    string
-	errFoo   = errors.New("error")
-	register = model.Register{
+	token     string
+	sessionID = "thisissession"
+	roles     = []string{rbac.MEMBER}
+	errFoo    = errors.New("error")
+	register  = model.Register{
 		ID:        1,
 		Username:  "johndoe",
 		Email:     "johndoe@test.com",
@@ -38,6 +60,17 @@ var (
 		Username: "johndoe",
 		Password: "password",
 	}
+	expiry = Expiry{
+		Access:         time.Hour,
+		Refresh:        168 * time.Hour,
+		PasswordReset:  30 * time.Minute,
+		EmailVerify:    24 * time.Hour,
+		ResendInterval: time.Minute,
+		MFA:            5 * time.Minute,
+		Impersonate:    15 * time.Minute,
+		OIDCState:      10 * time.Minute,
+	}
+	app = App{Name: "gin-example", MailFrom: "noreply@test.com"}
 )
 
 type CustomResult struct {
@@ -58,9 +91,18 @@ func TestNew(t *testing.T) {
 	mockRepo := mockRepo.IRepository{}
 	mockHasher := mockHasher.HashPassword{}
 	mockJwt := mockJwt.JWTInterface{}
+	mockSession := mockSession.Store{}
+	mockMailer := mockMailer.Sender{}
+	mockLockout := mockLockout.Limiter{}
+	mockPassword := mockPassword.Checker{}
+	mockOidc := mockOidc.Provider{}
+	mockAudit := mockAudit.Store{}
+
+	mockHasher.On("HashedPassword", DUMMYPASSWORD).Return("dummyhash", nil)
 
-	u := New(&mockRepo, &mockHasher, &mockJwt)
+	u := New(&mockRepo, &mockHasher, &mockJwt, &mockSession, &mockMailer, &mockPassword, &mockOidc, &mockAudit, &mockLockout, &mockLockout, expiry, app)
 	assert.NotNil(t, u)
+	assert.Equal(t, "dummyhash", u.(*Usecase).dummyHash)
 }
 
 func TestRegister(t *testing.T) {
@@ -83,22 +125,56 @@ func TestRegister(t *testing.T) {
 		{
 			name: "Testcase #6: Negative", wantError: nil, wantIDError: nil, wantJwtError: nil, wantRedisError: nil, wantHashError: errFoo, result: CustomResult{lastInsertID: 1, rowsAffected: 1, err: nil}, payload: register, isErr: true,
 		},
+		{
+			name: "Testcase #7: Negative", wantError: nil, wantIDError: nil, wantJwtError: nil, wantRedisError: nil, wantHashError: nil, wantSessionError: errFoo, result: CustomResult{lastInsertID: 1, rowsAffected: 1, err: nil}, payload: register, isErr: true,
+		},
+		{
+			name: "Testcase #8: Negative", wantError: nil, wantIDError: nil, wantJwtError: nil, wantRedisError: nil, wantHashError: nil, wantRoleError: errFoo, result: CustomResult{lastInsertID: 1, rowsAffected: 1, err: nil}, payload: register, isErr: true,
+		},
+		{
+			name: "Testcase #9: Negative", wantError: nil, wantIDError: nil, wantJwtError: nil, wantRedisError: nil, wantHashError: nil, wantMemberError: errFoo, result: CustomResult{lastInsertID: 1, rowsAffected: 1, err: nil}, payload: register, isErr: true,
+		},
+		{
+			name: "Testcase #10: Negative", wantError: nil, wantIDError: nil, wantJwtError: nil, wantRedisError: nil, wantHashError: nil, wantMailError: errFoo, result: CustomResult{lastInsertID: 1, rowsAffected: 1, err: nil}, payload: register, isErr: true,
+		},
+		{
+			name: "Testcase #11: Negative", wantError: nil, wantIDError: nil, wantJwtError: nil, wantRedisError: nil, wantHashError: nil, wantPolicyError: &password.Error{}, result: CustomResult{lastInsertID: 1, rowsAffected: 1, err: nil}, payload: register, isErr: true,
+		},
+		{
+			name: "Testcase #12: Positive", wantError: nil, wantIDError: nil, wantJwtError: nil, wantRedisError: nil, wantHashError: nil, wantMemberError: pErrors.ErrConflict, result: CustomResult{lastInsertID: 1, rowsAffected: 1, err: nil}, payload: register, isErr: false,
+		},
 	}
 	for _, tt := range testCase {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := mockRepo.IRepository{}
 			mockHasher := mockHasher.HashPassword{}
 			mockJwt := mockJwt.JWTInterface{}
+			mockSession := mockSession.Store{}
+			mockMailer := mockMailer.Sender{}
+			mockPassword := mockPassword.Checker{}
 
+			mockPassword.On("Check", register.Password, register.Username, register.Email).Return(tt.wantPolicyError)
 			mockRepo.On("Register", mock.Anything, mock.Anything).Return(&tt.result, tt.wantError)
+			mockRepo.On("GrantRole", mock.Anything, int64(1), rbac.DEFAULTROLE).Return(tt.wantRoleError)
+			mockRepo.On("GetMemberByEmail", mock.Anything, register.Email).Return(model.Member{}, pErrors.ErrNotFound)
+			mockRepo.On("CreateMember", mock.Anything, mock.Anything).Return(&tt.result, tt.wantMemberError)
+			mockRepo.On("GetRoles", mock.Anything, int64(1)).Return(roles, nil)
+			mockRepo.On("GetByID", mock.Anything, int64(1)).Return(model.User{}, nil)
 			mockRepo.On("Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(tt.wantRedisError)
+			mockRepo.On("GetDel", mock.Anything, VERIFYUSERKEY+"1").Return("", redis.Nil)
 			mockHasher.On("HashedPassword", mock.Anything).Return("", tt.wantHashError)
-			mockJwt.On("Generate", mock.Anything, mock.Anything, mock.Anything).Return(token, tt.wantJwtError)
+			mockJwt.On("Generate", mock.Anything).Return(token, tt.wantJwtError)
+			mockSession.On("Save", mock.Anything, mock.Anything, mock.Anything).Return(tt.wantSessionError)
+			mockMailer.On("Send", mock.Anything, mock.Anything).Return(tt.wantMailError)
 
 			u := &Usecase{
+				expiry:  expiry,
 				repo:    &mockRepo,
 				hasher:  &mockHasher,
 				jwtImpl: &mockJwt,
+				session: &mockSession,
+				mailer:  &mockMailer,
+				policy:  &mockPassword,
 			}
 
 			_, err := u.Register(context.Background(), tt.payload)
@@ -106,6 +182,9 @@ func TestRegister(t *testing.T) {
 				assert.Error(t, err)
 			} else {
 				assert.NoError(t, err)
+				mockRepo.AssertCalled(t, "CreateMember", mock.Anything, mock.MatchedBy(func(m model.Member) bool {
+					return m.UserID.Int64 == 1 && m.Password == hasher.NOPASSWORD
+				}))
 			}
 		})
 	}
@@ -128,22 +207,45 @@ func TestLogin(t *testing.T) {
 		{
 			name: "Testcase #5: Negative", wantError: nil, wantJwtError: nil, wantRedisError: nil, wantHashError: bcrypt.ErrMismatchedHashAndPassword, isErr: true,
 		},
+		{
+			name: "Testcase #6: Negative", wantError: nil, wantJwtError: nil, wantRedisError: nil, wantHashError: nil, wantSessionError: errFoo, isErr: true,
+		},
+		{
+			name: "Testcase #7: Negative", wantError: nil, wantJwtError: nil, wantRedisError: nil, wantHashError: nil, wantRoleError: errFoo, isErr: true,
+		},
+		{
+			name: "Testcase #8: Negative", wantError: nil, wantJwtError: nil, wantRedisError: nil, wantHashError: bcrypt.ErrHashTooShort, isErr: true,
+		},
 	}
 	for _, tt := range testCase {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := mockRepo.IRepository{}
 			mockHasher := mockHasher.HashPassword{}
 			mockJwt := mockJwt.JWTInterface{}
+			mockSession := mockSession.Store{}
+			mockLockout := mockLockout.Limiter{}
 
 			mockRepo.On("Login", mock.Anything, mock.Anything).Return(model.Register{}, tt.wantError)
+			mockRepo.On("GetRoles", mock.Anything, mock.Anything).Return(roles, tt.wantRoleError)
+			mockRepo.On("GetByID", mock.Anything, mock.Anything).Return(model.User{}, nil)
+			mockRepo.On("GetMFA", mock.Anything, mock.Anything).Return(model.MFA{}, pErrors.ErrNotFound)
 			mockRepo.On("Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(tt.wantRedisError)
 			mockHasher.On("VerifyPassword", mock.Anything, mock.Anything).Return(tt.wantHashError)
-			mockJwt.On("Generate", mock.Anything, mock.Anything, mock.Anything).Return(token, tt.wantJwtError)
+			mockHasher.On("NeedsRehash", mock.Anything).Return(false)
+			mockJwt.On("Generate", mock.Anything).Return(token, tt.wantJwtError)
+			mockSession.On("Save", mock.Anything, mock.Anything, mock.Anything).Return(tt.wantSessionError)
+			mockLockout.On("Check", mock.Anything, mock.Anything).Return(nil)
+			mockLockout.On("Fail", mock.Anything, mock.Anything).Return(nil)
+			mockLockout.On("Reset", mock.Anything, mock.Anything).Return(nil)
 
 			u := &Usecase{
-				repo:    &mockRepo,
-				hasher:  &mockHasher,
-				jwtImpl: &mockJwt,
+				expiry:     expiry,
+				repo:       &mockRepo,
+				hasher:     &mockHasher,
+				jwtImpl:    &mockJwt,
+				session:    &mockSession,
+				loginUsers: &mockLockout,
+				loginIPs:   &mockLockout,
 			}
 
 			_, err := u.Login(context.Background(), login)
@@ -157,35 +259,221 @@ func TestLogin(t *testing.T) {
 }
 
 func TestLogout(t *testing.T) {
-	token := "thisistoken"
 	testCase := []testCase{
 		{
-			name: "Testcase #1: Positive", wantError: nil, isErr: false,
+			name: "Testcase #1: Positive", wantSessionError: nil, isErr: false,
 		},
 		{
-			name: "Testcase #2: Negative", wantError: errFoo, isErr: true,
+			name: "Testcase #2: Negative", wantSessionError: errFoo, isErr: true,
 		},
 	}
 	for _, tt := range testCase {
 		t.Run(tt.name, func(t *testing.T) {
-			mockRepo := mockRepo.IRepository{}
-			mockRepo.On("Del", mock.Anything, mock.Anything).Return(tt.wantError)
+			mockSession := mockSession.Store{}
+			mockSession.On("Revoke", mock.Anything, register.ID, sessionID).Return(tt.wantSessionError)
+
+			u := &Usecase{
+				expiry:  expiry,
+				session: &mockSession,
+			}
+
+			err := u.Logout(context.Background(), register.ID, sessionID)
+			if tt.isErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
 
+func TestRefresh(t *testing.T) {
+	refresh := model.Refresh{RefreshToken: "thisisrefreshtoken"}
+	current := session.Session{
+		ID: sessionID, UserID: 1, Username: "johndoe", Email: "johndoe@test.com", Refresh: pToken.Hash(refresh.RefreshToken),
+	}
+	rotated := current
+	rotated.Refresh = pToken.Hash("anotherrefreshtoken")
+	consumed := current
+	consumed.Refresh = ""
+
+	testCase := []struct {
+		name                                 string
+		session                              session.Session
+		wantSessionIDError, wantSessionError error
+		wantJwtError, wantRevokeError        error
+		wantRotateError, wantError           error
+		wantGetError, wantRoleError          error
+		isErr                                bool
+	}{
+		{
+			name: "Testcase #1: Positive", session: consumed, isErr: false,
+		},
+		{
+			name: "Testcase #2: Negative", session: current, wantSessionIDError: redis.Nil, wantError: ErrInvalidRefreshToken, isErr: true,
+		},
+		{
+			name: "Testcase #3: Negative", session: current, wantSessionIDError: errFoo, wantError: errFoo, isErr: true,
+		},
+		{
+			name: "Testcase #4: Negative", session: current, wantSessionError: session.ErrNotFound, wantError: ErrInvalidRefreshToken, isErr: true,
+		},
+		{
+			name: "Testcase #5: Negative", session: current, wantSessionError: errFoo, wantError: errFoo, isErr: true,
+		},
+		{
+			name: "Testcase #6: Negative", session: rotated, wantSessionError: session.ErrConsumed, wantError: ErrRefreshTokenReused, isErr: true,
+		},
+		{
+			name: "Testcase #7: Negative", session: rotated, wantSessionError: session.ErrConsumed, wantRevokeError: errFoo, wantError: errFoo, isErr: true,
+		},
+		{
+			name: "Testcase #8: Negative", session: consumed, wantJwtError: errFoo, wantError: errFoo, isErr: true,
+		},
+		{
+			name: "Testcase #9: Negative", session: consumed, wantRotateError: session.ErrNotFound, wantError: ErrInvalidRefreshToken, isErr: true,
+		},
+		{
+			name: "Testcase #10: Negative", session: consumed, wantRotateError: errFoo, wantError: errFoo, isErr: true,
+		},
+		{
+			name: "Testcase #11: Negative", session: current, wantGetError: session.ErrNotFound, wantError: ErrInvalidRefreshToken, isErr: true,
+		},
+		{
+			name: "Testcase #12: Negative", session: current, wantRoleError: errFoo, wantError: errFoo, isErr: true,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mockRepo.IRepository{}
 			mockJwt := mockJwt.JWTInterface{}
-			mockHasher := mockHasher.HashPassword{}
+			mockSession := mockSession.Store{}
+
+			mockRepo.On("Get", mock.Anything, REFRESHKEY+pToken.Hash(refresh.RefreshToken)).Return(sessionID, tt.wantSessionIDError)
+			mockRepo.On("Set", mock.Anything, mock.Anything, sessionID, mock.Anything).Return(nil)
+			mockSession.On("Get", mock.Anything, sessionID).Return(current, tt.wantGetError)
+			mockSession.On("Consume", mock.Anything, sessionID, current.Refresh).Return(tt.session, tt.wantSessionError)
+			mockSession.On("Revoke", mock.Anything, tt.session.UserID, sessionID).Return(tt.wantRevokeError)
+			mockSession.On("Rotate", mock.Anything, mock.Anything, mock.Anything).Return(func(_ context.Context, rotated session.Session, _ time.Duration) (session.Session, error) {
+				return rotated, tt.wantRotateError
+			})
+			mockRepo.On("GetRoles", mock.Anything, tt.session.UserID).Return(roles, tt.wantRoleError)
+			mockRepo.On("GetByID", mock.Anything, tt.session.UserID).Return(model.User{EmailVerifiedAt: &register.CreatedAt}, nil)
+			mockJwt.On("Generate", pJwt.Payload{
+				ID: tt.session.UserID, Username: tt.session.Username, Email: tt.session.Email, SessionID: sessionID, Roles: roles, EmailVerified: true,
+			}).Return(token, tt.wantJwtError)
 
 			u := &Usecase{
+				expiry:  expiry,
 				repo:    &mockRepo,
 				jwtImpl: &mockJwt,
-				hasher:  &mockHasher,
+				session: &mockSession,
 			}
 
-			err := u.Logout(context.Background(), token)
+			jwt, err := u.Refresh(context.Background(), refresh)
+			if tt.wantGetError != nil || tt.wantRoleError != nil {
+				mockSession.AssertNotCalled(t, "Consume", mock.Anything, mock.Anything, mock.Anything)
+			}
 			if tt.isErr {
 				assert.Error(t, err)
+				assert.Equal(t, tt.wantError, err)
 			} else {
 				assert.NoError(t, err)
+				assert.NotEmpty(t, jwt.RefreshToken)
+				assert.NotEqual(t, refresh.RefreshToken, jwt.RefreshToken)
+				mockSession.AssertCalled(t, "Rotate", mock.Anything, mock.MatchedBy(func(s session.Session) bool {
+					return s.ID == sessionID && s.Refresh == pToken.Hash(jwt.RefreshToken)
+				}), mock.Anything)
+				mockSession.AssertNotCalled(t, "Save", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestGetSessions(t *testing.T) {
+	sessions := []session.Session{
+		{ID: sessionID, UserID: register.ID},
+		{ID: "othersession", UserID: register.ID, ActorID: 9},
+	}
+	testCase := []testCase{
+		{
+			name: "Testcase #1: Positive", wantSessionError: nil, isErr: false,
+		},
+		{
+			name: "Testcase #2: Negative", wantSessionError: errFoo, isErr: true,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSession := mockSession.Store{}
+			mockSession.On("List", mock.Anything, register.ID).Return(sessions, tt.wantSessionError)
+
+			u := &Usecase{
+				expiry:  expiry,
+				session: &mockSession,
+			}
+
+			result, err := u.GetSessions(context.Background(), register.ID, sessionID)
+			if tt.isErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, result, 2)
+				assert.True(t, result[0].Current)
+				assert.False(t, result[1].Current)
+				assert.False(t, result[0].Impersonated)
+				assert.True(t, result[1].Impersonated)
+			}
+		})
+	}
+}
+
+func TestRevokeSession(t *testing.T) {
+	testCase := []testCase{
+		{
+			name: "Testcase #1: Positive", wantSessionError: nil, isErr: false,
+		},
+		{
+			name: "Testcase #2: Negative", wantSessionError: session.ErrNotFound, isErr: true,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSession := mockSession.Store{}
+			mockSession.On("Revoke", mock.Anything, register.ID, sessionID).Return(tt.wantSessionError)
+
+			u := &Usecase{
+				expiry:  expiry,
+				session: &mockSession,
+			}
+
+			err := u.RevokeSession(context.Background(), register.ID, sessionID)
+			assert.Equal(t, tt.wantSessionError, err)
+		})
+	}
+}
+
+func TestRevokeSessions(t *testing.T) {
+	testCase := []testCase{
+		{
+			name: "Testcase #1: Positive", wantSessionError: nil, isErr: false,
+		},
+		{
+			name: "Testcase #2: Negative", wantSessionError: errFoo, isErr: true,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSession := mockSession.Store{}
+			mockSession.On("RevokeAll", mock.Anything, register.ID).Return(tt.wantSessionError)
+
+			u := &Usecase{
+				expiry:  expiry,
+				session: &mockSession,
 			}
+
+			err := u.RevokeSessions(context.Background(), register.ID)
+			assert.Equal(t, tt.wantSessionError, err)
 		})
 	}
 }
@@ -203,11 +491,12 @@ func TestGetAll(t *testing.T) {
 	for _, tt := range testCase {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := mockRepo.IRepository{}
-			mockRepo.On("GetAll", mock.Anything, mock.Anything).Return([]model.User{}, tt.wantError)
-			mockRepo.On("Count", mock.Anything).Return(expectedCount, tt.wantError)
+			mockRepo.On("GetAll", mock.Anything, mock.Anything).Return([]model.User{}, param.Page{}, tt.wantError)
+			mockRepo.On("Count", mock.Anything, mock.Anything).Return(expectedCount, tt.wantError)
 
 			u := &Usecase{
-				repo: &mockRepo,
+				expiry: expiry,
+				repo:   &mockRepo,
 			}
 
 			_, _, err := u.GetAll(context.Background(), param.Param{})
@@ -231,7 +520,8 @@ func TestGetByID(t *testing.T) {
 			mockRepo.On("GetByID", mock.Anything, mock.Anything).Return(model.User{}, tt.wantError)
 
 			u := &Usecase{
-				repo: &mockRepo,
+				expiry: expiry,
+				repo:   &mockRepo,
 			}
 
 			_, err := u.GetByID(context.Background(), register.ID)
@@ -239,3 +529,731 @@ func TestGetByID(t *testing.T) {
 		})
 	}
 }
+
+func TestJWKS(t *testing.T) {
+	mockJwt := mockJwt.JWTInterface{}
+	mockJwt.On("JWKS").Return(pJwt.JWKS{Keys: []pJwt.JWK{{Kid: "rsa-1"}}})
+
+	u := &Usecase{
+		expiry:  expiry,
+		jwtImpl: &mockJwt,
+	}
+
+	jwks := u.JWKS(context.Background())
+	assert.Len(t, jwks.Keys, 1)
+	assert.Equal(t, "rsa-1", jwks.Keys[0].Kid)
+}
+
+func TestGetRoles(t *testing.T) {
+	testCase := []testCase{
+		{
+			name: "Testcase #1: Positive", wantError: nil, wantRoleError: nil, isErr: false,
+		},
+		{
+			name: "Testcase #2: Negative", wantError: errFoo, wantRoleError: nil, isErr: true,
+		},
+		{
+			name: "Testcase #3: Negative", wantError: nil, wantRoleError: errFoo, isErr: true,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mockRepo.IRepository{}
+			mockRepo.On("GetByID", mock.Anything, register.ID).Return(model.User{}, tt.wantError)
+			mockRepo.On("GetRoles", mock.Anything, register.ID).Return(nil, tt.wantRoleError)
+
+			u := &Usecase{
+				expiry: expiry,
+				repo:   &mockRepo,
+			}
+
+			result, err := u.GetRoles(context.Background(), register.ID)
+			if tt.isErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, []string{}, result)
+			}
+		})
+	}
+}
+
+func TestGrantRole(t *testing.T) {
+	testCase := []struct {
+		name                                     string
+		role                                     string
+		wantUserError, wantGrantError, wantError error
+	}{
+		{
+			name: "Testcase #1: Positive", role: rbac.ADMIN,
+		},
+		{
+			name: "Testcase #2: Negative", role: "root", wantError: rbac.ErrUnknownRole,
+		},
+		{
+			name: "Testcase #3: Negative", role: rbac.ADMIN, wantUserError: pErrors.ErrNotFound, wantError: pErrors.ErrNotFound,
+		},
+		{
+			name: "Testcase #4: Negative", role: rbac.ADMIN, wantGrantError: errFoo, wantError: errFoo,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mockRepo.IRepository{}
+			mockRepo.On("GetByID", mock.Anything, register.ID).Return(model.User{}, tt.wantUserError)
+			mockRepo.On("GrantRole", mock.Anything, register.ID, tt.role).Return(tt.wantGrantError)
+			mockRepo.On("GetRoles", mock.Anything, register.ID).Return([]string{rbac.ADMIN, rbac.MEMBER}, nil)
+
+			u := &Usecase{
+				expiry: expiry,
+				repo:   &mockRepo,
+			}
+
+			result, err := u.GrantRole(context.Background(), register.ID, tt.role)
+			assert.Equal(t, tt.wantError, err)
+			if tt.wantError == nil {
+				assert.Equal(t, []string{rbac.ADMIN, rbac.MEMBER}, result)
+			}
+		})
+	}
+}
+
+func TestRevokeRole(t *testing.T) {
+	testCase := []struct {
+		name                                      string
+		role                                      string
+		wantUserError, wantRevokeError, wantError error
+	}{
+		{
+			name: "Testcase #1: Positive", role: rbac.ADMIN,
+		},
+		{
+			name: "Testcase #2: Negative", role: "root", wantError: rbac.ErrUnknownRole,
+		},
+		{
+			name: "Testcase #3: Negative", role: rbac.ADMIN, wantUserError: pErrors.ErrNotFound, wantError: pErrors.ErrNotFound,
+		},
+		{
+			name: "Testcase #4: Negative", role: rbac.ADMIN, wantRevokeError: errFoo, wantError: errFoo,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mockRepo.IRepository{}
+			mockRepo.On("GetByID", mock.Anything, register.ID).Return(model.User{}, tt.wantUserError)
+			mockRepo.On("RevokeRole", mock.Anything, register.ID, tt.role).Return(tt.wantRevokeError)
+			mockRepo.On("GetRoles", mock.Anything, register.ID).Return([]string{rbac.MEMBER}, nil)
+
+			u := &Usecase{
+				expiry: expiry,
+				repo:   &mockRepo,
+			}
+
+			result, err := u.RevokeRole(context.Background(), register.ID, tt.role)
+			assert.Equal(t, tt.wantError, err)
+			if tt.wantError == nil {
+				assert.Equal(t, []string{rbac.MEMBER}, result)
+			}
+		})
+	}
+}
+
+func TestRegisterExistingMember(t *testing.T) {
+	mockRepo := mockRepo.IRepository{}
+	mockHasher := mockHasher.HashPassword{}
+	mockJwt := mockJwt.JWTInterface{}
+	mockSession := mockSession.Store{}
+	mockMailer := mockMailer.Sender{}
+	mockPassword := mockPassword.Checker{}
+
+	mockPassword.On("Check", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("Register", mock.Anything, mock.Anything).Return(&CustomResult{lastInsertID: 1, rowsAffected: 1}, nil)
+	mockRepo.On("GrantRole", mock.Anything, int64(1), rbac.DEFAULTROLE).Return(nil)
+	mockRepo.On("GetMemberByEmail", mock.Anything, register.Email).Return(model.Member{ID: 5, Email: register.Email}, nil)
+	mockRepo.On("GetRoles", mock.Anything, int64(1)).Return(roles, nil)
+	mockRepo.On("GetByID", mock.Anything, int64(1)).Return(model.User{}, nil)
+	mockRepo.On("Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("GetDel", mock.Anything, VERIFYUSERKEY+"1").Return("", redis.Nil)
+	mockHasher.On("HashedPassword", mock.Anything).Return("", nil)
+	mockJwt.On("Generate", mock.Anything).Return(token, nil)
+	mockSession.On("Save", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockMailer.On("Send", mock.Anything, mock.Anything).Return(nil)
+
+	u := &Usecase{
+		expiry:  expiry,
+		repo:    &mockRepo,
+		hasher:  &mockHasher,
+		jwtImpl: &mockJwt,
+		session: &mockSession,
+		mailer:  &mockMailer,
+		policy:  &mockPassword,
+	}
+
+	_, err := u.Register(context.Background(), register)
+	assert.NoError(t, err)
+	mockRepo.AssertNotCalled(t, "CreateMember", mock.Anything, mock.Anything)
+}
+
+func TestGetMember(t *testing.T) {
+	testCase := []testCase{
+		{
+			name: "Testcase #1: Positive", wantError: nil, isErr: false,
+		},
+		{
+			name: "Testcase #2: Negative", wantError: pErrors.ErrNotFound, isErr: true,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mockRepo.IRepository{}
+			mockRepo.On("GetMemberByUserID", mock.Anything, register.ID).Return(model.Member{ID: 5}, tt.wantError)
+
+			u := &Usecase{
+				expiry: expiry,
+				repo:   &mockRepo,
+			}
+
+			member, err := u.GetMember(context.Background(), register.ID)
+			if tt.isErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, int64(5), member.ID)
+			}
+		})
+	}
+}
+
+func TestClaimMember(t *testing.T) {
+	unclaimed := model.Member{ID: 5, Email: register.Email, Password: "hashed"}
+	verified := model.User{ID: register.ID, Email: register.Email, EmailVerifiedAt: &register.CreatedAt}
+
+	testCase := []struct {
+		name                                          string
+		user                                          model.User
+		member                                        model.Member
+		result                                        CustomResult
+		wantUserError, wantMemberError, wantLinkError error
+		wantError                                     error
+	}{
+		{
+			name: "Testcase #1: Positive", user: verified, member: unclaimed, result: CustomResult{rowsAffected: 1},
+		},
+		{
+			name: "Testcase #2: Positive", user: verified, member: model.Member{ID: 5, UserID: sql.NullInt64{Int64: register.ID, Valid: true}},
+		},
+		{
+			name: "Testcase #3: Negative", user: verified, member: model.Member{ID: 5, UserID: sql.NullInt64{Int64: 2, Valid: true}}, wantError: ErrMemberClaimed,
+		},
+		{
+			name: "Testcase #4: Negative", user: model.User{ID: register.ID, Email: register.Email}, member: unclaimed, wantError: ErrEmailNotVerified,
+		},
+		{
+			name: "Testcase #5: Negative", user: verified, member: unclaimed, result: CustomResult{rowsAffected: 0}, wantError: ErrMemberClaimed,
+		},
+		{
+			name: "Testcase #6: Negative", user: verified, member: unclaimed, wantLinkError: errFoo, wantError: errFoo,
+		},
+		{
+			name: "Testcase #7: Negative", wantUserError: pErrors.ErrNotFound, wantError: pErrors.ErrNotFound,
+		},
+		{
+			name: "Testcase #8: Negative", user: verified, wantMemberError: pErrors.ErrNotFound, wantError: pErrors.ErrNotFound,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mockRepo.IRepository{}
+			mockRepo.On("GetByID", mock.Anything, register.ID).Return(tt.user, tt.wantUserError)
+			mockRepo.On("GetMemberByEmail", mock.Anything, register.Email).Return(tt.member, tt.wantMemberError)
+			mockRepo.On("LinkMember", mock.Anything, int64(5), register.ID).Return(&tt.result, tt.wantLinkError)
+
+			u := &Usecase{
+				expiry: expiry,
+				repo:   &mockRepo,
+			}
+
+			member, err := u.ClaimMember(context.Background(), register.ID)
+			assert.Equal(t, tt.wantError, err)
+			if tt.wantError == nil {
+				assert.Equal(t, register.ID, member.UserID.Int64)
+			}
+			if tt.wantError == ErrEmailNotVerified {
+				mockRepo.AssertNotCalled(t, "LinkMember", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestForgotPassword(t *testing.T) {
+	testCase := []struct {
+		name                                                    string
+		wantUserError, wantRedisError, wantMailError, wantError error
+		previous                                                string
+		sent                                                    bool
+	}{
+		{
+			name: "Testcase #1: Positive", sent: true,
+		},
+		{
+			name: "Testcase #2: Positive", wantUserError: pErrors.ErrNotFound,
+		},
+		{
+			name: "Testcase #3: Negative", wantUserError: errFoo, wantError: errFoo,
+		},
+		{
+			name: "Testcase #4: Negative", wantRedisError: errFoo, wantError: errFoo,
+		},
+		{
+			name: "Testcase #5: Negative", wantMailError: errFoo, wantError: errFoo, sent: true,
+		},
+		{
+			name: "Testcase #6: Positive", previous: RESETKEY + "oldtoken", sent: true,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mockRepo.IRepository{}
+			mockMailer := mockMailer.Sender{}
+
+			var resetKey string
+			mockRepo.On("GetByEmail", mock.Anything, register.Email).Return(model.User{ID: register.ID, Username: register.Username, Email: register.Email}, tt.wantUserError)
+			if tt.previous != "" {
+				mockRepo.On("GetDel", mock.Anything, RESETUSERKEY+"1").Return(tt.previous, nil)
+			} else {
+				mockRepo.On("GetDel", mock.Anything, RESETUSERKEY+"1").Return("", redis.Nil)
+			}
+			mockRepo.On("Del", mock.Anything, tt.previous).Return(nil)
+			mockRepo.On("Set", mock.Anything, mock.Anything, "1", expiry.PasswordReset).
+				Run(func(args mock.Arguments) { resetKey = args.String(1) }).Return(tt.wantRedisError)
+			mockRepo.On("Set", mock.Anything, RESETUSERKEY+"1", mock.Anything, expiry.PasswordReset).Return(nil)
+			mockMailer.On("Send", mock.Anything, mock.Anything).Return(tt.wantMailError)
+
+			u := &Usecase{
+				expiry: expiry,
+				app:    app,
+				repo:   &mockRepo,
+				mailer: &mockMailer,
+			}
+
+			err := u.ForgotPassword(context.Background(), model.ForgotPassword{Email: register.Email})
+			assert.Equal(t, tt.wantError, err)
+			if !tt.sent {
+				mockMailer.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+				return
+			}
+
+			// the mail carries the raw token while redis only keeps its hash
+			message := mockMailer.Calls[0].Arguments.Get(1).(mailer.Message)
+			assert.Equal(t, app.MailFrom, message.From)
+			assert.Equal(t, register.Email, message.To)
+			found := false
+			for _, word := range strings.Fields(message.Body) {
+				if RESETKEY+pToken.Hash(word) == resetKey {
+					found = true
+				}
+			}
+			assert.True(t, found)
+
+			// the user points at the latest token, the one before is gone
+			mockRepo.AssertCalled(t, "Set", mock.Anything, RESETUSERKEY+"1", resetKey, expiry.PasswordReset)
+			if tt.previous != "" {
+				mockRepo.AssertCalled(t, "Del", mock.Anything, tt.previous)
+			} else {
+				mockRepo.AssertNotCalled(t, "Del", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestResetPassword(t *testing.T) {
+	reset := model.ResetPassword{Token: "thisisresettoken", Password: "newpassword"}
+	resetKey := RESETKEY + pToken.Hash(reset.Token)
+
+	testCase := []struct {
+		name                                                                        string
+		value                                                                       string
+		wantRedisError, wantHashError, wantUpdateError, wantSessionError, wantError error
+		wantUserError, wantPolicyError, wantDelError, wantMFAError                  error
+	}{
+		{
+			name: "Testcase #1: Positive", value: "1",
+		},
+		{
+			name: "Testcase #2: Negative", wantRedisError: redis.Nil, wantError: ErrInvalidResetToken,
+		},
+		{
+			name: "Testcase #3: Negative", wantRedisError: errFoo, wantError: errFoo,
+		},
+		{
+			name: "Testcase #4: Negative", value: "1", wantHashError: errFoo, wantError: errFoo,
+		},
+		{
+			name: "Testcase #5: Negative", value: "1", wantUpdateError: errFoo, wantError: errFoo,
+		},
+		{
+			name: "Testcase #6: Negative", value: "1", wantSessionError: errFoo, wantError: errFoo,
+		},
+		{
+			name: "Testcase #7: Negative", value: "1", wantPolicyError: &password.Error{}, wantError: &password.Error{},
+		},
+		{
+			name: "Testcase #8: Negative", value: "1", wantUserError: pErrors.ErrNotFound, wantError: pErrors.ErrNotFound,
+		},
+		{
+			name: "Testcase #9: Negative", value: "1", wantDelError: redis.Nil, wantError: ErrInvalidResetToken,
+		},
+		{
+			name: "Testcase #10: Negative", value: "1", wantMFAError: errFoo, wantError: errFoo,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mockRepo.IRepository{}
+			mockHasher := mockHasher.HashPassword{}
+			mockSession := mockSession.Store{}
+			mockPassword := mockPassword.Checker{}
+
+			mockRepo.On("Get", mock.Anything, resetKey).Return(tt.value, tt.wantRedisError)
+			mockRepo.On("GetDel", mock.Anything, resetKey).Return(tt.value, tt.wantDelError)
+			mockRepo.On("GetByID", mock.Anything, int64(1)).Return(model.User{ID: 1, Username: register.Username, Email: register.Email}, tt.wantUserError)
+			mockPassword.On("Check", reset.Password, register.Username, register.Email).Return(tt.wantPolicyError)
+			mockHasher.On("HashedPassword", reset.Password).Return("hashed", tt.wantHashError)
+			mockRepo.On("UpdatePassword", mock.Anything, int64(1), "hashed").Return(tt.wantUpdateError)
+			mockRepo.On("DeleteMFA", mock.Anything, int64(1)).Return(tt.wantMFAError)
+			mockSession.On("RevokeAll", mock.Anything, int64(1)).Return(tt.wantSessionError)
+
+			u := &Usecase{
+				expiry:  expiry,
+				repo:    &mockRepo,
+				hasher:  &mockHasher,
+				session: &mockSession,
+				policy:  &mockPassword,
+			}
+
+			err := u.ResetPassword(context.Background(), reset)
+			assert.Equal(t, tt.wantError, err)
+			if tt.wantError == nil {
+				mockRepo.AssertCalled(t, "DeleteMFA", mock.Anything, int64(1))
+				mockSession.AssertCalled(t, "RevokeAll", mock.Anything, int64(1))
+			}
+			if tt.wantPolicyError != nil || tt.wantDelError != nil {
+				mockRepo.AssertNotCalled(t, "UpdatePassword", mock.Anything, mock.Anything, mock.Anything)
+			}
+			if tt.wantPolicyError != nil {
+				mockRepo.AssertNotCalled(t, "GetDel", mock.Anything, resetKey)
+			}
+		})
+	}
+}
+
+func TestVerifyEmail(t *testing.T) {
+	verifyToken := "thisisverifytoken"
+	verifyKey := VERIFYKEY + pToken.Hash(verifyToken)
+	value := "1:" + register.Email
+
+	testCase := []struct {
+		name                                       string
+		value                                      string
+		email                                      string
+		wantRedisError, wantVerifyError, wantError error
+		wantUserError                              error
+	}{
+		{
+			name: "Testcase #1: Positive", value: value, email: register.Email,
+		},
+		{
+			name: "Testcase #2: Negative", wantRedisError: redis.Nil, wantError: ErrInvalidVerifyToken,
+		},
+		{
+			name: "Testcase #3: Negative", wantRedisError: errFoo, wantError: errFoo,
+		},
+		{
+			name: "Testcase #4: Negative", value: value, email: register.Email, wantVerifyError: errFoo, wantError: errFoo,
+		},
+		{
+			name: "Testcase #5: Negative", value: value, email: "jane@test.com", wantError: ErrInvalidVerifyToken,
+		},
+		{
+			name: "Testcase #6: Negative", value: "1", wantError: ErrInvalidVerifyToken,
+		},
+		{
+			name: "Testcase #7: Negative", value: value, wantUserError: pErrors.ErrNotFound, wantError: pErrors.ErrNotFound,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mockRepo.IRepository{}
+			mockRepo.On("GetDel", mock.Anything, verifyKey).Return(tt.value, tt.wantRedisError)
+			mockRepo.On("GetByID", mock.Anything, int64(1)).Return(model.User{ID: 1, Email: tt.email}, tt.wantUserError)
+			mockRepo.On("VerifyEmail", mock.Anything, int64(1), mock.Anything).Return(tt.wantVerifyError)
+
+			u := &Usecase{
+				expiry: expiry,
+				repo:   &mockRepo,
+			}
+
+			err := u.VerifyEmail(context.Background(), verifyToken)
+			assert.Equal(t, tt.wantError, err)
+			if tt.wantError == ErrInvalidVerifyToken {
+				mockRepo.AssertNotCalled(t, "VerifyEmail", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestResendVerification(t *testing.T) {
+	resendKey := RESENDKEY + pToken.Hash(register.Email)
+
+	testCase := []struct {
+		name                                                    string
+		allowed                                                 bool
+		user                                                    model.User
+		wantLimitError, wantUserError, wantMailError, wantError error
+		sent                                                    bool
+	}{
+		{
+			name: "Testcase #1: Positive", allowed: true, user: model.User{ID: 1, Email: register.Email}, sent: true,
+		},
+		{
+			name: "Testcase #2: Positive", allowed: true, user: model.User{ID: 1, Email: register.Email, EmailVerifiedAt: &register.CreatedAt},
+		},
+		{
+			name: "Testcase #3: Positive", allowed: true, wantUserError: pErrors.ErrNotFound,
+		},
+		{
+			name: "Testcase #4: Negative", allowed: false, wantError: ErrResendTooSoon,
+		},
+		{
+			name: "Testcase #5: Negative", wantLimitError: errFoo, wantError: errFoo,
+		},
+		{
+			name: "Testcase #6: Negative", allowed: true, wantUserError: errFoo, wantError: errFoo,
+		},
+		{
+			name: "Testcase #7: Negative", allowed: true, user: model.User{ID: 1, Email: register.Email}, wantMailError: errFoo, wantError: errFoo, sent: true,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mockRepo.IRepository{}
+			mockMailer := mockMailer.Sender{}
+			mockRepo.On("SetNX", mock.Anything, resendKey, "1", expiry.ResendInterval).Return(tt.allowed, tt.wantLimitError)
+			mockRepo.On("GetByEmail", mock.Anything, "JohnDoe@test.com").Return(tt.user, tt.wantUserError)
+			mockRepo.On("GetDel", mock.Anything, VERIFYUSERKEY+"1").Return("", redis.Nil)
+			mockRepo.On("Set", mock.Anything, mock.Anything, mock.Anything, expiry.EmailVerify).Return(nil)
+			mockMailer.On("Send", mock.Anything, mock.Anything).Return(tt.wantMailError)
+
+			u := &Usecase{
+				expiry: expiry,
+				repo:   &mockRepo,
+				mailer: &mockMailer,
+			}
+
+			err := u.ResendVerification(context.Background(), model.ResendVerification{Email: "JohnDoe@test.com"})
+			assert.Equal(t, tt.wantError, err)
+			if tt.sent {
+				mockMailer.AssertCalled(t, "Send", mock.Anything, mock.Anything)
+			} else {
+				mockMailer.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestLoginLockout(t *testing.T) {
+	locked := &lockout.Error{Locked: true, RetryAfter: time.Minute}
+	throttled := &lockout.Error{RetryAfter: time.Second}
+	login := model.Login{Username: "JohnDoe", Password: "password", IP: "127.0.0.1"}
+
+	testCase := []struct {
+		name                                               string
+		wantIPCheckError, wantUserCheckError               error
+		wantLoginError, wantHashError                      error
+		wantIPFailError, wantUserFailError, wantResetError error
+		wantError                                          error
+		failed                                             bool
+	}{
+		{
+			name: "Testcase #1: Positive",
+		},
+		{
+			name: "Testcase #2: Negative", wantIPCheckError: throttled, wantError: throttled,
+		},
+		{
+			name: "Testcase #3: Negative", wantUserCheckError: locked, wantError: locked,
+		},
+		{
+			name: "Testcase #4: Negative", wantLoginError: pErrors.ErrNotFound, wantError: ErrInvalidCredentials, failed: true,
+		},
+		{
+			name: "Testcase #5: Negative", wantHashError: bcrypt.ErrMismatchedHashAndPassword, wantError: ErrInvalidCredentials, failed: true,
+		},
+		{
+			name: "Testcase #6: Negative", wantHashError: bcrypt.ErrMismatchedHashAndPassword, wantUserFailError: locked, wantError: locked, failed: true,
+		},
+		{
+			name: "Testcase #7: Negative", wantHashError: bcrypt.ErrMismatchedHashAndPassword, wantIPFailError: errFoo, wantError: errFoo, failed: true,
+		},
+		{
+			name: "Testcase #8: Negative", wantHashError: bcrypt.ErrHashTooShort, wantError: bcrypt.ErrHashTooShort,
+		},
+		{
+			name: "Testcase #9: Negative", wantLoginError: errFoo, wantError: errFoo,
+		},
+		{
+			name: "Testcase #10: Negative", wantResetError: errFoo, wantError: errFoo,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mockRepo.IRepository{}
+			mockHasher := mockHasher.HashPassword{}
+			mockJwt := mockJwt.JWTInterface{}
+			mockSession := mockSession.Store{}
+			mockUsers := mockLockout.Limiter{}
+			mockIPs := mockLockout.Limiter{}
+
+			mockIPs.On("Check", mock.Anything, "127.0.0.1").Return(tt.wantIPCheckError)
+			mockIPs.On("Fail", mock.Anything, "127.0.0.1").Return(tt.wantIPFailError)
+			mockUsers.On("Check", mock.Anything, "johndoe").Return(tt.wantUserCheckError)
+			mockUsers.On("Fail", mock.Anything, "johndoe").Return(tt.wantUserFailError)
+			mockUsers.On("Reset", mock.Anything, "johndoe").Return(tt.wantResetError)
+			mockRepo.On("Login", mock.Anything, login).Return(model.Register{ID: 1, Password: "hashed"}, tt.wantLoginError)
+			mockRepo.On("GetMFA", mock.Anything, int64(1)).Return(model.MFA{}, pErrors.ErrNotFound)
+			mockRepo.On("GetRoles", mock.Anything, int64(1)).Return(roles, nil)
+			mockRepo.On("GetByID", mock.Anything, int64(1)).Return(model.User{}, nil)
+			mockRepo.On("Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			mockHasher.On("VerifyPassword", "hashed", login.Password).Return(tt.wantHashError)
+			mockHasher.On("VerifyPassword", "dummyhash", login.Password).Return(bcrypt.ErrMismatchedHashAndPassword)
+			mockHasher.On("NeedsRehash", "hashed").Return(false)
+			mockJwt.On("Generate", mock.Anything).Return(token, nil)
+			mockSession.On("Save", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+			u := &Usecase{
+				expiry:     expiry,
+				repo:       &mockRepo,
+				hasher:     &mockHasher,
+				jwtImpl:    &mockJwt,
+				session:    &mockSession,
+				loginUsers: &mockUsers,
+				loginIPs:   &mockIPs,
+				dummyHash:  "dummyhash",
+			}
+
+			_, err := u.Login(context.Background(), login)
+			assert.Equal(t, tt.wantError, err)
+			if tt.wantLoginError == pErrors.ErrNotFound {
+				mockHasher.AssertCalled(t, "VerifyPassword", "dummyhash", login.Password)
+			}
+			if tt.failed {
+				mockIPs.AssertCalled(t, "Fail", mock.Anything, "127.0.0.1")
+			} else {
+				mockIPs.AssertNotCalled(t, "Fail", mock.Anything, mock.Anything)
+			}
+			if tt.wantError != nil {
+				mockJwt.AssertNotCalled(t, "Generate", mock.Anything)
+			}
+		})
+	}
+}
+
+func TestUnlock(t *testing.T) {
+	testCase := []struct {
+		name                                     string
+		wantUserError, wantResetError, wantError error
+	}{
+		{
+			name: "Testcase #1: Positive",
+		},
+		{
+			name: "Testcase #2: Negative", wantUserError: pErrors.ErrNotFound, wantError: pErrors.ErrNotFound,
+		},
+		{
+			name: "Testcase #3: Negative", wantResetError: errFoo, wantError: errFoo,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mockRepo.IRepository{}
+			mockUsers := mockLockout.Limiter{}
+			mockRepo.On("GetByID", mock.Anything, int64(1)).Return(model.User{ID: 1, Username: "JohnDoe"}, tt.wantUserError)
+			mockUsers.On("Reset", mock.Anything, "johndoe").Return(tt.wantResetError)
+
+			u := &Usecase{
+				expiry:     expiry,
+				repo:       &mockRepo,
+				loginUsers: &mockUsers,
+			}
+
+			err := u.Unlock(context.Background(), 1)
+			assert.Equal(t, tt.wantError, err)
+		})
+	}
+}
+
+func TestLoginRehash(t *testing.T) {
+	login := model.Login{Username: "JohnDoe", Password: "password", IP: "127.0.0.1"}
+
+	testCase := []struct {
+		name                         string
+		rehash                       bool
+		wantHashError, wantRepoError error
+		updated                      bool
+	}{
+		{
+			name: "Testcase #1: Positive", rehash: false, updated: false,
+		},
+		{
+			name: "Testcase #2: Positive", rehash: true, updated: true,
+		},
+		{
+			name: "Testcase #3: Negative", rehash: true, wantHashError: errFoo, updated: false,
+		},
+		{
+			name: "Testcase #4: Negative", rehash: true, wantRepoError: errFoo, updated: true,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mockRepo.IRepository{}
+			mockHasher := mockHasher.HashPassword{}
+			mockJwt := mockJwt.JWTInterface{}
+			mockSession := mockSession.Store{}
+			mockLockout := mockLockout.Limiter{}
+
+			mockLockout.On("Check", mock.Anything, mock.Anything).Return(nil)
+			mockLockout.On("Reset", mock.Anything, mock.Anything).Return(nil)
+			mockRepo.On("Login", mock.Anything, login).Return(model.Register{ID: 1, Password: "$2a$10$old"}, nil)
+			mockRepo.On("UpdatePassword", mock.Anything, int64(1), "$argon2id$new").Return(tt.wantRepoError)
+			mockRepo.On("GetMFA", mock.Anything, int64(1)).Return(model.MFA{}, pErrors.ErrNotFound)
+			mockRepo.On("GetRoles", mock.Anything, int64(1)).Return(roles, nil)
+			mockRepo.On("GetByID", mock.Anything, int64(1)).Return(model.User{}, nil)
+			mockRepo.On("Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			mockHasher.On("VerifyPassword", "$2a$10$old", login.Password).Return(nil)
+			mockHasher.On("NeedsRehash", "$2a$10$old").Return(tt.rehash)
+			mockHasher.On("HashedPassword", login.Password).Return("$argon2id$new", tt.wantHashError)
+			mockJwt.On("Generate", mock.Anything).Return(token, nil)
+			mockSession.On("Save", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+			u := &Usecase{
+				expiry:     expiry,
+				repo:       &mockRepo,
+				hasher:     &mockHasher,
+				jwtImpl:    &mockJwt,
+				session:    &mockSession,
+				loginUsers: &mockLockout,
+				loginIPs:   &mockLockout,
+			}
+
+			jwt, err := u.Login(context.Background(), login)
+			assert.NoError(t, err)
+			assert.Equal(t, token, jwt.Token)
+			if tt.updated {
+				mockRepo.AssertCalled(t, "UpdatePassword", mock.Anything, int64(1), "$argon2id$new")
+			} else {
+				mockRepo.AssertNotCalled(t, "UpdatePassword", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
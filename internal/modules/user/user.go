@@ -7,15 +7,64 @@ import (
 	"github.com/rzfhlv/gin-example/internal/modules/user/repository"
 	"github.com/rzfhlv/gin-example/internal/modules/user/usecase"
 	"github.com/rzfhlv/gin-example/middleware/auth"
+	"github.com/rzfhlv/gin-example/pkg/rbac"
 )
 
 func Mount(route *gin.RouterGroup, h handler.IHandler, a auth.IAuth) (g *gin.RouterGroup) {
 	g = route.Group("/users")
 	g.POST("/register", h.Register)
 	g.POST("/login", h.Login)
+	g.POST("/login/mfa", h.LoginMFA)
 	g.POST("/logout", a.Bearer(), h.Logout)
-	g.GET("", a.Bearer(), h.GetAll)
-	g.GET("/:id", a.Bearer(), h.GetByID)
+	g.POST("/token/refresh", h.Refresh)
+	g.POST("/password/forgot", h.ForgotPassword)
+	g.POST("/password/reset", h.ResetPassword)
+	g.GET("/oidc/login", h.OIDCLogin)
+	g.GET("/oidc/callback", h.OIDCCallback)
+	g.GET("/verify", h.VerifyEmail)
+	g.POST("/verify/resend", h.ResendVerification)
+	g.GET("/me", a.Bearer(), h.GetMe)
+	g.PATCH("/me", a.Bearer(), h.UpdateMe)
+	g.DELETE("/me", a.Bearer(), h.DeleteMe)
+	g.PUT("/me/password", a.Bearer(), h.ChangePassword)
+	g.PUT("/me/email", a.Bearer(), h.ChangeEmail)
+	g.GET("/me/sessions", a.Bearer(), h.GetSessions)
+	g.DELETE("/me/sessions", a.Bearer(), h.RevokeSessions)
+	g.DELETE("/me/sessions/:session_id", a.Bearer(), h.RevokeSession)
+	g.GET("/me/member", a.Bearer(), h.GetMember)
+	g.POST("/me/member", a.Bearer(), h.ClaimMember)
+	g.POST("/me/mfa", a.Bearer(), h.EnrollMFA)
+	g.POST("/me/mfa/verify", a.Bearer(), h.ConfirmMFA)
+	g.DELETE("/me/mfa", a.Bearer(), h.DisableMFA)
+	g.GET("", a.Bearer(), a.Can(rbac.USERSREAD), h.GetAll)
+	g.GET("/:id", a.Bearer(), a.Can(rbac.USERSREAD), h.GetByID)
+	return
+}
+
+func MountAdmin(route *gin.RouterGroup, h handler.IHandler, a auth.IAuth) (g *gin.RouterGroup) {
+	g = route.Group("/admin/users")
+	g.Use(a.Bearer())
+	g.GET("/:id/roles", a.Can(rbac.USERSREAD), h.GetRoles)
+	g.POST("/:id/roles", a.Can(rbac.ROLESWRITE), h.GrantRole)
+	g.DELETE("/:id/roles/:role", a.Can(rbac.ROLESWRITE), h.RevokeRole)
+	g.DELETE("/:id/lock", a.Can(rbac.USERSWRITE), h.Unlock)
+	g.POST("/:id/impersonate", a.Can(rbac.USERSIMPERSONATE), h.Impersonate)
+	return
+}
+
+// MountOAuth serves other services, they authenticate with client
+// credentials or an API key instead of a user token.
+func MountOAuth(route *gin.RouterGroup, h handler.IHandler, a auth.IAuth) (g *gin.RouterGroup) {
+	g = route.Group("/oauth")
+	g.Use(a.APIKey())
+	g.POST("/introspect", a.Client(rbac.TOKENSINTROSPECT), h.Introspect)
+	g.POST("/revoke", a.Client(rbac.TOKENSREVOKE), h.RevokeToken)
+	return
+}
+
+func MountWellKnown(route *gin.RouterGroup, h handler.IHandler) (g *gin.RouterGroup) {
+	g = route.Group("/.well-known")
+	g.GET("/jwks.json", h.JWKS)
 	return
 }
 
@@ -25,7 +74,19 @@ type User struct {
 
 func New(cfg *config.Config) *User {
 	Repo := repository.New(cfg.MySQL, cfg.Redis)
-	Usecase := usecase.New(Repo, cfg.Pkg.Hasher, cfg.Pkg.JWTImpl)
+	Usecase := usecase.New(Repo, cfg.Pkg.Hasher, cfg.Pkg.JWTImpl, cfg.Pkg.Session, cfg.Pkg.Mailer, cfg.Pkg.Policy, cfg.Pkg.OIDC, cfg.Pkg.Audit, cfg.Pkg.LoginUsers, cfg.Pkg.LoginIPs, usecase.Expiry{
+		Access:         cfg.Expiry.Access,
+		Refresh:        cfg.Expiry.Refresh,
+		PasswordReset:  cfg.Expiry.PasswordReset,
+		EmailVerify:    cfg.Expiry.EmailVerify,
+		ResendInterval: cfg.Expiry.ResendInterval,
+		MFA:            cfg.Expiry.MFA,
+		Impersonate:    cfg.Expiry.Impersonate,
+		OIDCState:      cfg.Expiry.OIDCState,
+	}, usecase.App{
+		Name:     cfg.App.Name,
+		MailFrom: cfg.App.MailFrom,
+	})
 	Handler := handler.New(Usecase)
 
 	return &User{
@@ -5,15 +5,22 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/rzfhlv/gin-example/config"
+	"github.com/rzfhlv/gin-example/pkg/hasher"
+	"github.com/rzfhlv/gin-example/pkg/rbac"
 	mockAuth "github.com/rzfhlv/gin-example/shared/mocks/middleware/auth"
 	mockHandler "github.com/rzfhlv/gin-example/shared/mocks/modules/user/handler"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestNew(t *testing.T) {
+	hasher, err := hasher.New(hasher.Config{})
+	assert.NoError(t, err)
+
 	cfg := config.Config{
 		MySQL: nil,
 		Redis: nil,
+		Pkg:   config.Pkg{Hasher: hasher},
 	}
 
 	m := New(&cfg)
@@ -28,9 +35,57 @@ func TestMount(t *testing.T) {
 			c.Next()
 		}
 	})
+	mockAuth.On("Can", mock.Anything).Return(func(permission string) gin.HandlerFunc {
+		return func(c *gin.Context) {
+			c.Next()
+		}
+	})
 
 	g := gin.Default()
 	route := g.Group("/v1")
 	u := Mount(route, &mockHandler, &mockAuth)
 	assert.NotNil(t, u)
 }
+
+func TestMountAdmin(t *testing.T) {
+	mockHandler := mockHandler.IHandler{}
+	mockAuth := mockAuth.IAuth{}
+	mockAuth.On("Bearer").Return(func() gin.HandlerFunc {
+		return func(c *gin.Context) {
+			c.Next()
+		}
+	})
+	mockAuth.On("Can", mock.Anything).Return(func(permission string) gin.HandlerFunc {
+		return func(c *gin.Context) {
+			c.Next()
+		}
+	})
+
+	g := gin.Default()
+	route := g.Group("/v1")
+	u := MountAdmin(route, &mockHandler, &mockAuth)
+	assert.NotNil(t, u)
+	mockAuth.AssertCalled(t, "Can", rbac.ROLESWRITE)
+}
+
+func TestMountOAuth(t *testing.T) {
+	mockHandler := mockHandler.IHandler{}
+	mockAuth := mockAuth.IAuth{}
+	mockAuth.On("APIKey").Return(func() gin.HandlerFunc {
+		return func(c *gin.Context) {
+			c.Next()
+		}
+	})
+	mockAuth.On("Client", mock.Anything).Return(func(permission string) gin.HandlerFunc {
+		return func(c *gin.Context) {
+			c.Next()
+		}
+	})
+
+	g := gin.Default()
+	route := g.Group("/v1")
+	u := MountOAuth(route, &mockHandler, &mockAuth)
+	assert.NotNil(t, u)
+	mockAuth.AssertCalled(t, "Client", rbac.TOKENSINTROSPECT)
+	mockAuth.AssertCalled(t, "Client", rbac.TOKENSREVOKE)
+}
@@ -2,6 +2,7 @@ package internal
 
 import (
 	"github.com/rzfhlv/gin-example/config"
+	"github.com/rzfhlv/gin-example/internal/modules/apikey"
 	"github.com/rzfhlv/gin-example/internal/modules/gathering"
 	healthcheck "github.com/rzfhlv/gin-example/internal/modules/health-check"
 	"github.com/rzfhlv/gin-example/internal/modules/invitation"
@@ -16,6 +17,7 @@ type Service struct {
 	Gathering   *gathering.Gathering
 	Invitation  *invitation.Invitation
 	User        *user.User
+	APIKey      *apikey.APIKey
 	Middleware  *middleware.Middleware
 }
 
@@ -25,6 +27,7 @@ func New(cfg *config.Config) *Service {
 	gathering := gathering.New(cfg)
 	invitation := invitation.New(cfg)
 	user := user.New(cfg)
+	apiKey := apikey.New(cfg)
 
 	middleware := middleware.New(cfg)
 
@@ -34,6 +37,7 @@ func New(cfg *config.Config) *Service {
 		Gathering:   gathering,
 		Invitation:  invitation,
 		User:        user,
+		APIKey:      apiKey,
 		Middleware:  middleware,
 	}
 }
@@ -4,13 +4,18 @@ import (
 	"testing"
 
 	"github.com/rzfhlv/gin-example/config"
+	"github.com/rzfhlv/gin-example/pkg/hasher"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestService(t *testing.T) {
+	hasher, err := hasher.New(hasher.Config{})
+	assert.NoError(t, err)
+
 	cfg := config.Config{
 		MySQL: nil,
 		Redis: nil,
+		Pkg:   config.Pkg{Hasher: hasher},
 	}
 
 	s := New(&cfg)
@@ -1,69 +1,110 @@
 package auth
 
 import (
-	"context"
+	"crypto/subtle"
 	"log"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/redis/go-redis/v9"
 	"github.com/rzfhlv/gin-example/config"
+	"github.com/rzfhlv/gin-example/pkg/apikey"
+	"github.com/rzfhlv/gin-example/pkg/audit"
+	"github.com/rzfhlv/gin-example/pkg/identity"
 	pJwt "github.com/rzfhlv/gin-example/pkg/jwt"
 	"github.com/rzfhlv/gin-example/pkg/message"
+	"github.com/rzfhlv/gin-example/pkg/rbac"
 	"github.com/rzfhlv/gin-example/pkg/response"
+	"github.com/rzfhlv/gin-example/pkg/session"
+	pToken "github.com/rzfhlv/gin-example/pkg/token"
 )
 
 var (
 	ID       = "id"
 	EMAIL    = "email"
 	USERNAME = "username"
+	SESSION  = "session_id"
+	ROLES    = "roles"
+	APIKEYID = "api_key_id"
+	SCOPES   = "scopes"
+	CLIENTID = "client_id"
+
+	ACTORID       = "actor_id"
+	ACTORUSERNAME = "actor_username"
+
+	TOUCHINTERVAL = time.Minute
 
 	BEARER        = "Bearer"
 	AUTHORIZATION = "Authorization"
+	APIKEYHEADER  = "X-API-Key"
+	AUTHENTICATE  = "WWW-Authenticate"
+	CLIENTREALM   = `Basic realm="oauth"`
 
 	UNSUPPORTEDTOKENLOG  = "Auth Unsupported Token"
 	EMPTYTOKENLOG        = "Auth Empty Token"
 	VALIDATIONINVALIDLOG = "Auth Validation Invalid"
-	REDISLOG             = "Auth Redis Key Deleted"
+	SESSIONLOG           = "Auth Session Invalid"
+	FORBIDDENLOG         = "Auth Permission Denied"
+	UNVERIFIEDLOG        = "Auth Email Not Verified"
+	APIKEYINVALIDLOG     = "Auth API Key Invalid"
+	CLIENTINVALIDLOG     = "Auth Client Invalid"
+	AUDITLOG             = "Auth Audit Failed"
 )
 
 type IAuth interface {
 	Bearer() gin.HandlerFunc
+	APIKey() gin.HandlerFunc
+	Can(permission string) gin.HandlerFunc
+	Client(permission string) gin.HandlerFunc
 }
 
 type Auth struct {
-	redis   *redis.Client
-	jwtImpl pJwt.JWTInterface
+	session         session.Store
+	jwtImpl         pJwt.JWTInterface
+	apiKeys         apikey.Store
+	audit           audit.Store
+	requireVerified bool
+	clients         map[string]string
 }
 
 func New(cfg *config.Config) IAuth {
 	return &Auth{
-		redis:   cfg.Redis,
-		jwtImpl: cfg.Pkg.JWTImpl,
+		session:         cfg.Pkg.Session,
+		jwtImpl:         cfg.Pkg.JWTImpl,
+		apiKeys:         cfg.Pkg.APIKeys,
+		audit:           cfg.Pkg.Audit,
+		requireVerified: cfg.Auth.RequireVerifiedEmail,
+		clients:         cfg.Auth.Clients,
 	}
 }
 
+// Bearer lets through a request already authenticated by APIKey.
 func (a *Auth) Bearer() gin.HandlerFunc {
 	return func(c *gin.Context) {
+		if _, ok := c.Get(APIKEYID); ok {
+			c.Next()
+			return
+		}
+
 		split := strings.Split(c.Request.Header.Get(AUTHORIZATION), " ")
 		if len(split) < 2 {
 			log.Printf(UNSUPPORTEDTOKENLOG+" %v", split)
-			c.JSON(http.StatusUnauthorized, response.Set(message.ERROR, message.UNAUTHORIZED, nil, nil))
+			response.Error(c, http.StatusUnauthorized, message.UNAUTHORIZED)
 			c.Abort()
 			return
 		}
 
 		if split[0] != BEARER {
 			log.Printf(UNSUPPORTEDTOKENLOG+" %v", split[0])
-			c.JSON(http.StatusUnauthorized, response.Set(message.ERROR, message.UNAUTHORIZED, nil, nil))
+			response.Error(c, http.StatusUnauthorized, message.UNAUTHORIZED)
 			c.Abort()
 			return
 		}
 
 		if split[1] == "" {
 			log.Printf(UNSUPPORTEDTOKENLOG+" %v", split[1])
-			c.JSON(http.StatusUnauthorized, response.Set(message.ERROR, message.UNAUTHORIZED, nil, nil))
+			response.Error(c, http.StatusUnauthorized, message.UNAUTHORIZED)
 			c.Abort()
 			return
 		}
@@ -71,23 +112,206 @@ func (a *Auth) Bearer() gin.HandlerFunc {
 		claims, err := a.jwtImpl.ValidateToken(split[1])
 		if err != nil {
 			log.Printf(VALIDATIONINVALIDLOG+" %v", err.Error())
-			c.JSON(http.StatusUnauthorized, response.Set(message.ERROR, message.UNAUTHORIZED, nil, nil))
+			response.Error(c, http.StatusUnauthorized, message.UNAUTHORIZED)
 			c.Abort()
 			return
 		}
 
-		err = a.redis.Get(context.Background(), claims.Username).Err()
+		ctx := c.Request.Context()
+		current, err := a.session.Get(ctx, claims.RegisteredClaims.ID)
 		if err != nil {
-			log.Printf(REDISLOG+" %v", err.Error())
-			c.JSON(http.StatusUnauthorized, response.Set(message.ERROR, message.UNAUTHORIZED, nil, nil))
+			log.Printf(SESSIONLOG+" %v", err.Error())
+			response.Error(c, http.StatusUnauthorized, message.UNAUTHORIZED)
+			c.Abort()
+			return
+		}
+
+		// an impersonation token only fits the session started for its actor
+		if !current.Fits(claims.ID, claims.ActorID()) {
+			log.Printf(SESSIONLOG+" %v", current.ID)
+			response.Error(c, http.StatusUnauthorized, message.UNAUTHORIZED)
 			c.Abort()
 			return
 		}
 
+		if a.requireVerified && !claims.EmailVerified {
+			log.Printf(UNVERIFIEDLOG+" %v", claims.ID)
+			response.Error(c, http.StatusForbidden, message.EMAILNOTVERIFIED)
+			c.Abort()
+			return
+		}
+
+		now := time.Now()
+		if now.Sub(current.LastSeenAt) > TOUCHINTERVAL {
+			err = a.session.Touch(ctx, current.ID, now)
+			if err != nil {
+				log.Printf(SESSIONLOG+" %v", err.Error())
+			}
+		}
+
 		c.Set(ID, claims.ID)
 		c.Set(EMAIL, claims.Email)
 		c.Set(USERNAME, claims.Username)
+		c.Set(SESSION, current.ID)
+		c.Set(ROLES, claims.Roles)
+		if claims.Actor != nil {
+			c.Set(ACTORID, claims.Actor.ID)
+			c.Set(ACTORUSERNAME, claims.Actor.Username)
+		}
+		c.Request = c.Request.WithContext(identity.NewContext(ctx, identity.Identity{
+			ID:        claims.ID,
+			Username:  claims.Username,
+			Email:     claims.Email,
+			SessionID: current.ID,
+			Roles:     claims.Roles,
+			ActorID:   claims.ActorID(),
+		}))
 
 		c.Next()
+
+		if claims.Actor != nil {
+			a.record(c, claims, current.ID)
+		}
+	}
+}
+
+// record writes a request made while impersonating to the audit trail once it
+// has been handled, a failed write is logged and doesn't fail the request.
+func (a *Auth) record(c *gin.Context, claims *pJwt.JWTClaim, sessionID string) {
+	err := a.audit.Record(c.Request.Context(), audit.Entry{
+		ActorID:   claims.Actor.ID,
+		UserID:    claims.ID,
+		SessionID: sessionID,
+		Action:    audit.REQUEST,
+		Method:    c.Request.Method,
+		Path:      c.Request.URL.Path,
+		Status:    c.Writer.Status(),
+		IP:        c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		log.Printf(AUDITLOG+" %v", err.Error())
+	}
+}
+
+// APIKey authenticates a request carrying the X-API-Key header as the key
+// owner limited to the key scopes, a request without the header is left to
+// Bearer so both can guard the same routes.
+func (a *Auth) APIKey() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		secret := c.Request.Header.Get(APIKEYHEADER)
+		if secret == "" {
+			c.Next()
+			return
+		}
+
+		ctx := c.Request.Context()
+		key, err := a.apiKeys.GetByHash(ctx, pToken.Hash(secret))
+		if err != nil {
+			log.Printf(APIKEYINVALIDLOG+" %v", err.Error())
+			response.Error(c, http.StatusUnauthorized, message.UNAUTHORIZED)
+			c.Abort()
+			return
+		}
+
+		now := time.Now()
+		if !key.Active(now) {
+			log.Printf(APIKEYINVALIDLOG+" %v", key.ID)
+			response.Error(c, http.StatusUnauthorized, message.UNAUTHORIZED)
+			c.Abort()
+			return
+		}
+
+		roles, err := a.apiKeys.GetRoles(ctx, key.UserID)
+		if err != nil {
+			log.Printf(APIKEYINVALIDLOG+" %v", err.Error())
+			response.Error(c, http.StatusUnauthorized, message.UNAUTHORIZED)
+			c.Abort()
+			return
+		}
+
+		if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) > TOUCHINTERVAL {
+			err = a.apiKeys.Touch(ctx, key.ID, now)
+			if err != nil {
+				log.Printf(APIKEYINVALIDLOG+" %v", err.Error())
+			}
+		}
+
+		c.Set(ID, key.UserID)
+		c.Set(USERNAME, key.Username)
+		c.Set(APIKEYID, key.ID)
+		c.Set(ROLES, roles)
+		c.Set(SCOPES, []string(key.Scopes))
+		c.Request = c.Request.WithContext(identity.NewContext(ctx, identity.Identity{
+			ID:       key.UserID,
+			Username: key.Username,
+			Roles:    roles,
+			APIKeyID: key.ID,
+			Scopes:   key.Scopes,
+		}))
+
+		c.Next()
+	}
+}
+
+// Can must run after Bearer, it rejects the request unless one of the roles in
+// the token, or one of the scopes of the API key the owner's roles still
+// grant, gives permission.
+func (a *Auth) Can(permission string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		roles, _ := c.Value(ROLES).([]string)
+		allowed := rbac.Can(roles, permission)
+		if scopes, ok := c.Value(SCOPES).([]string); ok {
+			allowed = rbac.Scoped(roles, scopes, permission)
+		}
+		if !allowed {
+			log.Printf(FORBIDDENLOG+" %v %v", permission, roles)
+			response.Error(c, http.StatusForbidden, message.FORBIDDEN)
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// Client guards the endpoints other services call, it takes either an API key
+// with the permission as scope, authenticated by APIKey before, or client
+// credentials sent with basic auth.
+func (a *Auth) Client(permission string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if scopes, ok := c.Value(SCOPES).([]string); ok {
+			roles, _ := c.Value(ROLES).([]string)
+			if !rbac.Scoped(roles, scopes, permission) {
+				log.Printf(FORBIDDENLOG+" %v %v", permission, scopes)
+				response.Error(c, http.StatusForbidden, message.FORBIDDEN)
+				c.Abort()
+				return
+			}
+			c.Next()
+			return
+		}
+
+		clientID, secret, ok := c.Request.BasicAuth()
+		if !ok || !a.client(clientID, secret) {
+			log.Printf(CLIENTINVALIDLOG+" %v", clientID)
+			c.Header(AUTHENTICATE, CLIENTREALM)
+			response.Error(c, http.StatusUnauthorized, message.UNAUTHORIZED)
+			c.Abort()
+			return
+		}
+
+		c.Set(CLIENTID, clientID)
+		c.Next()
+	}
+}
+
+// client compares hashes so the time taken doesn't depend on the secret.
+func (a *Auth) client(clientID, secret string) bool {
+	expected, ok := a.clients[clientID]
+	if !ok {
+		return false
 	}
+	return subtle.ConstantTimeCompare([]byte(pToken.Hash(secret)), []byte(pToken.Hash(expected))) == 1
 }
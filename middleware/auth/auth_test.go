@@ -1,37 +1,49 @@
 package auth
 
 import (
+	"database/sql"
 	"errors"
 	"net/http"
 	"net/http/httptest"
-	"os"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/go-redis/redismock/v9"
 	"github.com/rzfhlv/gin-example/config"
+	"github.com/rzfhlv/gin-example/pkg/apikey"
+	"github.com/rzfhlv/gin-example/pkg/audit"
+	"github.com/rzfhlv/gin-example/pkg/identity"
 	pJwt "github.com/rzfhlv/gin-example/pkg/jwt"
+	"github.com/rzfhlv/gin-example/pkg/rbac"
+	"github.com/rzfhlv/gin-example/pkg/session"
+	pToken "github.com/rzfhlv/gin-example/pkg/token"
+	mockAPIKey "github.com/rzfhlv/gin-example/shared/mocks/pkg/apikey"
+	mockAudit "github.com/rzfhlv/gin-example/shared/mocks/pkg/audit"
+	mockSession "github.com/rzfhlv/gin-example/shared/mocks/pkg/session"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 var (
-	_ = os.Setenv("JWT_SECRET", "verysecret")
-	_ = os.Setenv("JWT_EXPIRED", "1")
+	jwtConfig = pJwt.Config{Secret: []byte("verysecret"), Expired: time.Hour}
+
+	sessionID = "thisissession"
+	payload   = pJwt.Payload{ID: 1, Username: "johndoe", Email: "johndoe@test.com", SessionID: sessionID, Roles: []string{rbac.MEMBER}}
 )
 
 func TestAuthSuccess(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
-	jwtImpl := pJwt.JWTImpl{}
-	token, _ := jwtImpl.Generate(int64(1), "johndoe", "johndoe@test.com")
+	jwtImpl, _ := pJwt.New(jwtConfig)
+	token, _ := jwtImpl.Generate(payload)
 
-	client, mock := redismock.NewClientMock()
-	mock.ExpectGet("johndoe").SetVal(token)
+	mockSession := mockSession.Store{}
+	mockSession.On("Get", mock.Anything, sessionID).Return(session.Session{ID: sessionID, UserID: 1, LastSeenAt: time.Now()}, nil)
 
 	cfg := config.Config{
-		Redis: client,
 		Pkg: config.Pkg{
-			JWTImpl: &jwtImpl,
+			JWTImpl: jwtImpl,
+			Session: &mockSession,
 		},
 	}
 
@@ -53,16 +65,16 @@ func TestAuthSuccess(t *testing.T) {
 func TestAuthFail(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
-	jwtImpl := pJwt.JWTImpl{}
+	jwtImpl, _ := pJwt.New(jwtConfig)
 	token := "invalidtoken"
 
-	client, mock := redismock.NewClientMock()
-	mock.ExpectGet("johndoe").SetErr(errors.New("error"))
+	mockSession := mockSession.Store{}
+	mockSession.On("Get", mock.Anything, sessionID).Return(session.Session{}, session.ErrNotFound)
 
 	cfg := config.Config{
-		Redis: client,
 		Pkg: config.Pkg{
-			JWTImpl: &jwtImpl,
+			JWTImpl: jwtImpl,
+			Session: &mockSession,
 		},
 	}
 
@@ -84,15 +96,15 @@ func TestAuthFail(t *testing.T) {
 func TestAuthFailHeader(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
-	jwtImpl := pJwt.JWTImpl{}
+	jwtImpl, _ := pJwt.New(jwtConfig)
 
-	client, mock := redismock.NewClientMock()
-	mock.ExpectGet("johndoe").SetErr(errors.New("error"))
+	mockSession := mockSession.Store{}
+	mockSession.On("Get", mock.Anything, sessionID).Return(session.Session{}, session.ErrNotFound)
 
 	cfg := config.Config{
-		Redis: client,
 		Pkg: config.Pkg{
-			JWTImpl: &jwtImpl,
+			JWTImpl: jwtImpl,
+			Session: &mockSession,
 		},
 	}
 
@@ -114,15 +126,15 @@ func TestAuthFailHeader(t *testing.T) {
 func TestAuthFailHeaderBearer(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
-	jwtImpl := pJwt.JWTImpl{}
+	jwtImpl, _ := pJwt.New(jwtConfig)
 
-	client, mock := redismock.NewClientMock()
-	mock.ExpectGet("johndoe").SetErr(errors.New("error"))
+	mockSession := mockSession.Store{}
+	mockSession.On("Get", mock.Anything, sessionID).Return(session.Session{}, session.ErrNotFound)
 
 	cfg := config.Config{
-		Redis: client,
 		Pkg: config.Pkg{
-			JWTImpl: &jwtImpl,
+			JWTImpl: jwtImpl,
+			Session: &mockSession,
 		},
 	}
 
@@ -144,15 +156,15 @@ func TestAuthFailHeaderBearer(t *testing.T) {
 func TestAuthFailEmpty(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
-	jwtImpl := pJwt.JWTImpl{}
+	jwtImpl, _ := pJwt.New(jwtConfig)
 
-	client, mock := redismock.NewClientMock()
-	mock.ExpectGet("johndoe").SetErr(errors.New("error"))
+	mockSession := mockSession.Store{}
+	mockSession.On("Get", mock.Anything, sessionID).Return(session.Session{}, session.ErrNotFound)
 
 	cfg := config.Config{
-		Redis: client,
 		Pkg: config.Pkg{
-			JWTImpl: &jwtImpl,
+			JWTImpl: jwtImpl,
+			Session: &mockSession,
 		},
 	}
 
@@ -174,16 +186,16 @@ func TestAuthFailEmpty(t *testing.T) {
 func TestAuthFailRedis(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
-	jwtImpl := pJwt.JWTImpl{}
-	token, _ := jwtImpl.Generate(int64(1), "johndoe", "johndoe@test.com")
+	jwtImpl, _ := pJwt.New(jwtConfig)
+	token, _ := jwtImpl.Generate(payload)
 
-	client, mock := redismock.NewClientMock()
-	mock.ExpectGet("johndoe").SetErr(errors.New("error"))
+	mockSession := mockSession.Store{}
+	mockSession.On("Get", mock.Anything, sessionID).Return(session.Session{}, session.ErrNotFound)
 
 	cfg := config.Config{
-		Redis: client,
 		Pkg: config.Pkg{
-			JWTImpl: &jwtImpl,
+			JWTImpl: jwtImpl,
+			Session: &mockSession,
 		},
 	}
 
@@ -201,3 +213,461 @@ func TestAuthFailRedis(t *testing.T) {
 
 	assert.Equal(t, http.StatusUnauthorized, w.Code)
 }
+
+func TestAuthFailSessionOwner(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	jwtImpl, _ := pJwt.New(jwtConfig)
+	token, _ := jwtImpl.Generate(payload)
+
+	mockSession := mockSession.Store{}
+	mockSession.On("Get", mock.Anything, sessionID).Return(session.Session{ID: sessionID, UserID: 2, LastSeenAt: time.Now()}, nil)
+
+	cfg := config.Config{
+		Pkg: config.Pkg{
+			JWTImpl: jwtImpl,
+			Session: &mockSession,
+		},
+	}
+
+	g := gin.Default()
+	auth := New(&cfg)
+	g.Use(auth.Bearer())
+	g.GET("/v1/users", func(c *gin.Context) {
+		c.JSON(http.StatusOK, nil)
+	})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/v1/users", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	g.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestAuthImpersonation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	jwtImpl, _ := pJwt.New(jwtConfig)
+	impersonation := payload
+	impersonation.Actor = &pJwt.Actor{ID: 9, Username: "admin"}
+	token, _ := jwtImpl.Generate(impersonation)
+
+	testCase := []struct {
+		name            string
+		actorID         int64
+		wantAuditError  error
+		wantStatus      int
+		wantAuditRecord bool
+	}{
+		{
+			name: "Testcase #1: Positive", actorID: 9, wantStatus: http.StatusTeapot, wantAuditRecord: true,
+		},
+		{
+			name: "Testcase #2: Positive", actorID: 9, wantAuditError: errors.New("error"), wantStatus: http.StatusTeapot, wantAuditRecord: true,
+		},
+		{
+			name: "Testcase #3: Negative", actorID: 0, wantStatus: http.StatusUnauthorized,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSession := mockSession.Store{}
+			mockSession.On("Get", mock.Anything, sessionID).Return(session.Session{ID: sessionID, UserID: 1, ActorID: tt.actorID, LastSeenAt: time.Now()}, nil)
+			mockAudit := mockAudit.Store{}
+			mockAudit.On("Record", mock.Anything, mock.Anything).Return(tt.wantAuditError)
+
+			cfg := config.Config{
+				Pkg: config.Pkg{
+					JWTImpl: jwtImpl,
+					Session: &mockSession,
+					Audit:   &mockAudit,
+				},
+			}
+
+			g := gin.Default()
+			auth := New(&cfg)
+			g.Use(auth.Bearer())
+			g.GET("/v1/users/me", func(c *gin.Context) {
+				assert.Equal(t, int64(9), c.GetInt64(ACTORID))
+				assert.Equal(t, "admin", c.GetString(ACTORUSERNAME))
+				assert.Equal(t, int64(1), c.GetInt64(ID))
+				current, _ := identity.FromContext(c.Request.Context())
+				assert.Equal(t, int64(9), current.ActorID)
+				c.JSON(http.StatusTeapot, nil)
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/v1/users/me", nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			req.Header.Set("User-Agent", "test-agent")
+			g.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantAuditRecord {
+				mockAudit.AssertCalled(t, "Record", mock.Anything, mock.MatchedBy(func(e audit.Entry) bool {
+					return e.ActorID == 9 && e.UserID == 1 && e.SessionID == sessionID && e.Action == audit.REQUEST &&
+						e.Method == http.MethodGet && e.Path == "/v1/users/me" && e.Status == http.StatusTeapot && e.UserAgent == "test-agent"
+				}))
+			} else {
+				mockAudit.AssertNotCalled(t, "Record", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestAuthTouchSession(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	jwtImpl, _ := pJwt.New(jwtConfig)
+	token, _ := jwtImpl.Generate(payload)
+
+	for _, wantError := range []error{nil, errors.New("error")} {
+		mockSession := mockSession.Store{}
+		mockSession.On("Get", mock.Anything, sessionID).Return(session.Session{ID: sessionID, UserID: 1, LastSeenAt: time.Now().Add(-time.Hour)}, nil)
+		mockSession.On("Touch", mock.Anything, sessionID, mock.Anything).Return(wantError)
+
+		cfg := config.Config{
+			Pkg: config.Pkg{
+				JWTImpl: jwtImpl,
+				Session: &mockSession,
+			},
+		}
+
+		g := gin.Default()
+		auth := New(&cfg)
+		g.Use(auth.Bearer())
+		g.GET("/v1/users", func(c *gin.Context) {
+			assert.Equal(t, sessionID, c.GetString(SESSION))
+			current, ok := identity.FromContext(c.Request.Context())
+			assert.True(t, ok)
+			assert.Equal(t, int64(1), current.ID)
+			assert.Equal(t, []string{rbac.MEMBER}, current.Roles)
+			c.JSON(http.StatusOK, nil)
+		})
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/v1/users", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		g.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockSession.AssertCalled(t, "Touch", mock.Anything, sessionID, mock.Anything)
+	}
+}
+
+func TestAuthCan(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	jwtImpl, _ := pJwt.New(jwtConfig)
+	token, _ := jwtImpl.Generate(payload)
+
+	testCase := []struct {
+		name, permission string
+		code             int
+	}{
+		{
+			name: "Testcase #1: Positive", permission: rbac.GATHERINGSREAD, code: http.StatusOK,
+		},
+		{
+			name: "Testcase #2: Negative", permission: rbac.GATHERINGSWRITE, code: http.StatusForbidden,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSession := mockSession.Store{}
+			mockSession.On("Get", mock.Anything, sessionID).Return(session.Session{ID: sessionID, UserID: 1, LastSeenAt: time.Now()}, nil)
+
+			cfg := config.Config{
+				Pkg: config.Pkg{
+					JWTImpl: jwtImpl,
+					Session: &mockSession,
+				},
+			}
+
+			g := gin.Default()
+			auth := New(&cfg)
+			g.GET("/v1/gatherings", auth.Bearer(), auth.Can(tt.permission), func(c *gin.Context) {
+				c.JSON(http.StatusOK, nil)
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/v1/gatherings", nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			g.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.code, w.Code)
+		})
+	}
+}
+
+func TestAuthCanWithoutBearer(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	g := gin.Default()
+	auth := New(&config.Config{})
+	g.GET("/v1/gatherings", auth.Can(rbac.GATHERINGSREAD), func(c *gin.Context) {
+		c.JSON(http.StatusOK, nil)
+	})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/v1/gatherings", nil)
+	g.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestAuthRequireVerifiedEmail(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	jwtImpl, _ := pJwt.New(jwtConfig)
+	verified := payload
+	verified.EmailVerified = true
+
+	testCase := []struct {
+		name            string
+		payload         pJwt.Payload
+		requireVerified bool
+		code            int
+	}{
+		{
+			name: "Testcase #1: Positive", payload: verified, requireVerified: true, code: http.StatusOK,
+		},
+		{
+			name: "Testcase #2: Positive", payload: payload, requireVerified: false, code: http.StatusOK,
+		},
+		{
+			name: "Testcase #3: Negative", payload: payload, requireVerified: true, code: http.StatusForbidden,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			token, _ := jwtImpl.Generate(tt.payload)
+
+			mockSession := mockSession.Store{}
+			mockSession.On("Get", mock.Anything, sessionID).Return(session.Session{ID: sessionID, UserID: 1, LastSeenAt: time.Now()}, nil)
+
+			cfg := config.Config{
+				Pkg: config.Pkg{
+					JWTImpl: jwtImpl,
+					Session: &mockSession,
+				},
+				Auth: config.Auth{
+					RequireVerifiedEmail: tt.requireVerified,
+				},
+			}
+
+			g := gin.Default()
+			auth := New(&cfg)
+			g.GET("/v1/users", auth.Bearer(), func(c *gin.Context) {
+				c.JSON(http.StatusOK, nil)
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/v1/users", nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			g.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.code, w.Code)
+		})
+	}
+}
+
+func TestAuthAPIKey(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	secret := "gek_thisisapikey"
+	now := time.Now()
+	past, future, recent := now.Add(-time.Hour), now.Add(time.Hour), now.Add(-time.Second)
+	scopes := apikey.Scopes{rbac.GATHERINGSREAD}
+
+	organizer := []string{rbac.ORGANIZER}
+
+	testCase := []struct {
+		name, header, permission string
+		key                      apikey.Key
+		roles                    []string
+		wantError, rolesError    error
+		code                     int
+		touched                  bool
+	}{
+		{
+			name: "Testcase #1: Positive", header: secret, permission: rbac.GATHERINGSREAD, roles: organizer,
+			key: apikey.Key{ID: 7, UserID: 1, Scopes: scopes, ExpiresAt: &future, Username: "johndoe"}, code: http.StatusOK, touched: true,
+		},
+		{
+			name: "Testcase #2: Positive", header: secret, permission: rbac.GATHERINGSREAD, roles: organizer,
+			key: apikey.Key{ID: 7, UserID: 1, Scopes: scopes, LastUsedAt: &recent}, code: http.StatusOK, touched: false,
+		},
+		{
+			name: "Testcase #3: Negative", header: secret, permission: rbac.GATHERINGSWRITE, roles: organizer,
+			key: apikey.Key{ID: 7, UserID: 1, Scopes: scopes}, code: http.StatusForbidden, touched: true,
+		},
+		{
+			name: "Testcase #4: Negative", header: secret, permission: rbac.GATHERINGSREAD,
+			wantError: sql.ErrNoRows, code: http.StatusUnauthorized,
+		},
+		{
+			name: "Testcase #5: Negative", header: secret, permission: rbac.GATHERINGSREAD,
+			key: apikey.Key{ID: 7, UserID: 1, Scopes: scopes, ExpiresAt: &past}, code: http.StatusUnauthorized,
+		},
+		{
+			name: "Testcase #6: Negative", header: secret, permission: rbac.GATHERINGSREAD,
+			key: apikey.Key{ID: 7, UserID: 1, Scopes: scopes, RevokedAt: &past}, code: http.StatusUnauthorized,
+		},
+		{
+			name: "Testcase #7: Negative", header: "", permission: rbac.GATHERINGSREAD, code: http.StatusUnauthorized,
+		},
+		{
+			name: "Testcase #8: Negative", header: secret, permission: rbac.GATHERINGSWRITE, roles: []string{},
+			key: apikey.Key{ID: 7, UserID: 1, Scopes: apikey.Scopes{rbac.GATHERINGSWRITE}}, code: http.StatusForbidden, touched: true,
+		},
+		{
+			name: "Testcase #9: Negative", header: secret, permission: rbac.GATHERINGSREAD, rolesError: errors.New("error"),
+			key: apikey.Key{ID: 7, UserID: 1, Scopes: scopes}, code: http.StatusUnauthorized,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			jwtImpl, _ := pJwt.New(jwtConfig)
+			mockAPIKey := mockAPIKey.Store{}
+			mockAPIKey.On("GetByHash", mock.Anything, pToken.Hash(secret)).Return(tt.key, tt.wantError)
+			mockAPIKey.On("Touch", mock.Anything, int64(7), mock.Anything).Return(errors.New("error"))
+			mockAPIKey.On("GetRoles", mock.Anything, int64(1)).Return(tt.roles, tt.rolesError)
+
+			cfg := config.Config{
+				Pkg: config.Pkg{
+					JWTImpl: jwtImpl,
+					APIKeys: &mockAPIKey,
+				},
+			}
+
+			var caller identity.Identity
+			g := gin.Default()
+			auth := New(&cfg)
+			g.GET("/v1/gatherings", auth.APIKey(), auth.Bearer(), auth.Can(tt.permission), func(c *gin.Context) {
+				caller, _ = identity.FromContext(c.Request.Context())
+				c.JSON(http.StatusOK, nil)
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/v1/gatherings", nil)
+			if tt.header != "" {
+				req.Header.Set(APIKEYHEADER, tt.header)
+			}
+			g.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.code, w.Code)
+			if tt.code == http.StatusOK {
+				assert.Equal(t, int64(1), caller.ID)
+				assert.Equal(t, int64(7), caller.APIKeyID)
+				assert.Equal(t, tt.key.Username, caller.Username)
+				assert.False(t, caller.Can(rbac.GATHERINGSWRITE))
+			}
+			if tt.touched {
+				mockAPIKey.AssertCalled(t, "Touch", mock.Anything, int64(7), mock.Anything)
+			} else {
+				mockAPIKey.AssertNotCalled(t, "Touch", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestAuthClient(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	secret := "gek_thisisapikey"
+	key := apikey.Key{ID: 7, UserID: 1, Scopes: apikey.Scopes{rbac.TOKENSINTROSPECT}}
+
+	testCase := []struct {
+		name, clientID, clientSecret, apiKey string
+		roles                                []string
+		code                                 int
+		challenged                           bool
+	}{
+		{
+			name: "Testcase #1: Positive", clientID: "gateway", clientSecret: "thisisclientsecret", code: http.StatusOK,
+		},
+		{
+			name: "Testcase #2: Positive", apiKey: secret, roles: []string{rbac.ADMIN}, code: http.StatusOK,
+		},
+		{
+			name: "Testcase #3: Negative", clientID: "gateway", clientSecret: "wrongsecret", code: http.StatusUnauthorized, challenged: true,
+		},
+		{
+			name: "Testcase #4: Negative", clientID: "unknown", clientSecret: "thisisclientsecret", code: http.StatusUnauthorized, challenged: true,
+		},
+		{
+			name: "Testcase #5: Negative", code: http.StatusUnauthorized, challenged: true,
+		},
+		{
+			name: "Testcase #6: Negative", apiKey: secret, roles: []string{rbac.ORGANIZER}, code: http.StatusForbidden,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPIKey := mockAPIKey.Store{}
+			mockAPIKey.On("GetByHash", mock.Anything, pToken.Hash(secret)).Return(key, nil)
+			mockAPIKey.On("Touch", mock.Anything, int64(7), mock.Anything).Return(nil)
+			mockAPIKey.On("GetRoles", mock.Anything, int64(1)).Return(tt.roles, nil)
+
+			cfg := config.Config{
+				Pkg: config.Pkg{
+					APIKeys: &mockAPIKey,
+				},
+				Auth: config.Auth{
+					Clients: map[string]string{"gateway": "thisisclientsecret"},
+				},
+			}
+
+			var clientID string
+			g := gin.Default()
+			auth := New(&cfg)
+			g.POST("/v1/oauth/introspect", auth.APIKey(), auth.Client(rbac.TOKENSINTROSPECT), func(c *gin.Context) {
+				clientID = c.GetString(CLIENTID)
+				c.JSON(http.StatusOK, nil)
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/v1/oauth/introspect", nil)
+			if tt.clientID != "" {
+				req.SetBasicAuth(tt.clientID, tt.clientSecret)
+			}
+			if tt.apiKey != "" {
+				req.Header.Set(APIKEYHEADER, tt.apiKey)
+			}
+			g.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.code, w.Code)
+			if tt.code == http.StatusOK && tt.apiKey == "" {
+				assert.Equal(t, tt.clientID, clientID)
+			}
+			if tt.challenged {
+				assert.Equal(t, CLIENTREALM, w.Header().Get(AUTHENTICATE))
+			}
+		})
+	}
+}
+
+func TestAuthClientScope(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	secret := "gek_thisisapikey"
+	mockAPIKey := mockAPIKey.Store{}
+	mockAPIKey.On("GetByHash", mock.Anything, pToken.Hash(secret)).Return(apikey.Key{ID: 7, UserID: 1, Scopes: apikey.Scopes{rbac.TOKENSINTROSPECT}}, nil)
+	mockAPIKey.On("Touch", mock.Anything, int64(7), mock.Anything).Return(nil)
+	mockAPIKey.On("GetRoles", mock.Anything, int64(1)).Return([]string{rbac.ADMIN}, nil)
+
+	g := gin.Default()
+	auth := New(&config.Config{Pkg: config.Pkg{APIKeys: &mockAPIKey}})
+	g.POST("/v1/oauth/revoke", auth.APIKey(), auth.Client(rbac.TOKENSREVOKE), func(c *gin.Context) {
+		c.JSON(http.StatusOK, nil)
+	})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/v1/oauth/revoke", nil)
+	req.Header.Set(APIKEYHEADER, secret)
+	g.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	pErrors "github.com/rzfhlv/gin-example/pkg/errors"
+	"github.com/rzfhlv/gin-example/pkg/response"
+)
+
+// Errors answers for the handlers that hand their error over with g.Error
+// instead of replying themselves.
+func Errors() gin.HandlerFunc {
+	return func(g *gin.Context) {
+		g.Next()
+		RenderError(g)
+	}
+}
+
+// RenderError replies with the status and message of the last error of the
+// request, nothing is done when there is none or a reply was already sent.
+func RenderError(g *gin.Context) {
+	if len(g.Errors) < 1 || g.Writer.Written() {
+		return
+	}
+
+	err := g.Errors.Last().Err
+	response.Error(g, pErrors.Status(err), pErrors.Message(err), pErrors.Fields(err)...)
+}
+
+// Problem answers every error as application/problem+json when always is
+// set, otherwise only the requests that accept it get one.
+func Problem(always bool) gin.HandlerFunc {
+	return func(g *gin.Context) {
+		if always {
+			g.Set(response.PROBLEMKEY, true)
+		}
+		g.Next()
+	}
+}
@@ -0,0 +1,127 @@
+package middleware
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	pErrors "github.com/rzfhlv/gin-example/pkg/errors"
+	"github.com/rzfhlv/gin-example/pkg/message"
+	"github.com/rzfhlv/gin-example/pkg/response"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrors(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCase := []struct {
+		name    string
+		handler gin.HandlerFunc
+		code    int
+		body    string
+	}{
+		{
+			name: "Testcase #1: Positive",
+			handler: func(g *gin.Context) {
+				g.JSON(http.StatusOK, gin.H{"status": message.SUCCESS})
+			},
+			code: http.StatusOK, body: `{"status":"success"}`,
+		},
+		{
+			name: "Testcase #2: Negative",
+			handler: func(g *gin.Context) {
+				_ = g.Error(pErrors.Conflict(message.EMAILEXIST))
+			},
+			code: http.StatusConflict, body: `{"status":"error","message":"Email Exist"}`,
+		},
+		{
+			name: "Testcase #3: Negative",
+			handler: func(g *gin.Context) {
+				_ = g.Error(sql.ErrNoRows)
+			},
+			code: http.StatusNotFound, body: `{"status":"error","message":"Data Not found"}`,
+		},
+		{
+			name: "Testcase #4: Negative",
+			handler: func(g *gin.Context) {
+				_ = g.Error(errors.New("dial tcp: connection refused"))
+			},
+			code: http.StatusInternalServerError, body: `{"status":"error","message":"Something went wrong"}`,
+		},
+		{
+			name: "Testcase #5: Negative",
+			handler: func(g *gin.Context) {
+				_ = g.Error(pErrors.ErrForbidden)
+				g.JSON(http.StatusTeapot, gin.H{"status": message.ERROR})
+			},
+			code: http.StatusTeapot, body: `{"status":"error"}`,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			r := gin.New()
+			r.Use(Errors())
+			r.GET("/", tt.handler)
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+			assert.Equal(t, tt.code, w.Code)
+			assert.JSONEq(t, tt.body, w.Body.String())
+		})
+	}
+}
+
+func TestProblem(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCase := []struct {
+		name        string
+		always      bool
+		accept      string
+		err         error
+		contentType string
+		body        string
+	}{
+		{
+			name: "Testcase #1: Positive", always: true, err: pErrors.Conflict(message.EMAILEXIST),
+			contentType: response.PROBLEMCONTENTTYPE,
+			body:        `{"type":"about:blank","title":"Conflict","status":409,"detail":"Email Exist","instance":"/v1/members","code":"email_exists"}`,
+		},
+		{
+			name: "Testcase #2: Positive", accept: response.PROBLEMCONTENTTYPE,
+			err:         &pErrors.Error{Kind: pErrors.VALIDATION, Message: message.WEAKPASSWORD, Fields: []pErrors.FieldError{{Field: "password", Message: "is too short"}}},
+			contentType: response.PROBLEMCONTENTTYPE,
+			body: `{"type":"about:blank","title":"Unprocessable Entity","status":422,"detail":"Password Does Not Meet The Policy","instance":"/v1/members",` +
+				`"code":"weak_password","errors":[{"field":"password","message":"is too short"}]}`,
+		},
+		{
+			name: "Testcase #3: Positive", always: true, err: errors.New("dial tcp: connection refused"),
+			contentType: response.PROBLEMCONTENTTYPE,
+			body:        `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"Something went wrong","instance":"/v1/members","code":"internal_error"}`,
+		},
+		{
+			name: "Testcase #4: Negative", err: pErrors.Conflict(message.EMAILEXIST),
+			contentType: "application/json; charset=utf-8",
+			body:        `{"status":"error","message":"Email Exist"}`,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			r := gin.New()
+			r.Use(Problem(tt.always), Errors())
+			r.POST("/v1/members", func(g *gin.Context) {
+				_ = g.Error(tt.err)
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/v1/members", nil)
+			req.Header.Set("Accept", tt.accept)
+			r.ServeHTTP(w, req)
+			assert.Equal(t, tt.contentType, w.Header().Get("Content-Type"))
+			assert.JSONEq(t, tt.body, w.Body.String())
+		})
+	}
+}
@@ -1,18 +1,21 @@
 package middleware
 
 import (
+	"github.com/gin-gonic/gin"
 	"github.com/rzfhlv/gin-example/config"
 	"github.com/rzfhlv/gin-example/middleware/auth"
 )
 
 type Middleware struct {
-	Auth auth.IAuth
+	Auth    auth.IAuth
+	Problem gin.HandlerFunc
 }
 
 func New(cfg *config.Config) *Middleware {
 	auth := auth.New(cfg)
 
 	return &Middleware{
-		Auth: auth,
+		Auth:    auth,
+		Problem: Problem(cfg.Response.Problem),
 	}
 }
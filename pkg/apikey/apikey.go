@@ -0,0 +1,181 @@
+package apikey
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	pErrors "github.com/rzfhlv/gin-example/pkg/errors"
+	"github.com/rzfhlv/gin-example/pkg/message"
+	pToken "github.com/rzfhlv/gin-example/pkg/token"
+)
+
+var (
+	// keys are recognizable in logs and secret scanners by their prefix, the
+	// first DISPLAYLENGTH characters are stored in clear to tell them apart
+	KEYPREFIX     = "gek_"
+	DISPLAYLENGTH = 12
+
+	ErrNotFound = pErrors.NotFound(message.NOTFOUND)
+
+	CreateQuery = `INSERT INTO api_keys (user_id, name, prefix, key_hash, scopes, expires_at, created_at) VALUES (?, ?, ?, ?, ?, ?, ?);`
+
+	ListQuery = `SELECT id, user_id, name, prefix, scopes, expires_at, last_used_at, revoked_at, created_at
+	FROM api_keys ORDER BY id DESC;`
+
+	GetByHashQuery = `SELECT api_keys.id, api_keys.user_id, api_keys.name, api_keys.prefix, api_keys.scopes,
+	api_keys.expires_at, api_keys.last_used_at, api_keys.revoked_at, api_keys.created_at, users.username
+	FROM api_keys JOIN users ON users.id = api_keys.user_id WHERE api_keys.key_hash = ?;`
+
+	RevokeQuery = `UPDATE api_keys SET revoked_at = ? WHERE id = ? AND revoked_at IS NULL;`
+
+	TouchQuery = `UPDATE api_keys SET last_used_at = ? WHERE id = ?;`
+
+	GetRolesQuery = `SELECT roles.name
+	FROM roles JOIN user_roles ON user_roles.role_id = roles.id
+	WHERE user_roles.user_id = ? ORDER BY roles.name;`
+)
+
+// Scopes are the permissions a key carries, stored comma separated.
+type Scopes []string
+
+func (s Scopes) Value() (driver.Value, error) {
+	return strings.Join(s, ","), nil
+}
+
+func (s *Scopes) Scan(src interface{}) error {
+	var value string
+	switch v := src.(type) {
+	case string:
+		value = v
+	case []byte:
+		value = string(v)
+	case nil:
+	default:
+		return fmt.Errorf("unsupported scopes type %T", src)
+	}
+
+	*s = Scopes{}
+	for _, scope := range strings.Split(value, ",") {
+		if scope != "" {
+			*s = append(*s, scope)
+		}
+	}
+	return nil
+}
+
+// Key never holds the secret, only its hash is stored and the secret is shown
+// once when the key is created.
+type Key struct {
+	ID         int64      `json:"id" db:"id"`
+	UserID     int64      `json:"user_id" db:"user_id"`
+	Name       string     `json:"name" db:"name"`
+	Prefix     string     `json:"prefix" db:"prefix"`
+	Hash       string     `json:"-" db:"key_hash"`
+	Scopes     Scopes     `json:"scopes" db:"scopes"`
+	ExpiresAt  *time.Time `json:"expires_at" db:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at" db:"last_used_at"`
+	RevokedAt  *time.Time `json:"revoked_at" db:"revoked_at"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+	// Username is the owner's, only GetByHash reads it so a request made
+	// with the key is attributed like one made by the owner
+	Username string `json:"-" db:"username"`
+}
+
+func (k Key) Active(now time.Time) bool {
+	if k.RevokedAt != nil {
+		return false
+	}
+	return k.ExpiresAt == nil || now.Before(*k.ExpiresAt)
+}
+
+// Generate returns a new secret with its display prefix and hash.
+func Generate() (secret, prefix, hash string, err error) {
+	random, err := pToken.Generate(pToken.DEFAULTLENGTH)
+	if err != nil {
+		return
+	}
+
+	secret = KEYPREFIX + random
+	prefix = secret[:DISPLAYLENGTH]
+	hash = pToken.Hash(secret)
+	return
+}
+
+type Store interface {
+	Create(ctx context.Context, key Key) (id int64, err error)
+	List(ctx context.Context) (keys []Key, err error)
+	GetByHash(ctx context.Context, hash string) (key Key, err error)
+	Revoke(ctx context.Context, id int64, revokedAt time.Time) (err error)
+	Touch(ctx context.Context, id int64, lastUsedAt time.Time) (err error)
+	GetRoles(ctx context.Context, userID int64) (roles []string, err error)
+}
+
+type MySQLStore struct {
+	db *sqlx.DB
+}
+
+func New(db *sqlx.DB) Store {
+	return &MySQLStore{
+		db: db,
+	}
+}
+
+func (s *MySQLStore) Create(ctx context.Context, key Key) (id int64, err error) {
+	result, err := s.db.Exec(CreateQuery, key.UserID, key.Name, key.Prefix, key.Hash, key.Scopes, key.ExpiresAt, key.CreatedAt)
+	if err != nil {
+		err = pErrors.FromSQL(err)
+		return
+	}
+
+	id, err = result.LastInsertId()
+	return
+}
+
+func (s *MySQLStore) List(ctx context.Context) (keys []Key, err error) {
+	err = s.db.Select(&keys, ListQuery)
+	err = pErrors.FromSQL(err)
+	return
+}
+
+func (s *MySQLStore) GetByHash(ctx context.Context, hash string) (key Key, err error) {
+	err = s.db.Get(&key, GetByHashQuery, hash)
+	err = pErrors.FromSQL(err)
+	return
+}
+
+// Revoke reports ErrNotFound for an unknown or already revoked key.
+func (s *MySQLStore) Revoke(ctx context.Context, id int64, revokedAt time.Time) (err error) {
+	result, err := s.db.Exec(RevokeQuery, revokedAt, id)
+	if err != nil {
+		err = pErrors.FromSQL(err)
+		return
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return
+	}
+	if affected == 0 {
+		err = ErrNotFound
+	}
+	return
+}
+
+func (s *MySQLStore) Touch(ctx context.Context, id int64, lastUsedAt time.Time) (err error) {
+	_, err = s.db.Exec(TouchQuery, lastUsedAt, id)
+	err = pErrors.FromSQL(err)
+	return
+}
+
+// GetRoles reads the current roles of a key owner, a key never grants more
+// than they do.
+func (s *MySQLStore) GetRoles(ctx context.Context, userID int64) (roles []string, err error) {
+	roles = []string{}
+	err = s.db.Select(&roles, GetRolesQuery, userID)
+	err = pErrors.FromSQL(err)
+	return
+}
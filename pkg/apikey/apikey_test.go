@@ -0,0 +1,331 @@
+package apikey
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+	pErrors "github.com/rzfhlv/gin-example/pkg/errors"
+	pToken "github.com/rzfhlv/gin-example/pkg/token"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	ctx     = context.Background()
+	errFoo  = errors.New("foo")
+	now     = time.Now()
+	columns = []string{"id", "user_id", "name", "prefix", "scopes", "expires_at", "last_used_at", "revoked_at", "created_at"}
+	key     = Key{
+		UserID: 1, Name: "batch", Prefix: "gek_abcdefgh", Hash: "hashed",
+		Scopes: Scopes{"gatherings:read", "invitations:write"}, CreatedAt: now,
+	}
+)
+
+func newStore(t *testing.T) (*MySQLStore, sqlmock.Sqlmock) {
+	mockDB, mockSQL, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	assert.NoError(t, err)
+	t.Cleanup(func() { mockDB.Close() })
+
+	return &MySQLStore{db: sqlx.NewDb(mockDB, "sqlmock")}, mockSQL
+}
+
+func TestNew(t *testing.T) {
+	s := New(nil)
+	assert.NotNil(t, s)
+}
+
+func TestGenerate(t *testing.T) {
+	secret, prefix, hash, err := Generate()
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(secret, KEYPREFIX))
+	assert.Equal(t, secret[:DISPLAYLENGTH], prefix)
+	assert.Equal(t, pToken.Hash(secret), hash)
+
+	other, _, _, err := Generate()
+	assert.NoError(t, err)
+	assert.NotEqual(t, secret, other)
+}
+
+func TestActive(t *testing.T) {
+	past, future := now.Add(-time.Hour), now.Add(time.Hour)
+
+	testCase := []struct {
+		name   string
+		key    Key
+		active bool
+	}{
+		{
+			name: "Testcase #1: Positive", key: Key{}, active: true,
+		},
+		{
+			name: "Testcase #2: Positive", key: Key{ExpiresAt: &future}, active: true,
+		},
+		{
+			name: "Testcase #3: Negative", key: Key{ExpiresAt: &past}, active: false,
+		},
+		{
+			name: "Testcase #4: Negative", key: Key{RevokedAt: &past}, active: false,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.active, tt.key.Active(now))
+		})
+	}
+}
+
+func TestScopes(t *testing.T) {
+	value, err := key.Scopes.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "gatherings:read,invitations:write", value)
+
+	testCase := []struct {
+		name   string
+		src    interface{}
+		scopes Scopes
+		isErr  bool
+	}{
+		{
+			name: "Testcase #1: Positive", src: []byte("gatherings:read,invitations:write"), scopes: key.Scopes,
+		},
+		{
+			name: "Testcase #2: Positive", src: "gatherings:read", scopes: Scopes{"gatherings:read"},
+		},
+		{
+			name: "Testcase #3: Positive", src: nil, scopes: Scopes{},
+		},
+		{
+			name: "Testcase #4: Negative", src: 1, isErr: true,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			scopes := Scopes{}
+			err := scopes.Scan(tt.src)
+			if tt.isErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.scopes, scopes)
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	testCase := []struct {
+		name       string
+		beforeTest func(s sqlmock.Sqlmock)
+		wantError  error
+		isErr      bool
+	}{
+		{
+			name: "Testcase #1: Positive",
+			beforeTest: func(s sqlmock.Sqlmock) {
+				s.ExpectExec(CreateQuery).
+					WithArgs(key.UserID, key.Name, key.Prefix, key.Hash, "gatherings:read,invitations:write", key.ExpiresAt, key.CreatedAt).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+		},
+		{
+			name: "Testcase #2: Negative",
+			beforeTest: func(s sqlmock.Sqlmock) {
+				s.ExpectExec(CreateQuery).WillReturnError(errFoo)
+			},
+			isErr: true,
+		},
+		{
+			name: "Testcase #3: Negative",
+			beforeTest: func(s sqlmock.Sqlmock) {
+				s.ExpectExec(CreateQuery).WillReturnError(&mysql.MySQLError{Number: pErrors.MYSQLDUPLICATEENTRY, Message: "Duplicate entry 'hashed' for key 'api_keys.hash'"})
+			},
+			wantError: pErrors.ErrConflict,
+			isErr:     true,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			s, mockSQL := newStore(t)
+			tt.beforeTest(mockSQL)
+
+			id, err := s.Create(ctx, key)
+			if tt.isErr {
+				assert.Error(t, err)
+				if tt.wantError != nil {
+					assert.ErrorIs(t, err, tt.wantError)
+				}
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, int64(1), id)
+		})
+	}
+}
+
+func TestList(t *testing.T) {
+	testCase := []struct {
+		name       string
+		beforeTest func(s sqlmock.Sqlmock)
+		isErr      bool
+	}{
+		{
+			name: "Testcase #1: Positive",
+			beforeTest: func(s sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(columns).
+					AddRow(1, 1, "batch", "gek_abcdefgh", "gatherings:read,invitations:write", nil, nil, nil, now)
+				s.ExpectQuery(ListQuery).WillReturnRows(rows)
+			},
+		},
+		{
+			name: "Testcase #2: Negative",
+			beforeTest: func(s sqlmock.Sqlmock) {
+				s.ExpectQuery(ListQuery).WillReturnError(errFoo)
+			},
+			isErr: true,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			s, mockSQL := newStore(t)
+			tt.beforeTest(mockSQL)
+
+			keys, err := s.List(ctx)
+			if tt.isErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, keys, 1)
+			assert.Equal(t, key.Scopes, keys[0].Scopes)
+		})
+	}
+}
+
+func TestGetByHash(t *testing.T) {
+	testCase := []struct {
+		name       string
+		beforeTest func(s sqlmock.Sqlmock)
+		wantError  error
+	}{
+		{
+			name: "Testcase #1: Positive",
+			beforeTest: func(s sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(append(columns, "username")).
+					AddRow(1, 1, "batch", "gek_abcdefgh", "gatherings:read", nil, now, nil, now, "johndoe")
+				s.ExpectQuery(GetByHashQuery).WithArgs("hashed").WillReturnRows(rows)
+			},
+		},
+		{
+			name: "Testcase #2: Negative",
+			beforeTest: func(s sqlmock.Sqlmock) {
+				s.ExpectQuery(GetByHashQuery).WithArgs("hashed").WillReturnError(sql.ErrNoRows)
+			},
+			wantError: pErrors.ErrNotFound,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			s, mockSQL := newStore(t)
+			tt.beforeTest(mockSQL)
+
+			found, err := s.GetByHash(ctx, "hashed")
+			if tt.wantError != nil {
+				assert.ErrorIs(t, err, tt.wantError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, int64(1), found.ID)
+				assert.Equal(t, "johndoe", found.Username)
+				assert.NotNil(t, found.LastUsedAt)
+			}
+		})
+	}
+}
+
+func TestRevoke(t *testing.T) {
+	testCase := []struct {
+		name       string
+		beforeTest func(s sqlmock.Sqlmock)
+		wantError  error
+	}{
+		{
+			name: "Testcase #1: Positive",
+			beforeTest: func(s sqlmock.Sqlmock) {
+				s.ExpectExec(RevokeQuery).WithArgs(now, int64(1)).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name: "Testcase #2: Negative",
+			beforeTest: func(s sqlmock.Sqlmock) {
+				s.ExpectExec(RevokeQuery).WithArgs(now, int64(1)).WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantError: ErrNotFound,
+		},
+		{
+			name: "Testcase #3: Negative",
+			beforeTest: func(s sqlmock.Sqlmock) {
+				s.ExpectExec(RevokeQuery).WithArgs(now, int64(1)).WillReturnError(errFoo)
+			},
+			wantError: errFoo,
+		},
+		{
+			name: "Testcase #4: Negative",
+			beforeTest: func(s sqlmock.Sqlmock) {
+				s.ExpectExec(RevokeQuery).WithArgs(now, int64(1)).WillReturnResult(sqlmock.NewErrorResult(errFoo))
+			},
+			wantError: errFoo,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			s, mockSQL := newStore(t)
+			tt.beforeTest(mockSQL)
+
+			err := s.Revoke(ctx, 1, now)
+			assert.Equal(t, tt.wantError, err)
+		})
+	}
+}
+
+func TestTouch(t *testing.T) {
+	t.Run("Testcase #1: Positive", func(t *testing.T) {
+		s, mockSQL := newStore(t)
+		mockSQL.ExpectExec(TouchQuery).WithArgs(now, int64(1)).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := s.Touch(ctx, 1, now)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Testcase #2: Negative", func(t *testing.T) {
+		s, mockSQL := newStore(t)
+		mockSQL.ExpectExec(TouchQuery).WithArgs(now, int64(1)).WillReturnError(errFoo)
+
+		err := s.Touch(ctx, 1, now)
+		assert.Equal(t, errFoo, err)
+	})
+}
+
+func TestGetRoles(t *testing.T) {
+	t.Run("Testcase #1: Positive", func(t *testing.T) {
+		s, mockSQL := newStore(t)
+		rows := sqlmock.NewRows([]string{"name"}).AddRow("admin").AddRow("organizer")
+		mockSQL.ExpectQuery(GetRolesQuery).WithArgs(int64(1)).WillReturnRows(rows)
+
+		roles, err := s.GetRoles(ctx, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"admin", "organizer"}, roles)
+	})
+
+	t.Run("Testcase #2: Negative", func(t *testing.T) {
+		s, mockSQL := newStore(t)
+		mockSQL.ExpectQuery(GetRolesQuery).WithArgs(int64(1)).WillReturnError(errFoo)
+
+		_, err := s.GetRoles(ctx, 1)
+		assert.Equal(t, errFoo, err)
+	})
+}
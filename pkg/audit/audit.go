@@ -0,0 +1,53 @@
+package audit
+
+import (
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var (
+	START   = "impersonation.start"
+	REQUEST = "impersonation.request"
+
+	// rows are kept when either user is deleted, the trail has to outlive them
+	RecordQuery = `INSERT INTO impersonation_audit (actor_id, user_id, session_id, action, method, path, status, ip, user_agent, created_at)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?);`
+)
+
+// Entry is one thing done by an actor as another user, a request made while
+// impersonating carries its method, path and status.
+type Entry struct {
+	ID        int64     `json:"id" db:"id"`
+	ActorID   int64     `json:"actor_id" db:"actor_id"`
+	UserID    int64     `json:"user_id" db:"user_id"`
+	SessionID string    `json:"session_id" db:"session_id"`
+	Action    string    `json:"action" db:"action"`
+	Method    string    `json:"method" db:"method"`
+	Path      string    `json:"path" db:"path"`
+	Status    int       `json:"status" db:"status"`
+	IP        string    `json:"ip" db:"ip"`
+	UserAgent string    `json:"user_agent" db:"user_agent"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+type Store interface {
+	Record(ctx context.Context, entry Entry) (err error)
+}
+
+type MySQLStore struct {
+	db *sqlx.DB
+}
+
+func New(db *sqlx.DB) Store {
+	return &MySQLStore{
+		db: db,
+	}
+}
+
+func (s *MySQLStore) Record(ctx context.Context, entry Entry) (err error) {
+	_, err = s.db.Exec(RecordQuery, entry.ActorID, entry.UserID, entry.SessionID, entry.Action,
+		entry.Method, entry.Path, entry.Status, entry.IP, entry.UserAgent, entry.CreatedAt)
+	return
+}
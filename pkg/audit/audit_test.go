@@ -0,0 +1,57 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	ctx    = context.Background()
+	errFoo = errors.New("foo")
+	entry  = Entry{
+		ActorID: 1, UserID: 2, SessionID: "thisissession", Action: REQUEST, Method: "GET",
+		Path: "/v1/invitations", Status: 200, IP: "127.0.0.1", UserAgent: "curl", CreatedAt: time.Now(),
+	}
+)
+
+func newStore(t *testing.T) (*MySQLStore, sqlmock.Sqlmock) {
+	mockDB, mockSQL, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	assert.NoError(t, err)
+	t.Cleanup(func() { mockDB.Close() })
+
+	return &MySQLStore{db: sqlx.NewDb(mockDB, "sqlmock")}, mockSQL
+}
+
+func TestNew(t *testing.T) {
+	s := New(nil)
+	assert.NotNil(t, s)
+}
+
+func TestRecord(t *testing.T) {
+	t.Run("Testcase #1: Positive", func(t *testing.T) {
+		s, mockSQL := newStore(t)
+		mockSQL.ExpectExec(RecordQuery).
+			WithArgs(entry.ActorID, entry.UserID, entry.SessionID, entry.Action, entry.Method, entry.Path, entry.Status, entry.IP, entry.UserAgent, entry.CreatedAt).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		err := s.Record(ctx, entry)
+		assert.NoError(t, err)
+		assert.NoError(t, mockSQL.ExpectationsWereMet())
+	})
+
+	t.Run("Testcase #2: Negative", func(t *testing.T) {
+		s, mockSQL := newStore(t)
+		mockSQL.ExpectExec(RecordQuery).
+			WithArgs(entry.ActorID, entry.UserID, entry.SessionID, entry.Action, entry.Method, entry.Path, entry.Status, entry.IP, entry.UserAgent, entry.CreatedAt).
+			WillReturnError(errFoo)
+
+		err := s.Record(ctx, entry)
+		assert.Equal(t, errFoo, err)
+	})
+}
@@ -0,0 +1,200 @@
+package errors
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/rzfhlv/gin-example/pkg/message"
+)
+
+var (
+	NOTFOUND     = "not_found"
+	CONFLICT     = "conflict"
+	VALIDATION   = "validation"
+	FORBIDDEN    = "forbidden"
+	UNAUTHORIZED = "unauthorized"
+
+	// the kinds alone, errors.Is matches any error of the same kind with them
+	ErrNotFound     = &Error{Kind: NOTFOUND}
+	ErrConflict     = &Error{Kind: CONFLICT}
+	ErrValidation   = &Error{Kind: VALIDATION}
+	ErrForbidden    = &Error{Kind: FORBIDDEN}
+	ErrUnauthorized = &Error{Kind: UNAUTHORIZED}
+
+	STATUS = map[string]int{
+		NOTFOUND:     http.StatusNotFound,
+		CONFLICT:     http.StatusConflict,
+		VALIDATION:   http.StatusUnprocessableEntity,
+		FORBIDDEN:    http.StatusForbidden,
+		UNAUTHORIZED: http.StatusUnauthorized,
+	}
+	MESSAGES = map[string]string{
+		NOTFOUND:     message.NOTFOUND,
+		CONFLICT:     message.CONFLICT,
+		VALIDATION:   message.UNPROCESSABLEENTITY,
+		FORBIDDEN:    message.FORBIDDEN,
+		UNAUTHORIZED: message.UNAUTHORIZED,
+	}
+
+	MYSQLDUPLICATEENTRY uint16 = 1062
+	MYSQLNOREFERENCED   uint16 = 1452
+
+	duplicateKey = regexp.MustCompile(`for key '([^']+)'`)
+	foreignKey   = regexp.MustCompile("FOREIGN KEY \\(`([^`]+)`\\)")
+)
+
+// Error is a failure the client can do something about, its Kind decides the
+// status it is answered with and its Message is safe to show.
+type Error struct {
+	Kind    string
+	Message string
+	// Field is the column a translated driver error is about, if known
+	Field string
+	// Fields lists what is wrong with each field of a rejected request
+	Fields []FieldError
+	Err    error
+}
+
+// FieldError is one problem with one field of a request, Field is the path
+// of the field as the client sent it.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	text := e.Message
+	if text == "" {
+		text = e.Kind
+	}
+	if e.Err != nil {
+		text += ": " + e.Err.Error()
+	}
+	return text
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is lets the bare kinds match, ErrNotFound is any not found error.
+func (e *Error) Is(target error) bool {
+	kind, ok := target.(*Error)
+	return ok && kind.Message == "" && kind.Err == nil && kind.Kind == e.Kind
+}
+
+func NotFound(message string) *Error {
+	return &Error{Kind: NOTFOUND, Message: message}
+}
+
+func Conflict(message string) *Error {
+	return &Error{Kind: CONFLICT, Message: message}
+}
+
+func Validation(message string) *Error {
+	return &Error{Kind: VALIDATION, Message: message}
+}
+
+func Forbidden(message string) *Error {
+	return &Error{Kind: FORBIDDEN, Message: message}
+}
+
+func Unauthorized(message string) *Error {
+	return &Error{Kind: UNAUTHORIZED, Message: message}
+}
+
+// FromSQL turns what the driver says about the data into a typed error: no
+// rows is NotFound, a duplicate key a Conflict and a missing foreign row a
+// Validation error. Anything else is returned as it is.
+func FromSQL(err error) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		return &Error{Kind: NOTFOUND, Message: message.NOTFOUND, Err: err}
+	}
+
+	var mysqlErr *mysql.MySQLError
+	if !errors.As(err, &mysqlErr) {
+		return err
+	}
+	switch mysqlErr.Number {
+	case MYSQLDUPLICATEENTRY:
+		// the key is named after its column, MySQL 8 prefixes the table
+		field := match(duplicateKey, mysqlErr.Message)
+		field = field[strings.LastIndex(field, ".")+1:]
+		text := message.CONFLICT
+		if field != "" && field != "PRIMARY" {
+			text = humanize(field) + " Exist"
+		}
+		return &Error{Kind: CONFLICT, Message: text, Field: field, Err: err}
+	case MYSQLNOREFERENCED:
+		field := match(foreignKey, mysqlErr.Message)
+		text := message.REFERENCENOTFOUND
+		if field != "" {
+			text = humanize(strings.TrimSuffix(field, "_id")) + " Not Found"
+		}
+		return &Error{Kind: VALIDATION, Message: text, Field: field, Err: err}
+	}
+	return err
+}
+
+// Status is the HTTP status err is answered with, driver errors are
+// translated first so an untranslated no rows is still a 404.
+func Status(err error) int {
+	var typed *Error
+	if errors.As(FromSQL(err), &typed) {
+		if status, ok := STATUS[typed.Kind]; ok {
+			return status
+		}
+	}
+	return http.StatusInternalServerError
+}
+
+// Message is what the client is told about err, only typed errors say more
+// than that something went wrong.
+func Message(err error) string {
+	var typed *Error
+	if !errors.As(FromSQL(err), &typed) {
+		return message.SOMETHINGWENTWRONG
+	}
+	if typed.Message != "" {
+		return typed.Message
+	}
+	if text, ok := MESSAGES[typed.Kind]; ok {
+		return text
+	}
+	return message.SOMETHINGWENTWRONG
+}
+
+// Fields is what is wrong per field with the request err rejects, if it says.
+func Fields(err error) []FieldError {
+	var typed *Error
+	if !errors.As(err, &typed) {
+		return nil
+	}
+	return typed.Fields
+}
+
+func match(pattern *regexp.Regexp, text string) string {
+	found := pattern.FindStringSubmatch(text)
+	if len(found) < 2 {
+		return ""
+	}
+	return found[1]
+}
+
+// humanize makes first_name into First Name.
+func humanize(field string) string {
+	words := strings.Split(field, "_")
+	for i, word := range words {
+		if word != "" {
+			words[i] = strings.ToUpper(word[:1]) + word[1:]
+		}
+	}
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,143 @@
+package errors
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/rzfhlv/gin-example/pkg/message"
+	"github.com/stretchr/testify/assert"
+)
+
+var errFoo = errors.New("foo")
+
+func TestFromSQL(t *testing.T) {
+	testCase := []struct {
+		name        string
+		err         error
+		wantKind    string
+		wantMessage string
+		wantField   string
+	}{
+		{
+			name: "Testcase #1: Positive", err: sql.ErrNoRows, wantKind: NOTFOUND, wantMessage: message.NOTFOUND,
+		},
+		{
+			name: "Testcase #2: Positive", err: fmt.Errorf("get member: %w", sql.ErrNoRows), wantKind: NOTFOUND, wantMessage: message.NOTFOUND,
+		},
+		{
+			name:     "Testcase #3: Positive",
+			err:      &mysql.MySQLError{Number: 1062, Message: "Duplicate entry 'john@test.com' for key 'members.email'"},
+			wantKind: CONFLICT, wantMessage: message.EMAILEXIST, wantField: "email",
+		},
+		{
+			name:     "Testcase #4: Positive",
+			err:      &mysql.MySQLError{Number: 1062, Message: "Duplicate entry 'john' for key 'username'"},
+			wantKind: CONFLICT, wantMessage: message.USERNAMEEXIST, wantField: "username",
+		},
+		{
+			name:     "Testcase #5: Positive",
+			err:      &mysql.MySQLError{Number: 1062, Message: "Duplicate entry '1' for key 'PRIMARY'"},
+			wantKind: CONFLICT, wantMessage: message.CONFLICT, wantField: "PRIMARY",
+		},
+		{
+			name: "Testcase #6: Positive",
+			err: &mysql.MySQLError{Number: 1452, Message: "Cannot add or update a child row: a foreign key constraint fails " +
+				"(`gin`.`invitations`, CONSTRAINT `invitations_ibfk_1` FOREIGN KEY (`member_id`) REFERENCES `members` (`id`))"},
+			wantKind: VALIDATION, wantMessage: "Member Not Found", wantField: "member_id",
+		},
+		{
+			name:     "Testcase #7: Positive",
+			err:      &mysql.MySQLError{Number: 1452, Message: "Cannot add or update a child row"},
+			wantKind: VALIDATION, wantMessage: message.REFERENCENOTFOUND,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			err := FromSQL(tt.err)
+
+			var typed *Error
+			assert.True(t, errors.As(err, &typed))
+			assert.Equal(t, tt.wantKind, typed.Kind)
+			assert.Equal(t, tt.wantMessage, typed.Message)
+			assert.Equal(t, tt.wantField, typed.Field)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+
+	t.Run("Testcase #8: Negative", func(t *testing.T) {
+		assert.Nil(t, FromSQL(nil))
+		assert.Equal(t, errFoo, FromSQL(errFoo))
+
+		deadlock := &mysql.MySQLError{Number: 1213, Message: "Deadlock found"}
+		assert.Equal(t, deadlock, FromSQL(deadlock))
+	})
+}
+
+func TestIs(t *testing.T) {
+	conflict := Conflict(message.EMAILEXIST)
+
+	assert.ErrorIs(t, conflict, ErrConflict)
+	assert.ErrorIs(t, fmt.Errorf("update: %w", conflict), conflict)
+	assert.NotErrorIs(t, conflict, ErrNotFound)
+	assert.NotErrorIs(t, conflict, Conflict(message.USERNAMEEXIST))
+	assert.NotErrorIs(t, ErrConflict, conflict)
+	assert.ErrorIs(t, FromSQL(sql.ErrNoRows), ErrNotFound)
+}
+
+func TestError(t *testing.T) {
+	assert.Equal(t, "Email Exist", Conflict(message.EMAILEXIST).Error())
+	assert.Equal(t, "not_found", ErrNotFound.Error())
+	assert.Equal(t, "Data Not found: sql: no rows in result set", FromSQL(sql.ErrNoRows).Error())
+}
+
+func TestStatus(t *testing.T) {
+	testCase := []struct {
+		name        string
+		err         error
+		wantStatus  int
+		wantMessage string
+	}{
+		{
+			name: "Testcase #1: Positive", err: NotFound("Gathering Not Found"), wantStatus: http.StatusNotFound, wantMessage: "Gathering Not Found",
+		},
+		{
+			name: "Testcase #2: Positive", err: fmt.Errorf("wrapped: %w", Conflict(message.EMAILEXIST)), wantStatus: http.StatusConflict, wantMessage: message.EMAILEXIST,
+		},
+		{
+			name: "Testcase #3: Positive", err: Validation(message.UNKNOWNROLE), wantStatus: http.StatusUnprocessableEntity, wantMessage: message.UNKNOWNROLE,
+		},
+		{
+			name: "Testcase #4: Positive", err: ErrForbidden, wantStatus: http.StatusForbidden, wantMessage: message.FORBIDDEN,
+		},
+		{
+			name: "Testcase #5: Positive", err: Unauthorized(""), wantStatus: http.StatusUnauthorized, wantMessage: message.UNAUTHORIZED,
+		},
+		{
+			name: "Testcase #6: Positive", err: sql.ErrNoRows, wantStatus: http.StatusNotFound, wantMessage: message.NOTFOUND,
+		},
+		{
+			name: "Testcase #7: Negative", err: errFoo, wantStatus: http.StatusInternalServerError, wantMessage: message.SOMETHINGWENTWRONG,
+		},
+		{
+			name: "Testcase #8: Negative", err: &Error{Kind: "unknown"}, wantStatus: http.StatusInternalServerError, wantMessage: message.SOMETHINGWENTWRONG,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantStatus, Status(tt.err))
+			assert.Equal(t, tt.wantMessage, Message(tt.err))
+		})
+	}
+}
+
+func TestFields(t *testing.T) {
+	fields := []FieldError{{Field: "email", Message: "must be a valid email"}}
+
+	assert.Equal(t, fields, Fields(fmt.Errorf("create: %w", &Error{Kind: VALIDATION, Fields: fields})))
+	assert.Nil(t, Fields(Conflict(message.EMAILEXIST)))
+	assert.Nil(t, Fields(errFoo))
+}
@@ -0,0 +1,196 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+var (
+	CSV    = "csv"
+	NDJSON = "ndjson"
+
+	// FORMULAPREFIXES start a formula in a spreadsheet cell
+	FORMULAPREFIXES = "=+-@\t\r"
+
+	ContentTypes = map[string]string{
+		CSV:    "text/csv; charset=utf-8",
+		NDJSON: "application/x-ndjson",
+	}
+
+	ErrUnknownFormat = errors.New("unknown export format")
+)
+
+// Column is one field of an exported row, it is the CSV header and the
+// NDJSON key alike so both formats carry the same data.
+type Column[T any] struct {
+	Name  string
+	Value func(row T) interface{}
+}
+
+// Writer streams rows of T in the format it was made for. Nothing is held
+// but the current row, Flush pushes what is buffered to the client.
+type Writer[T any] struct {
+	out     io.Writer
+	format  string
+	columns []Column[T]
+	csv     *csv.Writer
+	header  bool
+}
+
+func NewWriter[T any](out io.Writer, format string, columns []Column[T]) (writer *Writer[T], err error) {
+	if _, ok := ContentTypes[format]; !ok {
+		err = fmt.Errorf("%w: %s", ErrUnknownFormat, format)
+		return
+	}
+
+	writer = &Writer[T]{out: out, format: format, columns: columns}
+	if format == CSV {
+		writer.csv = csv.NewWriter(out)
+	}
+	return
+}
+
+// ContentType is the Content-Type of format, it fails for one NewWriter
+// doesn't know.
+func ContentType(format string) (contentType string, err error) {
+	contentType, ok := ContentTypes[format]
+	if !ok {
+		err = fmt.Errorf("%w: %s", ErrUnknownFormat, format)
+	}
+	return
+}
+
+// Disposition is the Content-Disposition that saves the export as name with
+// the extension of format.
+func Disposition(name, format string) string {
+	return fmt.Sprintf(`attachment; filename="%s.%s"`, name, format)
+}
+
+func (w *Writer[T]) Write(rows ...T) (err error) {
+	for _, row := range rows {
+		if w.format == CSV {
+			err = w.writeCSV(row)
+		} else {
+			err = w.writeNDJSON(row)
+		}
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+// Flush also writes the CSV header when there were no rows, an empty
+// export still says what it would have held.
+func (w *Writer[T]) Flush() (err error) {
+	if w.format == CSV {
+		err = w.writeHeader()
+		if err != nil {
+			return
+		}
+		w.csv.Flush()
+		err = w.csv.Error()
+		if err != nil {
+			return
+		}
+	}
+
+	if flusher, ok := w.out.(http.Flusher); ok {
+		flusher.Flush()
+	}
+	return
+}
+
+func (w *Writer[T]) writeHeader() (err error) {
+	if w.header {
+		return
+	}
+	w.header = true
+
+	names := make([]string, len(w.columns))
+	for i, column := range w.columns {
+		names[i] = column.Name
+	}
+	err = w.csv.Write(names)
+	return
+}
+
+func (w *Writer[T]) writeCSV(row T) (err error) {
+	err = w.writeHeader()
+	if err != nil {
+		return
+	}
+
+	record := make([]string, len(w.columns))
+	for i, column := range w.columns {
+		record[i] = cell(column.Value(row))
+	}
+	err = w.csv.Write(record)
+	return
+}
+
+// writeNDJSON keeps the keys in the order of the columns, a map would sort
+// them.
+func (w *Writer[T]) writeNDJSON(row T) (err error) {
+	line := bytes.Buffer{}
+	line.WriteByte('{')
+	for i, column := range w.columns {
+		if i > 0 {
+			line.WriteByte(',')
+		}
+
+		var key, value []byte
+		key, err = json.Marshal(column.Name)
+		if err != nil {
+			return
+		}
+		value, err = json.Marshal(column.Value(row))
+		if err != nil {
+			return
+		}
+		line.Write(key)
+		line.WriteByte(':')
+		line.Write(value)
+	}
+	line.WriteString("}\n")
+
+	_, err = w.out.Write(line.Bytes())
+	return
+}
+
+// cell renders a CSV value, text is escaped so a spreadsheet never runs it
+// as a formula while numbers keep their sign.
+func cell(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return escape(v)
+	case int, int64, float64:
+		return fmt.Sprint(v)
+	case time.Time:
+		return v.Format(time.RFC3339)
+	case *time.Time:
+		if v == nil {
+			return ""
+		}
+		return v.Format(time.RFC3339)
+	default:
+		return escape(fmt.Sprint(v))
+	}
+}
+
+// escape prefixes text a spreadsheet would read as a formula with a quote.
+func escape(text string) string {
+	if text != "" && strings.ContainsRune(FORMULAPREFIXES, rune(text[0])) {
+		return "'" + text
+	}
+	return text
+}
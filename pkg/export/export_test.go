@@ -0,0 +1,134 @@
+package export
+
+import (
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type row struct {
+	ID        int64
+	Name      string
+	DeletedAt *time.Time
+}
+
+var (
+	deletedAt = time.Date(2026, time.October, 18, 10, 0, 0, 0, time.UTC)
+	rows      = []row{
+		{ID: 1, Name: "Doe, John"},
+		{ID: 2, Name: `Jane "JD" Doe`, DeletedAt: &deletedAt},
+	}
+	columns = []Column[row]{
+		{Name: "id", Value: func(r row) interface{} { return r.ID }},
+		{Name: "name", Value: func(r row) interface{} { return r.Name }},
+		{Name: "deleted_at", Value: func(r row) interface{} { return r.DeletedAt }},
+	}
+)
+
+func TestWriter(t *testing.T) {
+	testCase := []struct {
+		name, format string
+		rows         []row
+		want         string
+		wantError    error
+	}{
+		{
+			name: "Testcase #1: Positive", format: CSV, rows: rows,
+			want: "id,name,deleted_at\n1,\"Doe, John\",\n2,\"Jane \"\"JD\"\" Doe\",2026-10-18T10:00:00Z\n",
+		},
+		{
+			name: "Testcase #2: Positive", format: NDJSON, rows: rows,
+			want: `{"id":1,"name":"Doe, John","deleted_at":null}` + "\n" +
+				`{"id":2,"name":"Jane \"JD\" Doe","deleted_at":"2026-10-18T10:00:00Z"}` + "\n",
+		},
+		{
+			name: "Testcase #3: Positive", format: CSV, want: "id,name,deleted_at\n",
+		},
+		{
+			name: "Testcase #4: Positive", format: NDJSON, want: "",
+		},
+		{
+			name: "Testcase #5: Negative", format: "xml", wantError: ErrUnknownFormat,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			out := httptest.NewRecorder()
+			writer, err := NewWriter(out, tt.format, columns)
+			if tt.wantError != nil {
+				assert.True(t, errors.Is(err, tt.wantError))
+				return
+			}
+			assert.NoError(t, err)
+
+			// rows come in chunks, the header is written once
+			for _, r := range tt.rows {
+				assert.NoError(t, writer.Write(r))
+				assert.NoError(t, writer.Flush())
+			}
+			assert.NoError(t, writer.Flush())
+			assert.Equal(t, tt.want, out.Body.String())
+			assert.True(t, out.Flushed)
+		})
+	}
+}
+
+func TestWriterFormula(t *testing.T) {
+	formulas := []row{
+		{ID: -1, Name: "=HYPERLINK(\"http://evil\")"},
+		{ID: 2, Name: "+1"},
+		{ID: 3, Name: "-1"},
+		{ID: 4, Name: "@SUM(A1)"},
+		{ID: 5, Name: "\tcmd"},
+		{ID: 6, Name: "\rcmd"},
+		{ID: 7, Name: "a=b"},
+	}
+
+	t.Run("Testcase #1: Positive", func(t *testing.T) {
+		out := httptest.NewRecorder()
+		writer, err := NewWriter(out, CSV, columns[:2])
+		assert.NoError(t, err)
+		assert.NoError(t, writer.Write(formulas...))
+		assert.NoError(t, writer.Flush())
+
+		want := "id,name\n" +
+			"-1,\"'=HYPERLINK(\"\"http://evil\"\")\"\n" +
+			"2,'+1\n" +
+			"3,'-1\n" +
+			"4,'@SUM(A1)\n" +
+			"5,'\tcmd\n" +
+			"6,\"'\rcmd\"\n" +
+			"7,a=b\n"
+		assert.Equal(t, want, out.Body.String())
+	})
+
+	t.Run("Testcase #2: Positive", func(t *testing.T) {
+		out := httptest.NewRecorder()
+		writer, err := NewWriter(out, NDJSON, columns[:2])
+		assert.NoError(t, err)
+		assert.NoError(t, writer.Write(formulas[1]))
+		assert.NoError(t, writer.Flush())
+		assert.Equal(t, `{"id":2,"name":"+1"}`+"\n", out.Body.String())
+	})
+}
+
+func TestContentType(t *testing.T) {
+	contentType, err := ContentType(CSV)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(contentType, "text/csv"))
+
+	contentType, err = ContentType(NDJSON)
+	assert.NoError(t, err)
+	assert.Equal(t, "application/x-ndjson", contentType)
+
+	_, err = ContentType("xml")
+	assert.True(t, errors.Is(err, ErrUnknownFormat))
+}
+
+func TestDisposition(t *testing.T) {
+	assert.Equal(t, `attachment; filename="members.ndjson"`, Disposition("members", NDJSON))
+}
@@ -0,0 +1,106 @@
+package hasher
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+var argon2Prefix = "$argon2id$"
+
+// Argon2Params are the argon2id settings, Memory is in KiB.
+type Argon2Params struct {
+	Time       uint32
+	Memory     uint32
+	Threads    uint8
+	KeyLength  uint32
+	SaltLength uint32
+}
+
+func (p Argon2Params) withDefaults() Argon2Params {
+	if p.Time == 0 {
+		p.Time = 1
+	}
+	if p.Memory == 0 {
+		p.Memory = 64 * 1024
+	}
+	if p.Threads == 0 {
+		p.Threads = 4
+	}
+	if p.KeyLength == 0 {
+		p.KeyLength = 32
+	}
+	if p.SaltLength == 0 {
+		p.SaltLength = 16
+	}
+	return p
+}
+
+// hashArgon2 encodes in the PHC string format,
+// $argon2id$v=19$m=65536,t=1,p=4$<salt>$<key>.
+func hashArgon2(password string, params Argon2Params) (hashed string, err error) {
+	salt := make([]byte, params.SaltLength)
+	_, err = rand.Read(salt)
+	if err != nil {
+		return
+	}
+
+	key := argon2.IDKey([]byte(password), salt, params.Time, params.Memory, params.Threads, params.KeyLength)
+	hashed = fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s", argon2Prefix, argon2.Version, params.Memory, params.Time, params.Threads,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key))
+	return
+}
+
+func verifyArgon2(hashed, password string) (err error) {
+	params, salt, key, err := decodeArgon2(hashed)
+	if err != nil {
+		return
+	}
+
+	other := argon2.IDKey([]byte(password), salt, params.Time, params.Memory, params.Threads, params.KeyLength)
+	if subtle.ConstantTimeCompare(key, other) != 1 {
+		err = ErrMismatchedHashAndPassword
+	}
+	return
+}
+
+func decodeArgon2(hashed string) (params Argon2Params, salt, key []byte, err error) {
+	parts := strings.Split(hashed, "$")
+	if len(parts) != 6 || "$"+parts[1]+"$" != argon2Prefix {
+		err = ErrUnknownHash
+		return
+	}
+
+	var version int
+	_, err = fmt.Sscanf(parts[2], "v=%d", &version)
+	if err != nil || version != argon2.Version {
+		err = ErrUnknownHash
+		return
+	}
+
+	_, err = fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Time, &params.Threads)
+	if err != nil {
+		err = ErrUnknownHash
+		return
+	}
+
+	salt, err = base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		err = ErrUnknownHash
+		return
+	}
+
+	key, err = base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		err = ErrUnknownHash
+		return
+	}
+
+	params.SaltLength = uint32(len(salt))
+	params.KeyLength = uint32(len(key))
+	return
+}
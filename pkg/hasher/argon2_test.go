@@ -0,0 +1,51 @@
+package hasher
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeArgon2(t *testing.T) {
+	testCase := []struct {
+		name, hashed string
+		wantError    error
+	}{
+		{
+			name: "Testcase #1: Positive", hashed: "$argon2id$v=19$m=1024,t=1,p=4$c29tZXNhbHRzb21lc2FsdA$mRKbTv5o8AIcyB7SMvmO5tGk0D6oqXlhv7xyNm8L2hA",
+		},
+		{
+			name: "Testcase #2: Negative", hashed: "$argon2i$v=19$m=1024,t=1,p=4$c29tZXNhbHRzb21lc2FsdA$mRKbTv5o8AIcyB7SMvmO5tGk0D6oqXlhv7xyNm8L2hA", wantError: ErrUnknownHash,
+		},
+		{
+			name: "Testcase #3: Negative", hashed: "$argon2id$v=16$m=1024,t=1,p=4$c29tZXNhbHRzb21lc2FsdA$mRKbTv5o8AIcyB7SMvmO5tGk0D6oqXlhv7xyNm8L2hA", wantError: ErrUnknownHash,
+		},
+		{
+			name: "Testcase #4: Negative", hashed: "$argon2id$v=19$m=x,t=1,p=4$c29tZXNhbHRzb21lc2FsdA$mRKbTv5o8AIcyB7SMvmO5tGk0D6oqXlhv7xyNm8L2hA", wantError: ErrUnknownHash,
+		},
+		{
+			name: "Testcase #5: Negative", hashed: "$argon2id$v=19$m=1024,t=1,p=4$!!!$mRKbTv5o8AIcyB7SMvmO5tGk0D6oqXlhv7xyNm8L2hA", wantError: ErrUnknownHash,
+		},
+		{
+			name: "Testcase #6: Negative", hashed: "$argon2id$v=19$m=1024,t=1,p=4$c29tZXNhbHRzb21lc2FsdA$", wantError: ErrUnknownHash,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			params, _, _, err := decodeArgon2(tt.hashed)
+			assert.Equal(t, tt.wantError, err)
+			if tt.wantError == nil {
+				assert.Equal(t, Argon2Params{Time: 1, Memory: 1024, Threads: 4, KeyLength: 32, SaltLength: 16}, params)
+			}
+		})
+	}
+}
+
+func TestVerifyArgon2(t *testing.T) {
+	hashed, err := hashArgon2("password", Argon2Params{Time: 1, Memory: 1024, Threads: 1, KeyLength: 32, SaltLength: 16})
+	assert.NoError(t, err)
+
+	assert.NoError(t, verifyArgon2(hashed, "password"))
+	assert.Equal(t, ErrMismatchedHashAndPassword, verifyArgon2(hashed, "invalidPassword"))
+	assert.Equal(t, ErrUnknownHash, verifyArgon2("$argon2id$", "password"))
+}
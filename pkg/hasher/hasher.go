@@ -1,15 +1,77 @@
 package hasher
 
-import "golang.org/x/crypto/bcrypt"
+import (
+	"errors"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+var (
+	BCRYPT   = "bcrypt"
+	ARGON2ID = "argon2id"
+
+	// NOPASSWORD is stored for an account nobody chose a password for, no
+	// hash ever has this form so it never verifies
+	NOPASSWORD = "!"
+
+	// a wrong password is reported the same whatever produced the hash
+	ErrMismatchedHashAndPassword = bcrypt.ErrMismatchedHashAndPassword
+	ErrUnsupportedAlgorithm      = errors.New("unsupported hash algorithm")
+	ErrUnknownHash               = errors.New("unknown hash format")
+)
 
 type HashPassword interface {
 	HashedPassword(password string) (hashed string, err error)
 	VerifyPassword(hashed, password string) (err error)
+	NeedsRehash(hashed string) (rehash bool)
+}
+
+type Config struct {
+	Algorithm  string
+	BcryptCost int
+	Argon2     Argon2Params
 }
 
-type HasherPassword struct{}
+// HasherPassword hashes with the configured algorithm and verifies any hash
+// it knows, the zero value keeps bcrypt at the default cost.
+type HasherPassword struct {
+	algorithm  string
+	bcryptCost int
+	argon2     Argon2Params
+}
+
+func New(cfg Config) (h *HasherPassword, err error) {
+	switch cfg.Algorithm {
+	case BCRYPT, "":
+		if cfg.BcryptCost == 0 {
+			cfg.BcryptCost = bcrypt.DefaultCost
+		}
+		if cfg.BcryptCost < bcrypt.MinCost || cfg.BcryptCost > bcrypt.MaxCost {
+			err = bcrypt.InvalidCostError(cfg.BcryptCost)
+			return
+		}
+		h = &HasherPassword{
+			algorithm:  BCRYPT,
+			bcryptCost: cfg.BcryptCost,
+		}
+	case ARGON2ID:
+		h = &HasherPassword{
+			algorithm: ARGON2ID,
+			argon2:    cfg.Argon2.withDefaults(),
+		}
+	default:
+		err = ErrUnsupportedAlgorithm
+	}
+	return
+}
 
 func (h *HasherPassword) HashedPassword(password string) (hashed string, err error) {
+	if h.algorithm == ARGON2ID {
+		hashed, err = hashArgon2(password, h.argon2)
+		return
+	}
+
 	hashedPassword, err := h.Hash(password)
 	if err != nil {
 		return
@@ -19,9 +81,34 @@ func (h *HasherPassword) HashedPassword(password string) (hashed string, err err
 }
 
 func (h *HasherPassword) VerifyPassword(hashed, password string) (err error) {
+	if hashed == NOPASSWORD {
+		return ErrMismatchedHashAndPassword
+	}
+	if strings.HasPrefix(hashed, argon2Prefix) {
+		return verifyArgon2(hashed, password)
+	}
 	return bcrypt.CompareHashAndPassword([]byte(hashed), []byte(password))
 }
 
+// NeedsRehash reports a stored hash made by another algorithm or with other
+// settings than the configured ones.
+func (h *HasherPassword) NeedsRehash(hashed string) (rehash bool) {
+	if h.algorithm == ARGON2ID {
+		params, _, _, err := decodeArgon2(hashed)
+		return err != nil || params != h.argon2
+	}
+
+	cost, err := bcrypt.Cost([]byte(hashed))
+	return err != nil || cost != h.cost()
+}
+
 func (h *HasherPassword) Hash(password string) (hashed []byte, err error) {
-	return bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	return bcrypt.GenerateFromPassword([]byte(password), h.cost())
+}
+
+func (h *HasherPassword) cost() int {
+	if h.bcryptCost == 0 {
+		return bcrypt.DefaultCost
+	}
+	return h.bcryptCost
 }
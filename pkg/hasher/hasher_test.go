@@ -1,9 +1,11 @@
 package hasher
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
 )
 
 func TestHasher(t *testing.T) {
@@ -45,4 +47,109 @@ func TestHasherValidate(t *testing.T) {
 		err := h.VerifyPassword(hashed, password)
 		assert.Error(t, err)
 	})
+
+	t.Run("Testcase #3: Negative", func(t *testing.T) {
+		h := HasherPassword{}
+		err := h.VerifyPassword(NOPASSWORD, "")
+		assert.Equal(t, ErrMismatchedHashAndPassword, err)
+		err = h.VerifyPassword(NOPASSWORD, NOPASSWORD)
+		assert.Equal(t, ErrMismatchedHashAndPassword, err)
+	})
+}
+
+func TestNew(t *testing.T) {
+	testCase := []struct {
+		name      string
+		cfg       Config
+		algorithm string
+		wantError error
+	}{
+		{
+			name: "Testcase #1: Positive", cfg: Config{}, algorithm: BCRYPT,
+		},
+		{
+			name: "Testcase #2: Positive", cfg: Config{Algorithm: ARGON2ID}, algorithm: ARGON2ID,
+		},
+		{
+			name: "Testcase #3: Negative", cfg: Config{Algorithm: "md5"}, wantError: ErrUnsupportedAlgorithm,
+		},
+		{
+			name: "Testcase #4: Negative", cfg: Config{Algorithm: BCRYPT, BcryptCost: 64}, wantError: bcrypt.InvalidCostError(64),
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			h, err := New(tt.cfg)
+			assert.Equal(t, tt.wantError, err)
+			if tt.wantError == nil {
+				assert.Equal(t, tt.algorithm, h.algorithm)
+			}
+		})
+	}
+}
+
+func TestHasherArgon2(t *testing.T) {
+	h, err := New(Config{Algorithm: ARGON2ID, Argon2: Argon2Params{Memory: 1024}})
+	assert.NoError(t, err)
+
+	hashed, err := h.HashedPassword("password")
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(hashed, "$argon2id$v=19$m=1024,t=1,p=4$"))
+
+	t.Run("Testcase #1: Positive", func(t *testing.T) {
+		err := h.VerifyPassword(hashed, "password")
+		assert.NoError(t, err)
+	})
+
+	t.Run("Testcase #2: Negative", func(t *testing.T) {
+		err := h.VerifyPassword(hashed, "invalidPassword")
+		assert.Equal(t, ErrMismatchedHashAndPassword, err)
+	})
+
+	t.Run("Testcase #3: Positive", func(t *testing.T) {
+		// existing bcrypt hashes keep working after the switch
+		err := h.VerifyPassword("$2a$10$d3.zWWlz0tAnXis7fAJulumr2JHT5YDoZ7OzY9yJcx1TmQhS7c4mO", "password")
+		assert.NoError(t, err)
+	})
+}
+
+func TestNeedsRehash(t *testing.T) {
+	bcryptHash := "$2a$10$d3.zWWlz0tAnXis7fAJulumr2JHT5YDoZ7OzY9yJcx1TmQhS7c4mO"
+	argon2Hash := "$argon2id$v=19$m=1024,t=1,p=4$c29tZXNhbHRzb21lc2FsdA$mRKbTv5o8AIcyB7SMvmO5tGk0D6oqXlhv7xyNm8L2hA"
+
+	testCase := []struct {
+		name   string
+		cfg    Config
+		hashed string
+		rehash bool
+	}{
+		{
+			name: "Testcase #1: Positive", cfg: Config{}, hashed: bcryptHash, rehash: false,
+		},
+		{
+			name: "Testcase #2: Positive", cfg: Config{BcryptCost: 12}, hashed: bcryptHash, rehash: true,
+		},
+		{
+			name: "Testcase #3: Positive", cfg: Config{}, hashed: argon2Hash, rehash: true,
+		},
+		{
+			name: "Testcase #4: Positive", cfg: Config{Algorithm: ARGON2ID}, hashed: bcryptHash, rehash: true,
+		},
+		{
+			name: "Testcase #5: Positive", cfg: Config{Algorithm: ARGON2ID, Argon2: Argon2Params{Memory: 1024}}, hashed: argon2Hash, rehash: false,
+		},
+		{
+			name: "Testcase #6: Positive", cfg: Config{Algorithm: ARGON2ID, Argon2: Argon2Params{Memory: 1024, Time: 2}}, hashed: argon2Hash, rehash: true,
+		},
+		{
+			name: "Testcase #7: Negative", cfg: Config{Algorithm: ARGON2ID}, hashed: "garbage", rehash: true,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			h, err := New(tt.cfg)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.rehash, h.NeedsRehash(tt.hashed))
+		})
+	}
 }
@@ -0,0 +1,41 @@
+package identity
+
+import (
+	"context"
+
+	"github.com/rzfhlv/gin-example/pkg/rbac"
+)
+
+type key struct{}
+
+// Identity is the authenticated caller, auth.Bearer puts it into the request
+// context so usecases can make ownership decisions without gin. A caller using
+// an API key acts as the key owner, limited to the key scopes its current
+// roles still grant, and an impersonated caller carries the ID of the actor
+// behind it.
+type Identity struct {
+	ID        int64
+	Username  string
+	Email     string
+	SessionID string
+	Roles     []string
+	APIKeyID  int64
+	Scopes    []string
+	ActorID   int64
+}
+
+func NewContext(ctx context.Context, identity Identity) context.Context {
+	return context.WithValue(ctx, key{}, identity)
+}
+
+func FromContext(ctx context.Context) (identity Identity, ok bool) {
+	identity, ok = ctx.Value(key{}).(Identity)
+	return
+}
+
+func (i Identity) Can(permission string) bool {
+	if i.APIKeyID != 0 {
+		return rbac.Scoped(i.Roles, i.Scopes, permission)
+	}
+	return rbac.Can(i.Roles, permission)
+}
@@ -0,0 +1,39 @@
+package identity
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rzfhlv/gin-example/pkg/rbac"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromContext(t *testing.T) {
+	t.Run("Testcase #1: Positive", func(t *testing.T) {
+		ctx := NewContext(context.Background(), Identity{ID: 1, Username: "johndoe", Roles: []string{rbac.MEMBER}})
+
+		identity, ok := FromContext(ctx)
+		assert.True(t, ok)
+		assert.Equal(t, int64(1), identity.ID)
+		assert.Equal(t, "johndoe", identity.Username)
+	})
+
+	t.Run("Testcase #2: Negative", func(t *testing.T) {
+		identity, ok := FromContext(context.Background())
+		assert.False(t, ok)
+		assert.Empty(t, identity)
+	})
+}
+
+func TestCan(t *testing.T) {
+	identity := Identity{Roles: []string{rbac.ORGANIZER}}
+	assert.True(t, identity.Can(rbac.GATHERINGSWRITE))
+	assert.False(t, identity.Can(rbac.GATHERINGSMANAGE))
+
+	key := Identity{Roles: []string{rbac.ADMIN}, APIKeyID: 1, Scopes: []string{rbac.GATHERINGSREAD}}
+	assert.True(t, key.Can(rbac.GATHERINGSREAD))
+	assert.False(t, key.Can(rbac.GATHERINGSWRITE))
+
+	demoted := Identity{Roles: []string{rbac.MEMBER}, APIKeyID: 1, Scopes: []string{rbac.GATHERINGSWRITE}}
+	assert.False(t, demoted.Can(rbac.GATHERINGSWRITE))
+}
@@ -1,42 +1,96 @@
 package jwt
 
 import (
-	"os"
-	"strconv"
+	"errors"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 )
 
-var jwtKey = []byte(os.Getenv("JWT_SECRET"))
+var ErrSigningKeyNotConfigured = errors.New("signing key not configured")
 
 type JWTClaim struct {
-	ID       int64  `json:"id"`
-	Username string `json:"username"`
-	Email    string `json:"email"`
+	ID            int64    `json:"id"`
+	Username      string   `json:"username"`
+	Email         string   `json:"email"`
+	Roles         []string `json:"roles"`
+	EmailVerified bool     `json:"email_verified"`
+	Actor         *Actor   `json:"act,omitempty"`
 	jwt.RegisteredClaims
 }
 
-func (j *JWTImpl) Generate(id int64, username, email string) (tokenString string, err error) {
-	exp, err := strconv.Atoi(os.Getenv("JWT_EXPIRED"))
-	if err != nil {
+// Actor is who really acts when a token is issued to impersonate its subject,
+// the act claim of RFC 8693.
+type Actor struct {
+	ID       int64  `json:"id"`
+	Username string `json:"sub"`
+}
+
+// ActorID is who really acts with the token, 0 unless it impersonates.
+func (c *JWTClaim) ActorID() int64 {
+	if c.Actor == nil {
+		return 0
+	}
+	return c.Actor.ID
+}
+
+// Payload is the identity signed into a token, SessionID becomes the jti. A
+// positive Expired shortens the lifetime of this token only.
+type Payload struct {
+	ID            int64
+	Username      string
+	Email         string
+	SessionID     string
+	Roles         []string
+	EmailVerified bool
+	Actor         *Actor
+	Expired       time.Duration
+}
+
+func (j *JWTImpl) Generate(payload Payload) (tokenString string, err error) {
+	if j.method == nil || j.expired <= 0 {
+		err = ErrSigningKeyNotConfigured
 		return
 	}
-	expirationTime := time.Now().Add(time.Duration(exp) * time.Hour)
+
+	expired := j.expired
+	if payload.Expired > 0 && payload.Expired < expired {
+		expired = payload.Expired
+	}
+
+	expirationTime := time.Now().Add(expired)
 	claims := &JWTClaim{
-		ID:       id,
-		Username: username,
-		Email:    email,
+		ID:            payload.ID,
+		Username:      payload.Username,
+		Email:         payload.Email,
+		Roles:         payload.Roles,
+		EmailVerified: payload.EmailVerified,
+		Actor:         payload.Actor,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(expirationTime),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			NotBefore: jwt.NewNumericDate(time.Now()),
-			Issuer:    os.Getenv("APP_NAME"),
-			Subject:   username,
-			ID:        strconv.FormatInt(id, 10),
+			Issuer:    j.issuer,
+			Subject:   payload.Username,
+			ID:        payload.SessionID,
 		},
 	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err = token.SignedString(jwtKey)
+	token := jwt.NewWithClaims(j.method, claims)
+
+	if j.keys == nil {
+		if len(j.secret) == 0 {
+			err = ErrSigningKeyNotConfigured
+			return
+		}
+		tokenString, err = token.SignedString(j.secret)
+		return
+	}
+
+	key, err := j.keys.Active()
+	if err != nil {
+		return
+	}
+	token.Header["kid"] = key.ID
+	tokenString, err = token.SignedString(key.Private)
 	return
 }
@@ -1,7 +1,6 @@
 package jwt
 
 import (
-	"os"
 	"testing"
 	"time"
 
@@ -9,33 +8,42 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+var (
+	secret    = []byte("verysecret")
+	id        = int64(123)
+	username  = "testuser"
+	email     = "test@example.com"
+	sessionID = "session"
+	roles     = []string{"admin"}
+	payload   = Payload{ID: id, Username: username, Email: email, SessionID: sessionID, Roles: roles, EmailVerified: true}
+)
+
 func TestGenerateFail(t *testing.T) {
-	id := int64(123)
-	username := "testuser"
-	email := "test@example.com"
+	t.Run("Testcase #1: Negative", func(t *testing.T) {
+		jwtImpl := JWTImpl{}
+
+		_, err := jwtImpl.Generate(payload)
+		assert.Equal(t, ErrSigningKeyNotConfigured, err)
+	})
 
-	jwtImpl := JWTImpl{}
+	t.Run("Testcase #2: Negative", func(t *testing.T) {
+		jwtImpl, _ := New(Config{Expired: time.Hour})
 
-	_, err := jwtImpl.Generate(id, username, email)
-	assert.Error(t, err)
+		_, err := jwtImpl.Generate(payload)
+		assert.Equal(t, ErrSigningKeyNotConfigured, err)
+	})
 }
 
 func TestGenerate(t *testing.T) {
-	os.Setenv("JWT_SECRET", "verysecret")
-	os.Setenv("JWT_EXPIRED", "1")
-
-	id := int64(123)
-	username := "testuser"
-	email := "test@example.com"
-
-	jwtImpl := JWTImpl{}
+	jwtImpl, err := New(Config{Secret: secret, Expired: time.Hour, Issuer: "gin-example"})
+	assert.NoError(t, err)
 
-	tokenString, err := jwtImpl.Generate(id, username, email)
+	tokenString, err := jwtImpl.Generate(payload)
 	assert.NoError(t, err)
 	assert.NotEmpty(t, tokenString)
 
 	token, err := jwt.ParseWithClaims(tokenString, &JWTClaim{}, func(token *jwt.Token) (interface{}, error) {
-		return jwtKey, nil
+		return secret, nil
 	})
 	assert.NoError(t, err)
 	claims, ok := token.Claims.(*JWTClaim)
@@ -43,5 +51,70 @@ func TestGenerate(t *testing.T) {
 	assert.Equal(t, id, claims.ID)
 	assert.Equal(t, username, claims.Username)
 	assert.Equal(t, email, claims.Email)
+	assert.Equal(t, roles, claims.Roles)
+	assert.True(t, claims.EmailVerified)
+	assert.Equal(t, sessionID, claims.RegisteredClaims.ID)
+	assert.Equal(t, "gin-example", claims.Issuer)
 	assert.True(t, claims.ExpiresAt.Unix() > time.Now().Unix())
+	assert.Nil(t, claims.Actor)
+}
+
+func TestGenerateActor(t *testing.T) {
+	jwtImpl, err := New(Config{Secret: secret, Expired: time.Hour, Issuer: "gin-example"})
+	assert.NoError(t, err)
+
+	impersonated := payload
+	impersonated.Actor = &Actor{ID: 9, Username: "support"}
+	impersonated.Expired = 15 * time.Minute
+
+	tokenString, err := jwtImpl.Generate(impersonated)
+	assert.NoError(t, err)
+
+	claims, err := jwtImpl.ValidateToken(tokenString)
+	assert.NoError(t, err)
+	assert.Equal(t, &Actor{ID: 9, Username: "support"}, claims.Actor)
+	assert.Equal(t, username, claims.Subject)
+	assert.WithinDuration(t, time.Now().Add(15*time.Minute), claims.ExpiresAt.Time, time.Minute)
+
+	// a payload can only shorten the configured lifetime
+	impersonated.Expired = 2 * time.Hour
+	tokenString, err = jwtImpl.Generate(impersonated)
+	assert.NoError(t, err)
+
+	claims, err = jwtImpl.ValidateToken(tokenString)
+	assert.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), claims.ExpiresAt.Time, time.Minute)
+}
+
+func TestGenerateAsymmetric(t *testing.T) {
+	testCase := []struct {
+		name, algorithm string
+		key             *Key
+	}{
+		{
+			name: "Testcase #1: Positive", algorithm: RS256, key: rsaKey(t, "rsa-1"),
+		},
+		{
+			name: "Testcase #2: Positive", algorithm: EDDSA, key: ed25519Key(t, "ed-1"),
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			keys := NewKeySet(time.Hour)
+			keys.Add(tt.key, true)
+
+			jwtImpl, err := NewWithKeySet(tt.algorithm, keys, time.Hour, "gin-example")
+			assert.NoError(t, err)
+
+			tokenString, err := jwtImpl.Generate(payload)
+			assert.NoError(t, err)
+
+			token, err := jwt.ParseWithClaims(tokenString, &JWTClaim{}, func(token *jwt.Token) (interface{}, error) {
+				return tt.key.Public, nil
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.algorithm, token.Method.Alg())
+			assert.Equal(t, tt.key.ID, token.Header["kid"])
+		})
+	}
 }
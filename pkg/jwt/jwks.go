@@ -0,0 +1,91 @@
+package jwt
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
+)
+
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+// JWKS publishes the public keys that currently verify tokens, symmetric
+// secrets are never published.
+func (j *JWTImpl) JWKS() (jwks JWKS) {
+	jwks.Keys = []JWK{}
+	if j.keys == nil {
+		return
+	}
+
+	for _, key := range j.keys.Keys() {
+		jwk := JWK{
+			Kid: key.ID,
+			Use: "sig",
+			Alg: algorithmOf(key),
+		}
+
+		switch public := key.Public.(type) {
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.Kty = "OKP"
+			jwk.Crv = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(public)
+		default:
+			continue
+		}
+		jwks.Keys = append(jwks.Keys, jwk)
+	}
+	return
+}
+
+// PublicKey is the inverse of JWKS, it reads a published key back so tokens
+// signed by another issuer can be verified.
+func (k JWK) PublicKey() (public interface{}, err error) {
+	switch k.Kty {
+	case "RSA":
+		var n, e []byte
+		n, err = base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return
+		}
+		e, err = base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return
+		}
+		if len(n) == 0 || len(e) == 0 || len(e) > 4 {
+			err = ErrUnsupportedKey
+			return
+		}
+		public = &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+	case "OKP":
+		var x []byte
+		x, err = base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return
+		}
+		if k.Crv != "Ed25519" || len(x) != ed25519.PublicKeySize {
+			err = ErrUnsupportedKey
+			return
+		}
+		public = ed25519.PublicKey(x)
+	default:
+		err = ErrUnsupportedKey
+	}
+	return
+}
@@ -0,0 +1,83 @@
+package jwt
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJWKS(t *testing.T) {
+	t.Run("Testcase #1: Positive", func(t *testing.T) {
+		keys := NewKeySet(time.Hour)
+		keys.Add(rsaKey(t, "rsa-1"), true)
+		keys.Add(ed25519Key(t, "ed-1"), false)
+		jwtImpl, _ := NewWithKeySet(RS256, keys, time.Hour, "gin-example")
+
+		jwks := jwtImpl.JWKS()
+		assert.Len(t, jwks.Keys, 2)
+		assert.Equal(t, JWK{Kty: "OKP", Kid: "ed-1", Use: "sig", Alg: EDDSA, Crv: "Ed25519", X: jwks.Keys[0].X}, jwks.Keys[0])
+		assert.Equal(t, "RSA", jwks.Keys[1].Kty)
+		assert.Equal(t, "rsa-1", jwks.Keys[1].Kid)
+		assert.Equal(t, RS256, jwks.Keys[1].Alg)
+		assert.Equal(t, "AQAB", jwks.Keys[1].E)
+		assert.NotEmpty(t, jwks.Keys[1].N)
+	})
+
+	t.Run("Testcase #2: Positive", func(t *testing.T) {
+		jwtImpl, _ := New(Config{Secret: secret, Expired: time.Hour})
+
+		jwks := jwtImpl.JWKS()
+		assert.Empty(t, jwks.Keys)
+		assert.NotNil(t, jwks.Keys)
+	})
+}
+
+func TestJWKPublicKey(t *testing.T) {
+	keys := NewKeySet(time.Hour)
+	rsa := rsaKey(t, "rsa-1")
+	ed := ed25519Key(t, "ed-1")
+	keys.Add(rsa, true)
+	keys.Add(ed, false)
+	jwtImpl, _ := NewWithKeySet(RS256, keys, time.Hour, "gin-example")
+
+	for _, jwk := range jwtImpl.JWKS().Keys {
+		public, err := jwk.PublicKey()
+		assert.NoError(t, err)
+		if jwk.Kid == "rsa-1" {
+			assert.Equal(t, rsa.Public, public)
+		} else {
+			assert.Equal(t, ed.Public, public)
+		}
+	}
+
+	testCase := []struct {
+		name string
+		jwk  JWK
+	}{
+		{
+			name: "Testcase #1: Negative", jwk: JWK{Kty: "EC"},
+		},
+		{
+			name: "Testcase #2: Negative", jwk: JWK{Kty: "RSA", N: "!!", E: "AQAB"},
+		},
+		{
+			name: "Testcase #3: Negative", jwk: JWK{Kty: "RSA", N: "", E: "AQAB"},
+		},
+		{
+			name: "Testcase #4: Negative", jwk: JWK{Kty: "OKP", Crv: "X25519", X: "AQAB"},
+		},
+		{
+			name: "Testcase #5: Negative", jwk: JWK{Kty: "OKP", Crv: "Ed25519", X: "!!"},
+		},
+		{
+			name: "Testcase #6: Negative", jwk: JWK{Kty: "RSA", N: "AQAB", E: "!!"},
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.jwk.PublicKey()
+			assert.Error(t, err)
+		})
+	}
+}
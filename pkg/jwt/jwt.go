@@ -1,8 +1,95 @@
 package jwt
 
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var (
+	HS256 = "HS256"
+	RS256 = "RS256"
+	EDDSA = "EdDSA"
+
+	ErrUnsupportedAlgorithm = errors.New("unsupported signing algorithm")
+)
+
 type JWTInterface interface {
-	Generate(id int64, username, email string) (tokenString string, err error)
+	Generate(payload Payload) (tokenString string, err error)
 	ValidateToken(signedToken string) (claims *JWTClaim, err error)
+	JWKS() (jwks JWKS)
+}
+
+type Config struct {
+	Algorithm string
+	Secret    []byte
+	KeysDir   string
+	ActiveKID string
+	Expired   time.Duration
+	Issuer    string
+}
+
+type JWTImpl struct {
+	method  jwt.SigningMethod
+	secret  []byte
+	keys    *KeySet
+	expired time.Duration
+	issuer  string
 }
 
-type JWTImpl struct{}
+func New(cfg Config) (jwtImpl *JWTImpl, err error) {
+	if cfg.Algorithm == HS256 || cfg.Algorithm == "" {
+		jwtImpl = &JWTImpl{
+			method:  jwt.SigningMethodHS256,
+			secret:  cfg.Secret,
+			expired: cfg.Expired,
+			issuer:  cfg.Issuer,
+		}
+		return
+	}
+
+	keys, err := LoadKeySet(cfg.KeysDir, cfg.ActiveKID, cfg.Expired)
+	if err != nil {
+		return
+	}
+
+	jwtImpl, err = NewWithKeySet(cfg.Algorithm, keys, cfg.Expired, cfg.Issuer)
+	return
+}
+
+func NewWithKeySet(algorithm string, keys *KeySet, expired time.Duration, issuer string) (jwtImpl *JWTImpl, err error) {
+	active, err := keys.Active()
+	if err != nil {
+		return
+	}
+
+	if algorithmOf(active) != algorithm {
+		err = ErrUnsupportedAlgorithm
+		return
+	}
+
+	jwtImpl = &JWTImpl{
+		method:  jwt.GetSigningMethod(algorithm),
+		keys:    keys,
+		expired: expired,
+		issuer:  issuer,
+	}
+	return
+}
+
+func (j *JWTImpl) KeySet() *KeySet {
+	return j.keys
+}
+
+func algorithmOf(key *Key) string {
+	switch key.Public.(type) {
+	case *rsa.PublicKey:
+		return RS256
+	case ed25519.PublicKey:
+		return EDDSA
+	}
+	return ""
+}
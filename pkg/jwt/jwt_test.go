@@ -0,0 +1,80 @@
+package jwt
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func rsaKey(t *testing.T, kid string) *Key {
+	private, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	return &Key{ID: kid, Private: private, Public: private.Public()}
+}
+
+func ed25519Key(t *testing.T, kid string) *Key {
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	return &Key{ID: kid, Private: private, Public: public}
+}
+
+func writeKey(t *testing.T, dir string, key *Key, public bool) {
+	block := &pem.Block{}
+	var err error
+	if public {
+		block.Type = "PUBLIC KEY"
+		block.Bytes, err = x509.MarshalPKIXPublicKey(key.Public)
+	} else {
+		block.Type = "PRIVATE KEY"
+		block.Bytes, err = x509.MarshalPKCS8PrivateKey(key.Private)
+	}
+	assert.NoError(t, err)
+
+	err = os.WriteFile(filepath.Join(dir, key.ID+PEMEXTENSION), pem.EncodeToMemory(block), 0600)
+	assert.NoError(t, err)
+}
+
+func TestNew(t *testing.T) {
+	dir := t.TempDir()
+	writeKey(t, dir, ed25519Key(t, "ed-2"), false)
+	writeKey(t, dir, ed25519Key(t, "ed-1"), true)
+
+	testCase := []struct {
+		name    string
+		cfg     Config
+		wantErr error
+	}{
+		{
+			name: "Testcase #1: Positive", cfg: Config{Algorithm: HS256, Secret: secret, Expired: time.Hour}, wantErr: nil,
+		},
+		{
+			name: "Testcase #2: Positive", cfg: Config{Algorithm: EDDSA, KeysDir: dir, ActiveKID: "ed-2", Expired: time.Hour}, wantErr: nil,
+		},
+		{
+			name: "Testcase #3: Negative", cfg: Config{Algorithm: RS256, KeysDir: dir, ActiveKID: "ed-2", Expired: time.Hour}, wantErr: ErrUnsupportedAlgorithm,
+		},
+		{
+			name: "Testcase #4: Negative", cfg: Config{Algorithm: EDDSA, KeysDir: dir, ActiveKID: "ed-1", Expired: time.Hour}, wantErr: ErrKeyNotFound,
+		},
+		{
+			name: "Testcase #5: Negative", cfg: Config{Algorithm: "none", KeysDir: dir, ActiveKID: "ed-2", Expired: time.Hour}, wantErr: ErrUnsupportedAlgorithm,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			jwtImpl, err := New(tt.cfg)
+			assert.Equal(t, tt.wantErr, err)
+			if tt.wantErr == nil {
+				assert.NotNil(t, jwtImpl)
+			}
+		})
+	}
+}
@@ -0,0 +1,229 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	PEMEXTENSION  = ".pem"
+	RETIREDHEADER = "Retired-At"
+
+	ErrUnsupportedKey = errors.New("unsupported key type")
+	ErrKeyNotFound    = errors.New("signing key not found")
+)
+
+type Key struct {
+	ID        string
+	Private   crypto.Signer
+	Public    crypto.PublicKey
+	RetiredAt time.Time
+}
+
+// KeySet holds the active signing key and the retired keys that still verify
+// tokens, a retired key is dropped once every token it signed has expired.
+type KeySet struct {
+	mu      sync.RWMutex
+	active  string
+	keys    map[string]*Key
+	expired time.Duration
+}
+
+func NewKeySet(expired time.Duration) *KeySet {
+	return &KeySet{
+		keys:    map[string]*Key{},
+		expired: expired,
+	}
+}
+
+// LoadKeySet reads every PEM file in dir using the file name as kid, the key
+// named activeKID signs new tokens and the others are retired. A retired key
+// keeps the time in its Retired-At PEM header, or else the modification time
+// of its file, so a restart never extends how long it verifies tokens.
+func LoadKeySet(dir, activeKID string, expired time.Duration) (keySet *KeySet, err error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*"+PEMEXTENSION))
+	if err != nil {
+		return
+	}
+	sort.Strings(files)
+
+	keySet = NewKeySet(expired)
+	for _, file := range files {
+		kid := strings.TrimSuffix(filepath.Base(file), PEMEXTENSION)
+
+		var data []byte
+		data, err = os.ReadFile(file)
+		if err != nil {
+			return
+		}
+
+		var key *Key
+		key, err = ParseKey(kid, data)
+		if err != nil {
+			err = fmt.Errorf("%s: %w", file, err)
+			return
+		}
+
+		switch {
+		case kid == activeKID:
+			key.RetiredAt = time.Time{}
+		case key.RetiredAt.IsZero():
+			var info os.FileInfo
+			info, err = os.Stat(file)
+			if err != nil {
+				return
+			}
+			key.RetiredAt = info.ModTime()
+		}
+		keySet.keys[kid] = key
+	}
+
+	active, ok := keySet.keys[activeKID]
+	if !ok || active.Private == nil {
+		err = ErrKeyNotFound
+		return
+	}
+	keySet.active = activeKID
+	return
+}
+
+// ParseKey accepts a PKCS#1 or PKCS#8 private key, or a PKIX public key for
+// retired keys whose private half was already destroyed.
+func ParseKey(kid string, data []byte) (key *Key, err error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		err = errors.New("invalid pem")
+		return
+	}
+
+	key = &Key{ID: kid}
+	if retiredAt, ok := block.Headers[RETIREDHEADER]; ok {
+		key.RetiredAt, err = time.Parse(time.RFC3339, retiredAt)
+		if err != nil {
+			return
+		}
+	}
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key.Private, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		var parsed interface{}
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return
+		}
+		signer, ok := parsed.(crypto.Signer)
+		if !ok {
+			err = ErrUnsupportedKey
+			return
+		}
+		key.Private = signer
+	case "PUBLIC KEY":
+		key.Public, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		err = ErrUnsupportedKey
+	}
+	if err != nil {
+		return
+	}
+
+	if key.Private != nil {
+		key.Public = key.Private.Public()
+	}
+
+	switch key.Public.(type) {
+	case *rsa.PublicKey, ed25519.PublicKey:
+	default:
+		err = ErrUnsupportedKey
+	}
+	return
+}
+
+func (k *KeySet) Add(key *Key, active bool) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	k.keys[key.ID] = key
+	if active {
+		k.active = key.ID
+	}
+}
+
+// Rotate makes key the active signing key and retires the previous one.
+func (k *KeySet) Rotate(key *Key) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if previous, ok := k.keys[k.active]; ok {
+		previous.RetiredAt = time.Now()
+	}
+	k.keys[key.ID] = key
+	k.active = key.ID
+}
+
+func (k *KeySet) Active() (key *Key, err error) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	key, ok := k.keys[k.active]
+	if !ok || key.Private == nil {
+		err = ErrKeyNotFound
+	}
+	return
+}
+
+func (k *KeySet) Get(kid string) (key *Key, err error) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	key, ok := k.keys[kid]
+	if !ok || !k.valid(key, time.Now()) {
+		key = nil
+		err = ErrKeyNotFound
+	}
+	return
+}
+
+func (k *KeySet) Keys() (keys []*Key) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	now := time.Now()
+	for _, key := range k.keys {
+		if k.valid(key, now) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].ID < keys[j].ID
+	})
+	return
+}
+
+// Prune forgets retired keys that can no longer verify any token.
+func (k *KeySet) Prune() {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	now := time.Now()
+	for kid, key := range k.keys {
+		if !k.valid(key, now) {
+			delete(k.keys, kid)
+		}
+	}
+}
+
+func (k *KeySet) valid(key *Key, now time.Time) bool {
+	return key.RetiredAt.IsZero() || now.Before(key.RetiredAt.Add(k.expired))
+}
@@ -0,0 +1,148 @@
+package jwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadKeySet(t *testing.T) {
+	t.Run("Testcase #1: Positive", func(t *testing.T) {
+		dir := t.TempDir()
+		writeKey(t, dir, rsaKey(t, "rsa-1"), true)
+		writeKey(t, dir, rsaKey(t, "rsa-2"), false)
+
+		keys, err := LoadKeySet(dir, "rsa-2", time.Hour)
+		assert.NoError(t, err)
+
+		active, err := keys.Active()
+		assert.NoError(t, err)
+		assert.Equal(t, "rsa-2", active.ID)
+
+		retired, err := keys.Get("rsa-1")
+		assert.NoError(t, err)
+		assert.Nil(t, retired.Private)
+		assert.False(t, retired.RetiredAt.IsZero())
+	})
+
+	t.Run("Testcase #2: Positive", func(t *testing.T) {
+		dir := t.TempDir()
+		writeKey(t, dir, rsaKey(t, "rsa-1"), true)
+		writeKey(t, dir, rsaKey(t, "rsa-2"), false)
+
+		modified := time.Now().Add(-30 * time.Minute).Truncate(time.Second)
+		err := os.Chtimes(filepath.Join(dir, "rsa-1"+PEMEXTENSION), modified, modified)
+		assert.NoError(t, err)
+
+		keys, err := LoadKeySet(dir, "rsa-2", time.Hour)
+		assert.NoError(t, err)
+
+		retired, err := keys.Get("rsa-1")
+		assert.NoError(t, err)
+		assert.True(t, modified.Equal(retired.RetiredAt))
+	})
+
+	t.Run("Testcase #3: Positive", func(t *testing.T) {
+		dir := t.TempDir()
+		old := rsaKey(t, "rsa-1")
+		bytes, err := x509.MarshalPKIXPublicKey(old.Public)
+		assert.NoError(t, err)
+		block := &pem.Block{
+			Type:    "PUBLIC KEY",
+			Headers: map[string]string{RETIREDHEADER: time.Now().Add(-2 * time.Hour).Format(time.RFC3339)},
+			Bytes:   bytes,
+		}
+		err = os.WriteFile(filepath.Join(dir, "rsa-1"+PEMEXTENSION), pem.EncodeToMemory(block), 0600)
+		assert.NoError(t, err)
+		writeKey(t, dir, rsaKey(t, "rsa-2"), false)
+
+		keys, err := LoadKeySet(dir, "rsa-2", time.Hour)
+		assert.NoError(t, err)
+
+		_, err = keys.Get("rsa-1")
+		assert.Equal(t, ErrKeyNotFound, err)
+	})
+
+	t.Run("Testcase #4: Negative", func(t *testing.T) {
+		dir := t.TempDir()
+		writeKey(t, dir, rsaKey(t, "rsa-1"), false)
+
+		_, err := LoadKeySet(dir, "rsa-2", time.Hour)
+		assert.Equal(t, ErrKeyNotFound, err)
+	})
+
+	t.Run("Testcase #5: Negative", func(t *testing.T) {
+		dir := t.TempDir()
+		err := os.WriteFile(filepath.Join(dir, "broken"+PEMEXTENSION), []byte("broken"), 0600)
+		assert.NoError(t, err)
+
+		_, err = LoadKeySet(dir, "broken", time.Hour)
+		assert.Error(t, err)
+	})
+}
+
+func TestParseKey(t *testing.T) {
+	private := rsaKey(t, "rsa-1").Private.(*rsa.PrivateKey)
+	ecdsaPrivate, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	ecdsaBytes, err := x509.MarshalPKCS8PrivateKey(ecdsaPrivate)
+	assert.NoError(t, err)
+
+	testCase := []struct {
+		name    string
+		data    []byte
+		wantErr error
+	}{
+		{
+			name: "Testcase #1: Positive", data: pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(private)}), wantErr: nil,
+		},
+		{
+			name: "Testcase #2: Negative", data: pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: ecdsaBytes}), wantErr: ErrUnsupportedKey,
+		},
+		{
+			name: "Testcase #3: Negative", data: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("cert")}), wantErr: ErrUnsupportedKey,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := ParseKey("kid", tt.data)
+			assert.Equal(t, tt.wantErr, err)
+			if tt.wantErr == nil {
+				assert.Equal(t, "kid", key.ID)
+				assert.NotNil(t, key.Public)
+			}
+		})
+	}
+}
+
+func TestKeySetRotate(t *testing.T) {
+	first := ed25519Key(t, "ed-1")
+	second := ed25519Key(t, "ed-2")
+
+	keys := NewKeySet(time.Hour)
+	keys.Add(first, true)
+	keys.Rotate(second)
+
+	active, err := keys.Active()
+	assert.NoError(t, err)
+	assert.Equal(t, "ed-2", active.ID)
+	assert.False(t, first.RetiredAt.IsZero())
+	assert.Len(t, keys.Keys(), 2)
+
+	first.RetiredAt = time.Now().Add(-2 * time.Hour)
+	assert.Len(t, keys.Keys(), 1)
+
+	keys.Prune()
+	_, err = keys.Get("ed-1")
+	assert.Equal(t, ErrKeyNotFound, err)
+	assert.Len(t, keys.keys, 1)
+}
@@ -7,20 +7,48 @@ import (
 )
 
 func (j *JWTImpl) ValidateToken(signedToken string) (claims *JWTClaim, err error) {
+	if j.method == nil {
+		err = ErrSigningKeyNotConfigured
+		return
+	}
+
+	// a key set verifies each token with the algorithm of the key named by its
+	// kid, so tokens signed before a switch from RS256 to EdDSA stay valid
+	methods := []string{j.method.Alg()}
+	if j.keys != nil {
+		methods = []string{RS256, EDDSA}
+	}
+
 	token, err := jwt.ParseWithClaims(
 		signedToken,
 		&JWTClaim{},
-		func(t *jwt.Token) (interface{}, error) {
-			return []byte(jwtKey), nil
-		},
+		j.keyFunc,
+		jwt.WithValidMethods(methods),
 	)
 	if err != nil {
 		return
 	}
 	claims, ok := token.Claims.(*JWTClaim)
-	if !ok && !token.Valid {
+	if !ok || !token.Valid {
+		claims = nil
 		err = errors.New("couldn't parse claims")
 		return
 	}
 	return
 }
+
+func (j *JWTImpl) keyFunc(t *jwt.Token) (interface{}, error) {
+	if j.keys == nil {
+		return j.secret, nil
+	}
+
+	kid, _ := t.Header["kid"].(string)
+	key, err := j.keys.Get(kid)
+	if err != nil {
+		return nil, err
+	}
+	if t.Method.Alg() != algorithmOf(key) {
+		return nil, ErrUnsupportedAlgorithm
+	}
+	return key.Public, nil
+}
@@ -1,37 +1,26 @@
 package jwt
 
 import (
-	"os"
 	"testing"
+	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestValidateTokenExp(t *testing.T) {
-	os.Setenv("JWT_SECRET", "verysecret")
-	os.Setenv("JWT_EXPIRED", "1")
+	jwtImpl, _ := New(Config{Secret: secret, Expired: time.Nanosecond})
+	expiredToken, _ := jwtImpl.Generate(payload)
+	time.Sleep(time.Second)
 
-	jwtImpl := JWTImpl{}
-	validToken, _ := jwtImpl.Generate(123, "testuser", "test@example.com")
-
-	token, err := jwt.ParseWithClaims(
-		validToken,
-		&JWTClaim{},
-		func(t *jwt.Token) (interface{}, error) {
-			return []byte(jwtKey), nil
-		},
-	)
-	t.Logf("%+v", token.Claims)
-	t.Logf("%+v", err)
+	claims, err := jwtImpl.ValidateToken(expiredToken)
+	assert.ErrorIs(t, err, jwt.ErrTokenExpired)
+	assert.Nil(t, claims)
 }
 
 func TestValidateTokenValid(t *testing.T) {
-	os.Setenv("JWT_SECRET", "verysecret")
-	os.Setenv("JWT_EXPIRED", "1")
-
-	jwtImpl := JWTImpl{}
-	validToken, _ := jwtImpl.Generate(123, "testuser", "test@example.com")
+	jwtImpl, _ := New(Config{Secret: secret, Expired: time.Hour})
+	validToken, _ := jwtImpl.Generate(payload)
 
 	claims, err := jwtImpl.ValidateToken(validToken)
 	assert.NoError(t, err)
@@ -39,15 +28,102 @@ func TestValidateTokenValid(t *testing.T) {
 	assert.Equal(t, int64(123), claims.ID)
 	assert.Equal(t, "testuser", claims.Username)
 	assert.Equal(t, "test@example.com", claims.Email)
+	assert.Equal(t, []string{"admin"}, claims.Roles)
 }
 
 func TestValidateTokenInvalid(t *testing.T) {
 	invalidToken := "invalidtoken"
 
-	jwtImpl := JWTImpl{}
+	jwtImpl, _ := New(Config{Secret: secret, Expired: time.Hour})
 	claims, err := jwtImpl.ValidateToken(invalidToken)
 	assert.Error(t, err)
-	assert.Error(t, err)
 	assert.Nil(t, claims)
 	assert.Equal(t, "token is malformed: token contains an invalid number of segments", err.Error())
 }
+
+func TestValidateTokenNotConfigured(t *testing.T) {
+	jwtImpl := JWTImpl{}
+	claims, err := jwtImpl.ValidateToken("invalidtoken")
+	assert.Equal(t, ErrSigningKeyNotConfigured, err)
+	assert.Nil(t, claims)
+}
+
+func TestValidateTokenRotation(t *testing.T) {
+	first := rsaKey(t, "rsa-1")
+	second := rsaKey(t, "rsa-2")
+
+	keys := NewKeySet(time.Hour)
+	keys.Add(first, true)
+	jwtImpl, _ := NewWithKeySet(RS256, keys, time.Hour, "gin-example")
+
+	oldToken, _ := jwtImpl.Generate(payload)
+
+	keys.Rotate(second)
+	newToken, _ := jwtImpl.Generate(payload)
+
+	t.Run("Testcase #1: Positive", func(t *testing.T) {
+		claims, err := jwtImpl.ValidateToken(oldToken)
+		assert.NoError(t, err)
+		assert.NotNil(t, claims)
+	})
+
+	t.Run("Testcase #2: Positive", func(t *testing.T) {
+		claims, err := jwtImpl.ValidateToken(newToken)
+		assert.NoError(t, err)
+		assert.NotNil(t, claims)
+	})
+
+	t.Run("Testcase #3: Negative", func(t *testing.T) {
+		first.RetiredAt = time.Now().Add(-2 * time.Hour)
+
+		claims, err := jwtImpl.ValidateToken(oldToken)
+		assert.ErrorIs(t, err, ErrKeyNotFound)
+		assert.Nil(t, claims)
+	})
+
+	t.Run("Testcase #4: Negative", func(t *testing.T) {
+		hmacImpl, _ := New(Config{Secret: secret, Expired: time.Hour})
+		hmacToken, _ := hmacImpl.Generate(payload)
+
+		claims, err := jwtImpl.ValidateToken(hmacToken)
+		assert.ErrorIs(t, err, jwt.ErrTokenSignatureInvalid)
+		assert.Nil(t, claims)
+	})
+}
+
+func TestValidateTokenAlgorithm(t *testing.T) {
+	first := rsaKey(t, "rsa-1")
+	second := ed25519Key(t, "ed-1")
+
+	keys := NewKeySet(time.Hour)
+	keys.Add(first, true)
+	rsaImpl, _ := NewWithKeySet(RS256, keys, time.Hour, "gin-example")
+	oldToken, _ := rsaImpl.Generate(payload)
+
+	keys.Rotate(second)
+	jwtImpl, _ := NewWithKeySet(EDDSA, keys, time.Hour, "gin-example")
+	newToken, _ := jwtImpl.Generate(payload)
+
+	t.Run("Testcase #1: Positive", func(t *testing.T) {
+		claims, err := jwtImpl.ValidateToken(oldToken)
+		assert.NoError(t, err)
+		assert.NotNil(t, claims)
+	})
+
+	t.Run("Testcase #2: Positive", func(t *testing.T) {
+		claims, err := jwtImpl.ValidateToken(newToken)
+		assert.NoError(t, err)
+		assert.NotNil(t, claims)
+	})
+
+	t.Run("Testcase #3: Negative", func(t *testing.T) {
+		token := jwt.NewWithClaims(jwt.SigningMethodEdDSA, &JWTClaim{ID: 123})
+		token.Header["kid"] = first.ID
+		forged, err := token.SignedString(second.Private)
+		assert.NoError(t, err)
+
+		claims, err := jwtImpl.ValidateToken(forged)
+		assert.ErrorIs(t, err, ErrUnsupportedAlgorithm)
+		assert.Nil(t, claims)
+	})
+}
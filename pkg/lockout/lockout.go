@@ -0,0 +1,143 @@
+package lockout
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+var (
+	FAILKEY  = "%s:fail:%s"
+	BLOCKKEY = "%s:block:%s"
+
+	LOCKED    = "locked"
+	THROTTLED = "throttled"
+)
+
+// Policy tunes a limiter. Failures inside Window add up, from BackoffAfter on
+// every failure blocks the key for BackoffBase doubled per extra failure up to
+// BackoffMax, and LockAfter failures lock the key for LockFor. A zero
+// LockAfter never locks.
+type Policy struct {
+	Window       time.Duration
+	BackoffAfter int
+	BackoffBase  time.Duration
+	BackoffMax   time.Duration
+	LockAfter    int
+	LockFor      time.Duration
+}
+
+// Error tells the caller how long the key stays blocked, Locked separates a
+// lockout from a backoff.
+type Error struct {
+	Locked     bool
+	RetryAfter time.Duration
+}
+
+func (e *Error) Error() string {
+	if e.Locked {
+		return fmt.Sprintf("locked, retry after %v", e.RetryAfter)
+	}
+	return fmt.Sprintf("too many attempts, retry after %v", e.RetryAfter)
+}
+
+type Limiter interface {
+	Check(ctx context.Context, key string) (err error)
+	Fail(ctx context.Context, key string) (err error)
+	Reset(ctx context.Context, key string) (err error)
+}
+
+type RedisLimiter struct {
+	redis  *redis.Client
+	prefix string
+	policy Policy
+}
+
+func New(redis *redis.Client, prefix string, policy Policy) Limiter {
+	return &RedisLimiter{
+		redis:  redis,
+		prefix: prefix,
+		policy: policy,
+	}
+}
+
+// Check returns an *Error while the key is blocked.
+func (l *RedisLimiter) Check(ctx context.Context, key string) (err error) {
+	blockKey := fmt.Sprintf(BLOCKKEY, l.prefix, key)
+	reason, err := l.redis.Get(ctx, blockKey).Result()
+	if err != nil {
+		if err == redis.Nil {
+			err = nil
+		}
+		return
+	}
+
+	ttl, err := l.redis.PTTL(ctx, blockKey).Result()
+	if err != nil {
+		return
+	}
+	if ttl <= 0 {
+		return
+	}
+
+	err = &Error{Locked: reason == LOCKED, RetryAfter: ttl}
+	return
+}
+
+// Fail records a failed attempt, it returns an *Error only when this failure
+// locks the key, a backoff only applies to the next attempt.
+func (l *RedisLimiter) Fail(ctx context.Context, key string) (err error) {
+	failKey := fmt.Sprintf(FAILKEY, l.prefix, key)
+	blockKey := fmt.Sprintf(BLOCKKEY, l.prefix, key)
+
+	failures, err := l.redis.Incr(ctx, failKey).Result()
+	if err != nil {
+		return
+	}
+
+	err = l.redis.Expire(ctx, failKey, l.policy.Window).Err()
+	if err != nil {
+		return
+	}
+
+	if l.policy.LockAfter > 0 && failures >= int64(l.policy.LockAfter) {
+		err = l.redis.Set(ctx, blockKey, LOCKED, l.policy.LockFor).Err()
+		if err != nil {
+			return
+		}
+
+		// the count starts over once the lock runs out
+		err = l.redis.Del(ctx, failKey).Err()
+		if err != nil {
+			return
+		}
+
+		err = &Error{Locked: true, RetryAfter: l.policy.LockFor}
+		return
+	}
+
+	if l.policy.BackoffAfter < 1 || failures < int64(l.policy.BackoffAfter) {
+		return
+	}
+
+	err = l.redis.Set(ctx, blockKey, THROTTLED, l.backoff(failures)).Err()
+	return
+}
+
+func (l *RedisLimiter) Reset(ctx context.Context, key string) (err error) {
+	err = l.redis.Del(ctx, fmt.Sprintf(FAILKEY, l.prefix, key), fmt.Sprintf(BLOCKKEY, l.prefix, key)).Err()
+	return
+}
+
+func (l *RedisLimiter) backoff(failures int64) (delay time.Duration) {
+	delay = l.policy.BackoffBase
+	for i := int64(l.policy.BackoffAfter); i < failures && delay < l.policy.BackoffMax; i++ {
+		delay *= 2
+	}
+	if delay > l.policy.BackoffMax {
+		delay = l.policy.BackoffMax
+	}
+	return
+}
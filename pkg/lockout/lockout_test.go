@@ -0,0 +1,230 @@
+package lockout
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redismock/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	ctx    = context.Background()
+	errFoo = errors.New("error")
+	policy = Policy{
+		Window: 15 * time.Minute, BackoffAfter: 3, BackoffBase: time.Second, BackoffMax: 8 * time.Second,
+		LockAfter: 5, LockFor: 15 * time.Minute,
+	}
+	failKey  = "login:user:fail:johndoe"
+	blockKey = "login:user:block:johndoe"
+)
+
+func TestNew(t *testing.T) {
+	client, _ := redismock.NewClientMock()
+
+	l := New(client, "login:user", policy)
+	assert.NotNil(t, l)
+}
+
+func TestError(t *testing.T) {
+	assert.Contains(t, (&Error{Locked: true, RetryAfter: time.Minute}).Error(), "locked")
+	assert.Contains(t, (&Error{RetryAfter: time.Minute}).Error(), "too many attempts")
+}
+
+func TestCheck(t *testing.T) {
+	testCase := []struct {
+		name       string
+		beforeTest func(mock redismock.ClientMock)
+		wantError  error
+		isErr      bool
+	}{
+		{
+			name: "Testcase #1: Positive",
+			beforeTest: func(mock redismock.ClientMock) {
+				mock.ExpectGet(blockKey).RedisNil()
+			},
+		},
+		{
+			name: "Testcase #2: Negative",
+			beforeTest: func(mock redismock.ClientMock) {
+				mock.ExpectGet(blockKey).SetVal(THROTTLED)
+				mock.ExpectPTTL(blockKey).SetVal(2 * time.Second)
+			},
+			wantError: &Error{Locked: false, RetryAfter: 2 * time.Second}, isErr: true,
+		},
+		{
+			name: "Testcase #3: Negative",
+			beforeTest: func(mock redismock.ClientMock) {
+				mock.ExpectGet(blockKey).SetVal(LOCKED)
+				mock.ExpectPTTL(blockKey).SetVal(10 * time.Minute)
+			},
+			wantError: &Error{Locked: true, RetryAfter: 10 * time.Minute}, isErr: true,
+		},
+		{
+			name: "Testcase #4: Positive",
+			beforeTest: func(mock redismock.ClientMock) {
+				mock.ExpectGet(blockKey).SetVal(LOCKED)
+				mock.ExpectPTTL(blockKey).SetVal(-2)
+			},
+		},
+		{
+			name: "Testcase #5: Negative",
+			beforeTest: func(mock redismock.ClientMock) {
+				mock.ExpectGet(blockKey).SetErr(errFoo)
+			},
+			wantError: errFoo, isErr: true,
+		},
+		{
+			name: "Testcase #6: Negative",
+			beforeTest: func(mock redismock.ClientMock) {
+				mock.ExpectGet(blockKey).SetVal(LOCKED)
+				mock.ExpectPTTL(blockKey).SetErr(errFoo)
+			},
+			wantError: errFoo, isErr: true,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			client, mock := redismock.NewClientMock()
+			l := &RedisLimiter{redis: client, prefix: "login:user", policy: policy}
+			tt.beforeTest(mock)
+
+			err := l.Check(ctx, "johndoe")
+			if tt.isErr {
+				assert.Equal(t, tt.wantError, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestFail(t *testing.T) {
+	testCase := []struct {
+		name       string
+		beforeTest func(mock redismock.ClientMock)
+		wantError  error
+		isErr      bool
+	}{
+		{
+			name: "Testcase #1: Positive",
+			beforeTest: func(mock redismock.ClientMock) {
+				mock.ExpectIncr(failKey).SetVal(1)
+				mock.ExpectExpire(failKey, policy.Window).SetVal(true)
+			},
+		},
+		{
+			name: "Testcase #2: Positive",
+			beforeTest: func(mock redismock.ClientMock) {
+				mock.ExpectIncr(failKey).SetVal(3)
+				mock.ExpectExpire(failKey, policy.Window).SetVal(true)
+				mock.ExpectSet(blockKey, THROTTLED, time.Second).SetVal("OK")
+			},
+		},
+		{
+			name: "Testcase #3: Positive",
+			beforeTest: func(mock redismock.ClientMock) {
+				mock.ExpectIncr(failKey).SetVal(4)
+				mock.ExpectExpire(failKey, policy.Window).SetVal(true)
+				mock.ExpectSet(blockKey, THROTTLED, 2*time.Second).SetVal("OK")
+			},
+		},
+		{
+			name: "Testcase #4: Negative",
+			beforeTest: func(mock redismock.ClientMock) {
+				mock.ExpectIncr(failKey).SetVal(5)
+				mock.ExpectExpire(failKey, policy.Window).SetVal(true)
+				mock.ExpectSet(blockKey, LOCKED, policy.LockFor).SetVal("OK")
+				mock.ExpectDel(failKey).SetVal(1)
+			},
+			wantError: &Error{Locked: true, RetryAfter: policy.LockFor}, isErr: true,
+		},
+		{
+			name: "Testcase #5: Negative",
+			beforeTest: func(mock redismock.ClientMock) {
+				mock.ExpectIncr(failKey).SetErr(errFoo)
+			},
+			wantError: errFoo, isErr: true,
+		},
+		{
+			name: "Testcase #6: Negative",
+			beforeTest: func(mock redismock.ClientMock) {
+				mock.ExpectIncr(failKey).SetVal(1)
+				mock.ExpectExpire(failKey, policy.Window).SetErr(errFoo)
+			},
+			wantError: errFoo, isErr: true,
+		},
+		{
+			name: "Testcase #7: Negative",
+			beforeTest: func(mock redismock.ClientMock) {
+				mock.ExpectIncr(failKey).SetVal(5)
+				mock.ExpectExpire(failKey, policy.Window).SetVal(true)
+				mock.ExpectSet(blockKey, LOCKED, policy.LockFor).SetErr(errFoo)
+			},
+			wantError: errFoo, isErr: true,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			client, mock := redismock.NewClientMock()
+			l := &RedisLimiter{redis: client, prefix: "login:user", policy: policy}
+			tt.beforeTest(mock)
+
+			err := l.Fail(ctx, "johndoe")
+			if tt.isErr {
+				assert.Equal(t, tt.wantError, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestFailWithoutLock(t *testing.T) {
+	client, mock := redismock.NewClientMock()
+	noLock := policy
+	noLock.LockAfter = 0
+	l := &RedisLimiter{redis: client, prefix: "login:ip", policy: noLock}
+
+	mock.ExpectIncr("login:ip:fail:127.0.0.1").SetVal(50)
+	mock.ExpectExpire("login:ip:fail:127.0.0.1", policy.Window).SetVal(true)
+	mock.ExpectSet("login:ip:block:127.0.0.1", THROTTLED, policy.BackoffMax).SetVal("OK")
+
+	err := l.Fail(ctx, "127.0.0.1")
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReset(t *testing.T) {
+	t.Run("Testcase #1: Positive", func(t *testing.T) {
+		client, mock := redismock.NewClientMock()
+		l := &RedisLimiter{redis: client, prefix: "login:user", policy: policy}
+
+		mock.ExpectDel(failKey, blockKey).SetVal(2)
+		err := l.Reset(ctx, "johndoe")
+		assert.NoError(t, err)
+	})
+
+	t.Run("Testcase #2: Negative", func(t *testing.T) {
+		client, mock := redismock.NewClientMock()
+		l := &RedisLimiter{redis: client, prefix: "login:user", policy: policy}
+
+		mock.ExpectDel(failKey, blockKey).SetErr(errFoo)
+		err := l.Reset(ctx, "johndoe")
+		assert.Error(t, err)
+	})
+}
+
+func TestBackoff(t *testing.T) {
+	l := &RedisLimiter{policy: policy}
+
+	assert.Equal(t, time.Second, l.backoff(3))
+	assert.Equal(t, 2*time.Second, l.backoff(4))
+	assert.Equal(t, 4*time.Second, l.backoff(5))
+	assert.Equal(t, 8*time.Second, l.backoff(6))
+	assert.Equal(t, 8*time.Second, l.backoff(60))
+}
@@ -0,0 +1,47 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// FileSender appends every message to a plain text outbox, meant for local
+// development where the mail can be read with tail.
+type FileSender struct {
+	path string
+	mu   sync.Mutex
+}
+
+func NewFile(path string) *FileSender {
+	return &FileSender{
+		path: path,
+	}
+}
+
+func (s *FileSender) Send(ctx context.Context, message Message) (err error) {
+	if message.SentAt.IsZero() {
+		message.SentAt = time.Now()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	err = os.MkdirAll(filepath.Dir(s.path), 0o755)
+	if err != nil {
+		return
+	}
+
+	file, err := os.OpenFile(s.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	_, err = fmt.Fprintf(file, "Date: %s\nFrom: %s\nTo: %s\nSubject: %s\n\n%s\n\n",
+		message.SentAt.Format(time.RFC1123Z), message.From, message.To, message.Subject, message.Body)
+	return
+}
@@ -0,0 +1,33 @@
+package mailer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileSend(t *testing.T) {
+	t.Run("Testcase #1: Positive", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "mail", "outbox.txt")
+		s := NewFile(path)
+
+		assert.NoError(t, s.Send(ctx, message))
+		assert.NoError(t, s.Send(ctx, message))
+
+		content, err := os.ReadFile(path)
+		assert.NoError(t, err)
+		assert.Contains(t, string(content), "To: johndoe@test.com\nSubject: Hello\n\nHello John\n")
+		assert.Equal(t, 2, strings.Count(string(content), "Subject: Hello"))
+	})
+
+	t.Run("Testcase #2: Negative", func(t *testing.T) {
+		dir := t.TempDir()
+		s := NewFile(dir)
+
+		err := s.Send(ctx, message)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,40 @@
+package mailer
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	FILE   = "file"
+	MEMORY = "memory"
+
+	ErrUnknownDriver = errors.New("unknown mail driver")
+)
+
+type Message struct {
+	From    string    `json:"from"`
+	To      string    `json:"to"`
+	Subject string    `json:"subject"`
+	Body    string    `json:"body"`
+	SentAt  time.Time `json:"sent_at"`
+}
+
+type Sender interface {
+	Send(ctx context.Context, message Message) (err error)
+}
+
+// New picks the sender by driver, an empty driver keeps the messages in
+// memory so the app runs without any mail service.
+func New(driver, path string) (sender Sender, err error) {
+	switch driver {
+	case FILE:
+		sender = NewFile(path)
+	case MEMORY, "":
+		sender = NewMemory()
+	default:
+		err = ErrUnknownDriver
+	}
+	return
+}
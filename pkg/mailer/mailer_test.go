@@ -0,0 +1,43 @@
+package mailer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	ctx     = context.Background()
+	message = Message{
+		From: "no-reply@test.com", To: "johndoe@test.com", Subject: "Hello", Body: "Hello John",
+	}
+)
+
+func TestNew(t *testing.T) {
+	testCase := []struct {
+		name, driver string
+		want         Sender
+		wantError    error
+	}{
+		{
+			name: "Testcase #1: Positive", driver: FILE, want: &FileSender{path: "outbox.txt"},
+		},
+		{
+			name: "Testcase #2: Positive", driver: MEMORY, want: &MemorySender{},
+		},
+		{
+			name: "Testcase #3: Positive", driver: "", want: &MemorySender{},
+		},
+		{
+			name: "Testcase #4: Negative", driver: "smtp", wantError: ErrUnknownDriver,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			sender, err := New(tt.driver, "outbox.txt")
+			assert.Equal(t, tt.wantError, err)
+			assert.Equal(t, tt.want, sender)
+		})
+	}
+}
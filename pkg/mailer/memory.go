@@ -0,0 +1,37 @@
+package mailer
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type MemorySender struct {
+	messages []Message
+	mu       sync.Mutex
+}
+
+func NewMemory() *MemorySender {
+	return &MemorySender{}
+}
+
+func (s *MemorySender) Send(ctx context.Context, message Message) (err error) {
+	if message.SentAt.IsZero() {
+		message.SentAt = time.Now()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.messages = append(s.messages, message)
+	return
+}
+
+func (s *MemorySender) Outbox() (messages []Message) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	messages = make([]Message, len(s.messages))
+	copy(messages, s.messages)
+	return
+}
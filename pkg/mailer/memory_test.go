@@ -0,0 +1,25 @@
+package mailer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemorySend(t *testing.T) {
+	t.Run("Testcase #1: Positive", func(t *testing.T) {
+		s := NewMemory()
+		assert.Empty(t, s.Outbox())
+
+		err := s.Send(ctx, message)
+		assert.NoError(t, err)
+
+		outbox := s.Outbox()
+		assert.Len(t, outbox, 1)
+		assert.Equal(t, message.To, outbox[0].To)
+		assert.False(t, outbox[0].SentAt.IsZero())
+
+		outbox[0].To = "changed"
+		assert.Equal(t, message.To, s.Outbox()[0].To)
+	})
+}
@@ -6,13 +6,72 @@ var (
 	OK      = "ok"
 
 	UNAUTHORIZED        = "Unauthorized"
+	FORBIDDEN           = "Forbidden"
 	SOMETHINGWENTWRONG  = "Something went wrong"
 	NOTFOUND            = "Data Not found"
 	HEALTHCHECK         = "I'm health"
 	INCOMINGREQUEST     = "Incoming Request"
 	USERNAMEEXIST       = "Username Exist"
+	EMAILEXIST          = "Email Exist"
 	INVALIDTOKEN        = "Invalid Token"
+	INVALIDREFRESHTOKEN = "Invalid Refresh Token"
 	UNPROCESSABLEENTITY = "Unprocessable Entity"
+	UNKNOWNROLE         = "Unknown Role"
+	MEMBERCLAIMED       = "Member Already Claimed"
+	INVALIDRESETTOKEN   = "Invalid Reset Token"
+	PASSWORDRESETSENT   = "If the email is registered, a reset token has been sent"
+	INVALIDVERIFYTOKEN  = "Invalid Verification Token"
+	EMAILNOTVERIFIED    = "Email Not Verified"
+	VERIFICATIONSENT    = "If the email is registered and not verified, a verification token has been sent"
+	TOOMANYREQUESTS     = "Too Many Requests"
+	MFAREQUIRED         = "MFA Required"
+	MFAENABLED          = "MFA Already Enabled"
+	INVALIDMFACODE      = "Invalid MFA Code"
+	INVALIDCREDENTIALS  = "Invalid Username or Password"
+	ACCOUNTLOCKED       = "Account Temporarily Locked"
+	WEAKPASSWORD        = "Password Does Not Meet The Policy"
+	UNKNOWNSCOPE        = "Unknown Scope"
+	INVALIDEXPIRY       = "Expiry Must Be In The Future"
+	INVALIDOIDCSTATE    = "Invalid Or Expired Login State"
+	INCORRECTPASSWORD   = "Incorrect Password"
+	OIDCACCOUNTEXISTS   = "Account Exists, Sign In With Your Password To Link It"
+	FILETOOLARGE        = "File Too Large"
+	CONFLICT            = "Data Already Exist"
+	REFERENCENOTFOUND   = "Referenced Data Not Found"
 
 	ERRUSERNAMEEXIST = "username exist"
+
+	// CODES are the machine readable counterparts of the error messages,
+	// they stay the same when a message is reworded.
+	CODES = map[string]string{
+		UNAUTHORIZED:        "unauthorized",
+		FORBIDDEN:           "forbidden",
+		SOMETHINGWENTWRONG:  "internal_error",
+		NOTFOUND:            "not_found",
+		USERNAMEEXIST:       "username_exists",
+		EMAILEXIST:          "email_exists",
+		INVALIDTOKEN:        "invalid_token",
+		INVALIDREFRESHTOKEN: "invalid_refresh_token",
+		UNPROCESSABLEENTITY: "unprocessable_entity",
+		UNKNOWNROLE:         "unknown_role",
+		MEMBERCLAIMED:       "member_claimed",
+		INVALIDRESETTOKEN:   "invalid_reset_token",
+		INVALIDVERIFYTOKEN:  "invalid_verification_token",
+		EMAILNOTVERIFIED:    "email_not_verified",
+		TOOMANYREQUESTS:     "too_many_requests",
+		MFAREQUIRED:         "mfa_required",
+		MFAENABLED:          "mfa_enabled",
+		INVALIDMFACODE:      "invalid_mfa_code",
+		INVALIDCREDENTIALS:  "invalid_credentials",
+		ACCOUNTLOCKED:       "account_locked",
+		WEAKPASSWORD:        "weak_password",
+		UNKNOWNSCOPE:        "unknown_scope",
+		INVALIDEXPIRY:       "invalid_expiry",
+		INVALIDOIDCSTATE:    "invalid_oidc_state",
+		INCORRECTPASSWORD:   "incorrect_password",
+		OIDCACCOUNTEXISTS:   "oidc_account_exists",
+		FILETOOLARGE:        "file_too_large",
+		CONFLICT:            "conflict",
+		REFERENCENOTFOUND:   "reference_not_found",
+	}
 )
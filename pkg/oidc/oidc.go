@@ -0,0 +1,279 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	pJwt "github.com/rzfhlv/gin-example/pkg/jwt"
+)
+
+var (
+	DISCOVERYPATH  = "/.well-known/openid-configuration"
+	DEFAULTSCOPES  = []string{"openid", "email", "profile"}
+	DEFAULTTIMEOUT = 10 * time.Second
+	// JWKSREFRESH is the least time between two fetches of the provider keys,
+	// tokens with an unknown kid can't make the client hammer the provider
+	JWKSREFRESH = time.Minute
+
+	ErrIssuerMismatch    = errors.New("oidc issuer mismatch")
+	ErrTokenExchange     = errors.New("oidc token exchange failed")
+	ErrMissingIDToken    = errors.New("oidc response without id_token")
+	ErrInvalidIDToken    = errors.New("oidc invalid id token")
+	ErrNonceMismatch     = errors.New("oidc nonce mismatch")
+	ErrUnknownSigningKey = errors.New("oidc unknown signing key")
+)
+
+type Config struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+}
+
+// Discovery is the part of the provider metadata this client needs.
+type Discovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// Claims are the verified ID token claims a login is mapped from, the issuer
+// and subject come with the registered claims.
+type Claims struct {
+	Email             string `json:"email"`
+	EmailVerified     bool   `json:"email_verified"`
+	Name              string `json:"name"`
+	PreferredUsername string `json:"preferred_username"`
+	Nonce             string `json:"nonce"`
+	jwt.RegisteredClaims
+}
+
+type Provider interface {
+	AuthCodeURL(ctx context.Context, state, nonce, challenge string) (authURL string, err error)
+	Exchange(ctx context.Context, code, verifier, nonce string) (claims Claims, err error)
+}
+
+// Client discovers the provider on first use so the app still starts while
+// the provider is down, the metadata and keys are cached afterwards.
+type Client struct {
+	cfg  Config
+	http *http.Client
+
+	mu        sync.Mutex
+	discovery *Discovery
+	keys      map[string]interface{}
+	fetchedAt time.Time
+}
+
+func New(cfg Config, httpClient *http.Client) *Client {
+	if len(cfg.Scopes) == 0 {
+		cfg.Scopes = DEFAULTSCOPES
+	}
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: DEFAULTTIMEOUT}
+	}
+
+	return &Client{
+		cfg:  cfg,
+		http: httpClient,
+	}
+}
+
+func (c *Client) AuthCodeURL(ctx context.Context, state, nonce, challenge string) (authURL string, err error) {
+	discovery, err := c.discover(ctx)
+	if err != nil {
+		return
+	}
+
+	query := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {c.cfg.ClientID},
+		"redirect_uri":          {c.cfg.RedirectURL},
+		"scope":                 {strings.Join(c.cfg.Scopes, " ")},
+		"state":                 {state},
+		"nonce":                 {nonce},
+		"code_challenge":        {challenge},
+		"code_challenge_method": {S256},
+	}
+
+	separator := "?"
+	if strings.Contains(discovery.AuthorizationEndpoint, "?") {
+		separator = "&"
+	}
+	authURL = discovery.AuthorizationEndpoint + separator + query.Encode()
+	return
+}
+
+// Exchange redeems the authorization code and returns the claims of the
+// verified ID token.
+func (c *Client) Exchange(ctx context.Context, code, verifier, nonce string) (claims Claims, err error) {
+	discovery, err := c.discover(ctx)
+	if err != nil {
+		return
+	}
+
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {c.cfg.RedirectURL},
+		"client_id":     {c.cfg.ClientID},
+		"client_secret": {c.cfg.ClientSecret},
+		"code_verifier": {verifier},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, discovery.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	res, err := c.http.Do(req)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		err = fmt.Errorf("%w: status %d", ErrTokenExchange, res.StatusCode)
+		return
+	}
+
+	token := struct {
+		IDToken string `json:"id_token"`
+	}{}
+	err = json.NewDecoder(res.Body).Decode(&token)
+	if err != nil {
+		return
+	}
+	if token.IDToken == "" {
+		err = ErrMissingIDToken
+		return
+	}
+
+	claims, err = c.verify(ctx, discovery, token.IDToken, nonce)
+	return
+}
+
+func (c *Client) verify(ctx context.Context, discovery Discovery, rawIDToken, nonce string) (claims Claims, err error) {
+	_, err = jwt.ParseWithClaims(rawIDToken, &claims, func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		return c.key(ctx, discovery, kid)
+	},
+		jwt.WithValidMethods([]string{pJwt.RS256, pJwt.EDDSA}),
+		jwt.WithIssuer(discovery.Issuer),
+		jwt.WithAudience(c.cfg.ClientID),
+	)
+	if err != nil {
+		err = fmt.Errorf("%w: %v", ErrInvalidIDToken, err)
+		return
+	}
+
+	if claims.ExpiresAt == nil || claims.Subject == "" {
+		err = ErrInvalidIDToken
+		return
+	}
+	if claims.Nonce != nonce {
+		err = ErrNonceMismatch
+	}
+	return
+}
+
+func (c *Client) discover(ctx context.Context) (discovery Discovery, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.discovery != nil {
+		discovery = *c.discovery
+		return
+	}
+
+	err = c.getJSON(ctx, strings.TrimSuffix(c.cfg.Issuer, "/")+DISCOVERYPATH, &discovery)
+	if err != nil {
+		return
+	}
+	if discovery.Issuer != c.cfg.Issuer {
+		err = ErrIssuerMismatch
+		return
+	}
+
+	c.discovery = &discovery
+	return
+}
+
+// key looks the kid up in the cached JWKS, an unknown kid refetches the set
+// since the provider may have rotated its keys, but no more than once every
+// JWKSREFRESH. The lock isn't held while fetching.
+func (c *Client) key(ctx context.Context, discovery Discovery, kid string) (public interface{}, err error) {
+	c.mu.Lock()
+	public, ok := c.keys[kid]
+	refetch := !ok && time.Since(c.fetchedAt) >= JWKSREFRESH
+	if refetch {
+		c.fetchedAt = time.Now()
+	}
+	c.mu.Unlock()
+
+	if ok {
+		return
+	}
+	if !refetch {
+		err = ErrUnknownSigningKey
+		return
+	}
+
+	jwks := pJwt.JWKS{}
+	err = c.getJSON(ctx, discovery.JWKSURI, &jwks)
+	if err != nil {
+		return
+	}
+
+	keys := map[string]interface{}{}
+	for _, jwk := range jwks.Keys {
+		key, keyErr := jwk.PublicKey()
+		if keyErr != nil {
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+
+	c.mu.Lock()
+	c.keys = keys
+	c.mu.Unlock()
+
+	public, ok = keys[kid]
+	if !ok {
+		err = ErrUnknownSigningKey
+	}
+	return
+}
+
+func (c *Client) getJSON(ctx context.Context, target string, value interface{}) (err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return
+	}
+	req.Header.Set("Accept", "application/json")
+
+	res, err := c.http.Do(req)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		err = fmt.Errorf("oidc get %s: status %d", target, res.StatusCode)
+		return
+	}
+
+	err = json.NewDecoder(res.Body).Decode(value)
+	return
+}
@@ -0,0 +1,232 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	pJwt "github.com/rzfhlv/gin-example/pkg/jwt"
+	"github.com/stretchr/testify/assert"
+)
+
+// provider is an in-process stand-in for the identity provider, it hands out
+// one authorization code and signs ID tokens with its own RSA key.
+type provider struct {
+	*httptest.Server
+	key       *rsa.PrivateKey
+	kid       string
+	issuer    string
+	code      string
+	verifier  string
+	claims    jwt.MapClaims
+	status    int
+	jwksCalls int32
+}
+
+func newProvider(t *testing.T) *provider {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	p := &provider{key: key, kid: "idp-1", code: "thisiscode", status: http.StatusOK}
+	mux := http.NewServeMux()
+	mux.HandleFunc(DISCOVERYPATH, func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(Discovery{
+			Issuer:                p.issuer,
+			AuthorizationEndpoint: p.URL + "/authorize",
+			TokenEndpoint:         p.URL + "/token",
+			JWKSURI:               p.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&p.jwksCalls, 1)
+		json.NewEncoder(w).Encode(pJwt.JWKS{Keys: []pJwt.JWK{{
+			Kty: "RSA", Kid: "idp-1", Use: "sig", Alg: pJwt.RS256,
+			N: base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			E: base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if p.status != http.StatusOK {
+			w.WriteHeader(p.status)
+			return
+		}
+		if r.Form.Get("code") != p.code || r.Form.Get("client_id") != "gin-example" ||
+			r.Form.Get("code_verifier") != p.verifier {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, p.claims)
+		token.Header["kid"] = p.kid
+		idToken, _ := token.SignedString(key)
+		json.NewEncoder(w).Encode(map[string]string{"access_token": "thisisaccess", "id_token": idToken})
+	})
+	p.Server = httptest.NewServer(mux)
+	p.issuer = p.URL
+	p.verifier = "thisisverifier"
+	p.claims = jwt.MapClaims{
+		"iss":            p.URL,
+		"sub":            "248289761001",
+		"aud":            "gin-example",
+		"exp":            time.Now().Add(time.Minute).Unix(),
+		"iat":            time.Now().Unix(),
+		"nonce":          "thisisnonce",
+		"email":          "jane@test.com",
+		"email_verified": true,
+	}
+	t.Cleanup(p.Close)
+	return p
+}
+
+func (p *provider) client() *Client {
+	return New(Config{
+		Issuer:       p.URL,
+		ClientID:     "gin-example",
+		ClientSecret: "verysecret",
+		RedirectURL:  "http://localhost:8899/v1/users/oidc/callback",
+	}, p.Client())
+}
+
+func TestAuthCodeURL(t *testing.T) {
+	p := newProvider(t)
+
+	authURL, err := p.client().AuthCodeURL(context.Background(), "thisisstate", "thisisnonce", "thisischallenge")
+	assert.NoError(t, err)
+
+	parsed, err := url.Parse(authURL)
+	assert.NoError(t, err)
+	assert.Equal(t, p.URL+"/authorize", parsed.Scheme+"://"+parsed.Host+parsed.Path)
+
+	query := parsed.Query()
+	assert.Equal(t, "code", query.Get("response_type"))
+	assert.Equal(t, "gin-example", query.Get("client_id"))
+	assert.Equal(t, "openid email profile", query.Get("scope"))
+	assert.Equal(t, "thisisstate", query.Get("state"))
+	assert.Equal(t, "thisisnonce", query.Get("nonce"))
+	assert.Equal(t, "thisischallenge", query.Get("code_challenge"))
+	assert.Equal(t, S256, query.Get("code_challenge_method"))
+}
+
+func TestDiscover(t *testing.T) {
+	t.Run("Testcase #1: Negative", func(t *testing.T) {
+		p := newProvider(t)
+		p.issuer = "https://evil.test"
+
+		_, err := p.client().AuthCodeURL(context.Background(), "state", "nonce", "challenge")
+		assert.Equal(t, ErrIssuerMismatch, err)
+	})
+
+	t.Run("Testcase #2: Negative", func(t *testing.T) {
+		p := newProvider(t)
+		c := New(Config{Issuer: p.URL + "/missing"}, p.Client())
+
+		_, err := c.AuthCodeURL(context.Background(), "state", "nonce", "challenge")
+		assert.Error(t, err)
+	})
+}
+
+func TestExchange(t *testing.T) {
+	testCase := []struct {
+		name      string
+		prepare   func(p *provider)
+		code      string
+		nonce     string
+		wantError error
+	}{
+		{
+			name: "Testcase #1: Positive", code: "thisiscode", nonce: "thisisnonce",
+		},
+		{
+			name: "Testcase #2: Negative", code: "thisiscode", nonce: "othernonce", wantError: ErrNonceMismatch,
+		},
+		{
+			name: "Testcase #3: Negative", code: "wrongcode", nonce: "thisisnonce", wantError: ErrTokenExchange,
+		},
+		{
+			name: "Testcase #4: Negative", code: "thisiscode", nonce: "thisisnonce", wantError: ErrInvalidIDToken,
+			prepare: func(p *provider) { p.claims["aud"] = "another-client" },
+		},
+		{
+			name: "Testcase #5: Negative", code: "thisiscode", nonce: "thisisnonce", wantError: ErrInvalidIDToken,
+			prepare: func(p *provider) { p.claims["exp"] = time.Now().Add(-time.Minute).Unix() },
+		},
+		{
+			name: "Testcase #6: Negative", code: "thisiscode", nonce: "thisisnonce", wantError: ErrInvalidIDToken,
+			prepare: func(p *provider) { delete(p.claims, "exp") },
+		},
+		{
+			name: "Testcase #7: Negative", code: "thisiscode", nonce: "thisisnonce", wantError: ErrInvalidIDToken,
+			prepare: func(p *provider) { p.claims["iss"] = "https://evil.test" },
+		},
+		{
+			name: "Testcase #8: Negative", code: "thisiscode", nonce: "thisisnonce", wantError: ErrInvalidIDToken,
+			prepare: func(p *provider) { p.kid = "unknown" },
+		},
+		{
+			name: "Testcase #9: Negative", code: "thisiscode", nonce: "thisisnonce", wantError: ErrTokenExchange,
+			prepare: func(p *provider) { p.status = http.StatusInternalServerError },
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newProvider(t)
+			if tt.prepare != nil {
+				tt.prepare(p)
+			}
+
+			claims, err := p.client().Exchange(context.Background(), tt.code, p.verifier, tt.nonce)
+			assert.ErrorIs(t, err, tt.wantError)
+			if tt.wantError == nil {
+				assert.Equal(t, "248289761001", claims.Subject)
+				assert.Equal(t, p.URL, claims.Issuer)
+				assert.Equal(t, "jane@test.com", claims.Email)
+				assert.True(t, claims.EmailVerified)
+			}
+		})
+	}
+}
+
+func TestExchangeCachesKeys(t *testing.T) {
+	p := newProvider(t)
+	c := p.client()
+
+	for i := 0; i < 2; i++ {
+		_, err := c.Exchange(context.Background(), p.code, p.verifier, "thisisnonce")
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&p.jwksCalls))
+}
+
+func TestExchangeLimitsRefetch(t *testing.T) {
+	p := newProvider(t)
+	p.kid = "unknown"
+	c := p.client()
+
+	for i := 0; i < 3; i++ {
+		_, err := c.Exchange(context.Background(), p.code, p.verifier, "thisisnonce")
+		assert.ErrorIs(t, err, ErrInvalidIDToken)
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&p.jwksCalls))
+
+	// once the interval passed an unknown kid may fetch again
+	c.fetchedAt = time.Now().Add(-JWKSREFRESH)
+	_, err := c.Exchange(context.Background(), p.code, p.verifier, "thisisnonce")
+	assert.ErrorIs(t, err, ErrInvalidIDToken)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&p.jwksCalls))
+
+	p.kid = "idp-1"
+	_, err = c.Exchange(context.Background(), p.code, p.verifier, "thisisnonce")
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&p.jwksCalls))
+}
@@ -0,0 +1,21 @@
+package oidc
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+
+	pToken "github.com/rzfhlv/gin-example/pkg/token"
+)
+
+var S256 = "S256"
+
+// NewVerifier returns a PKCE code verifier, 32 random bytes encode to the 43
+// characters the RFC 7636 minimum asks for.
+func NewVerifier() (verifier string, err error) {
+	return pToken.Generate(pToken.DEFAULTLENGTH)
+}
+
+func Challenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
@@ -0,0 +1,18 @@
+package oidc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewVerifier(t *testing.T) {
+	verifier, err := NewVerifier()
+	assert.NoError(t, err)
+	assert.Len(t, verifier, 43)
+}
+
+func TestChallenge(t *testing.T) {
+	// base64url of the SHA-256 without padding
+	assert.Equal(t, "z1RITABixBI2UPy-4hpt6G98v2SviNXKNA-2D9EmkRo", Challenge("thisisverifier"))
+}
@@ -0,0 +1,231 @@
+package param
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+var (
+	// CURSORTIMELAYOUT is how a time is kept in a cursor, MySQL compares it
+	// with a DATETIME or TIMESTAMP column as it is
+	CURSORTIMELAYOUT = "2006-01-02 15:04:05.999999"
+
+	ErrInvalidCursor  = errors.New("invalid cursor")
+	ErrNoCursorSecret = errors.New("cursor secret is empty")
+	ErrCursorDisabled = errors.New("cursor pagination is disabled")
+
+	cursorConfig = CursorConfig{Location: time.UTC}
+)
+
+// CursorConfig is what every cursor is made and read with, it is set once at
+// startup with Configure.
+type CursorConfig struct {
+	// Secret signs the cursors, without one none is issued or accepted
+	Secret []byte
+	// Location is the time zone of the database connection, times are kept
+	// in it so a cursor holds the same wall clock as the column it seeks
+	Location *time.Location
+}
+
+// Configure sets the secret and location of every cursor. Without a secret
+// the lists only page by number, a cursor asked for is ErrCursorDisabled.
+func Configure(config CursorConfig) {
+	if config.Location == nil {
+		config.Location = time.UTC
+	}
+
+	cursorConfig = config
+}
+
+// CursorEnabled reports whether a secret was configured to sign cursors with.
+func CursorEnabled() bool {
+	return len(cursorConfig.Secret) > 0
+}
+
+// Cursor points right after a row of a list, or right before it when
+// Backward. Values are the sort columns of the row, the last is the Default
+// sort of the Spec so every row has a position of its own.
+type Cursor struct {
+	Sort     string   `json:"s,omitempty"`
+	Values   []string `json:"v"`
+	Backward bool     `json:"b,omitempty"`
+}
+
+// Page is what a list knows about a page beside its rows, Total is nil when
+// it wasn't counted.
+type Page struct {
+	Total *int64
+	Next  string
+	Prev  string
+}
+
+// Encode signs the cursor with the configured secret, the client can hand it
+// back but not make one up.
+func (c Cursor) Encode() (token string, err error) {
+	if !CursorEnabled() {
+		err = ErrNoCursorSecret
+		return
+	}
+
+	payload, err := json.Marshal(c)
+	if err != nil {
+		return
+	}
+
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	token = encoded + "." + sign(encoded)
+	return
+}
+
+func DecodeCursor(token string) (cursor Cursor, err error) {
+	if !CursorEnabled() {
+		err = ErrCursorDisabled
+		return
+	}
+
+	encoded, signature, ok := strings.Cut(token, ".")
+	if !ok || !hmac.Equal([]byte(signature), []byte(sign(encoded))) {
+		err = ErrInvalidCursor
+		return
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		err = ErrInvalidCursor
+		return
+	}
+	err = json.Unmarshal(payload, &cursor)
+	if err != nil {
+		err = ErrInvalidCursor
+	}
+	return
+}
+
+// Seek is Where with the cursor of the param as the first condition, only
+// the rows past the cursor are matched. Count keeps using Where, the total
+// is about the whole list.
+func (s Spec) Seek(param Param, conditions ...string) (where string, args []interface{}, err error) {
+	if param.Cursor == "" {
+		where, args, err = s.Where(param, conditions...)
+		return
+	}
+
+	cursor, orders, err := s.cursor(param)
+	if err != nil {
+		return
+	}
+
+	// (a > ?) OR (a = ? AND b > ?), the last column is unique
+	alternatives := []string{}
+	for i, order := range orders {
+		matches := []string{}
+		for j, before := range orders[:i] {
+			matches = append(matches, before.column+" = ?")
+			args = append(args, cursor.Values[j])
+		}
+		matches = append(matches, order.column+" "+order.past(cursor.Backward)+" ?")
+		args = append(args, cursor.Values[i])
+		alternatives = append(alternatives, "("+strings.Join(matches, " AND ")+")")
+	}
+	seek := alternatives[0]
+	if len(alternatives) > 1 {
+		seek = "(" + strings.Join(alternatives, " OR ") + ")"
+	}
+
+	where, filterArgs, err := s.Where(param, append([]string{seek}, conditions...)...)
+	args = append(args, filterArgs...)
+	return
+}
+
+// Paginate puts the rows of a page read with Seek and OrderBy back in the
+// order asked for and finds the cursors around them. Rows is a slice, like
+// for sort.Slice, and values gives the value of each sort column of row i.
+func (s Spec) Paginate(param Param, rows interface{}, values func(i int) map[string]interface{}) (page Page, err error) {
+	cursor, orders, err := s.cursor(param)
+	if err != nil {
+		return
+	}
+
+	length := reflect.ValueOf(rows).Len()
+	if cursor.Backward {
+		swap := reflect.Swapper(rows)
+		for i, j := 0, length-1; i < j; i, j = i+1, j-1 {
+			swap(i, j)
+		}
+	}
+	if length < 1 || !CursorEnabled() {
+		return
+	}
+
+	// a full page may have more after it, a page read backward always has
+	full := param.Limit > 0 && length >= param.Limit
+	if full || cursor.Backward {
+		page.Next, err = encode(param, orders, values(length-1), false)
+		if err != nil {
+			return
+		}
+	}
+	if (cursor.Backward && full) || (!cursor.Backward && (param.Cursor != "" || param.Page > 1)) {
+		page.Prev, err = encode(param, orders, values(0), true)
+	}
+	return
+}
+
+// cursor decodes the cursor of the param, it must have been made for the
+// same sort. Without one the cursor is empty and the list read forward.
+func (s Spec) cursor(param Param) (cursor Cursor, orders []order, err error) {
+	orders, err = s.orders(param)
+	if err != nil || param.Cursor == "" {
+		return
+	}
+
+	cursor, err = DecodeCursor(param.Cursor)
+	if err != nil {
+		return
+	}
+	if cursor.Sort != param.Sort || len(cursor.Values) != len(orders) {
+		err = ErrInvalidCursor
+	}
+	return
+}
+
+func encode(param Param, orders []order, values map[string]interface{}, backward bool) (token string, err error) {
+	cursor := Cursor{Sort: param.Sort, Backward: backward}
+	for _, order := range orders {
+		value, ok := values[order.column]
+		if !ok {
+			err = fmt.Errorf("no cursor value for %s", order.column)
+			return
+		}
+		cursor.Values = append(cursor.Values, cursorValue(value))
+	}
+	token, err = cursor.Encode()
+	return
+}
+
+func cursorValue(value interface{}) string {
+	switch v := value.(type) {
+	case time.Time:
+		return v.In(cursorConfig.Location).Format(CURSORTIMELAYOUT)
+	case *time.Time:
+		if v == nil {
+			return ""
+		}
+		return v.In(cursorConfig.Location).Format(CURSORTIMELAYOUT)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func sign(encoded string) string {
+	mac := hmac.New(sha256.New, cursorConfig.Secret)
+	mac.Write([]byte(encoded))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
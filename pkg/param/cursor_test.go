@@ -0,0 +1,244 @@
+package param
+
+import (
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type row struct {
+	ID        int64
+	CreatedAt time.Time
+}
+
+// configure sets the cursor config for the test and puts the previous one
+// back after it.
+func configure(t *testing.T, config CursorConfig) {
+	previous := cursorConfig
+	t.Cleanup(func() {
+		cursorConfig = previous
+	})
+	Configure(config)
+}
+
+func token(t *testing.T, cursor Cursor) string {
+	encoded, err := cursor.Encode()
+	assert.NoError(t, err)
+	return encoded
+}
+
+func TestConfigure(t *testing.T) {
+	configure(t, CursorConfig{Secret: []byte("verysecret")})
+	assert.Equal(t, time.UTC, cursorConfig.Location)
+	assert.True(t, CursorEnabled())
+
+	// without a secret no cursor is made or read
+	configure(t, CursorConfig{})
+	assert.False(t, CursorEnabled())
+	_, err := Cursor{Values: []string{"7"}}.Encode()
+	assert.ErrorIs(t, err, ErrNoCursorSecret)
+	_, err = DecodeCursor("e30.")
+	assert.ErrorIs(t, err, ErrCursorDisabled)
+	_, _, err = spec.Seek(Param{Sort: "-created_at", Cursor: "e30."})
+	assert.ErrorIs(t, err, ErrCursorDisabled)
+	assert.False(t, IsInvalid(err))
+
+	// the pages are still read, only without cursors around them
+	page, err := spec.Paginate(Param{Limit: 1, Page: 1, Sort: "-created_at"}, []row{{9, time.Now()}}, func(i int) map[string]interface{} {
+		return map[string]interface{}{"id": int64(9), "created_at": time.Now()}
+	})
+	assert.NoError(t, err)
+	assert.Empty(t, page.Next)
+	assert.Empty(t, page.Prev)
+}
+
+func TestCursor(t *testing.T) {
+	configure(t, CursorConfig{Secret: []byte("verysecret")})
+	cursor := Cursor{Sort: "-created_at", Values: []string{"2026-10-18 10:00:00", "7"}, Backward: true}
+	encoded := token(t, cursor)
+
+	testCase := []struct {
+		name      string
+		token     string
+		secret    string
+		want      Cursor
+		wantError error
+	}{
+		{
+			name: "Testcase #1: Positive", token: encoded, secret: "verysecret", want: cursor,
+		},
+		{
+			name: "Testcase #2: Negative", token: encoded, secret: "othersecret", wantError: ErrInvalidCursor,
+		},
+		{
+			name: "Testcase #3: Negative", token: "e30" + encoded, secret: "verysecret", wantError: ErrInvalidCursor,
+		},
+		{
+			name: "Testcase #4: Negative", token: "notacursor", secret: "verysecret", wantError: ErrInvalidCursor,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			configure(t, CursorConfig{Secret: []byte(tt.secret)})
+
+			decoded, err := DecodeCursor(tt.token)
+			assert.ErrorIs(t, err, tt.wantError)
+			if tt.wantError == nil {
+				assert.Equal(t, tt.want, decoded)
+			}
+		})
+	}
+}
+
+func TestSeek(t *testing.T) {
+	configure(t, CursorConfig{Secret: []byte("verysecret")})
+	testCase := []struct {
+		name       string
+		param      Param
+		conditions []string
+		wantWhere  string
+		wantArgs   []interface{}
+		wantError  error
+	}{
+		{
+			name: "Testcase #1: Positive", param: Param{}, conditions: []string{"deleted_at IS NULL"},
+			wantWhere: "WHERE deleted_at IS NULL",
+		},
+		{
+			name: "Testcase #2: Positive", param: Param{Cursor: token(t, Cursor{Values: []string{"7"}})},
+			wantWhere: "WHERE (id < ?)",
+			wantArgs:  []interface{}{"7"},
+		},
+		{
+			name:       "Testcase #3: Positive",
+			param:      Param{Sort: "name", Filters: url.Values{"email": {"john@test.com"}}, Cursor: token(t, Cursor{Sort: "name", Values: []string{"John", "7"}})},
+			conditions: []string{"deleted_at IS NULL"},
+			wantWhere:  "WHERE ((first_name > ?) OR (first_name = ? AND id < ?)) AND deleted_at IS NULL AND email = ?",
+			wantArgs:   []interface{}{"John", "John", "7", "john@test.com"},
+		},
+		{
+			name:      "Testcase #4: Positive",
+			param:     Param{Sort: "name", Cursor: token(t, Cursor{Sort: "name", Values: []string{"John", "7"}, Backward: true})},
+			wantWhere: "WHERE ((first_name < ?) OR (first_name = ? AND id > ?))",
+			wantArgs:  []interface{}{"John", "John", "7"},
+		},
+		{
+			name: "Testcase #5: Negative", param: Param{Sort: "-created_at", Cursor: token(t, Cursor{Sort: "name", Values: []string{"John", "7"}})},
+			wantError: ErrInvalidCursor,
+		},
+		{
+			name: "Testcase #6: Negative", param: Param{Sort: "name", Cursor: token(t, Cursor{Sort: "name", Values: []string{"7"}})},
+			wantError: ErrInvalidCursor,
+		},
+		{
+			name: "Testcase #7: Negative", param: Param{Sort: "password", Cursor: token(t, Cursor{Sort: "password", Values: []string{"7"}})},
+			wantError: ErrInvalidSort,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			where, args, err := spec.Seek(tt.param, tt.conditions...)
+			assert.ErrorIs(t, err, tt.wantError)
+			assert.True(t, tt.wantError == nil || IsInvalid(err))
+			assert.Equal(t, tt.wantWhere, where)
+			assert.Equal(t, tt.wantArgs, args)
+		})
+	}
+}
+
+func TestOrderByBackward(t *testing.T) {
+	configure(t, CursorConfig{Secret: []byte("verysecret")})
+	orderBy, err := spec.OrderBy(Param{Sort: "name", Cursor: token(t, Cursor{Sort: "name", Values: []string{"John", "7"}, Backward: true})})
+	assert.NoError(t, err)
+	assert.Equal(t, "ORDER BY first_name DESC, id ASC", orderBy)
+
+	_, err = spec.OrderBy(Param{Cursor: "notacursor"})
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+}
+
+func TestPaginate(t *testing.T) {
+	configure(t, CursorConfig{Secret: []byte("verysecret")})
+	day := time.Date(2026, time.October, 18, 10, 0, 0, 0, time.FixedZone("WIB", 7*60*60))
+	values := func(rows []row) func(i int) map[string]interface{} {
+		return func(i int) map[string]interface{} {
+			return map[string]interface{}{"id": rows[i].ID, "created_at": rows[i].CreatedAt}
+		}
+	}
+	next := func(id int64) string {
+		return token(t, Cursor{Sort: "-created_at", Values: []string{"2026-10-18 03:00:00", strconv.FormatInt(id, 10)}})
+	}
+	prev := func(id int64) string {
+		return token(t, Cursor{Sort: "-created_at", Values: []string{"2026-10-18 03:00:00", strconv.FormatInt(id, 10)}, Backward: true})
+	}
+
+	testCase := []struct {
+		name     string
+		param    Param
+		rows     []row
+		wantIDs  []int64
+		wantNext string
+		wantPrev string
+	}{
+		{
+			name: "Testcase #1: Positive", param: Param{Limit: 2, Page: 1, Sort: "-created_at"},
+			rows: []row{{9, day}, {8, day}}, wantIDs: []int64{9, 8}, wantNext: next(8),
+		},
+		{
+			name: "Testcase #2: Positive", param: Param{Limit: 2, Page: 2, Sort: "-created_at"},
+			rows: []row{{7, day}}, wantIDs: []int64{7}, wantPrev: prev(7),
+		},
+		{
+			name: "Testcase #3: Positive", param: Param{Limit: 2, Sort: "-created_at", Cursor: next(8)},
+			rows: []row{{7, day}, {6, day}}, wantIDs: []int64{7, 6}, wantNext: next(6), wantPrev: prev(7),
+		},
+		{
+			name: "Testcase #4: Positive", param: Param{Limit: 2, Sort: "-created_at", Cursor: prev(7)},
+			rows: []row{{8, day}, {9, day}}, wantIDs: []int64{9, 8}, wantNext: next(8), wantPrev: prev(9),
+		},
+		{
+			name: "Testcase #5: Positive", param: Param{Limit: 2, Sort: "-created_at", Cursor: prev(8)},
+			rows: []row{{9, day}}, wantIDs: []int64{9}, wantNext: next(9),
+		},
+		{
+			name: "Testcase #6: Positive", param: Param{Limit: 2, Sort: "-created_at", Cursor: next(6)},
+			rows: []row{}, wantIDs: []int64{},
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := spec.Paginate(tt.param, tt.rows, values(tt.rows))
+			assert.NoError(t, err)
+
+			ids := []int64{}
+			for _, r := range tt.rows {
+				ids = append(ids, r.ID)
+			}
+			assert.Equal(t, tt.wantIDs, ids)
+			assert.Equal(t, tt.wantNext, page.Next)
+			assert.Equal(t, tt.wantPrev, page.Prev)
+			assert.Nil(t, page.Total)
+		})
+	}
+
+	t.Run("Testcase #7: Negative", func(t *testing.T) {
+		_, err := spec.Paginate(Param{Limit: 1, Page: 1}, []row{{1, day}}, func(i int) map[string]interface{} {
+			return map[string]interface{}{}
+		})
+		assert.Error(t, err)
+		assert.False(t, IsInvalid(err))
+	})
+
+	t.Run("Testcase #8: Positive", func(t *testing.T) {
+		// the database runs in WIB, the cursor holds the wall clock it stores
+		configure(t, CursorConfig{Secret: []byte("verysecret"), Location: day.Location()})
+
+		page, err := spec.Paginate(Param{Limit: 1, Page: 1, Sort: "-created_at"}, []row{{9, day.UTC()}}, values([]row{{9, day.UTC()}}))
+		assert.NoError(t, err)
+		cursor, err := DecodeCursor(page.Next)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"2026-10-18 10:00:00", "9"}, cursor.Values)
+	})
+}
@@ -1,17 +1,40 @@
 package param
 
+import "net/url"
+
 var (
 	DEFAULTLIMIT = 10
 	DEFAULTPAGE  = 1
 )
 
 type Param struct {
-	Page   int   `json:"page" form:"page"`
-	Limit  int   `json:"limit" form:"limit"`
-	Offset int   `json:"offset"`
-	Total  int64 `json:"total"`
+	Page   int `json:"page" form:"page"`
+	Limit  int `json:"limit" form:"limit"`
+	Offset int `json:"offset"`
+
+	Q    string `json:"q,omitempty" form:"q"`
+	Sort string `json:"sort,omitempty" form:"sort"`
+	// Cursor is the next_cursor or prev_cursor of a page, it replaces page
+	Cursor string `json:"cursor,omitempty" form:"cursor"`
+	// Count asks for the total, a list is counted by default unless it is
+	// read by cursor
+	Count *bool `json:"count,omitempty" form:"count"`
+	// Filters holds the raw query, a Spec only reads the keys it whitelists
+	Filters url.Values `json:"-" form:"-"`
 }
 
+// CalculateOffset is always 0 for a cursor, the cursor tells where the page
+// starts.
 func (f *Param) CalculateOffset() int {
+	if f.Cursor != "" {
+		return 0
+	}
 	return f.Limit * (f.Page - 1)
 }
+
+func (f *Param) Counts() bool {
+	if f.Count != nil {
+		return *f.Count
+	}
+	return f.Cursor == ""
+}
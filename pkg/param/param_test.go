@@ -12,9 +12,22 @@ func TestCalculateOffset(t *testing.T) {
 		param := Param{
 			Page:  1,
 			Limit: 10,
-			Total: 100,
 		}
 		offset := param.CalculateOffset()
 		assert.Equal(t, expect, offset)
 	})
 }
+
+func TestCursorOffset(t *testing.T) {
+	param := Param{Page: 3, Limit: 10, Cursor: "cursor"}
+	assert.Equal(t, 0, param.CalculateOffset())
+}
+
+func TestCounts(t *testing.T) {
+	yes, no := true, false
+
+	assert.True(t, (&Param{}).Counts())
+	assert.False(t, (&Param{Count: &no}).Counts())
+	assert.False(t, (&Param{Cursor: "cursor"}).Counts())
+	assert.True(t, (&Param{Cursor: "cursor", Count: &yes}).Counts())
+}
@@ -0,0 +1,213 @@
+package param
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+var (
+	// a filter matches its value in one of these ways
+	EQUAL    = "equal"
+	CONTAINS = "contains"
+	FROM     = "from"
+	TO       = "to"
+
+	DATELAYOUT = "2006-01-02"
+
+	ErrInvalidSort   = errors.New("invalid sort")
+	ErrInvalidFilter = errors.New("invalid filter")
+)
+
+// Filter is what a query key is matched against, a value matches when any of
+// the columns does.
+type Filter struct {
+	Columns []string
+	Match   string
+}
+
+// Spec whitelists what a list can be filtered, searched and sorted by. The
+// keys are the names used in the query string and the values the columns
+// they map to, nothing from the request reaches the SQL but values bound as
+// arguments.
+type Spec struct {
+	Filters map[string]Filter
+	Search  []string
+	Sorts   map[string]string
+	// Default is the sort key used when none is asked for, prefixed with -
+	// for descending. It is also appended to every sort so pages are stable.
+	Default string
+}
+
+// Where builds the WHERE clause from the filters and q of the param, the
+// conditions are added as they are. It is empty when there is nothing to
+// match.
+func (s Spec) Where(param Param, conditions ...string) (where string, args []interface{}, err error) {
+	conditions = append([]string{}, conditions...)
+
+	// keys are sorted so the same param always builds the same query
+	keys := make([]string, 0, len(s.Filters))
+	for key := range s.Filters {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		filter := s.Filters[key]
+		value := strings.TrimSpace(param.Filters.Get(key))
+		if value == "" {
+			continue
+		}
+
+		var condition string
+		var arg interface{}
+		condition, arg, err = filter.condition(value)
+		if err != nil {
+			err = fmt.Errorf("%w: %s", ErrInvalidFilter, key)
+			return
+		}
+		conditions = append(conditions, condition)
+		for range filter.Columns {
+			args = append(args, arg)
+		}
+	}
+
+	q := strings.TrimSpace(param.Q)
+	if q != "" && len(s.Search) > 0 {
+		conditions = append(conditions, anyOf(s.Search, "LIKE ?"))
+		for range s.Search {
+			args = append(args, "%"+escapeLike(q)+"%")
+		}
+	}
+
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+	return
+}
+
+// OrderBy builds the ORDER BY clause from the comma separated sort keys of
+// the param, a key prefixed with - sorts descending. A cursor read backward
+// turns every direction around, Paginate puts the rows back.
+func (s Spec) OrderBy(param Param) (orderBy string, err error) {
+	cursor, orders, err := s.cursor(param)
+	if err != nil || len(orders) < 1 {
+		return
+	}
+
+	clauses := make([]string, len(orders))
+	for i, order := range orders {
+		direction := "ASC"
+		if order.descending != cursor.Backward {
+			direction = "DESC"
+		}
+		clauses[i] = order.column + " " + direction
+	}
+
+	orderBy = "ORDER BY " + strings.Join(clauses, ", ")
+	return
+}
+
+// orders are the columns the param sorts by, each once.
+func (s Spec) orders(param Param) (orders []order, err error) {
+	keys := []string{}
+	for _, key := range strings.Split(param.Sort, ",") {
+		key = strings.TrimSpace(key)
+		if key != "" {
+			keys = append(keys, key)
+		}
+	}
+	if s.Default != "" {
+		keys = append(keys, s.Default)
+	}
+
+	seen := map[string]bool{}
+	for _, key := range keys {
+		descending := strings.HasPrefix(key, "-")
+		key = strings.TrimPrefix(key, "-")
+
+		column, ok := s.Sorts[key]
+		if !ok {
+			err = fmt.Errorf("%w: %s", ErrInvalidSort, key)
+			return
+		}
+		if seen[column] {
+			continue
+		}
+		seen[column] = true
+		orders = append(orders, order{column: column, descending: descending})
+	}
+	return
+}
+
+// IsInvalid reports whether err comes from a sort, filter or cursor the Spec
+// doesn't allow, it is the caller's fault rather than the server's.
+func IsInvalid(err error) bool {
+	return errors.Is(err, ErrInvalidSort) || errors.Is(err, ErrInvalidFilter) || errors.Is(err, ErrInvalidCursor)
+}
+
+type order struct {
+	column     string
+	descending bool
+}
+
+// past is how a column compares with the cursor for the rows that come after
+// it, or before it when backward.
+func (o order) past(backward bool) string {
+	if o.descending != backward {
+		return "<"
+	}
+	return ">"
+}
+
+func (f Filter) condition(value string) (condition string, arg interface{}, err error) {
+	switch f.Match {
+	case CONTAINS:
+		condition, arg = anyOf(f.Columns, "LIKE ?"), "%"+escapeLike(value)+"%"
+	case FROM:
+		var from time.Time
+		from, _, err = parseTime(value)
+		condition, arg = anyOf(f.Columns, ">= ?"), from
+	case TO:
+		// a date alone covers the whole of that day
+		var to time.Time
+		var dateOnly bool
+		to, dateOnly, err = parseTime(value)
+		if dateOnly {
+			condition, arg = anyOf(f.Columns, "< ?"), to.AddDate(0, 0, 1)
+		} else {
+			condition, arg = anyOf(f.Columns, "<= ?"), to
+		}
+	default:
+		condition, arg = anyOf(f.Columns, "= ?"), value
+	}
+	return
+}
+
+func anyOf(columns []string, match string) string {
+	conditions := make([]string, len(columns))
+	for i, column := range columns {
+		conditions[i] = column + " " + match
+	}
+	if len(conditions) == 1 {
+		return conditions[0]
+	}
+	return "(" + strings.Join(conditions, " OR ") + ")"
+}
+
+func parseTime(value string) (t time.Time, dateOnly bool, err error) {
+	t, err = time.Parse(time.RFC3339, value)
+	if err == nil {
+		return
+	}
+	t, err = time.Parse(DATELAYOUT, value)
+	dateOnly = err == nil
+	return
+}
+
+// escapeLike keeps the wildcards of a value literal inside a LIKE pattern.
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
@@ -0,0 +1,122 @@
+package param
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var spec = Spec{
+	Filters: map[string]Filter{
+		"name":         {Columns: []string{"first_name", "last_name"}, Match: CONTAINS},
+		"email":        {Columns: []string{"email"}, Match: EQUAL},
+		"created_from": {Columns: []string{"created_at"}, Match: FROM},
+		"created_to":   {Columns: []string{"created_at"}, Match: TO},
+	},
+	Search:  []string{"first_name", "email"},
+	Sorts:   map[string]string{"id": "id", "name": "first_name", "created_at": "created_at"},
+	Default: "-id",
+}
+
+func TestWhere(t *testing.T) {
+	day := time.Date(2026, time.October, 18, 0, 0, 0, 0, time.UTC)
+
+	testCase := []struct {
+		name       string
+		param      Param
+		conditions []string
+		wantWhere  string
+		wantArgs   []interface{}
+		wantError  error
+	}{
+		{
+			name: "Testcase #1: Positive", param: Param{},
+		},
+		{
+			name: "Testcase #2: Positive", param: Param{}, conditions: []string{"deleted_at IS NULL"},
+			wantWhere: "WHERE deleted_at IS NULL",
+		},
+		{
+			name: "Testcase #3: Positive", param: Param{Filters: url.Values{"name": {"jo"}, "email": {"john@test.com"}, "page": {"2"}}},
+			wantWhere: "WHERE email = ? AND (first_name LIKE ? OR last_name LIKE ?)",
+			wantArgs:  []interface{}{"john@test.com", "%jo%", "%jo%"},
+		},
+		{
+			name: "Testcase #4: Positive", param: Param{Filters: url.Values{"created_from": {"2026-10-18"}, "created_to": {"2026-10-18"}}},
+			wantWhere: "WHERE created_at >= ? AND created_at < ?",
+			wantArgs:  []interface{}{day, day.AddDate(0, 0, 1)},
+		},
+		{
+			name: "Testcase #5: Positive", param: Param{Filters: url.Values{"created_to": {"2026-10-18T10:00:00Z"}}},
+			wantWhere: "WHERE created_at <= ?",
+			wantArgs:  []interface{}{day.Add(10 * time.Hour)},
+		},
+		{
+			name: "Testcase #6: Positive", param: Param{Q: " 50%_off "}, conditions: []string{"deleted_at IS NULL"},
+			wantWhere: "WHERE deleted_at IS NULL AND (first_name LIKE ? OR email LIKE ?)",
+			wantArgs:  []interface{}{`%50\%\_off%`, `%50\%\_off%`},
+		},
+		{
+			name: "Testcase #7: Negative", param: Param{Filters: url.Values{"created_from": {"yesterday"}}}, wantError: ErrInvalidFilter,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			where, args, err := spec.Where(tt.param, tt.conditions...)
+			if tt.wantError != nil {
+				assert.True(t, errors.Is(err, tt.wantError))
+				assert.True(t, IsInvalid(err))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantWhere, where)
+			assert.Equal(t, tt.wantArgs, args)
+		})
+	}
+}
+
+func TestOrderBy(t *testing.T) {
+	testCase := []struct {
+		name      string
+		spec      Spec
+		sort      string
+		want      string
+		wantError error
+	}{
+		{
+			name: "Testcase #1: Positive", spec: spec, sort: "", want: "ORDER BY id DESC",
+		},
+		{
+			name: "Testcase #2: Positive", spec: spec, sort: "name,-created_at", want: "ORDER BY first_name ASC, created_at DESC, id DESC",
+		},
+		{
+			name: "Testcase #3: Positive", spec: spec, sort: "id", want: "ORDER BY id ASC",
+		},
+		{
+			name: "Testcase #4: Positive", spec: Spec{}, sort: "", want: "",
+		},
+		{
+			name: "Testcase #5: Negative", spec: spec, sort: "password", wantError: ErrInvalidSort,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			orderBy, err := tt.spec.OrderBy(Param{Sort: tt.sort})
+			if tt.wantError != nil {
+				assert.True(t, errors.Is(err, tt.wantError))
+				assert.True(t, IsInvalid(err))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, orderBy)
+		})
+	}
+}
+
+func TestIsInvalid(t *testing.T) {
+	assert.False(t, IsInvalid(errors.New("error")))
+	assert.False(t, IsInvalid(nil))
+}
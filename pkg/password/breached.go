@@ -0,0 +1,79 @@
+package password
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"os"
+	"strings"
+)
+
+var (
+	PREFIXLENGTH = 5
+
+	ErrInvalidBreachedLine = errors.New("invalid breached password line")
+)
+
+// Breached is an offline list of SHA-1 hashes of leaked passwords, indexed by
+// the 5 character prefix the way the Pwned Passwords ranges are.
+type Breached struct {
+	ranges map[string]map[string]struct{}
+}
+
+// LoadBreached reads one uppercase or lowercase SHA-1 per line, an optional
+// ":count" suffix and lines starting with # are ignored.
+func LoadBreached(path string) (breached *Breached, err error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	breached = &Breached{
+		ranges: map[string]map[string]struct{}{},
+	}
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		hash, _, _ := strings.Cut(line, ":")
+		hash = strings.ToUpper(hash)
+		_, err = hex.DecodeString(hash)
+		if err != nil || len(hash) != sha1.Size*2 {
+			breached, err = nil, ErrInvalidBreachedLine
+			return
+		}
+		breached.add(hash)
+	}
+
+	err = scanner.Err()
+	if err != nil {
+		breached = nil
+	}
+	return
+}
+
+// Contains is safe on a nil list, it then knows no breached password.
+func (b *Breached) Contains(password string) bool {
+	if b == nil {
+		return false
+	}
+
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	_, found := b.ranges[hash[:PREFIXLENGTH]][hash[PREFIXLENGTH:]]
+	return found
+}
+
+func (b *Breached) add(hash string) {
+	prefix, suffix := hash[:PREFIXLENGTH], hash[PREFIXLENGTH:]
+	if b.ranges[prefix] == nil {
+		b.ranges[prefix] = map[string]struct{}{}
+	}
+	b.ranges[prefix][suffix] = struct{}{}
+}
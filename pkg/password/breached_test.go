@@ -0,0 +1,53 @@
+package password
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadBreached(t *testing.T) {
+	testCase := []struct {
+		name, content string
+		wantError     error
+	}{
+		{
+			name:    "Testcase #1: Positive",
+			content: "# leaked\n5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8:9545824\n\n21bd12dc183f740ee76f27b78eb39c8ad972a757\n",
+		},
+		{
+			name: "Testcase #2: Negative", content: "5BAA61E4C9B93F3F\n", wantError: ErrInvalidBreachedLine,
+		},
+		{
+			name: "Testcase #3: Negative", content: "ZZAA61E4C9B93F3F0682250B6CF8331B7EE68FD8\n", wantError: ErrInvalidBreachedLine,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "breached.txt")
+			assert.NoError(t, os.WriteFile(path, []byte(tt.content), 0o600))
+
+			breached, err := LoadBreached(path)
+			assert.Equal(t, tt.wantError, err)
+			if tt.wantError != nil {
+				assert.Nil(t, breached)
+				return
+			}
+			assert.True(t, breached.Contains("password"))
+			assert.True(t, breached.Contains("P@ssw0rd"))
+			assert.False(t, breached.Contains("Correct-Horse-9"))
+		})
+	}
+
+	t.Run("Testcase #4: Negative", func(t *testing.T) {
+		_, err := LoadBreached(filepath.Join(t.TempDir(), "missing.txt"))
+		assert.Error(t, err)
+	})
+}
+
+func TestBreachedNil(t *testing.T) {
+	var breached *Breached
+	assert.False(t, breached.Contains("password"))
+}
@@ -0,0 +1,117 @@
+package password
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	pErrors "github.com/rzfhlv/gin-example/pkg/errors"
+)
+
+var (
+	FIELD = "password"
+
+	// identities shorter than this are too common to reject on
+	MINIDENTITY = 3
+)
+
+// FieldError is one broken rule, serialized as the result of a 422.
+type FieldError = pErrors.FieldError
+
+// Error collects every rule the password breaks so the client can show them
+// all at once.
+type Error struct {
+	Fields []FieldError
+}
+
+func (e *Error) Error() string {
+	messages := make([]string, 0, len(e.Fields))
+	for _, field := range e.Fields {
+		messages = append(messages, field.Field+" "+field.Message)
+	}
+	return strings.Join(messages, ", ")
+}
+
+type Checker interface {
+	Check(password string, identities ...string) (err error)
+}
+
+type Config struct {
+	MinLength     int
+	RequireUpper  bool
+	RequireLower  bool
+	RequireDigit  bool
+	RequireSymbol bool
+	Breached      *Breached
+}
+
+type Policy struct {
+	cfg Config
+}
+
+func New(cfg Config) Checker {
+	return &Policy{
+		cfg: cfg,
+	}
+}
+
+// Check validates the password against the policy, identities are the
+// username, email or names it must not contain.
+func (p *Policy) Check(password string, identities ...string) (err error) {
+	fields := []FieldError{}
+	reject := func(format string, args ...interface{}) {
+		fields = append(fields, FieldError{Field: FIELD, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if utf8.RuneCountInString(password) < p.cfg.MinLength {
+		reject("must be at least %d characters", p.cfg.MinLength)
+	}
+
+	var upper, lower, digit, symbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.IsSpace(r):
+			symbol = true
+		}
+	}
+	if p.cfg.RequireUpper && !upper {
+		reject("must contain an uppercase letter")
+	}
+	if p.cfg.RequireLower && !lower {
+		reject("must contain a lowercase letter")
+	}
+	if p.cfg.RequireDigit && !digit {
+		reject("must contain a digit")
+	}
+	if p.cfg.RequireSymbol && !symbol {
+		reject("must contain a symbol")
+	}
+
+	lowered := strings.ToLower(password)
+	for _, identity := range identities {
+		identity = strings.ToLower(strings.TrimSpace(identity))
+		if local, _, found := strings.Cut(identity, "@"); found && local != "" {
+			identity = local
+		}
+		if utf8.RuneCountInString(identity) >= MINIDENTITY && strings.Contains(lowered, identity) {
+			reject("must not contain your username, email or name")
+			break
+		}
+	}
+
+	if p.cfg.Breached.Contains(password) {
+		reject("has appeared in a data breach, choose another one")
+	}
+
+	if len(fields) > 0 {
+		err = &Error{Fields: fields}
+	}
+	return
+}
@@ -0,0 +1,89 @@
+package password
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheck(t *testing.T) {
+	breached := &Breached{ranges: map[string]map[string]struct{}{}}
+	breached.add("21BD12DC183F740EE76F27B78EB39C8AD972A757")
+
+	policy := New(Config{
+		MinLength:     8,
+		RequireUpper:  true,
+		RequireLower:  true,
+		RequireDigit:  true,
+		RequireSymbol: true,
+		Breached:      breached,
+	})
+
+	testCase := []struct {
+		name, password string
+		identities     []string
+		messages       []string
+	}{
+		{
+			name: "Testcase #1: Positive", password: "Correct-Horse-9", identities: []string{"johndoe", "john@test.com"},
+		},
+		{
+			name: "Testcase #2: Negative", password: "Sh0rt!", messages: []string{"must be at least 8 characters"},
+		},
+		{
+			name: "Testcase #3: Negative", password: "alllowercase", messages: []string{
+				"must contain an uppercase letter", "must contain a digit", "must contain a symbol",
+			},
+		},
+		{
+			name: "Testcase #4: Negative", password: "ALLUPPER-9", messages: []string{"must contain a lowercase letter"},
+		},
+		{
+			name: "Testcase #5: Negative", password: "I-am-JohnDoe-1", identities: []string{"johndoe", "john@test.com"}, messages: []string{
+				"must not contain your username, email or name",
+			},
+		},
+		{
+			name: "Testcase #6: Negative", password: "Hello-John-1", identities: []string{"someone", "john@test.com"}, messages: []string{
+				"must not contain your username, email or name",
+			},
+		},
+		{
+			name: "Testcase #7: Positive", password: "Hello-Jo-1234", identities: []string{"jo", ""},
+		},
+		{
+			name: "Testcase #8: Negative", password: "P@ssw0rd", messages: []string{"has appeared in a data breach, choose another one"},
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			err := policy.Check(tt.password, tt.identities...)
+			if len(tt.messages) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+
+			invalid, ok := err.(*Error)
+			assert.True(t, ok)
+			messages := []string{}
+			for _, field := range invalid.Fields {
+				assert.Equal(t, FIELD, field.Field)
+				messages = append(messages, field.Message)
+			}
+			assert.Equal(t, tt.messages, messages)
+		})
+	}
+}
+
+func TestCheckZeroPolicy(t *testing.T) {
+	err := New(Config{}).Check("x")
+	assert.NoError(t, err)
+}
+
+func TestError(t *testing.T) {
+	err := &Error{Fields: []FieldError{
+		{Field: FIELD, Message: "must contain a digit"},
+		{Field: FIELD, Message: "must contain a symbol"},
+	}}
+	assert.Equal(t, "password must contain a digit, password must contain a symbol", err.Error())
+}
@@ -0,0 +1,128 @@
+package rbac
+
+import (
+	"errors"
+	"sort"
+
+	pErrors "github.com/rzfhlv/gin-example/pkg/errors"
+	"github.com/rzfhlv/gin-example/pkg/message"
+)
+
+var (
+	ADMIN     = "admin"
+	ORGANIZER = "organizer"
+	MEMBER    = "member"
+
+	DEFAULTROLE = MEMBER
+
+	// permissions are named resource:action
+	ALL                = "*"
+	USERSREAD          = "users:read"
+	USERSWRITE         = "users:write"
+	USERSIMPERSONATE   = "users:impersonate"
+	ROLESWRITE         = "roles:write"
+	MEMBERSREAD        = "members:read"
+	MEMBERSWRITE       = "members:write"
+	MEMBERSMANAGE      = "members:manage"
+	GATHERINGSREAD     = "gatherings:read"
+	GATHERINGSWRITE    = "gatherings:write"
+	GATHERINGSMANAGE   = "gatherings:manage"
+	INVITATIONSREAD    = "invitations:read"
+	INVITATIONSWRITE   = "invitations:write"
+	INVITATIONSRESPOND = "invitations:respond"
+	INVITATIONSMANAGE  = "invitations:manage"
+	APIKEYSMANAGE      = "api-keys:manage"
+	TOKENSINTROSPECT   = "tokens:introspect"
+	TOKENSREVOKE       = "tokens:revoke"
+
+	ErrUnknownRole       = errors.New("unknown role")
+	ErrUnknownPermission = errors.New("unknown permission")
+	ErrForbidden         = pErrors.Forbidden(message.FORBIDDEN)
+)
+
+// Permissions are all the named permissions, API key scopes must be one of
+// them.
+var Permissions = []string{
+	USERSREAD, USERSWRITE, USERSIMPERSONATE, ROLESWRITE,
+	MEMBERSREAD, MEMBERSWRITE, MEMBERSMANAGE,
+	GATHERINGSREAD, GATHERINGSWRITE, GATHERINGSMANAGE,
+	INVITATIONSREAD, INVITATIONSWRITE, INVITATIONSRESPOND, INVITATIONSMANAGE,
+	APIKEYSMANAGE,
+	TOKENSINTROSPECT, TOKENSREVOKE,
+}
+
+// Policy lists the permissions granted by each role, the roles themselves are
+// stored in MySQL and must match these names. A manage permission lets the
+// holder act on resources they do not own.
+var Policy = map[string][]string{
+	ADMIN: {ALL},
+	ORGANIZER: {
+		MEMBERSREAD, MEMBERSWRITE,
+		GATHERINGSREAD, GATHERINGSWRITE,
+		INVITATIONSREAD, INVITATIONSWRITE, INVITATIONSRESPOND,
+	},
+	MEMBER: {
+		GATHERINGSREAD,
+		INVITATIONSRESPOND,
+	},
+}
+
+func IsRole(role string) bool {
+	_, ok := Policy[role]
+	return ok
+}
+
+func IsPermission(permission string) bool {
+	for _, known := range Permissions {
+		if known == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// Scoped checks an API key, its scopes are permissions granted one by one
+// and only count while the roles of its owner still grant them too.
+func Scoped(roles, scopes []string, permission string) bool {
+	for _, scope := range scopes {
+		if scope == permission {
+			return Can(roles, permission)
+		}
+	}
+	return false
+}
+
+func Can(roles []string, permission string) bool {
+	for _, role := range roles {
+		for _, granted := range Policy[role] {
+			if granted == ALL || granted == permission {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Grants lists the permissions held through the roles, the wildcard is
+// expanded so the list can be handed to other services as scopes.
+func Grants(roles []string) (permissions []string) {
+	granted := map[string]bool{}
+	for _, role := range roles {
+		for _, permission := range Policy[role] {
+			if permission == ALL {
+				for _, known := range Permissions {
+					granted[known] = true
+				}
+				continue
+			}
+			granted[permission] = true
+		}
+	}
+
+	permissions = []string{}
+	for permission := range granted {
+		permissions = append(permissions, permission)
+	}
+	sort.Strings(permissions)
+	return
+}
@@ -0,0 +1,73 @@
+package rbac
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsRole(t *testing.T) {
+	assert.True(t, IsRole(ADMIN))
+	assert.True(t, IsRole(ORGANIZER))
+	assert.True(t, IsRole(MEMBER))
+	assert.False(t, IsRole("root"))
+}
+
+func TestIsPermission(t *testing.T) {
+	assert.True(t, IsPermission(GATHERINGSREAD))
+	assert.True(t, IsPermission(APIKEYSMANAGE))
+	assert.False(t, IsPermission(ALL))
+	assert.False(t, IsPermission("gatherings:delete"))
+}
+
+func TestScoped(t *testing.T) {
+	scopes := []string{GATHERINGSREAD, INVITATIONSWRITE}
+	assert.True(t, Scoped([]string{ORGANIZER}, scopes, INVITATIONSWRITE))
+	assert.False(t, Scoped([]string{ORGANIZER}, scopes, GATHERINGSWRITE))
+	assert.False(t, Scoped([]string{MEMBER}, scopes, INVITATIONSWRITE))
+	assert.False(t, Scoped([]string{ADMIN}, nil, GATHERINGSREAD))
+}
+
+func TestCan(t *testing.T) {
+	testCase := []struct {
+		name       string
+		roles      []string
+		permission string
+		want       bool
+	}{
+		{
+			name: "Testcase #1: Positive", roles: []string{ADMIN}, permission: ROLESWRITE, want: true,
+		},
+		{
+			name: "Testcase #2: Positive", roles: []string{ORGANIZER}, permission: GATHERINGSWRITE, want: true,
+		},
+		{
+			name: "Testcase #3: Positive", roles: []string{MEMBER, ORGANIZER}, permission: MEMBERSWRITE, want: true,
+		},
+		{
+			name: "Testcase #4: Negative", roles: []string{MEMBER}, permission: GATHERINGSWRITE, want: false,
+		},
+		{
+			name: "Testcase #5: Negative", roles: []string{ORGANIZER}, permission: USERSREAD, want: false,
+		},
+		{
+			name: "Testcase #6: Negative", roles: nil, permission: GATHERINGSREAD, want: false,
+		},
+		{
+			name: "Testcase #7: Negative", roles: []string{"root"}, permission: GATHERINGSREAD, want: false,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Can(tt.roles, tt.permission))
+		})
+	}
+}
+
+func TestGrants(t *testing.T) {
+	assert.Equal(t, []string{GATHERINGSREAD, INVITATIONSRESPOND}, Grants([]string{MEMBER}))
+	assert.Equal(t, []string{GATHERINGSREAD, INVITATIONSRESPOND}, Grants([]string{MEMBER, MEMBER}))
+	assert.Len(t, Grants([]string{ADMIN, MEMBER}), len(Permissions))
+	assert.Empty(t, Grants(nil))
+	assert.Empty(t, Grants([]string{"root"}))
+}
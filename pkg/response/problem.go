@@ -0,0 +1,90 @@
+package response
+
+import (
+	"mime"
+	"net/http"
+	"strings"
+	"unicode"
+
+	"github.com/gin-gonic/gin"
+	pErrors "github.com/rzfhlv/gin-example/pkg/errors"
+	"github.com/rzfhlv/gin-example/pkg/message"
+)
+
+var (
+	PROBLEMCONTENTTYPE = "application/problem+json"
+	// PROBLEMKEY is set on the context when every error of the request is
+	// answered as a problem, whatever it accepts
+	PROBLEMKEY = "response.problem"
+	// a problem without a type of its own is described by its status
+	BLANKTYPE = "about:blank"
+)
+
+// Problem is an error answered as RFC 7807 application/problem+json. Code is
+// stable where Detail is meant for people.
+type Problem struct {
+	Type     string               `json:"type"`
+	Title    string               `json:"title"`
+	Status   int                  `json:"status"`
+	Detail   string               `json:"detail,omitempty"`
+	Instance string               `json:"instance,omitempty"`
+	Code     string               `json:"code"`
+	Errors   []pErrors.FieldError `json:"errors,omitempty"`
+}
+
+func NewProblem(status int, detail, instance string, fields []pErrors.FieldError) Problem {
+	return Problem{
+		Type:     BLANKTYPE,
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   detail,
+		Instance: instance,
+		Code:     Code(status, detail),
+		Errors:   fields,
+	}
+}
+
+// Error replies with an error, as a problem when the request wants one and
+// in the status and message envelope otherwise. The fields are the result of
+// the envelope.
+func Error(g *gin.Context, status int, detail string, fields ...pErrors.FieldError) {
+	if !WantsProblem(g) {
+		var result interface{}
+		if len(fields) > 0 {
+			result = fields
+		}
+		g.JSON(status, Set(message.ERROR, detail, nil, result))
+		return
+	}
+
+	g.Header("Content-Type", PROBLEMCONTENTTYPE)
+	g.JSON(status, NewProblem(status, detail, g.Request.URL.Path, fields))
+}
+
+// WantsProblem reports whether errors of the request are answered as
+// problems, because it was switched on for all or the client accepts them.
+func WantsProblem(g *gin.Context) bool {
+	if g.GetBool(PROBLEMKEY) {
+		return true
+	}
+
+	for _, accept := range strings.Split(g.GetHeader("Accept"), ",") {
+		mediaType, _, err := mime.ParseMediaType(accept)
+		if err == nil && mediaType == PROBLEMCONTENTTYPE {
+			return true
+		}
+	}
+	return false
+}
+
+// Code is the machine readable code of detail, a detail without one falls
+// back to its status, "Not Found" is not_found.
+func Code(status int, detail string) string {
+	if code, ok := message.CODES[detail]; ok {
+		return code
+	}
+	words := strings.FieldsFunc(strings.ToLower(http.StatusText(status)), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(words, "_")
+}
@@ -0,0 +1,82 @@
+package response
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	pErrors "github.com/rzfhlv/gin-example/pkg/errors"
+	"github.com/rzfhlv/gin-example/pkg/message"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestError(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	fields := []pErrors.FieldError{{Field: "password", Message: "must contain a digit"}}
+
+	testCase := []struct {
+		name        string
+		accept      string
+		always      bool
+		detail      string
+		fields      []pErrors.FieldError
+		contentType string
+		body        string
+	}{
+		{
+			name: "Testcase #1: Positive", detail: message.EMAILEXIST,
+			contentType: "application/json; charset=utf-8",
+			body:        `{"status":"error","message":"Email Exist"}`,
+		},
+		{
+			name: "Testcase #2: Positive", detail: message.WEAKPASSWORD, fields: fields,
+			contentType: "application/json; charset=utf-8",
+			body:        `{"status":"error","message":"Password Does Not Meet The Policy","result":[{"field":"password","message":"must contain a digit"}]}`,
+		},
+		{
+			name: "Testcase #3: Positive", accept: "application/problem+json", detail: message.EMAILEXIST,
+			contentType: PROBLEMCONTENTTYPE,
+			body:        `{"type":"about:blank","title":"Conflict","status":409,"detail":"Email Exist","instance":"/v1/members","code":"email_exists"}`,
+		},
+		{
+			name: "Testcase #4: Positive", accept: "application/json, application/problem+json; q=0.9", detail: message.WEAKPASSWORD, fields: fields,
+			contentType: PROBLEMCONTENTTYPE,
+			body: `{"type":"about:blank","title":"Conflict","status":409,"detail":"Password Does Not Meet The Policy","instance":"/v1/members",` +
+				`"code":"weak_password","errors":[{"field":"password","message":"must contain a digit"}]}`,
+		},
+		{
+			name: "Testcase #5: Positive", always: true, detail: "Gathering Not Found",
+			contentType: PROBLEMCONTENTTYPE,
+			body:        `{"type":"about:blank","title":"Conflict","status":409,"detail":"Gathering Not Found","instance":"/v1/members","code":"conflict"}`,
+		},
+		{
+			name: "Testcase #6: Negative", accept: "application/problem", detail: message.EMAILEXIST,
+			contentType: "application/json; charset=utf-8",
+			body:        `{"status":"error","message":"Email Exist"}`,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = httptest.NewRequest(http.MethodPost, "/v1/members", nil)
+			ctx.Request.Header.Set("Accept", tt.accept)
+			if tt.always {
+				ctx.Set(PROBLEMKEY, true)
+			}
+
+			Error(ctx, http.StatusConflict, tt.detail, tt.fields...)
+			assert.Equal(t, http.StatusConflict, w.Code)
+			assert.Equal(t, tt.contentType, w.Header().Get("Content-Type"))
+			assert.JSONEq(t, tt.body, w.Body.String())
+		})
+	}
+}
+
+func TestCode(t *testing.T) {
+	assert.Equal(t, "invalid_mfa_code", Code(http.StatusUnauthorized, message.INVALIDMFACODE))
+	assert.Equal(t, "not_found", Code(http.StatusNotFound, "Gathering Not Found"))
+	assert.Equal(t, "unprocessable_entity", Code(http.StatusUnprocessableEntity, "invalid filter: type"))
+	assert.Equal(t, "i_m_a_teapot", Code(http.StatusTeapot, ""))
+}
@@ -2,6 +2,8 @@ package response
 
 import (
 	"math"
+	"net/url"
+	"strconv"
 
 	"github.com/rzfhlv/gin-example/pkg/param"
 )
@@ -14,25 +16,69 @@ type Response struct {
 }
 
 type Meta struct {
-	Limit     int   `json:"limit"`
-	Page      int   `json:"page"`
-	PerPage   int   `json:"perPage"`
-	PageCount int   `json:"pageCount"`
-	Total     int64 `json:"total"`
+	Limit   int `json:"limit"`
+	Page    int `json:"page,omitempty"`
+	PerPage int `json:"perPage"`
+	// PageCount and Total are left out when the list wasn't counted
+	PageCount  *int   `json:"pageCount,omitempty"`
+	Total      *int64 `json:"total,omitempty"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
+	Next       string `json:"next,omitempty"`
+	Prev       string `json:"prev,omitempty"`
 }
 
-func BuildMeta(param param.Param, data int) Meta {
-	pageCount := 0
-	if param.Limit > 0 {
-		pageCount = int(math.Ceil(float64(param.Total) / float64(param.Limit)))
+// BuildMeta describes the page of data rows found for param. The next and
+// prev links are u with the page or cursor that leads there, a list read by
+// page keeps being read by page.
+func BuildMeta(param param.Param, page param.Page, data int, u *url.URL) (meta Meta) {
+	meta = Meta{
+		Limit:      param.Limit,
+		PerPage:    data,
+		Total:      page.Total,
+		NextCursor: page.Next,
+		PrevCursor: page.Prev,
 	}
-	return Meta{
-		Limit:     param.Limit,
-		Page:      param.Page,
-		PerPage:   data,
-		PageCount: pageCount,
-		Total:     param.Total,
+	if page.Total != nil && param.Limit > 0 {
+		pageCount := int(math.Ceil(float64(*page.Total) / float64(param.Limit)))
+		meta.PageCount = &pageCount
 	}
+
+	if param.Cursor != "" {
+		if page.Next != "" {
+			meta.Next = link(u, "cursor", page.Next)
+		}
+		if page.Prev != "" {
+			meta.Prev = link(u, "cursor", page.Prev)
+		}
+		return
+	}
+
+	meta.Page = param.Page
+	hasNext := page.Next != ""
+	if meta.PageCount != nil {
+		hasNext = param.Page < *meta.PageCount
+	}
+	if hasNext {
+		meta.Next = link(u, "page", strconv.Itoa(param.Page+1))
+	}
+	if param.Page > 1 {
+		meta.Prev = link(u, "page", strconv.Itoa(param.Page-1))
+	}
+	return
+}
+
+// link is u with key set to value, a page and a cursor never go together.
+func link(u *url.URL, key, value string) string {
+	if u == nil {
+		return ""
+	}
+
+	query := u.Query()
+	query.Del("page")
+	query.Del("cursor")
+	query.Set(key, value)
+	return u.Path + "?" + query.Encode()
 }
 
 func Set(status string, message string, meta, result interface{}) Response {
@@ -0,0 +1,57 @@
+package response
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/rzfhlv/gin-example/pkg/param"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildMeta(t *testing.T) {
+	total, empty := int64(25), int64(0)
+	pageCount, none := 3, 0
+	u, _ := url.Parse("/v1/members?limit=10&page=2&sort=-created_at")
+	cursorURL, _ := url.Parse("/v1/members?limit=10&cursor=abc&sort=-created_at")
+
+	testCase := []struct {
+		name  string
+		param param.Param
+		page  param.Page
+		data  int
+		url   *url.URL
+		want  Meta
+	}{
+		{
+			name: "Testcase #1: Positive", param: param.Param{Limit: 10, Page: 2}, page: param.Page{Total: &total, Next: "n", Prev: "p"}, data: 10, url: u,
+			want: Meta{
+				Limit: 10, Page: 2, PerPage: 10, PageCount: &pageCount, Total: &total, NextCursor: "n", PrevCursor: "p",
+				Next: "/v1/members?limit=10&page=3&sort=-created_at", Prev: "/v1/members?limit=10&page=1&sort=-created_at",
+			},
+		},
+		{
+			name: "Testcase #2: Positive", param: param.Param{Limit: 10, Page: 1}, page: param.Page{Total: &empty}, url: u,
+			want: Meta{Limit: 10, Page: 1, PageCount: &none, Total: &empty},
+		},
+		{
+			name: "Testcase #3: Positive", param: param.Param{Limit: 10, Page: 1}, page: param.Page{Next: "n"}, data: 10, url: u,
+			want: Meta{Limit: 10, Page: 1, PerPage: 10, NextCursor: "n", Next: "/v1/members?limit=10&page=2&sort=-created_at"},
+		},
+		{
+			name: "Testcase #4: Positive", param: param.Param{Limit: 10, Page: 1, Cursor: "abc"}, page: param.Page{Next: "n", Prev: "p"}, data: 10, url: cursorURL,
+			want: Meta{
+				Limit: 10, PerPage: 10, NextCursor: "n", PrevCursor: "p",
+				Next: "/v1/members?cursor=n&limit=10&sort=-created_at", Prev: "/v1/members?cursor=p&limit=10&sort=-created_at",
+			},
+		},
+		{
+			name: "Testcase #5: Negative", param: param.Param{Limit: 10, Page: 1, Cursor: "abc"}, page: param.Page{Next: "n"}, data: 10,
+			want: Meta{Limit: 10, PerPage: 10, NextCursor: "n"},
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, BuildMeta(tt.param, tt.page, tt.data, tt.url))
+		})
+	}
+}
@@ -0,0 +1,367 @@
+package session
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+var (
+	SESSIONKEY     = "session:%s"
+	USERSESSIONKEY = "user_sessions:%d"
+	UPDATEATTEMPTS = 3
+
+	// SWAP sets KEYS[1] to ARGV[2] only while it still holds ARGV[1] and keeps
+	// its TTL, it answers -1 when the key is gone and 0 when it changed
+	SWAP = redis.NewScript(`
+local current = redis.call('GET', KEYS[1])
+if not current then
+	return -1
+end
+if current ~= ARGV[1] then
+	return 0
+end
+redis.call('SET', KEYS[1], ARGV[2], 'KEEPTTL')
+return 1
+`)
+
+	// EXTEND sets the TTL of KEYS[1] to ARGV[1] milliseconds unless it already
+	// lives longer, a key without a TTL yet gets one
+	EXTEND = redis.NewScript(`
+local ttl = redis.call('PTTL', KEYS[1])
+if ttl == -2 or (ttl >= 0 and ttl >= tonumber(ARGV[1])) then
+	return 0
+end
+redis.call('PEXPIRE', KEYS[1], ARGV[1])
+return 1
+`)
+
+	ErrNotFound = errors.New("session not found")
+	ErrConflict = errors.New("session changed concurrently")
+	ErrConsumed = errors.New("refresh token already used")
+)
+
+type Session struct {
+	ID         string    `json:"id"`
+	UserID     int64     `json:"user_id"`
+	Username   string    `json:"username"`
+	Email      string    `json:"email"`
+	IP         string    `json:"ip"`
+	UserAgent  string    `json:"user_agent"`
+	Refresh    string    `json:"refresh"`
+	ActorID    int64     `json:"actor_id,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+}
+
+// Fits reports whether a token of userID, impersonated by actorID or not
+// when 0, was issued for this session. A session started to impersonate
+// only takes the tokens of its actor.
+func (s Session) Fits(userID, actorID int64) bool {
+	return s.UserID == userID && s.ActorID == actorID
+}
+
+type Store interface {
+	Save(ctx context.Context, session Session, ttl time.Duration) (err error)
+	Get(ctx context.Context, id string) (session Session, err error)
+	TTL(ctx context.Context, id string) (ttl time.Duration, err error)
+	Touch(ctx context.Context, id string, lastSeenAt time.Time) (err error)
+	Consume(ctx context.Context, id, refresh string) (session Session, err error)
+	Rotate(ctx context.Context, rotated Session, ttl time.Duration) (session Session, err error)
+	List(ctx context.Context, userID int64) (sessions []Session, err error)
+	Revoke(ctx context.Context, userID int64, id string) (err error)
+	RevokeAll(ctx context.Context, userID int64) (err error)
+	RevokeOthers(ctx context.Context, userID int64, keepID string) (err error)
+	Rename(ctx context.Context, userID int64, username, email string) (err error)
+}
+
+type RedisStore struct {
+	redis *redis.Client
+}
+
+func New(redis *redis.Client) Store {
+	return &RedisStore{
+		redis: redis,
+	}
+}
+
+func (s *RedisStore) Save(ctx context.Context, session Session, ttl time.Duration) (err error) {
+	value, err := json.Marshal(session)
+	if err != nil {
+		return
+	}
+
+	err = s.redis.Set(ctx, fmt.Sprintf(SESSIONKEY, session.ID), string(value), ttl).Err()
+	if err != nil {
+		return
+	}
+
+	userKey := fmt.Sprintf(USERSESSIONKEY, session.UserID)
+	err = s.redis.SAdd(ctx, userKey, session.ID).Err()
+	if err != nil {
+		return
+	}
+
+	err = s.extend(ctx, userKey, ttl)
+	return
+}
+
+// extend keeps the index of a user alive as long as its longest session, a
+// short impersonation session must not cut it short. Stale members are
+// pruned while listing.
+func (s *RedisStore) extend(ctx context.Context, userKey string, ttl time.Duration) (err error) {
+	err = EXTEND.Run(ctx, s.redis, []string{userKey}, ttl.Milliseconds()).Err()
+	return
+}
+
+func (s *RedisStore) Get(ctx context.Context, id string) (session Session, err error) {
+	value, err := s.redis.Get(ctx, fmt.Sprintf(SESSIONKEY, id)).Result()
+	if err != nil {
+		if err == redis.Nil {
+			err = ErrNotFound
+		}
+		return
+	}
+
+	err = json.Unmarshal([]byte(value), &session)
+	return
+}
+
+// TTL is how long the session is still kept, which is as long as its
+// refresh token stays usable. It is 0 for a session kept without a TTL.
+func (s *RedisStore) TTL(ctx context.Context, id string) (ttl time.Duration, err error) {
+	ttl, err = s.redis.PTTL(ctx, fmt.Sprintf(SESSIONKEY, id)).Result()
+	if err != nil {
+		return
+	}
+
+	// PTTL answers -2 for a missing key and -1 for one without a TTL
+	switch ttl {
+	case -2:
+		ttl, err = 0, ErrNotFound
+	case -1:
+		ttl = 0
+	}
+	return
+}
+
+func (s *RedisStore) Touch(ctx context.Context, id string, lastSeenAt time.Time) (err error) {
+	_, err = s.update(ctx, id, func(session *Session) error {
+		session.LastSeenAt = lastSeenAt
+		return nil
+	})
+	return
+}
+
+// Consume takes the refresh token off the session while it is still the
+// current one, of two refreshes racing with the same token only one gets the
+// session. The other gets ErrConsumed along with the session it lost.
+func (s *RedisStore) Consume(ctx context.Context, id, refresh string) (session Session, err error) {
+	session, err = s.update(ctx, id, func(session *Session) error {
+		if session.Refresh != refresh {
+			return ErrConsumed
+		}
+		session.Refresh = ""
+		return nil
+	})
+	return
+}
+
+// Rotate stores the new refresh token and the device it was refreshed from on
+// the session and keeps it alive for ttl. Only those fields are written, a
+// rename meanwhile is kept and a session revoked meanwhile stays gone.
+func (s *RedisStore) Rotate(ctx context.Context, rotated Session, ttl time.Duration) (session Session, err error) {
+	session, err = s.update(ctx, rotated.ID, func(session *Session) error {
+		session.Refresh = rotated.Refresh
+		session.IP = rotated.IP
+		session.UserAgent = rotated.UserAgent
+		session.LastSeenAt = rotated.LastSeenAt
+		return nil
+	})
+	if err != nil {
+		return
+	}
+
+	// a session revoked since the swap has no key left to expire
+	err = s.redis.Expire(ctx, fmt.Sprintf(SESSIONKEY, session.ID), ttl).Err()
+	if err != nil {
+		return
+	}
+
+	err = s.extend(ctx, fmt.Sprintf(USERSESSIONKEY, session.UserID), ttl)
+	return
+}
+
+// update reads, changes and writes back the session as one compare-and-set,
+// a session revoked meanwhile stays gone and one changed meanwhile is read
+// again, so a write never brings back or overwrites what it didn't see.
+func (s *RedisStore) update(ctx context.Context, id string, change func(session *Session) error) (session Session, err error) {
+	key := fmt.Sprintf(SESSIONKEY, id)
+	for attempt := 0; attempt < UPDATEATTEMPTS; attempt++ {
+		var current string
+		current, err = s.redis.Get(ctx, key).Result()
+		if err != nil {
+			if err == redis.Nil {
+				err = ErrNotFound
+			}
+			return
+		}
+
+		session = Session{}
+		err = json.Unmarshal([]byte(current), &session)
+		if err != nil {
+			return
+		}
+
+		err = change(&session)
+		if err != nil {
+			return
+		}
+
+		var value []byte
+		value, err = json.Marshal(session)
+		if err != nil {
+			return
+		}
+
+		var swapped int64
+		swapped, err = SWAP.Run(ctx, s.redis, []string{key}, current, string(value)).Int64()
+		if err != nil {
+			return
+		}
+		switch swapped {
+		case 1:
+			return
+		case -1:
+			err = ErrNotFound
+			return
+		}
+	}
+
+	err = ErrConflict
+	return
+}
+
+func (s *RedisStore) List(ctx context.Context, userID int64) (sessions []Session, err error) {
+	userKey := fmt.Sprintf(USERSESSIONKEY, userID)
+	ids, err := s.redis.SMembers(ctx, userKey).Result()
+	if err != nil {
+		return
+	}
+
+	sessions = []Session{}
+	for _, id := range ids {
+		session, errGet := s.Get(ctx, id)
+		if errGet == ErrNotFound {
+			err = s.redis.SRem(ctx, userKey, id).Err()
+			if err != nil {
+				return
+			}
+			continue
+		}
+		if errGet != nil {
+			err = errGet
+			return
+		}
+		sessions = append(sessions, session)
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].CreatedAt.After(sessions[j].CreatedAt)
+	})
+	return
+}
+
+func (s *RedisStore) Revoke(ctx context.Context, userID int64, id string) (err error) {
+	session, err := s.Get(ctx, id)
+	if err != nil {
+		return
+	}
+
+	if session.UserID != userID {
+		err = ErrNotFound
+		return
+	}
+
+	err = s.redis.Del(ctx, fmt.Sprintf(SESSIONKEY, id)).Err()
+	if err != nil {
+		return
+	}
+
+	err = s.redis.SRem(ctx, fmt.Sprintf(USERSESSIONKEY, userID), id).Err()
+	return
+}
+
+func (s *RedisStore) RevokeAll(ctx context.Context, userID int64) (err error) {
+	userKey := fmt.Sprintf(USERSESSIONKEY, userID)
+	ids, err := s.redis.SMembers(ctx, userKey).Result()
+	if err != nil {
+		return
+	}
+
+	for _, id := range ids {
+		err = s.redis.Del(ctx, fmt.Sprintf(SESSIONKEY, id)).Err()
+		if err != nil {
+			return
+		}
+	}
+
+	err = s.redis.Del(ctx, userKey).Err()
+	return
+}
+
+func (s *RedisStore) RevokeOthers(ctx context.Context, userID int64, keepID string) (err error) {
+	userKey := fmt.Sprintf(USERSESSIONKEY, userID)
+	ids, err := s.redis.SMembers(ctx, userKey).Result()
+	if err != nil {
+		return
+	}
+
+	for _, id := range ids {
+		if id == keepID {
+			continue
+		}
+
+		err = s.redis.Del(ctx, fmt.Sprintf(SESSIONKEY, id)).Err()
+		if err != nil {
+			return
+		}
+
+		err = s.redis.SRem(ctx, userKey, id).Err()
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+// Rename rewrites the username and email kept in every session of the user,
+// refreshed tokens are issued from the session so it has to follow the user.
+// A session revoked while renaming is skipped.
+func (s *RedisStore) Rename(ctx context.Context, userID int64, username, email string) (err error) {
+	sessions, err := s.List(ctx, userID)
+	if err != nil {
+		return
+	}
+
+	for _, session := range sessions {
+		_, err = s.update(ctx, session.ID, func(session *Session) error {
+			session.Username = username
+			session.Email = email
+			return nil
+		})
+		if err == ErrNotFound {
+			err = nil
+			continue
+		}
+		if err != nil {
+			return
+		}
+	}
+	return
+}
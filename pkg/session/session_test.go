@@ -0,0 +1,597 @@
+package session
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redismock/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	ctx     = context.Background()
+	ttl     = time.Duration(1 * time.Hour)
+	errFoo  = errors.New("error")
+	now     = time.Date(2023, time.October, 21, 13, 0, 0, 0, time.UTC)
+	current = Session{
+		ID: "thisissession", UserID: 1, Username: "johndoe", Email: "johndoe@test.com",
+		IP: "127.0.0.1", UserAgent: "curl", Refresh: "hashed", CreatedAt: now, LastSeenAt: now,
+	}
+	sessionKey = "session:thisissession"
+	userKey    = "user_sessions:1"
+)
+
+func marshal(session Session) string {
+	value, _ := json.Marshal(session)
+	return string(value)
+}
+
+func TestNew(t *testing.T) {
+	client, _ := redismock.NewClientMock()
+
+	s := New(client)
+	assert.NotNil(t, s)
+}
+
+func TestFits(t *testing.T) {
+	impersonated := current
+	impersonated.ActorID = 9
+
+	assert.True(t, current.Fits(1, 0))
+	assert.False(t, current.Fits(2, 0))
+	assert.False(t, current.Fits(1, 9))
+	assert.True(t, impersonated.Fits(1, 9))
+	assert.False(t, impersonated.Fits(1, 0))
+}
+
+func TestSave(t *testing.T) {
+	t.Run("Testcase #1: Positive", func(t *testing.T) {
+		client, mock := redismock.NewClientMock()
+		s := &RedisStore{redis: client}
+
+		mock.ExpectSet(sessionKey, marshal(current), ttl).SetVal("OK")
+		mock.ExpectSAdd(userKey, current.ID).SetVal(1)
+		mock.ExpectEvalSha(EXTEND.Hash(), []string{userKey}, ttl.Milliseconds()).SetVal(int64(1))
+
+		err := s.Save(ctx, current, ttl)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Testcase #2: Positive", func(t *testing.T) {
+		client, mock := redismock.NewClientMock()
+		s := &RedisStore{redis: client}
+
+		// a short impersonation session only ever extends the index, the
+		// sessions already in it can still be listed and revoked
+		impersonated := current
+		impersonated.ID, impersonated.ActorID = "thisisimpersonation", 9
+		mock.ExpectSet("session:thisisimpersonation", marshal(impersonated), time.Minute).SetVal("OK")
+		mock.ExpectSAdd(userKey, impersonated.ID).SetVal(1)
+		mock.ExpectEvalSha(EXTEND.Hash(), []string{userKey}, time.Minute.Milliseconds()).SetVal(int64(0))
+		mock.ExpectSMembers(userKey).SetVal([]string{current.ID, impersonated.ID})
+		mock.ExpectDel(sessionKey).SetVal(1)
+		mock.ExpectDel("session:thisisimpersonation").SetVal(1)
+		mock.ExpectDel(userKey).SetVal(1)
+
+		err := s.Save(ctx, impersonated, time.Minute)
+		assert.NoError(t, err)
+		err = s.RevokeAll(ctx, current.UserID)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Testcase #3: Negative", func(t *testing.T) {
+		client, mock := redismock.NewClientMock()
+		s := &RedisStore{redis: client}
+
+		mock.ExpectSet(sessionKey, marshal(current), ttl).SetErr(errFoo)
+
+		err := s.Save(ctx, current, ttl)
+		assert.Error(t, err)
+	})
+
+	t.Run("Testcase #4: Negative", func(t *testing.T) {
+		client, mock := redismock.NewClientMock()
+		s := &RedisStore{redis: client}
+
+		mock.ExpectSet(sessionKey, marshal(current), ttl).SetVal("OK")
+		mock.ExpectSAdd(userKey, current.ID).SetErr(errFoo)
+
+		err := s.Save(ctx, current, ttl)
+		assert.Error(t, err)
+	})
+}
+
+func TestGet(t *testing.T) {
+	t.Run("Testcase #1: Positive", func(t *testing.T) {
+		client, mock := redismock.NewClientMock()
+		s := &RedisStore{redis: client}
+
+		mock.ExpectGet(sessionKey).SetVal(marshal(current))
+
+		result, err := s.Get(ctx, current.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, current, result)
+	})
+
+	t.Run("Testcase #2: Negative", func(t *testing.T) {
+		client, mock := redismock.NewClientMock()
+		s := &RedisStore{redis: client}
+
+		mock.ExpectGet(sessionKey).RedisNil()
+
+		_, err := s.Get(ctx, current.ID)
+		assert.Equal(t, ErrNotFound, err)
+	})
+
+	t.Run("Testcase #3: Negative", func(t *testing.T) {
+		client, mock := redismock.NewClientMock()
+		s := &RedisStore{redis: client}
+
+		mock.ExpectGet(sessionKey).SetVal("invalid")
+
+		_, err := s.Get(ctx, current.ID)
+		assert.Error(t, err)
+	})
+}
+
+func TestTTL(t *testing.T) {
+	testCase := []struct {
+		name      string
+		pttl      time.Duration
+		redisErr  error
+		want      time.Duration
+		wantError error
+	}{
+		{
+			name: "Testcase #1: Positive", pttl: time.Hour, want: time.Hour,
+		},
+		{
+			name: "Testcase #2: Positive", pttl: -1, want: 0,
+		},
+		{
+			name: "Testcase #3: Negative", pttl: -2, wantError: ErrNotFound,
+		},
+		{
+			name: "Testcase #4: Negative", redisErr: errFoo, wantError: errFoo,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			client, mock := redismock.NewClientMock()
+			s := &RedisStore{redis: client}
+
+			if tt.redisErr != nil {
+				mock.ExpectPTTL(sessionKey).SetErr(tt.redisErr)
+			} else {
+				mock.ExpectPTTL(sessionKey).SetVal(tt.pttl)
+			}
+
+			ttl, err := s.TTL(ctx, current.ID)
+			assert.Equal(t, tt.wantError, err)
+			assert.Equal(t, tt.want, ttl)
+		})
+	}
+}
+
+func TestTouch(t *testing.T) {
+	seen := now.Add(time.Hour)
+	touched := current
+	touched.LastSeenAt = seen
+	rotated := current
+	rotated.Refresh = "rotated"
+	rotatedTouched := rotated
+	rotatedTouched.LastSeenAt = seen
+
+	t.Run("Testcase #1: Positive", func(t *testing.T) {
+		client, mock := redismock.NewClientMock()
+		s := &RedisStore{redis: client}
+
+		mock.ExpectGet(sessionKey).SetVal(marshal(current))
+		mock.ExpectEvalSha(SWAP.Hash(), []string{sessionKey}, marshal(current), marshal(touched)).SetVal(int64(1))
+
+		err := s.Touch(ctx, current.ID, seen)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Testcase #2: Negative", func(t *testing.T) {
+		client, mock := redismock.NewClientMock()
+		s := &RedisStore{redis: client}
+
+		mock.ExpectGet(sessionKey).RedisNil()
+
+		err := s.Touch(ctx, current.ID, seen)
+		assert.Equal(t, ErrNotFound, err)
+	})
+
+	t.Run("Testcase #3: Negative", func(t *testing.T) {
+		client, mock := redismock.NewClientMock()
+		s := &RedisStore{redis: client}
+
+		// revoked between the read and the write, the session stays gone
+		mock.ExpectGet(sessionKey).SetVal(marshal(current))
+		mock.ExpectEvalSha(SWAP.Hash(), []string{sessionKey}, marshal(current), marshal(touched)).SetVal(int64(-1))
+
+		err := s.Touch(ctx, current.ID, seen)
+		assert.Equal(t, ErrNotFound, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Testcase #4: Positive", func(t *testing.T) {
+		client, mock := redismock.NewClientMock()
+		s := &RedisStore{redis: client}
+
+		// rotated between the read and the write, the new refresh is kept
+		mock.ExpectGet(sessionKey).SetVal(marshal(current))
+		mock.ExpectEvalSha(SWAP.Hash(), []string{sessionKey}, marshal(current), marshal(touched)).SetVal(int64(0))
+		mock.ExpectGet(sessionKey).SetVal(marshal(rotated))
+		mock.ExpectEvalSha(SWAP.Hash(), []string{sessionKey}, marshal(rotated), marshal(rotatedTouched)).SetVal(int64(1))
+
+		err := s.Touch(ctx, current.ID, seen)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Testcase #5: Negative", func(t *testing.T) {
+		client, mock := redismock.NewClientMock()
+		s := &RedisStore{redis: client}
+
+		for i := 0; i < UPDATEATTEMPTS; i++ {
+			mock.ExpectGet(sessionKey).SetVal(marshal(current))
+			mock.ExpectEvalSha(SWAP.Hash(), []string{sessionKey}, marshal(current), marshal(touched)).SetVal(int64(0))
+		}
+
+		err := s.Touch(ctx, current.ID, seen)
+		assert.Equal(t, ErrConflict, err)
+	})
+
+	t.Run("Testcase #6: Negative", func(t *testing.T) {
+		client, mock := redismock.NewClientMock()
+		s := &RedisStore{redis: client}
+
+		mock.ExpectGet(sessionKey).SetVal(marshal(current))
+		mock.ExpectEvalSha(SWAP.Hash(), []string{sessionKey}, marshal(current), marshal(touched)).SetErr(errFoo)
+
+		err := s.Touch(ctx, current.ID, seen)
+		assert.Equal(t, errFoo, err)
+	})
+}
+
+func TestConsume(t *testing.T) {
+	consumed := current
+	consumed.Refresh = ""
+
+	t.Run("Testcase #1: Positive", func(t *testing.T) {
+		client, mock := redismock.NewClientMock()
+		s := &RedisStore{redis: client}
+
+		mock.ExpectGet(sessionKey).SetVal(marshal(current))
+		mock.ExpectEvalSha(SWAP.Hash(), []string{sessionKey}, marshal(current), marshal(consumed)).SetVal(int64(1))
+
+		result, err := s.Consume(ctx, current.ID, current.Refresh)
+		assert.NoError(t, err)
+		assert.Equal(t, consumed, result)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Testcase #2: Negative", func(t *testing.T) {
+		client, mock := redismock.NewClientMock()
+		s := &RedisStore{redis: client}
+
+		mock.ExpectGet(sessionKey).SetVal(marshal(current))
+
+		result, err := s.Consume(ctx, current.ID, "replayed")
+		assert.Equal(t, ErrConsumed, err)
+		assert.Equal(t, current.UserID, result.UserID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Testcase #3: Negative", func(t *testing.T) {
+		client, mock := redismock.NewClientMock()
+		s := &RedisStore{redis: client}
+
+		// the other refresh won the race, this one sees the token gone
+		mock.ExpectGet(sessionKey).SetVal(marshal(current))
+		mock.ExpectEvalSha(SWAP.Hash(), []string{sessionKey}, marshal(current), marshal(consumed)).SetVal(int64(0))
+		mock.ExpectGet(sessionKey).SetVal(marshal(consumed))
+
+		_, err := s.Consume(ctx, current.ID, current.Refresh)
+		assert.Equal(t, ErrConsumed, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Testcase #4: Negative", func(t *testing.T) {
+		client, mock := redismock.NewClientMock()
+		s := &RedisStore{redis: client}
+
+		mock.ExpectGet(sessionKey).RedisNil()
+
+		_, err := s.Consume(ctx, current.ID, current.Refresh)
+		assert.Equal(t, ErrNotFound, err)
+	})
+}
+
+func TestRotate(t *testing.T) {
+	seen := now.Add(time.Hour)
+	rotated := Session{ID: current.ID, IP: "10.0.0.1", UserAgent: "wget", Refresh: "rotated", LastSeenAt: seen}
+	stored := current
+	stored.Refresh = ""
+	want := stored
+	want.IP, want.UserAgent, want.Refresh, want.LastSeenAt = "10.0.0.1", "wget", "rotated", seen
+	renamed := stored
+	renamed.Username = "janedoe"
+	wantRenamed := want
+	wantRenamed.Username = "janedoe"
+
+	t.Run("Testcase #1: Positive", func(t *testing.T) {
+		client, mock := redismock.NewClientMock()
+		s := &RedisStore{redis: client}
+
+		mock.ExpectGet(sessionKey).SetVal(marshal(stored))
+		mock.ExpectEvalSha(SWAP.Hash(), []string{sessionKey}, marshal(stored), marshal(want)).SetVal(int64(1))
+		mock.ExpectExpire(sessionKey, ttl).SetVal(true)
+		mock.ExpectEvalSha(EXTEND.Hash(), []string{userKey}, ttl.Milliseconds()).SetVal(int64(1))
+
+		result, err := s.Rotate(ctx, rotated, ttl)
+		assert.NoError(t, err)
+		assert.Equal(t, want, result)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Testcase #2: Negative", func(t *testing.T) {
+		client, mock := redismock.NewClientMock()
+		s := &RedisStore{redis: client}
+
+		// revoked between the consume and the rotate, the session stays gone
+		mock.ExpectGet(sessionKey).SetVal(marshal(stored))
+		mock.ExpectEvalSha(SWAP.Hash(), []string{sessionKey}, marshal(stored), marshal(want)).SetVal(int64(-1))
+
+		_, err := s.Rotate(ctx, rotated, ttl)
+		assert.Equal(t, ErrNotFound, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Testcase #3: Positive", func(t *testing.T) {
+		client, mock := redismock.NewClientMock()
+		s := &RedisStore{redis: client}
+
+		// renamed between the consume and the rotate, the new name is kept
+		mock.ExpectGet(sessionKey).SetVal(marshal(stored))
+		mock.ExpectEvalSha(SWAP.Hash(), []string{sessionKey}, marshal(stored), marshal(want)).SetVal(int64(0))
+		mock.ExpectGet(sessionKey).SetVal(marshal(renamed))
+		mock.ExpectEvalSha(SWAP.Hash(), []string{sessionKey}, marshal(renamed), marshal(wantRenamed)).SetVal(int64(1))
+		mock.ExpectExpire(sessionKey, ttl).SetVal(true)
+		mock.ExpectEvalSha(EXTEND.Hash(), []string{userKey}, ttl.Milliseconds()).SetVal(int64(1))
+
+		result, err := s.Rotate(ctx, rotated, ttl)
+		assert.NoError(t, err)
+		assert.Equal(t, "janedoe", result.Username)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Testcase #4: Negative", func(t *testing.T) {
+		client, mock := redismock.NewClientMock()
+		s := &RedisStore{redis: client}
+
+		mock.ExpectGet(sessionKey).SetVal(marshal(stored))
+		mock.ExpectEvalSha(SWAP.Hash(), []string{sessionKey}, marshal(stored), marshal(want)).SetVal(int64(1))
+		mock.ExpectExpire(sessionKey, ttl).SetErr(errFoo)
+
+		_, err := s.Rotate(ctx, rotated, ttl)
+		assert.Equal(t, errFoo, err)
+	})
+}
+
+func TestList(t *testing.T) {
+	older := current
+	older.ID = "oldersession"
+	older.CreatedAt = now.Add(-time.Hour)
+
+	t.Run("Testcase #1: Positive", func(t *testing.T) {
+		client, mock := redismock.NewClientMock()
+		s := &RedisStore{redis: client}
+
+		mock.ExpectSMembers(userKey).SetVal([]string{older.ID, "expiredsession", current.ID})
+		mock.ExpectGet("session:oldersession").SetVal(marshal(older))
+		mock.ExpectGet("session:expiredsession").RedisNil()
+		mock.ExpectSRem(userKey, "expiredsession").SetVal(1)
+		mock.ExpectGet(sessionKey).SetVal(marshal(current))
+
+		sessions, err := s.List(ctx, current.UserID)
+		assert.NoError(t, err)
+		assert.Equal(t, []Session{current, older}, sessions)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Testcase #2: Negative", func(t *testing.T) {
+		client, mock := redismock.NewClientMock()
+		s := &RedisStore{redis: client}
+
+		mock.ExpectSMembers(userKey).SetErr(errFoo)
+
+		_, err := s.List(ctx, current.UserID)
+		assert.Error(t, err)
+	})
+
+	t.Run("Testcase #3: Negative", func(t *testing.T) {
+		client, mock := redismock.NewClientMock()
+		s := &RedisStore{redis: client}
+
+		mock.ExpectSMembers(userKey).SetVal([]string{current.ID})
+		mock.ExpectGet(sessionKey).SetErr(errFoo)
+
+		_, err := s.List(ctx, current.UserID)
+		assert.Error(t, err)
+	})
+}
+
+func TestRevoke(t *testing.T) {
+	t.Run("Testcase #1: Positive", func(t *testing.T) {
+		client, mock := redismock.NewClientMock()
+		s := &RedisStore{redis: client}
+
+		mock.ExpectGet(sessionKey).SetVal(marshal(current))
+		mock.ExpectDel(sessionKey).SetVal(1)
+		mock.ExpectSRem(userKey, current.ID).SetVal(1)
+
+		err := s.Revoke(ctx, current.UserID, current.ID)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Testcase #2: Negative", func(t *testing.T) {
+		client, mock := redismock.NewClientMock()
+		s := &RedisStore{redis: client}
+
+		mock.ExpectGet(sessionKey).SetVal(marshal(current))
+
+		err := s.Revoke(ctx, 2, current.ID)
+		assert.Equal(t, ErrNotFound, err)
+	})
+
+	t.Run("Testcase #3: Negative", func(t *testing.T) {
+		client, mock := redismock.NewClientMock()
+		s := &RedisStore{redis: client}
+
+		mock.ExpectGet(sessionKey).SetVal(marshal(current))
+		mock.ExpectDel(sessionKey).SetErr(errFoo)
+
+		err := s.Revoke(ctx, current.UserID, current.ID)
+		assert.Error(t, err)
+	})
+}
+
+func TestRevokeAll(t *testing.T) {
+	t.Run("Testcase #1: Positive", func(t *testing.T) {
+		client, mock := redismock.NewClientMock()
+		s := &RedisStore{redis: client}
+
+		mock.ExpectSMembers(userKey).SetVal([]string{current.ID})
+		mock.ExpectDel(sessionKey).SetVal(1)
+		mock.ExpectDel(userKey).SetVal(1)
+
+		err := s.RevokeAll(ctx, current.UserID)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Testcase #2: Negative", func(t *testing.T) {
+		client, mock := redismock.NewClientMock()
+		s := &RedisStore{redis: client}
+
+		mock.ExpectSMembers(userKey).SetErr(errFoo)
+
+		err := s.RevokeAll(ctx, current.UserID)
+		assert.Error(t, err)
+	})
+
+	t.Run("Testcase #3: Negative", func(t *testing.T) {
+		client, mock := redismock.NewClientMock()
+		s := &RedisStore{redis: client}
+
+		mock.ExpectSMembers(userKey).SetVal([]string{current.ID})
+		mock.ExpectDel(sessionKey).SetErr(errFoo)
+
+		err := s.RevokeAll(ctx, current.UserID)
+		assert.Error(t, err)
+	})
+}
+
+func TestRevokeOthers(t *testing.T) {
+	t.Run("Testcase #1: Positive", func(t *testing.T) {
+		client, mock := redismock.NewClientMock()
+		s := &RedisStore{redis: client}
+
+		mock.ExpectSMembers(userKey).SetVal([]string{current.ID, "othersession"})
+		mock.ExpectDel("session:othersession").SetVal(1)
+		mock.ExpectSRem(userKey, "othersession").SetVal(1)
+
+		err := s.RevokeOthers(ctx, current.UserID, current.ID)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Testcase #2: Negative", func(t *testing.T) {
+		client, mock := redismock.NewClientMock()
+		s := &RedisStore{redis: client}
+
+		mock.ExpectSMembers(userKey).SetErr(errFoo)
+
+		err := s.RevokeOthers(ctx, current.UserID, current.ID)
+		assert.Error(t, err)
+	})
+
+	t.Run("Testcase #3: Negative", func(t *testing.T) {
+		client, mock := redismock.NewClientMock()
+		s := &RedisStore{redis: client}
+
+		mock.ExpectSMembers(userKey).SetVal([]string{"othersession"})
+		mock.ExpectDel("session:othersession").SetErr(errFoo)
+
+		err := s.RevokeOthers(ctx, current.UserID, current.ID)
+		assert.Error(t, err)
+	})
+}
+
+func TestRename(t *testing.T) {
+	renamed := current
+	renamed.Username = "janedoe"
+	renamed.Email = "jane@test.com"
+
+	t.Run("Testcase #1: Positive", func(t *testing.T) {
+		client, mock := redismock.NewClientMock()
+		s := &RedisStore{redis: client}
+
+		mock.ExpectSMembers(userKey).SetVal([]string{current.ID})
+		mock.ExpectGet(sessionKey).SetVal(marshal(current))
+		mock.ExpectGet(sessionKey).SetVal(marshal(current))
+		mock.ExpectEvalSha(SWAP.Hash(), []string{sessionKey}, marshal(current), marshal(renamed)).SetVal(int64(1))
+
+		err := s.Rename(ctx, current.UserID, renamed.Username, renamed.Email)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Testcase #2: Negative", func(t *testing.T) {
+		client, mock := redismock.NewClientMock()
+		s := &RedisStore{redis: client}
+
+		mock.ExpectSMembers(userKey).SetErr(errFoo)
+
+		err := s.Rename(ctx, current.UserID, renamed.Username, renamed.Email)
+		assert.Error(t, err)
+	})
+
+	t.Run("Testcase #3: Negative", func(t *testing.T) {
+		client, mock := redismock.NewClientMock()
+		s := &RedisStore{redis: client}
+
+		mock.ExpectSMembers(userKey).SetVal([]string{current.ID})
+		mock.ExpectGet(sessionKey).SetVal(marshal(current))
+		mock.ExpectGet(sessionKey).SetVal(marshal(current))
+		mock.ExpectEvalSha(SWAP.Hash(), []string{sessionKey}, marshal(current), marshal(renamed)).SetErr(errFoo)
+
+		err := s.Rename(ctx, current.UserID, renamed.Username, renamed.Email)
+		assert.Error(t, err)
+	})
+
+	t.Run("Testcase #4: Positive", func(t *testing.T) {
+		client, mock := redismock.NewClientMock()
+		s := &RedisStore{redis: client}
+
+		// revoked after listing, it is not written back
+		mock.ExpectSMembers(userKey).SetVal([]string{current.ID})
+		mock.ExpectGet(sessionKey).SetVal(marshal(current))
+		mock.ExpectGet(sessionKey).SetVal(marshal(current))
+		mock.ExpectEvalSha(SWAP.Hash(), []string{sessionKey}, marshal(current), marshal(renamed)).SetVal(int64(-1))
+
+		err := s.Rename(ctx, current.UserID, renamed.Username, renamed.Email)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
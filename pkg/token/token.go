@@ -0,0 +1,25 @@
+package token
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+var DEFAULTLENGTH = 32
+
+func Generate(length int) (token string, err error) {
+	b := make([]byte, length)
+	_, err = rand.Read(b)
+	if err != nil {
+		return
+	}
+	token = base64.RawURLEncoding.EncodeToString(b)
+	return
+}
+
+func Hash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,28 @@
+package token
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerate(t *testing.T) {
+	t.Run("Testcase #1: Positive", func(t *testing.T) {
+		first, err := Generate(DEFAULTLENGTH)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, first)
+
+		second, err := Generate(DEFAULTLENGTH)
+		assert.NoError(t, err)
+		assert.NotEqual(t, first, second)
+	})
+}
+
+func TestHash(t *testing.T) {
+	t.Run("Testcase #1: Positive", func(t *testing.T) {
+		hashed := Hash("token")
+		assert.Len(t, hashed, 64)
+		assert.Equal(t, hashed, Hash("token"))
+		assert.NotEqual(t, hashed, Hash("other"))
+	})
+}
@@ -0,0 +1,101 @@
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+var (
+	DIGITS     = 6
+	PERIOD     = 30 * time.Second
+	SKEW       = 1
+	SECRETSIZE = 20
+
+	encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+)
+
+// GenerateSecret returns a random base32 secret as authenticator apps expect.
+func GenerateSecret() (secret string, err error) {
+	b := make([]byte, SECRETSIZE)
+	_, err = rand.Read(b)
+	if err != nil {
+		return
+	}
+	secret = encoding.EncodeToString(b)
+	return
+}
+
+// URI builds the otpauth URI that authenticator apps read from a QR code.
+func URI(issuer, account, secret string) string {
+	query := url.Values{}
+	query.Set("secret", secret)
+	query.Set("issuer", issuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", fmt.Sprint(DIGITS))
+	query.Set("period", fmt.Sprint(int(PERIOD.Seconds())))
+
+	label := url.PathEscape(issuer + ":" + account)
+	return "otpauth://totp/" + label + "?" + query.Encode()
+}
+
+// Code computes the RFC 6238 code of the time step containing t.
+func Code(secret string, t time.Time) (code string, err error) {
+	key, err := encoding.DecodeString(strings.ToUpper(strings.TrimSpace(secret)))
+	if err != nil {
+		return
+	}
+	code = generate(key, uint64(t.Unix()/int64(PERIOD.Seconds())))
+	return
+}
+
+// Validate accepts a code from the current time step or SKEW steps around it
+// so a slightly drifting clock still works.
+func Validate(secret, code string, t time.Time) bool {
+	_, ok := Match(secret, code, t)
+	return ok
+}
+
+// Match is Validate that also tells the time step the code belongs to, a
+// caller remembering the last step used can refuse a replayed code.
+func Match(secret, code string, t time.Time) (step int64, ok bool) {
+	key, err := encoding.DecodeString(strings.ToUpper(strings.TrimSpace(secret)))
+	if err != nil || len(code) != DIGITS {
+		return
+	}
+
+	counter := t.Unix() / int64(PERIOD.Seconds())
+	for i := -SKEW; i <= SKEW; i++ {
+		expected := generate(key, uint64(counter+int64(i)))
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			step, ok = counter+int64(i), true
+			return
+		}
+	}
+	return
+}
+
+func generate(key []byte, counter uint64) string {
+	msg := make([]byte, 8)
+	binary.BigEndian.PutUint64(msg, counter)
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for i := 0; i < DIGITS; i++ {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", DIGITS, value%mod)
+}
@@ -0,0 +1,118 @@
+package totp
+
+import (
+	"encoding/base32"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// the RFC 6238 SHA1 test vectors truncated to six digits
+var (
+	rfcSecret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+	vectors   = []struct {
+		unix int64
+		code string
+	}{
+		{unix: 59, code: "287082"},
+		{unix: 1111111109, code: "081804"},
+		{unix: 1111111111, code: "050471"},
+		{unix: 1234567890, code: "005924"},
+		{unix: 2000000000, code: "279037"},
+	}
+)
+
+func TestGenerateSecret(t *testing.T) {
+	t.Run("Testcase #1: Positive", func(t *testing.T) {
+		first, err := GenerateSecret()
+		assert.NoError(t, err)
+		assert.Len(t, first, 32)
+
+		second, err := GenerateSecret()
+		assert.NoError(t, err)
+		assert.NotEqual(t, first, second)
+	})
+}
+
+func TestURI(t *testing.T) {
+	t.Run("Testcase #1: Positive", func(t *testing.T) {
+		uri := URI("gin-example", "johndoe@test.com", "SECRET")
+
+		parsed, err := url.Parse(uri)
+		assert.NoError(t, err)
+		assert.Equal(t, "otpauth", parsed.Scheme)
+		assert.Equal(t, "totp", parsed.Host)
+		assert.Equal(t, "/gin-example:johndoe@test.com", parsed.Path)
+		assert.Equal(t, "SECRET", parsed.Query().Get("secret"))
+		assert.Equal(t, "gin-example", parsed.Query().Get("issuer"))
+		assert.Equal(t, "6", parsed.Query().Get("digits"))
+	})
+}
+
+func TestCode(t *testing.T) {
+	t.Run("Testcase #1: Positive", func(t *testing.T) {
+		for _, v := range vectors {
+			code, err := Code(rfcSecret, time.Unix(v.unix, 0))
+			assert.NoError(t, err)
+			assert.Equal(t, v.code, code)
+		}
+	})
+
+	t.Run("Testcase #2: Negative", func(t *testing.T) {
+		_, err := Code("not base32!", time.Now())
+		assert.Error(t, err)
+	})
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+
+	testCase := []struct {
+		name, secret, code string
+		at                 time.Time
+		want               bool
+	}{
+		{
+			name: "Testcase #1: Positive", secret: rfcSecret, code: "050471", at: now, want: true,
+		},
+		{
+			name: "Testcase #2: Positive", secret: rfcSecret, code: "050471", at: now.Add(PERIOD), want: true,
+		},
+		{
+			name: "Testcase #3: Negative", secret: rfcSecret, code: "050471", at: now.Add(3 * PERIOD), want: false,
+		},
+		{
+			name: "Testcase #4: Negative", secret: rfcSecret, code: "123456", at: now, want: false,
+		},
+		{
+			name: "Testcase #5: Negative", secret: rfcSecret, code: "0504", at: now, want: false,
+		},
+		{
+			name: "Testcase #6: Negative", secret: "not base32!", code: "050471", at: now, want: false,
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Validate(tt.secret, tt.code, tt.at))
+		})
+	}
+}
+
+func TestMatch(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	step := now.Unix() / int64(PERIOD.Seconds())
+
+	matched, ok := Match(rfcSecret, "050471", now)
+	assert.True(t, ok)
+	assert.Equal(t, step, matched)
+
+	// a code from the step before is still accepted, as that step
+	matched, ok = Match(rfcSecret, "050471", now.Add(PERIOD))
+	assert.True(t, ok)
+	assert.Equal(t, step, matched)
+
+	_, ok = Match(rfcSecret, "123456", now)
+	assert.False(t, ok)
+}
@@ -0,0 +1,202 @@
+package validation
+
+import (
+	"encoding/json"
+	"errors"
+	"net/mail"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/id"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	enTranslations "github.com/go-playground/validator/v10/translations/en"
+	idTranslations "github.com/go-playground/validator/v10/translations/id"
+	pErrors "github.com/rzfhlv/gin-example/pkg/errors"
+)
+
+var (
+	EMAIL            = "email_format"
+	GATHERINGTYPE    = "gathering_type"
+	RFC3339          = "rfc3339"
+	INVITATIONSTATUS = "invitation_status"
+
+	// the values the enum columns of gatherings and invitations allow
+	GATHERINGTYPES     = []string{"family", "employee", "customer"}
+	INVITATIONSTATUSES = []string{"pending", "accept", "reject"}
+
+	translator *ut.UniversalTranslator
+
+	// MESSAGES are the translations of the custom tags by locale, {0} is the
+	// field and {1} what it must be
+	MESSAGES = map[string]map[string]string{
+		"en": {
+			EMAIL:            "{0} must be a valid email address",
+			GATHERINGTYPE:    "{0} must be one of {1}",
+			RFC3339:          "{0} must be an RFC 3339 date time",
+			INVITATIONSTATUS: "{0} must be one of {1}",
+		},
+		"id": {
+			EMAIL:            "{0} harus berupa alamat email yang valid",
+			GATHERINGTYPE:    "{0} harus salah satu dari {1}",
+			RFC3339:          "{0} harus berupa tanggal dan waktu RFC 3339",
+			INVITATIONSTATUS: "{0} harus salah satu dari {1}",
+		},
+	}
+)
+
+// the binding validator of gin is set up once for the whole app, before any
+// request is bound against the custom tags
+func init() {
+	err := setup(binding.Validator.Engine().(*validator.Validate))
+	if err != nil {
+		panic(err)
+	}
+}
+
+func setup(v *validator.Validate) (err error) {
+	// fields are reported by the name the client sent them with
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		for _, tag := range []string{"json", "form"} {
+			name := strings.Split(field.Tag.Get(tag), ",")[0]
+			if name == "-" {
+				return ""
+			}
+			if name != "" {
+				return name
+			}
+		}
+		return field.Name
+	})
+
+	custom := []struct {
+		tag    string
+		fn     validator.Func
+		values []string
+	}{
+		{tag: EMAIL, fn: func(fl validator.FieldLevel) bool { return IsEmail(fl.Field().String()) }},
+		{tag: GATHERINGTYPE, fn: enum(GATHERINGTYPES), values: GATHERINGTYPES},
+		{tag: RFC3339, fn: func(fl validator.FieldLevel) bool { return IsRFC3339(fl.Field().String()) }},
+		{tag: INVITATIONSTATUS, fn: enum(INVITATIONSTATUSES), values: INVITATIONSTATUSES},
+	}
+	for _, c := range custom {
+		err = v.RegisterValidation(c.tag, c.fn)
+		if err != nil {
+			return
+		}
+	}
+
+	english := en.New()
+	translator = ut.New(english, english, id.New())
+	defaults := map[string]func(*validator.Validate, ut.Translator) error{
+		"en": enTranslations.RegisterDefaultTranslations,
+		"id": idTranslations.RegisterDefaultTranslations,
+	}
+	for locale, register := range defaults {
+		trans, _ := translator.GetTranslator(locale)
+		err = register(v, trans)
+		if err != nil {
+			return
+		}
+
+		for _, c := range custom {
+			err = v.RegisterTranslation(c.tag, trans, addTranslation(c.tag, MESSAGES[locale][c.tag]), translate(c.values))
+			if err != nil {
+				return
+			}
+		}
+	}
+	return
+}
+
+// Translate lists what is wrong per field with a request that failed to
+// bind, in the first language of acceptLanguage there is a translation for.
+// English is the fallback. Errors that aren't about a field give nothing.
+func Translate(err error, acceptLanguage string) (fields []pErrors.FieldError) {
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		fields = append(fields, pErrors.FieldError{Field: typeErr.Field, Message: "has an invalid type"})
+		return
+	}
+
+	var invalid validator.ValidationErrors
+	if !errors.As(err, &invalid) {
+		return
+	}
+
+	trans, _ := translator.FindTranslator(locales(acceptLanguage)...)
+	for _, fe := range invalid {
+		fields = append(fields, pErrors.FieldError{Field: path(fe.Namespace()), Message: fe.Translate(trans)})
+	}
+	return
+}
+
+// IsEmail accepts a bare address only, no display name or angle brackets.
+func IsEmail(value string) bool {
+	address, err := mail.ParseAddress(value)
+	return err == nil && address.Address == value
+}
+
+func IsRFC3339(value string) bool {
+	_, err := time.Parse(time.RFC3339, value)
+	return err == nil
+}
+
+func enum(values []string) validator.Func {
+	return func(fl validator.FieldLevel) bool {
+		value := fl.Field().String()
+		for _, allowed := range values {
+			if value == allowed {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func addTranslation(tag, text string) validator.RegisterTranslationsFunc {
+	return func(trans ut.Translator) error {
+		return trans.Add(tag, text, true)
+	}
+}
+
+func translate(values []string) validator.TranslationFunc {
+	return func(trans ut.Translator, fe validator.FieldError) string {
+		text, err := trans.T(fe.Tag(), fe.Field(), strings.Join(values, ", "))
+		if err != nil {
+			return fe.Error()
+		}
+		return text
+	}
+}
+
+// path drops the struct the namespace starts with, Member.first_name is the
+// first_name of the body.
+func path(namespace string) string {
+	_, field, ok := strings.Cut(namespace, ".")
+	if !ok {
+		return namespace
+	}
+	return field
+}
+
+// locales reads an Accept-Language header, id-ID is tried as id_id and then
+// as id. The order of the header is kept, weights are ignored.
+func locales(acceptLanguage string) (found []string) {
+	for _, language := range strings.Split(acceptLanguage, ",") {
+		language, _, _ = strings.Cut(language, ";")
+		language = strings.ToLower(strings.TrimSpace(language))
+		if language == "" || language == "*" {
+			continue
+		}
+
+		found = append(found, strings.ReplaceAll(language, "-", "_"))
+		if base, _, ok := strings.Cut(language, "-"); ok {
+			found = append(found, base)
+		}
+	}
+	return
+}
@@ -0,0 +1,93 @@
+package validation
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/gin-gonic/gin/binding"
+	pErrors "github.com/rzfhlv/gin-example/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type attendee struct {
+	Email string `json:"email" binding:"required,email_format"`
+}
+
+type gathering struct {
+	Type       string     `json:"type" binding:"required,gathering_type"`
+	ScheduleAt string     `json:"schedule_at" binding:"required,rfc3339"`
+	Status     string     `form:"status" binding:"omitempty,invitation_status"`
+	Name       string     `binding:"max=5"`
+	Attendees  []attendee `json:"attendees" binding:"dive"`
+	Secret     string     `json:"-"`
+}
+
+func TestTranslate(t *testing.T) {
+	valid := gathering{Type: "family", ScheduleAt: "2023-11-10T12:00:00+07:00", Status: "accept", Attendees: []attendee{{Email: "john@test.com"}}}
+	invalid := gathering{Type: "party", ScheduleAt: "2023-11-10 12:00:00", Status: "maybe", Name: "too long", Attendees: []attendee{{Email: "John <john@test.com>"}}}
+
+	testCase := []struct {
+		name           string
+		err            error
+		acceptLanguage string
+		want           []pErrors.FieldError
+	}{
+		{
+			name: "Testcase #1: Positive", err: binding.Validator.ValidateStruct(invalid),
+			want: []pErrors.FieldError{
+				{Field: "type", Message: "type must be one of family, employee, customer"},
+				{Field: "schedule_at", Message: "schedule_at must be an RFC 3339 date time"},
+				{Field: "status", Message: "status must be one of pending, accept, reject"},
+				{Field: "Name", Message: "Name must be a maximum of 5 characters in length"},
+				{Field: "attendees[0].email", Message: "email must be a valid email address"},
+			},
+		},
+		{
+			name: "Testcase #2: Positive", err: binding.Validator.ValidateStruct(gathering{}), acceptLanguage: "id-ID,id;q=0.9,en;q=0.8",
+			want: []pErrors.FieldError{
+				{Field: "type", Message: "type wajib diisi"},
+				{Field: "schedule_at", Message: "schedule_at wajib diisi"},
+			},
+		},
+		{
+			name: "Testcase #3: Positive", err: binding.Validator.ValidateStruct(attendee{Email: "john"}), acceptLanguage: "fr-FR, *",
+			want: []pErrors.FieldError{{Field: "email", Message: "email must be a valid email address"}},
+		},
+		{
+			name: "Testcase #4: Positive", err: json.Unmarshal([]byte(`{"type":1}`), &gathering{}),
+			want: []pErrors.FieldError{{Field: "type", Message: "has an invalid type"}},
+		},
+		{
+			name: "Testcase #5: Negative", err: binding.Validator.ValidateStruct(valid),
+		},
+		{
+			name: "Testcase #6: Negative", err: errors.New("unexpected EOF"),
+		},
+	}
+	for _, tt := range testCase {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Translate(tt.err, tt.acceptLanguage))
+		})
+	}
+}
+
+func TestIsEmail(t *testing.T) {
+	assert.True(t, IsEmail("john@test.com"))
+	assert.False(t, IsEmail("john"))
+	assert.False(t, IsEmail("John <john@test.com>"))
+	assert.False(t, IsEmail(""))
+}
+
+func TestIsRFC3339(t *testing.T) {
+	assert.True(t, IsRFC3339("2023-11-10T12:00:00Z"))
+	assert.True(t, IsRFC3339("2023-11-10T12:00:00+07:00"))
+	assert.False(t, IsRFC3339("2023-11-10 12:00:00"))
+	assert.False(t, IsRFC3339("2023-11-10"))
+}
+
+func TestLocales(t *testing.T) {
+	assert.Equal(t, []string{"id_id", "id", "en"}, locales("id-ID,en;q=0.8"))
+	assert.Equal(t, []string{"en"}, locales(" EN , *"))
+	assert.Nil(t, locales(""))
+}
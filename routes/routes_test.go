@@ -5,19 +5,25 @@ import (
 
 	"github.com/rzfhlv/gin-example/config"
 	"github.com/rzfhlv/gin-example/internal"
+	"github.com/rzfhlv/gin-example/internal/modules/apikey"
 	"github.com/rzfhlv/gin-example/internal/modules/gathering"
 	healthcheck "github.com/rzfhlv/gin-example/internal/modules/health-check"
 	"github.com/rzfhlv/gin-example/internal/modules/invitation"
 	"github.com/rzfhlv/gin-example/internal/modules/member"
 	"github.com/rzfhlv/gin-example/internal/modules/user"
 	"github.com/rzfhlv/gin-example/middleware"
+	"github.com/rzfhlv/gin-example/pkg/hasher"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestRoutes(t *testing.T) {
+	hasher, err := hasher.New(hasher.Config{})
+	assert.NoError(t, err)
+
 	cfg := config.Config{
 		MySQL: nil,
 		Redis: nil,
+		Pkg:   config.Pkg{Hasher: hasher},
 	}
 	service := internal.Service{
 		HealthCheck: healthcheck.New(&cfg),
@@ -25,6 +31,7 @@ func TestRoutes(t *testing.T) {
 		Gathering:   gathering.New(&cfg),
 		Invitation:  invitation.New(&cfg),
 		User:        user.New(&cfg),
+		APIKey:      apikey.New(&cfg),
 		Middleware:  middleware.New(&cfg),
 	}
 	r := ListRoutes(&service)
@@ -12,6 +12,22 @@ type IAuth struct {
 	mock.Mock
 }
 
+// APIKey provides a mock function with given fields:
+func (_m *IAuth) APIKey() gin.HandlerFunc {
+	ret := _m.Called()
+
+	var r0 gin.HandlerFunc
+	if rf, ok := ret.Get(0).(func() gin.HandlerFunc); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(gin.HandlerFunc)
+		}
+	}
+
+	return r0
+}
+
 // Bearer provides a mock function with given fields:
 func (_m *IAuth) Bearer() gin.HandlerFunc {
 	ret := _m.Called()
@@ -28,6 +44,38 @@ func (_m *IAuth) Bearer() gin.HandlerFunc {
 	return r0
 }
 
+// Can provides a mock function with given fields: permission
+func (_m *IAuth) Can(permission string) gin.HandlerFunc {
+	ret := _m.Called(permission)
+
+	var r0 gin.HandlerFunc
+	if rf, ok := ret.Get(0).(func(string) gin.HandlerFunc); ok {
+		r0 = rf(permission)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(gin.HandlerFunc)
+		}
+	}
+
+	return r0
+}
+
+// Client provides a mock function with given fields: permission
+func (_m *IAuth) Client(permission string) gin.HandlerFunc {
+	ret := _m.Called(permission)
+
+	var r0 gin.HandlerFunc
+	if rf, ok := ret.Get(0).(func(string) gin.HandlerFunc); ok {
+		r0 = rf(permission)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(gin.HandlerFunc)
+		}
+	}
+
+	return r0
+}
+
 // NewIAuth creates a new instance of IAuth. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewIAuth(t interface {
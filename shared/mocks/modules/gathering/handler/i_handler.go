@@ -13,6 +13,11 @@ type IHandler struct {
 	mock.Mock
 }
 
+// AddOrganizer provides a mock function with given fields: g
+func (_m *IHandler) AddOrganizer(g *gin.Context) {
+	_m.Called(g)
+}
+
 // Create provides a mock function with given fields: g
 func (_m *IHandler) Create(g *gin.Context) {
 	_m.Called(g)
@@ -33,6 +38,11 @@ func (_m *IHandler) GetDetailByID(g *gin.Context) {
 	_m.Called(g)
 }
 
+// Update provides a mock function with given fields: g
+func (_m *IHandler) Update(g *gin.Context) {
+	_m.Called(g)
+}
+
 // NewIHandler creates a new instance of IHandler. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewIHandler(t interface {
@@ -18,23 +18,37 @@ type IRepository struct {
 	mock.Mock
 }
 
-// Count provides a mock function with given fields: ctx
-func (_m *IRepository) Count(ctx context.Context) (int64, error) {
-	ret := _m.Called(ctx)
+// AddOrganizer provides a mock function with given fields: ctx, gatheringID, memberID
+func (_m *IRepository) AddOrganizer(ctx context.Context, gatheringID int64, memberID int64) error {
+	ret := _m.Called(ctx, gatheringID, memberID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, gatheringID, memberID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Count provides a mock function with given fields: ctx, _a1
+func (_m *IRepository) Count(ctx context.Context, _a1 param.Param) (int64, error) {
+	ret := _m.Called(ctx, _a1)
 
 	var r0 int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
-		return rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, param.Param) (int64, error)); ok {
+		return rf(ctx, _a1)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) int64); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, param.Param) int64); ok {
+		r0 = rf(ctx, _a1)
 	} else {
 		r0 = ret.Get(0).(int64)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, param.Param) error); ok {
+		r1 = rf(ctx, _a1)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -69,12 +83,13 @@ func (_m *IRepository) Create(ctx context.Context, gathering model.Gathering) (s
 }
 
 // Get provides a mock function with given fields: ctx, _a1
-func (_m *IRepository) Get(ctx context.Context, _a1 param.Param) ([]model.Gathering, error) {
+func (_m *IRepository) Get(ctx context.Context, _a1 param.Param) ([]model.Gathering, param.Page, error) {
 	ret := _m.Called(ctx, _a1)
 
 	var r0 []model.Gathering
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, param.Param) ([]model.Gathering, error)); ok {
+	var r1 param.Page
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, param.Param) ([]model.Gathering, param.Page, error)); ok {
 		return rf(ctx, _a1)
 	}
 	if rf, ok := ret.Get(0).(func(context.Context, param.Param) []model.Gathering); ok {
@@ -85,13 +100,19 @@ func (_m *IRepository) Get(ctx context.Context, _a1 param.Param) ([]model.Gather
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, param.Param) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, param.Param) param.Page); ok {
 		r1 = rf(ctx, _a1)
 	} else {
-		r1 = ret.Error(1)
+		r1 = ret.Get(1).(param.Page)
 	}
 
-	return r0, r1
+	if rf, ok := ret.Get(2).(func(context.Context, param.Param) error); ok {
+		r2 = rf(ctx, _a1)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
 }
 
 // GetByID provides a mock function with given fields: ctx, id
@@ -142,6 +163,80 @@ func (_m *IRepository) GetDetailByID(ctx context.Context, id int64) (model.Gathe
 	return r0, r1
 }
 
+// GetMemberIDByUserID provides a mock function with given fields: ctx, userID
+func (_m *IRepository) GetMemberIDByUserID(ctx context.Context, userID int64) (int64, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (int64, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) int64); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IsOrganizer provides a mock function with given fields: ctx, gatheringID, memberID
+func (_m *IRepository) IsOrganizer(ctx context.Context, gatheringID int64, memberID int64) (bool, error) {
+	ret := _m.Called(ctx, gatheringID, memberID)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) (bool, error)); ok {
+		return rf(ctx, gatheringID, memberID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) bool); ok {
+		r0 = rf(ctx, gatheringID, memberID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, gatheringID, memberID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: ctx, gathering, id
+func (_m *IRepository) Update(ctx context.Context, gathering model.Gathering, id int64) (sql.Result, error) {
+	ret := _m.Called(ctx, gathering, id)
+
+	var r0 sql.Result
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.Gathering, int64) (sql.Result, error)); ok {
+		return rf(ctx, gathering, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.Gathering, int64) sql.Result); ok {
+		r0 = rf(ctx, gathering, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(sql.Result)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.Gathering, int64) error); ok {
+		r1 = rf(ctx, gathering, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewIRepository creates a new instance of IRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewIRepository(t interface {
@@ -16,6 +16,20 @@ type IUsecase struct {
 	mock.Mock
 }
 
+// AddOrganizer provides a mock function with given fields: ctx, id, memberID
+func (_m *IUsecase) AddOrganizer(ctx context.Context, id int64, memberID int64) error {
+	ret := _m.Called(ctx, id, memberID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, id, memberID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Create provides a mock function with given fields: ctx, gathering
 func (_m *IUsecase) Create(ctx context.Context, gathering model.Gathering) (model.Gathering, error) {
 	ret := _m.Called(ctx, gathering)
@@ -41,13 +55,13 @@ func (_m *IUsecase) Create(ctx context.Context, gathering model.Gathering) (mode
 }
 
 // Get provides a mock function with given fields: ctx, _a1
-func (_m *IUsecase) Get(ctx context.Context, _a1 param.Param) ([]model.Gathering, int64, error) {
+func (_m *IUsecase) Get(ctx context.Context, _a1 param.Param) ([]model.Gathering, param.Page, error) {
 	ret := _m.Called(ctx, _a1)
 
 	var r0 []model.Gathering
-	var r1 int64
+	var r1 param.Page
 	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, param.Param) ([]model.Gathering, int64, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, param.Param) ([]model.Gathering, param.Page, error)); ok {
 		return rf(ctx, _a1)
 	}
 	if rf, ok := ret.Get(0).(func(context.Context, param.Param) []model.Gathering); ok {
@@ -58,10 +72,10 @@ func (_m *IUsecase) Get(ctx context.Context, _a1 param.Param) ([]model.Gathering
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, param.Param) int64); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, param.Param) param.Page); ok {
 		r1 = rf(ctx, _a1)
 	} else {
-		r1 = ret.Get(1).(int64)
+		r1 = ret.Get(1).(param.Page)
 	}
 
 	if rf, ok := ret.Get(2).(func(context.Context, param.Param) error); ok {
@@ -121,6 +135,30 @@ func (_m *IUsecase) GetDetailByID(ctx context.Context, id int64) (model.Gatherin
 	return r0, r1
 }
 
+// Update provides a mock function with given fields: ctx, gatheringPayload, id
+func (_m *IUsecase) Update(ctx context.Context, gatheringPayload model.Gathering, id int64) (model.Gathering, error) {
+	ret := _m.Called(ctx, gatheringPayload, id)
+
+	var r0 model.Gathering
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.Gathering, int64) (model.Gathering, error)); ok {
+		return rf(ctx, gatheringPayload, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.Gathering, int64) model.Gathering); ok {
+		r0 = rf(ctx, gatheringPayload, id)
+	} else {
+		r0 = ret.Get(0).(model.Gathering)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.Gathering, int64) error); ok {
+		r1 = rf(ctx, gatheringPayload, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewIUsecase creates a new instance of IUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewIUsecase(t interface {
@@ -33,6 +33,11 @@ func (_m *IHandler) GetByMemberID(g *gin.Context) {
 	_m.Called(g)
 }
 
+// GetMine provides a mock function with given fields: g
+func (_m *IHandler) GetMine(g *gin.Context) {
+	_m.Called(g)
+}
+
 // Update provides a mock function with given fields: g
 func (_m *IHandler) Update(g *gin.Context) {
 	_m.Called(g)
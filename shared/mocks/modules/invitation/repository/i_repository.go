@@ -18,23 +18,23 @@ type IRepository struct {
 	mock.Mock
 }
 
-// Count provides a mock function with given fields: ctx
-func (_m *IRepository) Count(ctx context.Context) (int64, error) {
-	ret := _m.Called(ctx)
+// Count provides a mock function with given fields: ctx, _a1
+func (_m *IRepository) Count(ctx context.Context, _a1 param.Param) (int64, error) {
+	ret := _m.Called(ctx, _a1)
 
 	var r0 int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
-		return rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, param.Param) (int64, error)); ok {
+		return rf(ctx, _a1)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) int64); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, param.Param) int64); ok {
+		r0 = rf(ctx, _a1)
 	} else {
 		r0 = ret.Get(0).(int64)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, param.Param) error); ok {
+		r1 = rf(ctx, _a1)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -83,12 +83,13 @@ func (_m *IRepository) CreateAttendee(ctx context.Context, attendee model.Attend
 }
 
 // Get provides a mock function with given fields: ctx, _a1
-func (_m *IRepository) Get(ctx context.Context, _a1 param.Param) ([]model.Invitation, error) {
+func (_m *IRepository) Get(ctx context.Context, _a1 param.Param) ([]model.Invitation, param.Page, error) {
 	ret := _m.Called(ctx, _a1)
 
 	var r0 []model.Invitation
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, param.Param) ([]model.Invitation, error)); ok {
+	var r1 param.Page
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, param.Param) ([]model.Invitation, param.Page, error)); ok {
 		return rf(ctx, _a1)
 	}
 	if rf, ok := ret.Get(0).(func(context.Context, param.Param) []model.Invitation); ok {
@@ -99,13 +100,19 @@ func (_m *IRepository) Get(ctx context.Context, _a1 param.Param) ([]model.Invita
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, param.Param) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, param.Param) param.Page); ok {
 		r1 = rf(ctx, _a1)
 	} else {
-		r1 = ret.Error(1)
+		r1 = ret.Get(1).(param.Page)
 	}
 
-	return r0, r1
+	if rf, ok := ret.Get(2).(func(context.Context, param.Param) error); ok {
+		r2 = rf(ctx, _a1)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
 }
 
 // GetByID provides a mock function with given fields: ctx, id
@@ -158,6 +165,54 @@ func (_m *IRepository) GetByMemberID(ctx context.Context, memberID int64) ([]mod
 	return r0, r1
 }
 
+// GetMemberIDByUserID provides a mock function with given fields: ctx, userID
+func (_m *IRepository) GetMemberIDByUserID(ctx context.Context, userID int64) (int64, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (int64, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) int64); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IsOrganizer provides a mock function with given fields: ctx, gatheringID, memberID
+func (_m *IRepository) IsOrganizer(ctx context.Context, gatheringID int64, memberID int64) (bool, error) {
+	ret := _m.Called(ctx, gatheringID, memberID)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) (bool, error)); ok {
+		return rf(ctx, gatheringID, memberID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) bool); ok {
+		r0 = rf(ctx, gatheringID, memberID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, gatheringID, memberID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Update provides a mock function with given fields: ctx, invitation, id
 func (_m *IRepository) Update(ctx context.Context, invitation model.Invitation, id int64) (sql.Result, error) {
 	ret := _m.Called(ctx, invitation, id)
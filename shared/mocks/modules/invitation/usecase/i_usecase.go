@@ -41,13 +41,13 @@ func (_m *IUsecase) Create(ctx context.Context, invitationPayload model.Invitati
 }
 
 // Get provides a mock function with given fields: ctx, _a1
-func (_m *IUsecase) Get(ctx context.Context, _a1 param.Param) ([]model.Invitation, int64, error) {
+func (_m *IUsecase) Get(ctx context.Context, _a1 param.Param) ([]model.Invitation, param.Page, error) {
 	ret := _m.Called(ctx, _a1)
 
 	var r0 []model.Invitation
-	var r1 int64
+	var r1 param.Page
 	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, param.Param) ([]model.Invitation, int64, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, param.Param) ([]model.Invitation, param.Page, error)); ok {
 		return rf(ctx, _a1)
 	}
 	if rf, ok := ret.Get(0).(func(context.Context, param.Param) []model.Invitation); ok {
@@ -58,10 +58,10 @@ func (_m *IUsecase) Get(ctx context.Context, _a1 param.Param) ([]model.Invitatio
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, param.Param) int64); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, param.Param) param.Page); ok {
 		r1 = rf(ctx, _a1)
 	} else {
-		r1 = ret.Get(1).(int64)
+		r1 = ret.Get(1).(param.Page)
 	}
 
 	if rf, ok := ret.Get(2).(func(context.Context, param.Param) error); ok {
@@ -123,6 +123,32 @@ func (_m *IUsecase) GetByMemberID(ctx context.Context, memberID int64) ([]model.
 	return r0, r1
 }
 
+// GetMine provides a mock function with given fields: ctx
+func (_m *IUsecase) GetMine(ctx context.Context) ([]model.InvitationDetail, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []model.InvitationDetail
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]model.InvitationDetail, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []model.InvitationDetail); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.InvitationDetail)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Update provides a mock function with given fields: ctx, invitationPayload, id
 func (_m *IUsecase) Update(ctx context.Context, invitationPayload model.Invitation, id int64) (model.Invitation, error) {
 	ret := _m.Called(ctx, invitationPayload, id)
@@ -18,6 +18,16 @@ func (_m *IHandler) Create(g *gin.Context) {
 	_m.Called(g)
 }
 
+// Delete provides a mock function with given fields: g
+func (_m *IHandler) Delete(g *gin.Context) {
+	_m.Called(g)
+}
+
+// Export provides a mock function with given fields: g
+func (_m *IHandler) Export(g *gin.Context) {
+	_m.Called(g)
+}
+
 // Get provides a mock function with given fields: g
 func (_m *IHandler) Get(g *gin.Context) {
 	_m.Called(g)
@@ -28,6 +38,26 @@ func (_m *IHandler) GetByID(g *gin.Context) {
 	_m.Called(g)
 }
 
+// GetImport provides a mock function with given fields: g
+func (_m *IHandler) GetImport(g *gin.Context) {
+	_m.Called(g)
+}
+
+// Import provides a mock function with given fields: g
+func (_m *IHandler) Import(g *gin.Context) {
+	_m.Called(g)
+}
+
+// Restore provides a mock function with given fields: g
+func (_m *IHandler) Restore(g *gin.Context) {
+	_m.Called(g)
+}
+
+// Update provides a mock function with given fields: g
+func (_m *IHandler) Update(g *gin.Context) {
+	_m.Called(g)
+}
+
 // NewIHandler creates a new instance of IHandler. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewIHandler(t interface {
@@ -11,6 +11,8 @@ import (
 	param "github.com/rzfhlv/gin-example/pkg/param"
 
 	sql "database/sql"
+
+	time "time"
 )
 
 // IRepository is an autogenerated mock type for the IRepository type
@@ -18,23 +20,23 @@ type IRepository struct {
 	mock.Mock
 }
 
-// Count provides a mock function with given fields: ctx
-func (_m *IRepository) Count(ctx context.Context) (int64, error) {
-	ret := _m.Called(ctx)
+// Count provides a mock function with given fields: ctx, _a1
+func (_m *IRepository) Count(ctx context.Context, _a1 param.Param) (int64, error) {
+	ret := _m.Called(ctx, _a1)
 
 	var r0 int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
-		return rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, param.Param) (int64, error)); ok {
+		return rf(ctx, _a1)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) int64); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, param.Param) int64); ok {
+		r0 = rf(ctx, _a1)
 	} else {
 		r0 = ret.Get(0).(int64)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, param.Param) error); ok {
+		r1 = rf(ctx, _a1)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -68,13 +70,118 @@ func (_m *IRepository) Create(ctx context.Context, member model.Member) (sql.Res
 	return r0, r1
 }
 
+// CreateBatch provides a mock function with given fields: ctx, members
+func (_m *IRepository) CreateBatch(ctx context.Context, members []model.Member) error {
+	ret := _m.Called(ctx, members)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []model.Member) error); ok {
+		r0 = rf(ctx, members)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Delete provides a mock function with given fields: ctx, id, deletedAt
+func (_m *IRepository) Delete(ctx context.Context, id int64, deletedAt time.Time) error {
+	ret := _m.Called(ctx, id, deletedAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time) error); ok {
+		r0 = rf(ctx, id, deletedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EmailExists provides a mock function with given fields: ctx, email, id
+func (_m *IRepository) EmailExists(ctx context.Context, email string, id int64) (bool, error) {
+	ret := _m.Called(ctx, email, id)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) (bool, error)); ok {
+		return rf(ctx, email, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) bool); ok {
+		r0 = rf(ctx, email, id)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64) error); ok {
+		r1 = rf(ctx, email, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExistingEmails provides a mock function with given fields: ctx, emails
+func (_m *IRepository) ExistingEmails(ctx context.Context, emails []string) ([]string, error) {
+	ret := _m.Called(ctx, emails)
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]string, error)); ok {
+		return rf(ctx, emails)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []string); ok {
+		r0 = rf(ctx, emails)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, emails)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Export provides a mock function with given fields: ctx, _a1, afterID, limit
+func (_m *IRepository) Export(ctx context.Context, _a1 param.Param, afterID int64, limit int) ([]model.Member, error) {
+	ret := _m.Called(ctx, _a1, afterID, limit)
+
+	var r0 []model.Member
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, param.Param, int64, int) ([]model.Member, error)); ok {
+		return rf(ctx, _a1, afterID, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, param.Param, int64, int) []model.Member); ok {
+		r0 = rf(ctx, _a1, afterID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Member)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, param.Param, int64, int) error); ok {
+		r1 = rf(ctx, _a1, afterID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Get provides a mock function with given fields: ctx, _a1
-func (_m *IRepository) Get(ctx context.Context, _a1 param.Param) ([]model.Member, error) {
+func (_m *IRepository) Get(ctx context.Context, _a1 param.Param) ([]model.Member, param.Page, error) {
 	ret := _m.Called(ctx, _a1)
 
 	var r0 []model.Member
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, param.Param) ([]model.Member, error)); ok {
+	var r1 param.Page
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, param.Param) ([]model.Member, param.Page, error)); ok {
 		return rf(ctx, _a1)
 	}
 	if rf, ok := ret.Get(0).(func(context.Context, param.Param) []model.Member); ok {
@@ -85,13 +192,19 @@ func (_m *IRepository) Get(ctx context.Context, _a1 param.Param) ([]model.Member
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, param.Param) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, param.Param) param.Page); ok {
 		r1 = rf(ctx, _a1)
 	} else {
-		r1 = ret.Error(1)
+		r1 = ret.Get(1).(param.Page)
 	}
 
-	return r0, r1
+	if rf, ok := ret.Get(2).(func(context.Context, param.Param) error); ok {
+		r2 = rf(ctx, _a1)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
 }
 
 // GetByID provides a mock function with given fields: ctx, id
@@ -118,6 +231,84 @@ func (_m *IRepository) GetByID(ctx context.Context, id int64) (model.Member, err
 	return r0, r1
 }
 
+// GetImportJob provides a mock function with given fields: ctx, id
+func (_m *IRepository) GetImportJob(ctx context.Context, id string) (model.ImportJob, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 model.ImportJob
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (model.ImportJob, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) model.ImportJob); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(model.ImportJob)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Restore provides a mock function with given fields: ctx, id
+func (_m *IRepository) Restore(ctx context.Context, id int64) (sql.Result, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 sql.Result
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (sql.Result, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) sql.Result); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(sql.Result)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveImportJob provides a mock function with given fields: ctx, job, ttl
+func (_m *IRepository) SaveImportJob(ctx context.Context, job model.ImportJob, ttl time.Duration) error {
+	ret := _m.Called(ctx, job, ttl)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.ImportJob, time.Duration) error); ok {
+		r0 = rf(ctx, job, ttl)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Update provides a mock function with given fields: ctx, member
+func (_m *IRepository) Update(ctx context.Context, member model.Member) error {
+	ret := _m.Called(ctx, member)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.Member) error); ok {
+		r0 = rf(ctx, member)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewIRepository creates a new instance of IRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewIRepository(t interface {
@@ -4,10 +4,12 @@ package mocks
 
 import (
 	context "context"
+	io "io"
 
-	model "github.com/rzfhlv/gin-example/internal/modules/member/model"
 	mock "github.com/stretchr/testify/mock"
 
+	model "github.com/rzfhlv/gin-example/internal/modules/member/model"
+
 	param "github.com/rzfhlv/gin-example/pkg/param"
 )
 
@@ -40,14 +42,42 @@ func (_m *IUsecase) Create(ctx context.Context, memberPayload model.Member) (mod
 	return r0, r1
 }
 
+// Delete provides a mock function with given fields: ctx, id
+func (_m *IUsecase) Delete(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Export provides a mock function with given fields: ctx, _a1, write
+func (_m *IUsecase) Export(ctx context.Context, _a1 param.Param, write func([]model.Member) error) error {
+	ret := _m.Called(ctx, _a1, write)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, param.Param, func([]model.Member) error) error); ok {
+		r0 = rf(ctx, _a1, write)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Get provides a mock function with given fields: ctx, _a1
-func (_m *IUsecase) Get(ctx context.Context, _a1 param.Param) ([]model.Member, int64, error) {
+func (_m *IUsecase) Get(ctx context.Context, _a1 param.Param) ([]model.Member, param.Page, error) {
 	ret := _m.Called(ctx, _a1)
 
 	var r0 []model.Member
-	var r1 int64
+	var r1 param.Page
 	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, param.Param) ([]model.Member, int64, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, param.Param) ([]model.Member, param.Page, error)); ok {
 		return rf(ctx, _a1)
 	}
 	if rf, ok := ret.Get(0).(func(context.Context, param.Param) []model.Member); ok {
@@ -58,10 +88,10 @@ func (_m *IUsecase) Get(ctx context.Context, _a1 param.Param) ([]model.Member, i
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, param.Param) int64); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, param.Param) param.Page); ok {
 		r1 = rf(ctx, _a1)
 	} else {
-		r1 = ret.Get(1).(int64)
+		r1 = ret.Get(1).(param.Page)
 	}
 
 	if rf, ok := ret.Get(2).(func(context.Context, param.Param) error); ok {
@@ -97,6 +127,102 @@ func (_m *IUsecase) GetByID(ctx context.Context, id int64) (model.Member, error)
 	return r0, r1
 }
 
+// GetImport provides a mock function with given fields: ctx, id
+func (_m *IUsecase) GetImport(ctx context.Context, id string) (model.ImportJob, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 model.ImportJob
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (model.ImportJob, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) model.ImportJob); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(model.ImportJob)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Import provides a mock function with given fields: ctx, file, dryRun
+func (_m *IUsecase) Import(ctx context.Context, file io.Reader, dryRun bool) (model.ImportJob, error) {
+	ret := _m.Called(ctx, file, dryRun)
+
+	var r0 model.ImportJob
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, io.Reader, bool) (model.ImportJob, error)); ok {
+		return rf(ctx, file, dryRun)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, io.Reader, bool) model.ImportJob); ok {
+		r0 = rf(ctx, file, dryRun)
+	} else {
+		r0 = ret.Get(0).(model.ImportJob)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, io.Reader, bool) error); ok {
+		r1 = rf(ctx, file, dryRun)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Restore provides a mock function with given fields: ctx, id
+func (_m *IUsecase) Restore(ctx context.Context, id int64) (model.Member, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 model.Member
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (model.Member, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) model.Member); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(model.Member)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: ctx, id, update
+func (_m *IUsecase) Update(ctx context.Context, id int64, update model.UpdateMember) (model.Member, error) {
+	ret := _m.Called(ctx, id, update)
+
+	var r0 model.Member
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, model.UpdateMember) (model.Member, error)); ok {
+		return rf(ctx, id, update)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, model.UpdateMember) model.Member); ok {
+		r0 = rf(ctx, id, update)
+	} else {
+		r0 = ret.Get(0).(model.Member)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, model.UpdateMember) error); ok {
+		r1 = rf(ctx, id, update)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewIUsecase creates a new instance of IUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewIUsecase(t interface {
@@ -13,6 +13,46 @@ type IHandler struct {
 	mock.Mock
 }
 
+// ChangeEmail provides a mock function with given fields: g
+func (_m *IHandler) ChangeEmail(g *gin.Context) {
+	_m.Called(g)
+}
+
+// ChangePassword provides a mock function with given fields: g
+func (_m *IHandler) ChangePassword(g *gin.Context) {
+	_m.Called(g)
+}
+
+// ClaimMember provides a mock function with given fields: g
+func (_m *IHandler) ClaimMember(g *gin.Context) {
+	_m.Called(g)
+}
+
+// ConfirmMFA provides a mock function with given fields: g
+func (_m *IHandler) ConfirmMFA(g *gin.Context) {
+	_m.Called(g)
+}
+
+// DeleteMe provides a mock function with given fields: g
+func (_m *IHandler) DeleteMe(g *gin.Context) {
+	_m.Called(g)
+}
+
+// DisableMFA provides a mock function with given fields: g
+func (_m *IHandler) DisableMFA(g *gin.Context) {
+	_m.Called(g)
+}
+
+// EnrollMFA provides a mock function with given fields: g
+func (_m *IHandler) EnrollMFA(g *gin.Context) {
+	_m.Called(g)
+}
+
+// ForgotPassword provides a mock function with given fields: g
+func (_m *IHandler) ForgotPassword(g *gin.Context) {
+	_m.Called(g)
+}
+
 // GetAll provides a mock function with given fields: g
 func (_m *IHandler) GetAll(g *gin.Context) {
 	_m.Called(g)
@@ -23,21 +63,126 @@ func (_m *IHandler) GetByID(g *gin.Context) {
 	_m.Called(g)
 }
 
+// GetMe provides a mock function with given fields: g
+func (_m *IHandler) GetMe(g *gin.Context) {
+	_m.Called(g)
+}
+
+// GetMember provides a mock function with given fields: g
+func (_m *IHandler) GetMember(g *gin.Context) {
+	_m.Called(g)
+}
+
+// GetRoles provides a mock function with given fields: g
+func (_m *IHandler) GetRoles(g *gin.Context) {
+	_m.Called(g)
+}
+
+// GetSessions provides a mock function with given fields: g
+func (_m *IHandler) GetSessions(g *gin.Context) {
+	_m.Called(g)
+}
+
+// GrantRole provides a mock function with given fields: g
+func (_m *IHandler) GrantRole(g *gin.Context) {
+	_m.Called(g)
+}
+
+// Impersonate provides a mock function with given fields: g
+func (_m *IHandler) Impersonate(g *gin.Context) {
+	_m.Called(g)
+}
+
+// Introspect provides a mock function with given fields: g
+func (_m *IHandler) Introspect(g *gin.Context) {
+	_m.Called(g)
+}
+
+// JWKS provides a mock function with given fields: g
+func (_m *IHandler) JWKS(g *gin.Context) {
+	_m.Called(g)
+}
+
 // Login provides a mock function with given fields: g
 func (_m *IHandler) Login(g *gin.Context) {
 	_m.Called(g)
 }
 
+// LoginMFA provides a mock function with given fields: g
+func (_m *IHandler) LoginMFA(g *gin.Context) {
+	_m.Called(g)
+}
+
 // Logout provides a mock function with given fields: g
 func (_m *IHandler) Logout(g *gin.Context) {
 	_m.Called(g)
 }
 
+// OIDCCallback provides a mock function with given fields: g
+func (_m *IHandler) OIDCCallback(g *gin.Context) {
+	_m.Called(g)
+}
+
+// OIDCLogin provides a mock function with given fields: g
+func (_m *IHandler) OIDCLogin(g *gin.Context) {
+	_m.Called(g)
+}
+
+// Refresh provides a mock function with given fields: g
+func (_m *IHandler) Refresh(g *gin.Context) {
+	_m.Called(g)
+}
+
 // Register provides a mock function with given fields: g
 func (_m *IHandler) Register(g *gin.Context) {
 	_m.Called(g)
 }
 
+// ResendVerification provides a mock function with given fields: g
+func (_m *IHandler) ResendVerification(g *gin.Context) {
+	_m.Called(g)
+}
+
+// ResetPassword provides a mock function with given fields: g
+func (_m *IHandler) ResetPassword(g *gin.Context) {
+	_m.Called(g)
+}
+
+// RevokeRole provides a mock function with given fields: g
+func (_m *IHandler) RevokeRole(g *gin.Context) {
+	_m.Called(g)
+}
+
+// RevokeSession provides a mock function with given fields: g
+func (_m *IHandler) RevokeSession(g *gin.Context) {
+	_m.Called(g)
+}
+
+// RevokeSessions provides a mock function with given fields: g
+func (_m *IHandler) RevokeSessions(g *gin.Context) {
+	_m.Called(g)
+}
+
+// RevokeToken provides a mock function with given fields: g
+func (_m *IHandler) RevokeToken(g *gin.Context) {
+	_m.Called(g)
+}
+
+// Unlock provides a mock function with given fields: g
+func (_m *IHandler) Unlock(g *gin.Context) {
+	_m.Called(g)
+}
+
+// UpdateMe provides a mock function with given fields: g
+func (_m *IHandler) UpdateMe(g *gin.Context) {
+	_m.Called(g)
+}
+
+// VerifyEmail provides a mock function with given fields: g
+func (_m *IHandler) VerifyEmail(g *gin.Context) {
+	_m.Called(g)
+}
+
 // NewIHandler creates a new instance of IHandler. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewIHandler(t interface {
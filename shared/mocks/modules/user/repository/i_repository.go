@@ -20,23 +20,63 @@ type IRepository struct {
 	mock.Mock
 }
 
-// Count provides a mock function with given fields: ctx
-func (_m *IRepository) Count(ctx context.Context) (int64, error) {
-	ret := _m.Called(ctx)
+// Count provides a mock function with given fields: ctx, _a1
+func (_m *IRepository) Count(ctx context.Context, _a1 param.Param) (int64, error) {
+	ret := _m.Called(ctx, _a1)
 
 	var r0 int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
-		return rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, param.Param) (int64, error)); ok {
+		return rf(ctx, _a1)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) int64); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, param.Param) int64); ok {
+		r0 = rf(ctx, _a1)
 	} else {
 		r0 = ret.Get(0).(int64)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, param.Param) error); ok {
+		r1 = rf(ctx, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateIdentity provides a mock function with given fields: ctx, identity
+func (_m *IRepository) CreateIdentity(ctx context.Context, identity model.ExternalIdentity) error {
+	ret := _m.Called(ctx, identity)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.ExternalIdentity) error); ok {
+		r0 = rf(ctx, identity)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateMember provides a mock function with given fields: ctx, member
+func (_m *IRepository) CreateMember(ctx context.Context, member model.Member) (sql.Result, error) {
+	ret := _m.Called(ctx, member)
+
+	var r0 sql.Result
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.Member) (sql.Result, error)); ok {
+		return rf(ctx, member)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.Member) sql.Result); ok {
+		r0 = rf(ctx, member)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(sql.Result)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.Member) error); ok {
+		r1 = rf(ctx, member)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -58,6 +98,60 @@ func (_m *IRepository) Del(ctx context.Context, key string) error {
 	return r0
 }
 
+// Delete provides a mock function with given fields: ctx, id
+func (_m *IRepository) Delete(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteMFA provides a mock function with given fields: ctx, userID
+func (_m *IRepository) DeleteMFA(ctx context.Context, userID int64) error {
+	ret := _m.Called(ctx, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EnableMFA provides a mock function with given fields: ctx, userID, enabledAt
+func (_m *IRepository) EnableMFA(ctx context.Context, userID int64, enabledAt time.Time) (sql.Result, error) {
+	ret := _m.Called(ctx, userID, enabledAt)
+
+	var r0 sql.Result
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time) (sql.Result, error)); ok {
+		return rf(ctx, userID, enabledAt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time) sql.Result); ok {
+		r0 = rf(ctx, userID, enabledAt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(sql.Result)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, time.Time) error); ok {
+		r1 = rf(ctx, userID, enabledAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Get provides a mock function with given fields: ctx, key
 func (_m *IRepository) Get(ctx context.Context, key string) (string, error) {
 	ret := _m.Called(ctx, key)
@@ -83,12 +177,13 @@ func (_m *IRepository) Get(ctx context.Context, key string) (string, error) {
 }
 
 // GetAll provides a mock function with given fields: ctx, _a1
-func (_m *IRepository) GetAll(ctx context.Context, _a1 param.Param) ([]model.User, error) {
+func (_m *IRepository) GetAll(ctx context.Context, _a1 param.Param) ([]model.User, param.Page, error) {
 	ret := _m.Called(ctx, _a1)
 
 	var r0 []model.User
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, param.Param) ([]model.User, error)); ok {
+	var r1 param.Page
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, param.Param) ([]model.User, param.Page, error)); ok {
 		return rf(ctx, _a1)
 	}
 	if rf, ok := ret.Get(0).(func(context.Context, param.Param) []model.User); ok {
@@ -99,8 +194,38 @@ func (_m *IRepository) GetAll(ctx context.Context, _a1 param.Param) ([]model.Use
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, param.Param) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, param.Param) param.Page); ok {
 		r1 = rf(ctx, _a1)
+	} else {
+		r1 = ret.Get(1).(param.Page)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, param.Param) error); ok {
+		r2 = rf(ctx, _a1)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetByEmail provides a mock function with given fields: ctx, email
+func (_m *IRepository) GetByEmail(ctx context.Context, email string) (model.User, error) {
+	ret := _m.Called(ctx, email)
+
+	var r0 model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (model.User, error)); ok {
+		return rf(ctx, email)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) model.User); ok {
+		r0 = rf(ctx, email)
+	} else {
+		r0 = ret.Get(0).(model.User)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, email)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -132,6 +257,242 @@ func (_m *IRepository) GetByID(ctx context.Context, id int64) (model.User, error
 	return r0, r1
 }
 
+// GetDel provides a mock function with given fields: ctx, key
+func (_m *IRepository) GetDel(ctx context.Context, key string) (string, error) {
+	ret := _m.Called(ctx, key)
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetIdentity provides a mock function with given fields: ctx, issuer, subject
+func (_m *IRepository) GetIdentity(ctx context.Context, issuer string, subject string) (int64, error) {
+	ret := _m.Called(ctx, issuer, subject)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (int64, error)); ok {
+		return rf(ctx, issuer, subject)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) int64); ok {
+		r0 = rf(ctx, issuer, subject)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, issuer, subject)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMFA provides a mock function with given fields: ctx, userID
+func (_m *IRepository) GetMFA(ctx context.Context, userID int64) (model.MFA, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 model.MFA
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (model.MFA, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) model.MFA); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(model.MFA)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMemberByEmail provides a mock function with given fields: ctx, email
+func (_m *IRepository) GetMemberByEmail(ctx context.Context, email string) (model.Member, error) {
+	ret := _m.Called(ctx, email)
+
+	var r0 model.Member
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (model.Member, error)); ok {
+		return rf(ctx, email)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) model.Member); ok {
+		r0 = rf(ctx, email)
+	} else {
+		r0 = ret.Get(0).(model.Member)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMemberByUserID provides a mock function with given fields: ctx, userID
+func (_m *IRepository) GetMemberByUserID(ctx context.Context, userID int64) (model.Member, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 model.Member
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (model.Member, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) model.Member); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(model.Member)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPassword provides a mock function with given fields: ctx, id
+func (_m *IRepository) GetPassword(ctx context.Context, id int64) (string, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (string, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) string); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRecoveryCodes provides a mock function with given fields: ctx, userID
+func (_m *IRepository) GetRecoveryCodes(ctx context.Context, userID int64) ([]model.RecoveryCode, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []model.RecoveryCode
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]model.RecoveryCode, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []model.RecoveryCode); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.RecoveryCode)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRoles provides a mock function with given fields: ctx, userID
+func (_m *IRepository) GetRoles(ctx context.Context, userID int64) ([]string, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]string, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []string); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GrantRole provides a mock function with given fields: ctx, userID, role
+func (_m *IRepository) GrantRole(ctx context.Context, userID int64, role string) error {
+	ret := _m.Called(ctx, userID, role)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) error); ok {
+		r0 = rf(ctx, userID, role)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// LinkMember provides a mock function with given fields: ctx, memberID, userID
+func (_m *IRepository) LinkMember(ctx context.Context, memberID int64, userID int64) (sql.Result, error) {
+	ret := _m.Called(ctx, memberID, userID)
+
+	var r0 sql.Result
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) (sql.Result, error)); ok {
+		return rf(ctx, memberID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) sql.Result); ok {
+		r0 = rf(ctx, memberID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(sql.Result)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, memberID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Login provides a mock function with given fields: ctx, login
 func (_m *IRepository) Login(ctx context.Context, login model.Login) (model.Register, error) {
 	ret := _m.Called(ctx, login)
@@ -182,6 +543,48 @@ func (_m *IRepository) Register(ctx context.Context, register model.Register) (s
 	return r0, r1
 }
 
+// RevokeRole provides a mock function with given fields: ctx, userID, role
+func (_m *IRepository) RevokeRole(ctx context.Context, userID int64, role string) error {
+	ret := _m.Called(ctx, userID, role)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) error); ok {
+		r0 = rf(ctx, userID, role)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveMFA provides a mock function with given fields: ctx, mfa
+func (_m *IRepository) SaveMFA(ctx context.Context, mfa model.MFA) error {
+	ret := _m.Called(ctx, mfa)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.MFA) error); ok {
+		r0 = rf(ctx, mfa)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveRecoveryCodes provides a mock function with given fields: ctx, userID, codes, createdAt
+func (_m *IRepository) SaveRecoveryCodes(ctx context.Context, userID int64, codes []string, createdAt time.Time) error {
+	ret := _m.Called(ctx, userID, codes, createdAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, []string, time.Time) error); ok {
+		r0 = rf(ctx, userID, codes, createdAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Set provides a mock function with given fields: ctx, key, value, ttl
 func (_m *IRepository) Set(ctx context.Context, key string, value string, ttl time.Duration) error {
 	ret := _m.Called(ctx, key, value, ttl)
@@ -196,6 +599,162 @@ func (_m *IRepository) Set(ctx context.Context, key string, value string, ttl ti
 	return r0
 }
 
+// SetNX provides a mock function with given fields: ctx, key, value, ttl
+func (_m *IRepository) SetNX(ctx context.Context, key string, value string, ttl time.Duration) (bool, error) {
+	ret := _m.Called(ctx, key, value, ttl)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Duration) (bool, error)); ok {
+		return rf(ctx, key, value, ttl)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Duration) bool); ok {
+		r0 = rf(ctx, key, value, ttl)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Duration) error); ok {
+		r1 = rf(ctx, key, value, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateEmail provides a mock function with given fields: ctx, id, email
+func (_m *IRepository) UpdateEmail(ctx context.Context, id int64, email string) error {
+	ret := _m.Called(ctx, id, email)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) error); ok {
+		r0 = rf(ctx, id, email)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdatePassword provides a mock function with given fields: ctx, id, password
+func (_m *IRepository) UpdatePassword(ctx context.Context, id int64, password string) error {
+	ret := _m.Called(ctx, id, password)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) error); ok {
+		r0 = rf(ctx, id, password)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateUsername provides a mock function with given fields: ctx, id, username
+func (_m *IRepository) UpdateUsername(ctx context.Context, id int64, username string) error {
+	ret := _m.Called(ctx, id, username)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) error); ok {
+		r0 = rf(ctx, id, username)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UseMFAStep provides a mock function with given fields: ctx, userID, step
+func (_m *IRepository) UseMFAStep(ctx context.Context, userID int64, step int64) (sql.Result, error) {
+	ret := _m.Called(ctx, userID, step)
+
+	var r0 sql.Result
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) (sql.Result, error)); ok {
+		return rf(ctx, userID, step)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) sql.Result); ok {
+		r0 = rf(ctx, userID, step)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(sql.Result)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, userID, step)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UseRecoveryCode provides a mock function with given fields: ctx, id, usedAt
+func (_m *IRepository) UseRecoveryCode(ctx context.Context, id int64, usedAt time.Time) (sql.Result, error) {
+	ret := _m.Called(ctx, id, usedAt)
+
+	var r0 sql.Result
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time) (sql.Result, error)); ok {
+		return rf(ctx, id, usedAt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time) sql.Result); ok {
+		r0 = rf(ctx, id, usedAt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(sql.Result)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, time.Time) error); ok {
+		r1 = rf(ctx, id, usedAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UsernameExists provides a mock function with given fields: ctx, username
+func (_m *IRepository) UsernameExists(ctx context.Context, username string) (bool, error) {
+	ret := _m.Called(ctx, username)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return rf(ctx, username)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, username)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VerifyEmail provides a mock function with given fields: ctx, id, verifiedAt
+func (_m *IRepository) VerifyEmail(ctx context.Context, id int64, verifiedAt time.Time) error {
+	ret := _m.Called(ctx, id, verifiedAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time) error); ok {
+		r0 = rf(ctx, id, verifiedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewIRepository creates a new instance of IRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewIRepository(t interface {
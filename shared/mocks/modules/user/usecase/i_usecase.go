@@ -5,9 +5,11 @@ package mocks
 import (
 	context "context"
 
-	model "github.com/rzfhlv/gin-example/internal/modules/user/model"
+	jwt "github.com/rzfhlv/gin-example/pkg/jwt"
 	mock "github.com/stretchr/testify/mock"
 
+	model "github.com/rzfhlv/gin-example/internal/modules/user/model"
+
 	param "github.com/rzfhlv/gin-example/pkg/param"
 )
 
@@ -16,14 +18,156 @@ type IUsecase struct {
 	mock.Mock
 }
 
+// ChangeEmail provides a mock function with given fields: ctx, userID, sessionID, change
+func (_m *IUsecase) ChangeEmail(ctx context.Context, userID int64, sessionID string, change model.ChangeEmail) error {
+	ret := _m.Called(ctx, userID, sessionID, change)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, model.ChangeEmail) error); ok {
+		r0 = rf(ctx, userID, sessionID, change)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ChangePassword provides a mock function with given fields: ctx, userID, sessionID, change
+func (_m *IUsecase) ChangePassword(ctx context.Context, userID int64, sessionID string, change model.ChangePassword) error {
+	ret := _m.Called(ctx, userID, sessionID, change)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, model.ChangePassword) error); ok {
+		r0 = rf(ctx, userID, sessionID, change)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ClaimMember provides a mock function with given fields: ctx, userID
+func (_m *IUsecase) ClaimMember(ctx context.Context, userID int64) (model.Member, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 model.Member
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (model.Member, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) model.Member); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(model.Member)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ConfirmMFA provides a mock function with given fields: ctx, userID, code
+func (_m *IUsecase) ConfirmMFA(ctx context.Context, userID int64, code model.MFACode) (model.RecoveryCodes, error) {
+	ret := _m.Called(ctx, userID, code)
+
+	var r0 model.RecoveryCodes
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, model.MFACode) (model.RecoveryCodes, error)); ok {
+		return rf(ctx, userID, code)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, model.MFACode) model.RecoveryCodes); ok {
+		r0 = rf(ctx, userID, code)
+	} else {
+		r0 = ret.Get(0).(model.RecoveryCodes)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, model.MFACode) error); ok {
+		r1 = rf(ctx, userID, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteMe provides a mock function with given fields: ctx, userID, remove
+func (_m *IUsecase) DeleteMe(ctx context.Context, userID int64, remove model.DeleteUser) error {
+	ret := _m.Called(ctx, userID, remove)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, model.DeleteUser) error); ok {
+		r0 = rf(ctx, userID, remove)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DisableMFA provides a mock function with given fields: ctx, userID, disable
+func (_m *IUsecase) DisableMFA(ctx context.Context, userID int64, disable model.DisableMFA) error {
+	ret := _m.Called(ctx, userID, disable)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, model.DisableMFA) error); ok {
+		r0 = rf(ctx, userID, disable)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EnrollMFA provides a mock function with given fields: ctx, userID
+func (_m *IUsecase) EnrollMFA(ctx context.Context, userID int64) (model.MFAEnrollment, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 model.MFAEnrollment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (model.MFAEnrollment, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) model.MFAEnrollment); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(model.MFAEnrollment)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ForgotPassword provides a mock function with given fields: ctx, forgot
+func (_m *IUsecase) ForgotPassword(ctx context.Context, forgot model.ForgotPassword) error {
+	ret := _m.Called(ctx, forgot)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.ForgotPassword) error); ok {
+		r0 = rf(ctx, forgot)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // GetAll provides a mock function with given fields: ctx, _a1
-func (_m *IUsecase) GetAll(ctx context.Context, _a1 param.Param) ([]model.User, int64, error) {
+func (_m *IUsecase) GetAll(ctx context.Context, _a1 param.Param) ([]model.User, param.Page, error) {
 	ret := _m.Called(ctx, _a1)
 
 	var r0 []model.User
-	var r1 int64
+	var r1 param.Page
 	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, param.Param) ([]model.User, int64, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, param.Param) ([]model.User, param.Page, error)); ok {
 		return rf(ctx, _a1)
 	}
 	if rf, ok := ret.Get(0).(func(context.Context, param.Param) []model.User); ok {
@@ -34,10 +178,10 @@ func (_m *IUsecase) GetAll(ctx context.Context, _a1 param.Param) ([]model.User,
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, param.Param) int64); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, param.Param) param.Page); ok {
 		r1 = rf(ctx, _a1)
 	} else {
-		r1 = ret.Get(1).(int64)
+		r1 = ret.Get(1).(param.Page)
 	}
 
 	if rf, ok := ret.Get(2).(func(context.Context, param.Param) error); ok {
@@ -73,6 +217,170 @@ func (_m *IUsecase) GetByID(ctx context.Context, id int64) (model.User, error) {
 	return r0, r1
 }
 
+// GetMember provides a mock function with given fields: ctx, userID
+func (_m *IUsecase) GetMember(ctx context.Context, userID int64) (model.Member, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 model.Member
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (model.Member, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) model.Member); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(model.Member)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRoles provides a mock function with given fields: ctx, userID
+func (_m *IUsecase) GetRoles(ctx context.Context, userID int64) ([]string, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]string, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []string); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSessions provides a mock function with given fields: ctx, userID, sessionID
+func (_m *IUsecase) GetSessions(ctx context.Context, userID int64, sessionID string) ([]model.Session, error) {
+	ret := _m.Called(ctx, userID, sessionID)
+
+	var r0 []model.Session
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) ([]model.Session, error)); ok {
+		return rf(ctx, userID, sessionID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) []model.Session); ok {
+		r0 = rf(ctx, userID, sessionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Session)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, string) error); ok {
+		r1 = rf(ctx, userID, sessionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GrantRole provides a mock function with given fields: ctx, userID, role
+func (_m *IUsecase) GrantRole(ctx context.Context, userID int64, role string) ([]string, error) {
+	ret := _m.Called(ctx, userID, role)
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) ([]string, error)); ok {
+		return rf(ctx, userID, role)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) []string); ok {
+		r0 = rf(ctx, userID, role)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, string) error); ok {
+		r1 = rf(ctx, userID, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Impersonate provides a mock function with given fields: ctx, impersonate
+func (_m *IUsecase) Impersonate(ctx context.Context, impersonate model.Impersonate) (model.JWT, error) {
+	ret := _m.Called(ctx, impersonate)
+
+	var r0 model.JWT
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.Impersonate) (model.JWT, error)); ok {
+		return rf(ctx, impersonate)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.Impersonate) model.JWT); ok {
+		r0 = rf(ctx, impersonate)
+	} else {
+		r0 = ret.Get(0).(model.JWT)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.Impersonate) error); ok {
+		r1 = rf(ctx, impersonate)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Introspect provides a mock function with given fields: ctx, request
+func (_m *IUsecase) Introspect(ctx context.Context, request model.TokenRequest) (model.Introspection, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 model.Introspection
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.TokenRequest) (model.Introspection, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.TokenRequest) model.Introspection); ok {
+		r0 = rf(ctx, request)
+	} else {
+		r0 = ret.Get(0).(model.Introspection)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.TokenRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// JWKS provides a mock function with given fields: ctx
+func (_m *IUsecase) JWKS(ctx context.Context) jwt.JWKS {
+	ret := _m.Called(ctx)
+
+	var r0 jwt.JWKS
+	if rf, ok := ret.Get(0).(func(context.Context) jwt.JWKS); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(jwt.JWKS)
+	}
+
+	return r0
+}
+
 // Login provides a mock function with given fields: ctx, login
 func (_m *IUsecase) Login(ctx context.Context, login model.Login) (model.JWT, error) {
 	ret := _m.Called(ctx, login)
@@ -97,13 +405,37 @@ func (_m *IUsecase) Login(ctx context.Context, login model.Login) (model.JWT, er
 	return r0, r1
 }
 
-// Logout provides a mock function with given fields: ctx, username
-func (_m *IUsecase) Logout(ctx context.Context, username string) error {
-	ret := _m.Called(ctx, username)
+// LoginMFA provides a mock function with given fields: ctx, login
+func (_m *IUsecase) LoginMFA(ctx context.Context, login model.LoginMFA) (model.JWT, error) {
+	ret := _m.Called(ctx, login)
+
+	var r0 model.JWT
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.LoginMFA) (model.JWT, error)); ok {
+		return rf(ctx, login)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.LoginMFA) model.JWT); ok {
+		r0 = rf(ctx, login)
+	} else {
+		r0 = ret.Get(0).(model.JWT)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.LoginMFA) error); ok {
+		r1 = rf(ctx, login)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Logout provides a mock function with given fields: ctx, userID, sessionID
+func (_m *IUsecase) Logout(ctx context.Context, userID int64, sessionID string) error {
+	ret := _m.Called(ctx, userID, sessionID)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = rf(ctx, username)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) error); ok {
+		r0 = rf(ctx, userID, sessionID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -111,6 +443,78 @@ func (_m *IUsecase) Logout(ctx context.Context, username string) error {
 	return r0
 }
 
+// OIDCCallback provides a mock function with given fields: ctx, callback
+func (_m *IUsecase) OIDCCallback(ctx context.Context, callback model.OIDCCallback) (model.JWT, error) {
+	ret := _m.Called(ctx, callback)
+
+	var r0 model.JWT
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.OIDCCallback) (model.JWT, error)); ok {
+		return rf(ctx, callback)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.OIDCCallback) model.JWT); ok {
+		r0 = rf(ctx, callback)
+	} else {
+		r0 = ret.Get(0).(model.JWT)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.OIDCCallback) error); ok {
+		r1 = rf(ctx, callback)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OIDCLogin provides a mock function with given fields: ctx
+func (_m *IUsecase) OIDCLogin(ctx context.Context) (string, error) {
+	ret := _m.Called(ctx)
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (string, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) string); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Refresh provides a mock function with given fields: ctx, refresh
+func (_m *IUsecase) Refresh(ctx context.Context, refresh model.Refresh) (model.JWT, error) {
+	ret := _m.Called(ctx, refresh)
+
+	var r0 model.JWT
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.Refresh) (model.JWT, error)); ok {
+		return rf(ctx, refresh)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.Refresh) model.JWT); ok {
+		r0 = rf(ctx, refresh)
+	} else {
+		r0 = ret.Get(0).(model.JWT)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.Refresh) error); ok {
+		r1 = rf(ctx, refresh)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Register provides a mock function with given fields: ctx, register
 func (_m *IUsecase) Register(ctx context.Context, register model.Register) (model.JWT, error) {
 	ret := _m.Called(ctx, register)
@@ -135,6 +539,154 @@ func (_m *IUsecase) Register(ctx context.Context, register model.Register) (mode
 	return r0, r1
 }
 
+// ResendVerification provides a mock function with given fields: ctx, resend
+func (_m *IUsecase) ResendVerification(ctx context.Context, resend model.ResendVerification) error {
+	ret := _m.Called(ctx, resend)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.ResendVerification) error); ok {
+		r0 = rf(ctx, resend)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ResetPassword provides a mock function with given fields: ctx, reset
+func (_m *IUsecase) ResetPassword(ctx context.Context, reset model.ResetPassword) error {
+	ret := _m.Called(ctx, reset)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.ResetPassword) error); ok {
+		r0 = rf(ctx, reset)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeRole provides a mock function with given fields: ctx, userID, role
+func (_m *IUsecase) RevokeRole(ctx context.Context, userID int64, role string) ([]string, error) {
+	ret := _m.Called(ctx, userID, role)
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) ([]string, error)); ok {
+		return rf(ctx, userID, role)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) []string); ok {
+		r0 = rf(ctx, userID, role)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, string) error); ok {
+		r1 = rf(ctx, userID, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevokeSession provides a mock function with given fields: ctx, userID, sessionID
+func (_m *IUsecase) RevokeSession(ctx context.Context, userID int64, sessionID string) error {
+	ret := _m.Called(ctx, userID, sessionID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) error); ok {
+		r0 = rf(ctx, userID, sessionID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeSessions provides a mock function with given fields: ctx, userID
+func (_m *IUsecase) RevokeSessions(ctx context.Context, userID int64) error {
+	ret := _m.Called(ctx, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeToken provides a mock function with given fields: ctx, request
+func (_m *IUsecase) RevokeToken(ctx context.Context, request model.TokenRequest) error {
+	ret := _m.Called(ctx, request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.TokenRequest) error); ok {
+		r0 = rf(ctx, request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Unlock provides a mock function with given fields: ctx, userID
+func (_m *IUsecase) Unlock(ctx context.Context, userID int64) error {
+	ret := _m.Called(ctx, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateMe provides a mock function with given fields: ctx, userID, update
+func (_m *IUsecase) UpdateMe(ctx context.Context, userID int64, update model.UpdateUser) (model.User, error) {
+	ret := _m.Called(ctx, userID, update)
+
+	var r0 model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, model.UpdateUser) (model.User, error)); ok {
+		return rf(ctx, userID, update)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, model.UpdateUser) model.User); ok {
+		r0 = rf(ctx, userID, update)
+	} else {
+		r0 = ret.Get(0).(model.User)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, model.UpdateUser) error); ok {
+		r1 = rf(ctx, userID, update)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VerifyEmail provides a mock function with given fields: ctx, verifyToken
+func (_m *IUsecase) VerifyEmail(ctx context.Context, verifyToken string) error {
+	ret := _m.Called(ctx, verifyToken)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, verifyToken)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewIUsecase creates a new instance of IUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewIUsecase(t interface {
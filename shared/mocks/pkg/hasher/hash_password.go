@@ -33,6 +33,20 @@ func (_m *HashPassword) HashedPassword(password string) (string, error) {
 	return r0, r1
 }
 
+// NeedsRehash provides a mock function with given fields: hashed
+func (_m *HashPassword) NeedsRehash(hashed string) bool {
+	ret := _m.Called(hashed)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = rf(hashed)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
 // VerifyPassword provides a mock function with given fields: hashed, password
 func (_m *HashPassword) VerifyPassword(hashed string, password string) error {
 	ret := _m.Called(hashed, password)
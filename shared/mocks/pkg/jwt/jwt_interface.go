@@ -12,23 +12,23 @@ type JWTInterface struct {
 	mock.Mock
 }
 
-// Generate provides a mock function with given fields: id, username, email
-func (_m *JWTInterface) Generate(id int64, username string, email string) (string, error) {
-	ret := _m.Called(id, username, email)
+// Generate provides a mock function with given fields: payload
+func (_m *JWTInterface) Generate(payload jwt.Payload) (string, error) {
+	ret := _m.Called(payload)
 
 	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(int64, string, string) (string, error)); ok {
-		return rf(id, username, email)
+	if rf, ok := ret.Get(0).(func(jwt.Payload) (string, error)); ok {
+		return rf(payload)
 	}
-	if rf, ok := ret.Get(0).(func(int64, string, string) string); ok {
-		r0 = rf(id, username, email)
+	if rf, ok := ret.Get(0).(func(jwt.Payload) string); ok {
+		r0 = rf(payload)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(int64, string, string) error); ok {
-		r1 = rf(id, username, email)
+	if rf, ok := ret.Get(1).(func(jwt.Payload) error); ok {
+		r1 = rf(payload)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -36,6 +36,20 @@ func (_m *JWTInterface) Generate(id int64, username string, email string) (strin
 	return r0, r1
 }
 
+// JWKS provides a mock function with given fields:
+func (_m *JWTInterface) JWKS() jwt.JWKS {
+	ret := _m.Called()
+
+	var r0 jwt.JWKS
+	if rf, ok := ret.Get(0).(func() jwt.JWKS); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(jwt.JWKS)
+	}
+
+	return r0
+}
+
 // ValidateToken provides a mock function with given fields: signedToken
 func (_m *JWTInterface) ValidateToken(signedToken string) (*jwt.JWTClaim, error) {
 	ret := _m.Called(signedToken)